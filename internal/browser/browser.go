@@ -0,0 +1,51 @@
+package browser
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// TaskURL returns the link for a task: its own URL if set, otherwise the Jira
+// browse page built from jiraBaseURL and the task's JIRA ID
+func TaskURL(task models.Task, jiraBaseURL string) (string, error) {
+	if task.URL != "" {
+		return task.URL, nil
+	}
+	if task.JiraID != "" {
+		if jiraBaseURL == "" {
+			return "", fmt.Errorf("task #%d has no URL; set [jira] base_url in the config to open %s", task.ID, task.JiraID)
+		}
+		return JiraURL(task.JiraID, jiraBaseURL), nil
+	}
+	return "", fmt.Errorf("task #%d has no URL or JIRA ID", task.ID)
+}
+
+// JiraURL returns the browse page of a Jira issue
+func JiraURL(jiraID, jiraBaseURL string) string {
+	return strings.TrimRight(jiraBaseURL, "/") + "/browse/" + jiraID
+}
+
+// Open opens a URL or file with the system's default application without
+// waiting for it to exit
+func Open(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	case "darwin":
+		cmd = exec.Command("open", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", target, err)
+	}
+	// Reap the opener once it exits; it usually hands off to the browser right away
+	go cmd.Wait()
+	return nil
+}
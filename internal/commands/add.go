@@ -2,11 +2,17 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/config"
 	"github.com/balkashynov/wrok/internal/db"
 	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/priority"
+	"github.com/balkashynov/wrok/internal/transcribe"
 	"github.com/balkashynov/wrok/internal/tui"
 )
 
@@ -23,14 +29,55 @@ Modes:
 Smart parsing syntax:
   #tag1,tag2  - Tags (comma-separated or individual)
   @project    - Project name  
-  +priority   - Priority (low/medium/high or 1/2/3)
+  +priority   - Priority (low/medium/high or 1/2/3, or a level from [priority] in the config)
   ABC-123     - JIRA ticket (auto-detected)
-  due:3days   - Due date (dd/mm/yyyy, X days, X hours, X weeks)`,
+  due:3days   - Due date (dd/mm/yyyy, X days, X hours, X weeks, X-business-days,
+                next-business-day or a weekday like friday)
+  est:4h      - Estimate, also ~4h (4h, 30m, 1h30m)
+  https://... - Link (the first one; more go to the note)
+  note:"text" - Note (note:word for one word)
+  // text     - Note: everything after a " //" at the end
+
+Start working on it right away with --start, or --timer to also open the timer.
+In the wizard, Space on the Save step picks the same.
+
+Voice notes:
+  wrok add --from-audio note.m4a - Transcribed by the OpenAI-compatible
+                                   [transcription] url of the config, parsed
+                                   like a description and opened in the wizard
+
+Scripting:
+  wrok add --stdin < todo.txt   - One task per line, prints created IDs`,
+	Example: `  wrok add "Fix login bug #frontend @auth +high APP-123 due:2days"
+  wrok add "Write release notes" -p docs --due 3days
+  wrok add "Review PR 481" --start
+  wrok add "Check flaky test @ci https://ci.example.com/run/17 // fails on main only"
+  wrok add -i`,
 	Args: cobra.ArbitraryArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		initDB()
 		interactive, _ := cmd.Flags().GetBool("interactive")
 		
+		// Read one task per line from stdin
+		if fromStdin, _ := cmd.Flags().GetBool("stdin"); fromStdin {
+			if afterAddFromFlags(cmd) != tui.AfterSaveNothing {
+				fmt.Println("Error: --start and --timer can't be used with --stdin")
+				return
+			}
+			addTasksFromLines(cmd, os.Stdin)
+			return
+		}
+		
+		// Transcribe a voice note and confirm the task it describes in the wizard
+		if audio, _ := cmd.Flags().GetString("from-audio"); audio != "" {
+			if len(args) > 0 {
+				fmt.Println("Error: give either a description or --from-audio, not both")
+				return
+			}
+			addTaskFromAudio(cmd, audio)
+			return
+		}
+		
 		// If no args and not explicitly interactive, go interactive
 		if len(args) == 0 && !interactive {
 			interactive = true
@@ -57,6 +104,32 @@ Smart parsing syntax:
 	},
 }
 
+// addTaskFromAudio transcribes a recording through [transcription] in the
+// config, runs the transcript through the smart parser and opens the wizard
+// with the result for confirmation
+func addTaskFromAudio(cmd *cobra.Command, path string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("🎙️  Transcribing %s...\n", filepath.Base(path))
+	text, err := transcribe.File(cfg.Transcription, path)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	// Speech comes back as a sentence; a task title has no full stop
+	text = strings.TrimSuffix(text, ".")
+	fmt.Printf("   Heard: %s\n", text)
+
+	parsed := parser.ParseTitle(text)
+	if len(parsed.Errors) > 0 {
+		fmt.Printf("⚠️  Found issues with parsing: %s\n", strings.Join(parsed.Errors, ", "))
+	}
+	runInteractiveAddWithParsed(cmd, parsed)
+}
+
 // runInteractiveAdd starts interactive mode
 func runInteractiveAdd(cmd *cobra.Command, args []string) {
 	prefilled := make(map[string]string)
@@ -85,9 +158,35 @@ func runInteractiveAdd(cmd *cobra.Command, args []string) {
 	if note, _ := cmd.Flags().GetString("note"); note != "" {
 		prefilled["notes"] = note
 	}
+	if url, _ := cmd.Flags().GetString("url"); url != "" {
+		prefilled["url"] = url
+	}
+	if estimate, _ := cmd.Flags().GetString("estimate"); estimate != "" {
+		prefilled["estimate"] = estimate
+	}
+	
+	// Default project/tag from the focus context
+	applyFocusToPrefilled(prefilled, loadFocus())
+	if prefilled["project"] == "" {
+		if project := defaultProject(); project != "" {
+			prefilled["project"] = project
+		}
+	}
+	
+	switch afterAddFromFlags(cmd) {
+	case tui.AfterSaveStart:
+		prefilled["after_save"] = "start"
+	case tui.AfterSaveTimer:
+		prefilled["after_save"] = "timer"
+	}
 	
-	if err := tui.RunAddTaskTUI(prefilled); err != nil {
+	result, err := tui.RunAddTaskTUI(prefilled)
+	if err != nil {
 		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if result.TaskID != 0 {
+		startAddedTask(result.TaskID, result.AfterSave)
 	}
 }
 
@@ -112,6 +211,15 @@ func runInteractiveAddWithParsed(cmd *cobra.Command, parsed parser.ParsedTask) {
 		// Convert time back to a readable format for the TUI input
 		prefilled["due_date"] = parsed.DueDate.Format("02/01/2006")
 	}
+	if parsed.URL != "" {
+		prefilled["url"] = parsed.URL
+	}
+	if parsed.Estimate > 0 {
+		prefilled["estimate"] = parser.FormatEstimate(parsed.Estimate)
+	}
+	if parsed.Note != "" {
+		prefilled["notes"] = parsed.Note
+	}
 	
 	// Override with any explicit flags
 	if project, _ := cmd.Flags().GetString("project"); project != "" {
@@ -132,14 +240,133 @@ func runInteractiveAddWithParsed(cmd *cobra.Command, parsed parser.ParsedTask) {
 	if note, _ := cmd.Flags().GetString("note"); note != "" {
 		prefilled["notes"] = note
 	}
+	if url, _ := cmd.Flags().GetString("url"); url != "" {
+		prefilled["url"] = url
+	}
+	if estimate, _ := cmd.Flags().GetString("estimate"); estimate != "" {
+		prefilled["estimate"] = estimate
+	}
+	
+	// Default project/tag from the focus context
+	applyFocusToPrefilled(prefilled, loadFocus())
+	if prefilled["project"] == "" {
+		if project := defaultProject(); project != "" {
+			prefilled["project"] = project
+		}
+	}
 	
-	if err := tui.RunAddTaskTUI(prefilled); err != nil {
+	switch afterAddFromFlags(cmd) {
+	case tui.AfterSaveStart:
+		prefilled["after_save"] = "start"
+	case tui.AfterSaveTimer:
+		prefilled["after_save"] = "timer"
+	}
+	
+	result, err := tui.RunAddTaskTUI(prefilled)
+	if err != nil {
 		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if result.TaskID != 0 {
+		startAddedTask(result.TaskID, result.AfterSave)
 	}
 }
 
 // runDirectAdd creates task directly without TUI
 func runDirectAdd(cmd *cobra.Command, parsed parser.ParsedTask) {
+	req, err := buildCreateRequest(cmd, parsed)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	
+	// Create the task
+	task, err := db.CreateTask(req)
+	if err != nil {
+		fmt.Printf("Error creating task: %v\n", err)
+		return
+	}
+	
+	// Success message
+	fmt.Printf("Created task #%d: %s\n", task.ID, task.Title)
+	if task.Project != "" {
+		fmt.Printf("  Project: %s\n", task.Project)
+	}
+	if len(task.Tags) > 0 {
+		var tagNames []string
+		for _, tag := range task.Tags {
+			tagNames = append(tagNames, tag.Name)
+		}
+		fmt.Printf("  Tags: %s\n", strings.Join(tagNames, ", "))
+	}
+	if task.Priority > 0 {
+		fmt.Printf("  Priority: %s\n", priority.Name(task.Priority))
+	}
+	if task.JiraID != "" {
+		fmt.Printf("  JIRA: %s\n", task.JiraID)
+	}
+	if task.Due != nil {
+		fmt.Printf("  Due: %s\n", parser.FormatDueDate(task.Due))
+	}
+	if task.EstimateSeconds > 0 {
+		fmt.Printf("  Estimate: %s\n", parser.FormatEstimate(time.Duration(task.EstimateSeconds)*time.Second))
+	}
+	if task.URL != "" {
+		fmt.Printf("  URL: %s\n", task.URL)
+	}
+	if task.Note != "" {
+		fmt.Printf("  Note: %s\n", task.Note)
+	}
+	
+	startAddedTask(task.ID, afterAddFromFlags(cmd))
+}
+
+// afterAddFromFlags reads --start and --timer
+func afterAddFromFlags(cmd *cobra.Command) tui.AfterSave {
+	if timer, _ := cmd.Flags().GetBool("timer"); timer {
+		return tui.AfterSaveTimer
+	}
+	if start, _ := cmd.Flags().GetBool("start"); start {
+		return tui.AfterSaveStart
+	}
+	return tui.AfterSaveNothing
+}
+
+// startAddedTask starts tracking time on a task that was just added, and opens
+// the timer if asked to
+func startAddedTask(taskID uint, after tui.AfterSave) {
+	if after == tui.AfterSaveNothing {
+		return
+	}
+	if !waitForBreak(taskID, after != tui.AfterSaveTimer) {
+		return
+	}
+	
+	session, err := db.StartSession(taskID)
+	if err != nil {
+		fmt.Printf("⚠️  Task added, but the timer didn't start: %v\n", err)
+		return
+	}
+	if after == tui.AfterSaveTimer {
+		if err := tui.RunTimerTUI(session); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+		return
+	}
+	fmt.Printf("⏱️  Started tracking time for task #%d: %s\n", session.TaskID, session.Task.Title)
+}
+
+// defaultProject returns the configured default project for new tasks
+func defaultProject() string {
+	cfg, err := config.Load()
+	if err != nil {
+		return ""
+	}
+	return cfg.DefaultProject
+}
+
+// buildCreateRequest merges parsed title metadata, explicit flags and the focus context
+func buildCreateRequest(cmd *cobra.Command, parsed parser.ParsedTask) (db.CreateTaskRequest, error) {
 	// Start with parsed data
 	title := parsed.Title
 	project := parsed.Project
@@ -164,17 +391,35 @@ func runDirectAdd(cmd *cobra.Command, parsed parser.ParsedTask) {
 	if flagDueDate, _ := cmd.Flags().GetString("due"); flagDueDate != "" {
 		parsedDueDate, err := parser.ParseDueDate(flagDueDate)
 		if err != nil {
-			fmt.Printf("Error parsing due date: %v\n", err)
-			return
+			return db.CreateTaskRequest{}, fmt.Errorf("invalid due date '%s': %w", flagDueDate, err)
 		}
 		dueDate = parsedDueDate
 	}
 	
-	url, _ := cmd.Flags().GetString("url")
-	note, _ := cmd.Flags().GetString("note")
+	// Default project/tag from the focus context
+	project, tags = applyFocusDefaults(project, tags, loadFocus())
+	if project == "" {
+		project = defaultProject()
+	}
 	
-	// Create task request
-	req := db.CreateTaskRequest{
+	url := parsed.URL
+	if flagURL, _ := cmd.Flags().GetString("url"); flagURL != "" {
+		url = flagURL
+	}
+	note := parsed.Note
+	if flagNote, _ := cmd.Flags().GetString("note"); flagNote != "" {
+		note = flagNote
+	}
+	estimate := parsed.Estimate
+	if flagEstimate, _ := cmd.Flags().GetString("estimate"); flagEstimate != "" {
+		parsedEstimate, err := parser.ParseEstimate(flagEstimate)
+		if err != nil {
+			return db.CreateTaskRequest{}, fmt.Errorf("invalid estimate '%s': %w", flagEstimate, err)
+		}
+		estimate = parsedEstimate
+	}
+	
+	return db.CreateTaskRequest{
 		Title:    title,
 		Project:  project,
 		Tags:     tags,
@@ -183,47 +428,23 @@ func runDirectAdd(cmd *cobra.Command, parsed parser.ParsedTask) {
 		URL:      url,
 		Note:     note,
 		DueDate:  dueDate,
-	}
-	
-	// Create the task
-	task, err := db.CreateTask(req)
-	if err != nil {
-		fmt.Printf("Error creating task: %v\n", err)
-		return
-	}
-	
-	// Success message
-	fmt.Printf("Created task #%d: %s\n", task.ID, task.Title)
-	if task.Project != "" {
-		fmt.Printf("  Project: %s\n", task.Project)
-	}
-	if len(task.Tags) > 0 {
-		var tagNames []string
-		for _, tag := range task.Tags {
-			tagNames = append(tagNames, tag.Name)
-		}
-		fmt.Printf("  Tags: %s\n", strings.Join(tagNames, ", "))
-	}
-	if task.Priority > 0 {
-		priorities := []string{"", "low", "medium", "high"}
-		fmt.Printf("  Priority: %s\n", priorities[task.Priority])
-	}
-	if task.JiraID != "" {
-		fmt.Printf("  JIRA: %s\n", task.JiraID)
-	}
-	if task.Due != nil {
-		fmt.Printf("  Due: %s\n", parser.FormatDueDate(task.Due))
-	}
+		Estimate: estimate,
+	}, nil
 }
 
 func init() {
 	// Add flags to the add command
 	addCmd.Flags().BoolP("interactive", "i", false, "Interactive mode with TUI")
+	addCmd.Flags().Bool("stdin", false, "Read one task per line from stdin")
+	addCmd.Flags().String("from-audio", "", "Transcribe this voice note through [transcription] in the config and confirm the task in the wizard")
 	addCmd.Flags().StringP("project", "p", "", "Project name")
 	addCmd.Flags().StringSliceP("tags", "t", []string{}, "Comma-separated tags")
-	addCmd.Flags().StringP("priority", "", "", "Priority: low, medium, high, or 1-3")
+	addCmd.Flags().StringP("priority", "", "", "Priority: a level name (low, medium, high by default) or its number")
 	addCmd.Flags().StringP("jira", "", "", "JIRA ticket ID")
-	addCmd.Flags().StringP("due", "", "", "Due date: dd/mm/yyyy, X days, X hours, X weeks")
+	addCmd.Flags().StringP("due", "", "", "Due date: dd/mm/yyyy, X days, X hours, X weeks, X business days, next business day, friday")
 	addCmd.Flags().StringP("url", "", "", "Related URL")
 	addCmd.Flags().StringP("note", "", "", "Additional notes")
+	addCmd.Flags().String("estimate", "", "How long the task should take, e.g. 4h, 30m or 1h30m")
+	addCmd.Flags().Bool("start", false, "Start tracking time on the new task")
+	addCmd.Flags().Bool("timer", false, "Start tracking time on the new task and open the timer")
 }
\ No newline at end of file
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/priority"
+)
+
+// alfredItem is one row of an Alfred script filter; Raycast script commands
+// read the same schema
+type alfredItem struct {
+	UID          string               `json:"uid"`
+	Title        string               `json:"title"`
+	Subtitle     string               `json:"subtitle"`
+	Arg          string               `json:"arg"`
+	Autocomplete string               `json:"autocomplete"`
+	Icon         alfredIcon           `json:"icon"`
+	Text         map[string]string    `json:"text"`
+	QuicklookURL string               `json:"quicklookurl,omitempty"`
+	Mods         map[string]alfredMod `json:"mods"`
+	Variables    map[string]string    `json:"variables"`
+}
+
+// alfredIcon points at an image; relative paths resolve in the workflow folder
+type alfredIcon struct {
+	Path string `json:"path"`
+}
+
+// alfredMod replaces arg and subtitle while a modifier key is held
+type alfredMod struct {
+	Arg      string `json:"arg"`
+	Subtitle string `json:"subtitle"`
+}
+
+// renderAlfred outputs tasks as Alfred script filter JSON. arg is the task ID;
+// holding cmd passes "start <id>" and alt "done <id>", so a workflow can pipe
+// arg straight into wrok. Icons are icons/<status>.png (todo, done, archived,
+// running) inside the workflow
+func renderAlfred(tasks []models.Task) {
+	var activeID uint
+	if session, _ := db.GetActiveSession(); session != nil {
+		activeID = session.TaskID
+	}
+
+	items := make([]alfredItem, 0, len(tasks))
+	for _, task := range tasks {
+		id := strconv.FormatUint(uint64(task.ID), 10)
+		icon := task.Status
+		if task.ID == activeID {
+			icon = "running"
+		}
+		items = append(items, alfredItem{
+			UID:          id,
+			Title:        task.Title,
+			Subtitle:     alfredSubtitle(task, task.ID == activeID),
+			Arg:          id,
+			Autocomplete: task.Title,
+			Icon:         alfredIcon{Path: "icons/" + icon + ".png"},
+			Text:         map[string]string{"copy": task.Title, "largetype": task.Title},
+			QuicklookURL: task.URL,
+			Mods: map[string]alfredMod{
+				"cmd": {Arg: "start " + id, Subtitle: "Start tracking #" + id},
+				"alt": {Arg: "done " + id, Subtitle: "Mark #" + id + " done"},
+			},
+			Variables: map[string]string{"task_id": id, "status": task.Status, "project": task.Project},
+		})
+	}
+
+	data, err := json.MarshalIndent(struct {
+		Items []alfredItem `json:"items"`
+	}{items}, "", "  ")
+	if err != nil {
+		fmt.Printf("Error generating JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// alfredSubtitle sums a task up in one line: id, project, priority, due date and tags
+func alfredSubtitle(task models.Task, running bool) string {
+	parts := []string{fmt.Sprintf("#%d", task.ID)}
+	if running {
+		parts = append(parts, "⏱ running")
+	} else if task.Status != "todo" {
+		parts = append(parts, task.Status)
+	}
+	if task.Project != "" {
+		parts = append(parts, "@"+task.Project)
+	}
+	if task.Priority > 0 {
+		parts = append(parts, priority.Name(task.Priority))
+	}
+	if task.Due != nil {
+		parts = append(parts, "due "+task.Due.Format("Mon 2 Jan"))
+	}
+	for _, tag := range task.Tags {
+		parts = append(parts, "#"+tag.Name)
+	}
+	return strings.Join(parts, " · ")
+}
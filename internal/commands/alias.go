@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias [task-id] [alias]",
+	Short: "Give a task a short name to use in place of its ID",
+	Long: `Give a task an alias, a short name that works anywhere a task ID does:
+'wrok start login-bug', 'wrok done login-bug', 'wrok open login-bug'.
+
+Aliases are unique, up to 32 letters, digits, - and _, and start with a letter.
+Giving a task a new alias replaces its old one. Without arguments, lists the
+tasks that have an alias.`,
+	Example: `  wrok alias 42 login-bug
+  wrok start login-bug
+  wrok alias login-bug auth-bug   # Rename
+  wrok alias 42 --clear
+  wrok alias`,
+	Args: cobra.RangeArgs(0, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		remove, _ := cmd.Flags().GetBool("clear")
+
+		if len(args) == 0 {
+			listAliases()
+			return
+		}
+
+		taskID, err := resolveTaskID(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		switch {
+		case remove && len(args) == 1:
+			task, err := db.SetTaskAlias(taskID, "")
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fmt.Printf("Removed the alias of task #%d: %s\n", task.ID, task.Title)
+		case !remove && len(args) == 2:
+			task, err := db.SetTaskAlias(taskID, args[1])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fmt.Printf("🏷️  Task #%d is now also '%s': %s\n", task.ID, task.Alias, task.Title)
+		default:
+			fmt.Println("Error: give a task and an alias, or a task and --clear")
+		}
+	},
+}
+
+// listAliases prints the tasks that have an alias
+func listAliases() {
+	tasks, err := db.GetAliasedTasks()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if len(tasks) == 0 {
+		fmt.Println("No aliases yet. Give a task one with 'wrok alias 42 login-bug'.")
+		return
+	}
+
+	for _, task := range tasks {
+		fmt.Printf("  %-20s #%-5d %s [%s]\n", task.Alias, task.ID, task.Title, task.Status)
+	}
+}
+
+func init() {
+	aliasCmd.Flags().Bool("clear", false, "Remove the task's alias")
+}
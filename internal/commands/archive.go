@@ -2,26 +2,42 @@ package commands
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/workflow"
 )
 
 var archiveCmd = &cobra.Command{
-	Use:     "archive [task-id]",
+	Use:     "archive [task-id... | range | title]",
 	Aliases: []string{"a"},
-	Short:   "Archive a task",
-	Args:    cobra.ExactArgs(1),
+	Short:   "Archive tasks",
+	Long: `Archive a task, given by ID or part of its title. Several IDs or a range
+like 12-18 archive tasks in bulk, after asking for confirmation unless --yes
+is given. To archive a whole project, see 'wrok project archive'.`,
+	Example: `  wrok archive 42
+  wrok archive "old spike"
+  wrok archive 100-120 --yes`,
+	Args:    cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		initDB()
-		taskID, err := strconv.ParseUint(args[0], 10, 32)
+		taskIDs, err := resolveTaskIDs(args, append(workflow.Open(), workflow.Done)...)
 		if err != nil {
-			fmt.Printf("Error: invalid task ID '%s'\n", args[0])
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(taskIDs) > 1 {
+			for _, task := range confirmBulk(cmd, taskIDs, append(workflow.Open(), workflow.Done), "Archive %d task(s)?") {
+				if _, err := db.ArchiveTask(task.ID); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				fmt.Printf("🗃️  Archived task #%d: %s\n", task.ID, task.Title)
+			}
 			return
 		}
 
-		task, err := db.ArchiveTask(uint(taskID))
+		task, err := db.ArchiveTask(taskIDs[0])
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 			return
@@ -38,16 +54,17 @@ var unarchiveCmd = &cobra.Command{
 	Use:     "unarchive [task-id]",
 	Aliases: []string{"ua"},
 	Short:   "Unarchive a task (move back to todo)",
+	Example: `  wrok unarchive 42`,
 	Args:    cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		initDB()
-		taskID, err := strconv.ParseUint(args[0], 10, 32)
+		taskID, err := resolveTaskID(args[0], "archived")
 		if err != nil {
-			fmt.Printf("Error: invalid task ID '%s'\n", args[0])
+			fmt.Printf("Error: %v\n", err)
 			return
 		}
 
-		task, err := db.UnarchiveTask(uint(taskID))
+		task, err := db.UnarchiveTask(taskID)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 			return
@@ -56,4 +73,8 @@ var unarchiveCmd = &cobra.Command{
 		fmt.Printf("📤 Unarchived task #%d: %s\n", task.ID, task.Title)
 		fmt.Printf("Status: %s\n", task.Status)
 	},
-}
\ No newline at end of file
+}
+
+func init() {
+	addYesFlag(archiveCmd, "Archive several tasks without asking for confirmation")
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+// defaultBackupKeep is how many automatic backups are kept when [backup] keep is unset
+const defaultBackupKeep = 7
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Save a compressed copy of the database",
+	Long: `Save a gzip-compressed copy of the database.
+
+Backups are named after the time they were taken, e.g.
+wrok-20250314-093000.db.gz, and go to ~/.wrok/backups unless --to names
+another directory or file. They are safe to take while a timer or the task
+list is running.
+
+Set auto = "daily" or "weekly" under [backup] in the config file to back up
+automatically on startup; keep sets how many automatic backups are kept (7 by
+default) and dir where they go.
+
+Examples:
+  wrok backup
+  wrok backup --to ~/Dropbox/wrok/
+  wrok backup --to /tmp/before-cleanup.db.gz`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		to, _ := cmd.Flags().GetString("to")
+
+		path, err := db.Backup(expandHome(to))
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("💾 Backed up database to %s (%s)\n", path, formatFileSize(path))
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Replace the database with a backup",
+	Long: `Replace the database with a backup made by 'wrok backup' (or a plain
+SQLite copy of wrok.db).
+
+The backup is checked before anything is replaced, and the current database is
+backed up to ~/.wrok/backups first, so a restore can be undone by restoring
+that file. It refuses while other wrok processes, such as a running timer,
+have the database open; quit them first.
+
+Examples:
+  wrok restore ~/.wrok/backups/wrok-20250314-093000.db.gz
+  wrok restore backup.db.gz --yes`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		src := expandHome(args[0])
+
+		if _, err := os.Stat(src); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if !confirm(cmd, fmt.Sprintf("Replace the current database with %s?", src)) {
+			fmt.Println("Restore cancelled.")
+			return
+		}
+
+		previous, err := db.Restore(src)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("♻️  Restored database from %s\n", src)
+		fmt.Printf("   The previous database was saved to %s\n", previous)
+	},
+}
+
+// applyAutoBackup takes the automatic backup configured under [backup] when one is due;
+// failures only warn so that a full disk never stops wrok from starting
+func applyAutoBackup(backup config.BackupConfig) {
+	if backup.Auto == "" {
+		return
+	}
+
+	keep := backup.Keep
+	if keep <= 0 {
+		keep = defaultBackupKeep
+	}
+	if _, err := db.RunAutoBackup(expandHome(backup.Dir), backup.Auto, keep, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: automatic backup failed: %v\n", err)
+	}
+}
+
+// expandHome replaces a leading ~ with the home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// formatFileSize returns the size of the file at path, e.g. "84.2 KB"
+func formatFileSize(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "unknown size"
+	}
+	size := float64(info.Size())
+	for _, unit := range []string{"B", "KB", "MB"} {
+		if size < 1024 {
+			if unit == "B" {
+				return fmt.Sprintf("%d %s", info.Size(), unit)
+			}
+			return fmt.Sprintf("%.1f %s", size, unit)
+		}
+		size /= 1024
+	}
+	return fmt.Sprintf("%.1f GB", size)
+}
+
+func init() {
+	backupCmd.Flags().String("to", "", "Directory or file to write the backup to (default ~/.wrok/backups)")
+	addYesFlag(restoreCmd, "Restore without asking for confirmation")
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/llm"
+	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/tui"
+	"github.com/balkashynov/wrok/internal/workflow"
+)
+
+var breakdownCmd = &cobra.Command{
+	Use:   "breakdown <task-id>",
+	Short: "Split a big task into steps proposed by an LLM",
+	Long: `Ask a language model to split a task into steps with estimates, pick the ones
+to keep and create them as tasks of their own, each marked as a step of it.
+The steps take over the task's project, tags, priority and due date.
+
+This is off unless [llm] in the config points at an OpenAI-compatible chat
+completions endpoint (a hosted API or a local server such as Ollama or
+llama.cpp); the key can come from WROK_LLM_KEY. Only the task's title,
+project, estimate and notes are sent.`,
+	Example: `  wrok breakdown 42
+  wrok breakdown 42 --dry-run   # Only print the proposals
+  wrok breakdown 42 --yes       # Create every proposed step`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if !llm.Enabled(cfg.LLM) {
+			fmt.Println("Breakdown is off. Point [llm] url and model in ~/.wrok/config.toml at an OpenAI-compatible chat completions endpoint to turn it on.")
+			return
+		}
+
+		taskID, err := resolveTaskID(args[0], workflow.Open()...)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		task, err := db.GetTaskByID(taskID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("🧩 Asking %s to break down #%d...\n", cfg.LLM.Model, task.ID)
+		subtasks, err := llm.Breakdown(cfg.LLM, *task)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		switch {
+		case dryRun:
+			for _, subtask := range subtasks {
+				fmt.Printf("  %-7s %s\n", formatStepEstimate(subtask.Estimate), subtask.Title)
+			}
+			return
+		case !yes:
+			if subtasks, err = tui.RunBreakdownTUI(*task, subtasks); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if len(subtasks) == 0 {
+				fmt.Println("Cancelled, nothing created.")
+				return
+			}
+		}
+
+		var tags []string
+		for _, tag := range task.Tags {
+			tags = append(tags, tag.Name)
+		}
+		level := ""
+		if task.Priority > 0 {
+			level = strconv.Itoa(task.Priority)
+		}
+		for _, subtask := range subtasks {
+			step, err := db.CreateTask(db.CreateTaskRequest{
+				Title:    subtask.Title,
+				Project:  task.Project,
+				Tags:     tags,
+				Priority: level,
+				DueDate:  task.Due,
+				Estimate: subtask.Estimate,
+				ParentID: task.ID,
+			})
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fmt.Printf("✅ #%d %s (%s)\n", step.ID, step.Title, formatStepEstimate(subtask.Estimate))
+		}
+		fmt.Printf("🧩 Broke #%d down into %d step(s)\n", task.ID, len(subtasks))
+	},
+}
+
+// formatStepEstimate renders a proposed step's estimate, "—" when it has none
+func formatStepEstimate(estimate time.Duration) string {
+	if estimate == 0 {
+		return "—"
+	}
+	return parser.FormatEstimate(estimate)
+}
+
+func init() {
+	breakdownCmd.Flags().Bool("dry-run", false, "Print the proposed steps without creating them")
+	breakdownCmd.Flags().BoolP("yes", "y", false, "Create every proposed step without asking")
+}
@@ -0,0 +1,160 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+var budgetCmd = &cobra.Command{
+	Use:   "budget [project] [amount/period]",
+	Short: "Set and check weekly or monthly time budgets per project",
+	Long: `Set and check time budgets per project.
+
+'wrok status', 'wrok stop' and the timer warn once a project has used 80%
+of its budget, and again when it goes over.
+
+Examples:
+  wrok budget                        # Show all budgets and their usage
+  wrok budget clientA 10h/week       # Set a weekly budget
+  wrok budget clientB 40h/month      # Set a monthly budget
+  wrok budget clientA --clear        # Remove a budget`,
+	Args: cobra.MaximumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		clear, _ := cmd.Flags().GetBool("clear")
+
+		switch {
+		case clear:
+			if len(args) != 1 {
+				fmt.Println("Error: --clear needs a project")
+				return
+			}
+			if err := saveBudget(args[0], ""); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fmt.Printf("🗑️  Removed the budget for @%s\n", args[0])
+
+		case len(args) == 2:
+			limit, period, err := parser.ParseBudget(args[1])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			spec := formatBudget(limit, period)
+			if err := saveBudget(args[0], spec); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fmt.Printf("💰 Budget for @%s set to %s\n", args[0], spec)
+			if status, err := db.GetBudgetStatus(args[0], time.Now()); err == nil && status != nil {
+				renderBudgetStatus(*status)
+			}
+
+		default:
+			statuses, err := db.GetBudgetStatuses(time.Now())
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if len(args) == 1 {
+				statuses = filterBudgets(statuses, args[0])
+			}
+			if len(statuses) == 0 {
+				fmt.Println("No budgets set. Use 'wrok budget <project> 10h/week' to add one.")
+				return
+			}
+			for _, status := range statuses {
+				renderBudgetStatus(status)
+			}
+		}
+	},
+}
+
+// saveBudget stores a project's budget spec in the config, removing it when spec is empty
+func saveBudget(project, spec string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if spec == "" {
+		if _, ok := cfg.Budgets[project]; !ok {
+			return fmt.Errorf("@%s has no budget", project)
+		}
+		delete(cfg.Budgets, project)
+	} else {
+		if cfg.Budgets == nil {
+			cfg.Budgets = make(map[string]string)
+		}
+		cfg.Budgets[project] = spec
+	}
+	return config.Save(cfg)
+}
+
+// formatBudget renders a budget in its canonical config form, e.g. "7h30m/week"
+func formatBudget(limit time.Duration, period string) string {
+	hours := int(limit.Hours())
+	minutes := int(limit.Minutes()) % 60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm/%s", minutes, period)
+	case minutes == 0:
+		return fmt.Sprintf("%dh/%s", hours, period)
+	default:
+		return fmt.Sprintf("%dh%dm/%s", hours, minutes, period)
+	}
+}
+
+// filterBudgets keeps the status of a single project
+func filterBudgets(statuses []db.BudgetStatus, project string) []db.BudgetStatus {
+	for _, status := range statuses {
+		if status.Project == project {
+			return []db.BudgetStatus{status}
+		}
+	}
+	return nil
+}
+
+// renderBudgetStatus prints a usage bar for one budget, followed by its warning if any
+func renderBudgetStatus(status db.BudgetStatus) {
+	fmt.Printf("@%-14s %s %s / %s this %s (%.0f%%)\n",
+		status.Project, progressBar(status.Ratio(), 20), formatDuration(status.Used), formatDuration(status.Limit), status.Period, status.Ratio()*100)
+	if warning := status.Warning(); warning != "" {
+		fmt.Printf("  %s\n", warning)
+	}
+}
+
+// printBudgetWarning prints the budget warning for a project, if it is near or over budget
+func printBudgetWarning(project string) {
+	status, err := db.GetBudgetStatus(project, time.Now())
+	if err != nil || status == nil {
+		return
+	}
+	if warning := status.Warning(); warning != "" {
+		fmt.Println(warning)
+	}
+}
+
+// printDailyLimitWarning prints a nudge when today's tracked time is past daily_limit
+func printDailyLimitWarning() {
+	status, err := db.GetDailyLimitStatus(time.Now())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if status == nil {
+		return
+	}
+	if warning := status.Warning(); warning != "" {
+		fmt.Println(warning)
+	}
+}
+
+func init() {
+	budgetCmd.Flags().Bool("clear", false, "Remove the project's budget")
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/tui"
+)
+
+var calCmd = &cobra.Command{
+	Use:   "cal",
+	Short: "Show a month calendar of due tasks",
+	Long: `Open a month calendar with the number of open tasks due on each day.
+
+Move between days with the arrow keys (↑/↓ a week at a time), switch months
+with [ and ], and jump back to today with t. Enter lists the tasks due on the
+selected day; enter on a task opens its details. Space picks the task up:
+move it to another day with the arrow keys and drop it with space or enter to
+make it due that day, keeping its due time. Esc puts it back.
+
+The week begins on the week_start day from the config file, and the current
+focus (see 'wrok focus') applies unless --no-focus is given.`,
+	Example: `  wrok cal
+  wrok cal --project web`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		project, _ := cmd.Flags().GetString("project")
+		noFocus, _ := cmd.Flags().GetBool("no-focus")
+
+		opts := db.TaskQueryOptions{Project: project}
+		if !noFocus {
+			applyFocus(&opts, loadFocus())
+		}
+
+		if err := tui.RunCalendarTUI(opts, firstWeekday()); err != nil {
+			fmt.Printf("Error running TUI: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	calCmd.Flags().StringP("project", "p", "", "Only show tasks of this project")
+	calCmd.Flags().Bool("no-focus", false, "Ignore the current focus context")
+}
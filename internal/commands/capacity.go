@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+var capacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Check whether the coming week's deadlines fit your time",
+	Long: `Weigh the estimates of the open tasks due in the coming days against the
+time you have for them, and flag the tasks that can't be done by their due date.
+
+The weekly capacity comes from capacity in the config (e.g. capacity = "30h")
+or --capacity, and is spread evenly over the working days: weekends and the
+holidays of the config get none, and today's share shrinks by what you have
+tracked today. A task's remaining work is its estimate less the time tracked
+on it. Tasks are worked in due date order, so a task fits when it and
+everything due before it can be done by the end of its due day. Overdue tasks
+never fit; tasks without an estimate are listed so you can add one.`,
+	Example: `  wrok capacity
+  wrok capacity --days 14
+  wrok capacity --capacity 20h`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		spec, _ := cmd.Flags().GetString("capacity")
+		days, _ := cmd.Flags().GetInt("days")
+
+		if spec == "" {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			spec = cfg.Capacity
+		}
+		if spec == "" {
+			fmt.Println("No capacity set. Add capacity = \"30h\" (hours a week for tasks) to ~/.wrok/config.toml or pass --capacity.")
+			return
+		}
+		weekly, err := db.ParseCapacity(spec)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if days < 1 {
+			fmt.Println("Error: --days must be at least 1")
+			return
+		}
+
+		initDB()
+		plan, err := db.GetCapacityPlan(weekly, days, time.Now())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		renderCapacityPlan(plan)
+	},
+}
+
+// renderCapacityPlan prints the committed work against the capacity, then each task's fit
+func renderCapacityPlan(plan *db.CapacityPlan) {
+	last := plan.End.AddDate(0, 0, -1)
+	fmt.Printf("📅 Capacity until %s (%s a week)\n", last.Format("Mon 2 Jan"), formatDuration(plan.Weekly))
+	fmt.Printf("%s %s committed of %s available (%.0f%%)\n",
+		progressBar(plan.Ratio(), 20), formatDuration(plan.Committed), formatDuration(plan.Available), plan.Ratio()*100)
+	if plan.Over() {
+		fmt.Printf("🚨 Over-committed by %s\n", formatDuration(plan.Committed-plan.Available))
+	} else if plan.Committed > 0 {
+		fmt.Printf("✅ %s to spare\n", formatDuration(plan.Available-plan.Committed))
+	}
+
+	if len(plan.Tasks) == 0 && len(plan.Unestimated) == 0 {
+		fmt.Println("\nNothing due in that time.")
+		return
+	}
+
+	if len(plan.Tasks) > 0 {
+		fmt.Printf("\n%-11s %-6s %-13s %s\n", "DUE", "LEFT", "FIT", "TASK")
+		for _, task := range plan.Tasks {
+			fit := "ok"
+			switch {
+			case task.Task.Due.Before(plan.Start):
+				fit = "⚠ overdue"
+			case !task.Fits():
+				fit = "⚠ short " + formatDuration(task.Needed-task.Available)
+			}
+			fmt.Printf("%-11s %-6s %-13s #%d %s\n",
+				task.Task.Due.Format("Mon 2 Jan"), formatDuration(task.Remaining), fit, task.Task.ID, capacityTaskTitle(task.Task.Title, task.Task.Project))
+		}
+	}
+
+	if len(plan.Unestimated) > 0 {
+		ids := make([]string, len(plan.Unestimated))
+		for i, task := range plan.Unestimated {
+			ids[i] = fmt.Sprintf("#%d", task.ID)
+		}
+		fmt.Printf("\n⚠️  Due without an estimate, not counted: %s (add one with 'wrok edit <id> --estimate 2h')\n", strings.Join(ids, ", "))
+	}
+}
+
+// capacityTaskTitle is a task's title followed by its project, if any
+func capacityTaskTitle(title, project string) string {
+	if project == "" {
+		return title
+	}
+	return title + " @" + project
+}
+
+func init() {
+	capacityCmd.Flags().String("capacity", "", "Hours a week for tasks, e.g. 30h (overrides capacity in the config)")
+	capacityCmd.Flags().Int("days", 7, "How many days ahead to plan, today included")
+}
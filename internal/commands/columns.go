@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/priority"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// columnData holds the per-task values that need extra queries, loaded only when a
+// selected column uses them
+type columnData struct {
+	now          time.Time
+	lastActivity map[uint]time.Time
+	tracked      map[uint]time.Duration
+}
+
+// listColumn is a column that can be picked with 'ls --columns'
+type listColumn struct {
+	header string
+	value  func(task models.Task, data *columnData) string
+}
+
+// columnNames lists the available columns in the order shown in help and errors
+var columnNames = []string{"id", "alias", "title", "project", "priority", "tags", "status", "due", "jira", "time", "age", "created", "pinned"}
+
+var listColumns = map[string]listColumn{
+	"id": {"ID", func(task models.Task, _ *columnData) string {
+		return fmt.Sprintf("%d", task.ID)
+	}},
+	"alias": {"ALIAS", func(task models.Task, _ *columnData) string {
+		return task.Alias
+	}},
+	"title": {"TITLE", func(task models.Task, _ *columnData) string {
+		return task.Title
+	}},
+	"project": {"PROJECT", func(task models.Task, _ *columnData) string {
+		return task.Project
+	}},
+	"priority": {"PRIORITY", func(task models.Task, _ *columnData) string {
+		return priority.Short(task.Priority)
+	}},
+	"tags": {"TAGS", func(task models.Task, _ *columnData) string {
+		var tagNames []string
+		for _, tag := range task.Tags {
+			tagNames = append(tagNames, tag.Name)
+		}
+		return strings.Join(tagNames, ",")
+	}},
+	"status": {"STATUS", func(task models.Task, _ *columnData) string {
+		return task.Status
+	}},
+	"due": {"DUE", func(task models.Task, _ *columnData) string {
+		if task.Due == nil {
+			return ""
+		}
+		return task.Due.Format("2006-01-02")
+	}},
+	"jira": {"JIRA", func(task models.Task, _ *columnData) string {
+		return task.JiraID
+	}},
+	"time": {"TIME", func(task models.Task, data *columnData) string {
+		tracked := data.tracked[task.ID]
+		if tracked == 0 {
+			return ""
+		}
+		return formatDuration(tracked)
+	}},
+	"age": {"AGE", func(task models.Task, data *columnData) string {
+		last, ok := data.lastActivity[task.ID]
+		if !ok {
+			last = task.UpdatedAt
+		}
+		age := parser.FormatAge(data.now.Sub(last))
+		if db.IsStale(task, last, data.now) {
+			age += "!"
+		}
+		return age
+	}},
+	"created": {"CREATED", func(task models.Task, _ *columnData) string {
+		return task.CreatedAt.Format("2006-01-02")
+	}},
+	"pinned": {"PINNED", func(task models.Task, _ *columnData) string {
+		if task.Pinned {
+			return "yes"
+		}
+		return ""
+	}},
+}
+
+// parseColumns splits a comma-separated column list, rejecting unknown names
+func parseColumns(spec string) ([]string, error) {
+	var columns []string
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := listColumns[name]; !ok {
+			return nil, fmt.Errorf("unknown column '%s', must be one of: %s", name, strings.Join(columnNames, ", "))
+		}
+		columns = append(columns, name)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns given, choose from: %s", strings.Join(columnNames, ", "))
+	}
+	return columns, nil
+}
+
+// loadColumnData runs only the queries the selected columns need
+func loadColumnData(tasks []models.Task, columns []string) (*columnData, error) {
+	data := &columnData{now: time.Now()}
+	for _, name := range columns {
+		var err error
+		switch {
+		case name == "time" && data.tracked == nil:
+			data.tracked, err = db.GetTrackedTime(tasks, data.now)
+		case name == "age" && data.lastActivity == nil:
+			data.lastActivity, err = db.GetLastActivity(tasks, data.now)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// renderColumns outputs tasks as a table of the chosen columns, each sized to its widest value
+func renderColumns(tasks []models.Task, columns []string) error {
+	data, err := loadColumnData(tasks, columns)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(tasks))
+	widths := make([]int, len(columns))
+	for i, name := range columns {
+		widths[i] = ansi.StringWidth(listColumns[name].header)
+	}
+	for r, task := range tasks {
+		rows[r] = make([]string, len(columns))
+		for i, name := range columns {
+			value := listColumns[name].value(task, data)
+			rows[r][i] = value
+			widths[i] = max(widths[i], ansi.StringWidth(value))
+		}
+	}
+
+	headers := make([]string, len(columns))
+	for i, name := range columns {
+		headers[i] = listColumns[name].header
+	}
+	printColumnRow(headers, widths)
+
+	total := len(widths) - 1
+	for _, width := range widths {
+		total += width
+	}
+	fmt.Println(strings.Repeat("-", total))
+
+	for _, row := range rows {
+		printColumnRow(row, widths)
+	}
+	return nil
+}
+
+// printColumnRow prints one table row, padding by grapheme display width and leaving the last cell unpadded
+func printColumnRow(cells []string, widths []int) {
+	var b strings.Builder
+	for i, cell := range cells {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(cell)
+		if i < len(cells)-1 {
+			b.WriteString(strings.Repeat(" ", widths[i]-ansi.StringWidth(cell)))
+		}
+	}
+	fmt.Println(strings.TrimRight(b.String(), " "))
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+var commentCmd = &cobra.Command{
+	Use:   "comment [task-id | title] [text...]",
+	Short: "Comment on a task or show its activity",
+	Long: `Add a comment to a task. Without text, show the task's activity: when it was
+created, status changes, comments and sessions, oldest first.
+
+Examples:
+  wrok comment 42 "waiting on review"
+  wrok comment 42`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		taskID, err := resolveTaskID(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if len(args) == 1 {
+			printActivity(taskID)
+			return
+		}
+
+		if _, err := db.AddComment(taskID, strings.Join(args[1:], " ")); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("💬 Commented on task #%d\n", taskID)
+	},
+}
+
+// activityIcons mark each kind of activity entry
+var activityIcons = map[string]string{
+	db.ActivityCreated: "✨",
+	db.ActivityStatus:  "🔄",
+	db.ActivityComment: "💬",
+	db.ActivitySession: "⏱️",
+}
+
+// printActivity outputs a task's activity feed
+func printActivity(taskID uint) {
+	items, err := db.GetTaskActivity(taskID)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	for _, item := range items {
+		fmt.Printf("%s  %s %s\n", item.At.Format("02/01/2006 15:04"), activityIcons[item.Kind], item.Text)
+	}
+}
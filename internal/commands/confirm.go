@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// addYesFlag adds the -y/--yes flag that skips confirm's question
+func addYesFlag(cmd *cobra.Command, usage string) {
+	cmd.Flags().BoolP("yes", "y", false, usage)
+}
+
+// confirm asks a yes/no question before a destructive change and reports whether
+// to go ahead. --yes answers it up front. Without a terminal there is nobody to
+// answer, so scripts get a refusal instead of a prompt and must pass --yes
+func confirm(cmd *cobra.Command, question string) bool {
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		return true
+	}
+	if !stdinIsTerminal() {
+		fmt.Printf("%s Not without --yes when stdin isn't a terminal.\n", question)
+		return false
+	}
+
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// confirmBulk loads the tasks ids refer to that have one of statuses, lists them
+// and asks question, which gets their count in place of %d. It returns the
+// tasks to go ahead with, none if the answer was no
+func confirmBulk(cmd *cobra.Command, ids []uint, statuses []string, question string) []models.Task {
+	var tasks []models.Task
+	for _, id := range ids {
+		task, err := db.GetTaskByID(id)
+		if err != nil {
+			continue
+		}
+		for _, status := range statuses {
+			if task.Status == status {
+				tasks = append(tasks, *task)
+				break
+			}
+		}
+	}
+	if len(tasks) == 0 {
+		fmt.Printf("Nothing to do: none of the tasks is %s.\n", strings.Join(statuses, " or "))
+		return nil
+	}
+
+	for _, task := range tasks {
+		fmt.Printf("  #%d [%s] %s\n", task.ID, task.Status, task.Title)
+	}
+	if !confirm(cmd, fmt.Sprintf(question, len(tasks))) {
+		fmt.Println("Nothing changed.")
+		return nil
+	}
+	return tasks
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// checkDanglingSessions looks for sessions left running longer than
+// dangling_after, which would otherwise count as absurd durations in every
+// report. Per on_dangling it offers to close each one at a sane end time
+// ("ask", the default), closes them right away ("close") or does nothing ("off").
+// Without a terminal to ask on, it only warns
+func checkDanglingSessions(cfg *config.Config) {
+	mode := strings.ToLower(strings.TrimSpace(cfg.OnDangling))
+	switch mode {
+	case "off":
+		return
+	case "", "ask", "close":
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: ignoring on_dangling: '%s' isn't ask, close or off\n", cfg.OnDangling)
+		mode = "ask"
+	}
+
+	after, err := db.DanglingAfter()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	now := time.Now()
+	dangling, err := db.GetDanglingSessions(after, now)
+	if err != nil || len(dangling) == 0 {
+		return
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for _, candidate := range dangling {
+		session := candidate.Session
+		fmt.Fprintf(os.Stderr, "⏰ Session #%d on #%d %s has been running since %s (%s).\n",
+			session.ID, session.TaskID, session.Task.Title,
+			session.StartedAt.Format("Mon 02/01 15:04"), formatDuration(now.Sub(session.StartedAt)))
+
+		end := candidate.End
+		switch {
+		case mode == "close":
+		case !stdinIsTerminal():
+			fmt.Fprintf(os.Stderr, "   Stop it with 'wrok stop', or set on_dangling = \"close\" to have it closed at %s.\n",
+				end.Format("Mon 02/01 15:04"))
+			continue
+		default:
+			var ok bool
+			if end, ok = askDanglingEnd(reader, candidate); !ok {
+				continue
+			}
+		}
+
+		closed, err := db.CloseSessionAt(session.ID, end)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "   Error: %v\n", err)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "   Closed at %s after %s.\n",
+			closed.FinishedAt.Format("Mon 02/01 15:04"), formatDuration(time.Duration(closed.DurationSeconds)*time.Second))
+	}
+}
+
+// askDanglingEnd asks when a dangling session ended, offering its sane end.
+// It reports false when the session should keep running
+func askDanglingEnd(reader *bufio.Reader, candidate db.DanglingSession) (time.Time, bool) {
+	for {
+		fmt.Fprintf(os.Stderr, "   Close it at %s? [Y/n, or HH:MM / dd/mm/yyyy HH:MM] ", candidate.End.Format("Mon 02/01 15:04"))
+		answer, err := reader.ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		switch {
+		case err != nil && answer == "":
+			fmt.Fprintln(os.Stderr)
+			return time.Time{}, false
+		case answer == "" || answer == "y" || answer == "yes":
+			return candidate.End, true
+		case answer == "n" || answer == "no":
+			fmt.Fprintln(os.Stderr, "   Left running.")
+			return time.Time{}, false
+		}
+
+		end, err := parser.ParseClockTime(answer, candidate.Session.StartedAt)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "   %v\n", err)
+			continue
+		}
+		if !end.After(candidate.Session.StartedAt) || end.After(time.Now()) {
+			fmt.Fprintln(os.Stderr, "   The end must be after the session started and not in the future.")
+			continue
+		}
+		return end, true
+	}
+}
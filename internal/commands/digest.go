@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/digest"
+)
+
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Write or email a weekly summary",
+	Long: `Sum up the week in Markdown: hours per project, tasks completed, deadlines
+coming up in the next 7 days and overdue tasks. It is printed, written to a
+file with --out, or emailed with --email through the [smtp] server of the
+config (the password can come from WROK_SMTP_PASSWORD instead).
+
+The layout comes from ~/.wrok/digest.tmpl when that file exists, a Go
+text/template that gets the digest's Start, End, Total, Sessions, Projects,
+Completed, Upcoming and Overdue, and the functions hours and date. Start from
+the built-in one: wrok digest --default-template > ~/.wrok/digest.tmpl
+
+Run it from cron on Friday afternoons to get it every week.`,
+	Example: `  wrok digest
+  wrok digest --out weekly.md
+  wrok digest --last --email me@example.com
+  wrok digest --default-template > ~/.wrok/digest.tmpl`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		emails, _ := cmd.Flags().GetStringSlice("email")
+		out, _ := cmd.Flags().GetString("out")
+		last, _ := cmd.Flags().GetBool("last")
+		showTemplate, _ := cmd.Flags().GetBool("default-template")
+
+		if showTemplate {
+			fmt.Print(digest.DefaultTemplate)
+			return
+		}
+
+		initDB()
+		now := time.Now()
+		at := now
+		if last {
+			at = now.AddDate(0, 0, -7)
+		}
+		summary, err := db.GetDigest(at, now)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		text, err := digest.Render(summary)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if out == "" && len(emails) == 0 {
+			fmt.Print(text)
+			return
+		}
+		if out != "" {
+			if err := os.WriteFile(out, []byte(text), 0644); err != nil {
+				fmt.Printf("Error: failed to write %s: %v\n", out, err)
+				return
+			}
+			fmt.Printf("📝 Digest written to %s\n", out)
+		}
+		if len(emails) > 0 {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if err := digest.Send(cfg.SMTP, emails, digest.Subject(summary), text); err != nil {
+				fmt.Printf("Error: failed to send the digest: %v\n", err)
+				return
+			}
+			fmt.Printf("📧 Digest sent to %s\n", strings.Join(emails, ", "))
+		}
+	},
+}
+
+func init() {
+	digestCmd.Flags().StringSlice("email", nil, "Email the digest to these addresses (comma-separated) through [smtp] in the config")
+	digestCmd.Flags().StringP("out", "o", "", "Write the digest to this file")
+	digestCmd.Flags().Bool("last", false, "Sum up the previous week")
+	digestCmd.Flags().Bool("default-template", false, "Print the built-in template, to start an override from")
+}
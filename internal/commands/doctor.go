@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the database for problems",
+	Long: `Check the database for inconsistencies and optionally repair them.
+
+Checks:
+  - orphaned sessions (their task no longer exists)
+  - active sessions started more than 24h ago
+  - dangling task/tag links
+  - duplicate tags differing only by case (or separator, see below)
+  - tags named against the [tags] policy of the config
+  - schema drift (missing tables or columns, pending migrations)
+
+With lowercase = true and/or separator = "-" (or "_") under [tags] in the
+config, new tags are created in that form; --fix merges the tags that become
+duplicates under it, like Backend/backend or code_review/code-review, moving
+their tasks onto the oldest one, and renames the rest to fit.
+
+Examples:
+  wrok doctor          # Report problems
+  wrok doctor --fix    # Repair problems and vacuum the database`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		fix, _ := cmd.Flags().GetBool("fix")
+
+		report, err := db.RunDoctor(24 * time.Hour)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		printDoctorReport(report)
+
+		if !fix {
+			if report.HasIssues() {
+				fmt.Println("\nRun 'wrok doctor --fix' to repair these problems.")
+			}
+			return
+		}
+
+		if report.HasIssues() {
+			if err := db.FixDoctorIssues(report); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fmt.Println("\n🔧 Repaired all problems")
+		}
+
+		if err := db.Vacuum(); err != nil {
+			fmt.Printf("Error vacuuming database: %v\n", err)
+			return
+		}
+		fmt.Println("🧹 Database vacuumed")
+	},
+}
+
+// printDoctorReport outputs the result of every check
+func printDoctorReport(report *db.DoctorReport) {
+	printDoctorCheck("Orphaned sessions", len(report.OrphanedSessions))
+	for _, session := range report.OrphanedSessions {
+		fmt.Printf("    session #%d → missing task #%d\n", session.ID, session.TaskID)
+	}
+
+	printDoctorCheck("Active sessions older than 24h", len(report.StaleSessions))
+	for _, session := range report.StaleSessions {
+		fmt.Printf("    session #%d on task #%d started %s\n", session.ID, session.TaskID, session.StartedAt.Format("02/01/2006 15:04"))
+	}
+
+	printDoctorCheck("Dangling task tags", len(report.DanglingTaskTags))
+	for _, tt := range report.DanglingTaskTags {
+		fmt.Printf("    task #%d ↔ tag #%d\n", tt.TaskID, tt.TagID)
+	}
+
+	printDoctorCheck("Duplicate tags (case)", len(report.DuplicateTags))
+	for _, group := range report.DuplicateTags {
+		fmt.Printf("    %s\n", formatTagGroup(group))
+	}
+
+	printDoctorCheck("Tag names ([tags] policy)", len(report.MisnamedTags))
+	for _, tag := range report.MisnamedTags {
+		fmt.Printf("    %q → %q\n", tag.Name, db.NormalizeTagName(tag.Name))
+	}
+
+	printDoctorCheck(fmt.Sprintf("Schema drift (version %d)", report.SchemaVersion), len(report.SchemaDrift))
+	for _, drift := range report.SchemaDrift {
+		fmt.Printf("    %s\n", drift)
+	}
+}
+
+// printDoctorCheck prints a single check line with its status
+func printDoctorCheck(name string, problems int) {
+	if problems == 0 {
+		fmt.Printf("✅ %s: ok\n", name)
+	} else {
+		fmt.Printf("❌ %s: %d found\n", name, problems)
+	}
+}
+
+// formatTagGroup renders a group of duplicate tags, the first one being kept on --fix
+func formatTagGroup(group []models.Tag) string {
+	var names []string
+	for _, tag := range group {
+		names = append(names, fmt.Sprintf("%q", tag.Name))
+	}
+	return fmt.Sprintf("%s (keeps %q)", strings.Join(names, ", "), group[0].Name)
+}
+
+func init() {
+	doctorCmd.Flags().Bool("fix", false, "Repair problems and vacuum the database")
+}
@@ -2,25 +2,46 @@ package commands
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/workflow"
 )
 
 var doneCmd = &cobra.Command{
-	Use:   "done [task-id]",
-	Short: "Mark a task as completed",
-	Args:  cobra.ExactArgs(1),
+	Use:   "done [task-id... | range | title]",
+	Short: "Mark tasks as completed",
+	Long: `Mark a task as completed.
+
+The task can be given by ID or by part of its title; when several open
+tasks match, you are asked which one you meant. Several IDs or a range
+like 12-18 complete tasks in bulk, after asking for confirmation unless
+--yes is given.
+
+Examples:
+  wrok done 42
+  wrok done "login bug"
+  wrok done 12-18 21 --yes`,
+	Args:  cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		initDB()
-		taskID, err := strconv.ParseUint(args[0], 10, 32)
+		taskIDs, err := resolveTaskIDs(args, workflow.Open()...)
 		if err != nil {
-			fmt.Printf("Error: invalid task ID '%s'\n", args[0])
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(taskIDs) > 1 {
+			for _, task := range confirmBulk(cmd, taskIDs, workflow.Open(), "Mark %d task(s) as done?") {
+				if _, err := db.MarkTaskDone(task.ID); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				fmt.Printf("✅ Marked task #%d as done: %s\n", task.ID, task.Title)
+			}
 			return
 		}
 
-		task, err := db.MarkTaskDone(uint(taskID))
+		task, err := db.MarkTaskDone(taskIDs[0])
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 			return
@@ -36,16 +57,17 @@ var doneCmd = &cobra.Command{
 var undoneCmd = &cobra.Command{
 	Use:   "undone [task-id]",
 	Short: "Mark a completed task back to todo status",
+	Example: `  wrok undone 42`,
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		initDB()
-		taskID, err := strconv.ParseUint(args[0], 10, 32)
+		taskID, err := resolveTaskID(args[0], "done")
 		if err != nil {
-			fmt.Printf("Error: invalid task ID '%s'\n", args[0])
+			fmt.Printf("Error: %v\n", err)
 			return
 		}
 
-		task, err := db.MarkTaskUndone(uint(taskID))
+		task, err := db.MarkTaskUndone(taskID)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 			return
@@ -54,4 +76,8 @@ var undoneCmd = &cobra.Command{
 		fmt.Printf("↩️  Marked task #%d back to todo: %s\n", task.ID, task.Title)
 		fmt.Printf("Status: %s\n", task.Status)
 	},
-}
\ No newline at end of file
+}
+
+func init() {
+	addYesFlag(doneCmd, "Complete several tasks without asking for confirmation")
+}
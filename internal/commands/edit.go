@@ -2,54 +2,84 @@ package commands
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/editor"
+	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/priority"
 	"github.com/balkashynov/wrok/internal/tui"
+	"github.com/balkashynov/wrok/internal/workflow"
 )
 
 var editCmd = &cobra.Command{
-	Use:   "edit <task_id>",
+	Use:   "edit <task_id | title>",
 	Short: "Edit an existing task",
 	Long: `Edit an existing task in interactive mode.
 
 Opens the same interface as 'wrok add' but with all fields pre-populated
 with the current task data. You can modify any field and save changes.
 
+With --notes-editor, open the task's notes in $VISUAL or $EDITOR instead,
+which suits long notes. The notes are saved when the editor exits.
+
+Field flags change just those fields, without the interface; everything else
+is left as it is. An empty value ("" or none) clears a field.
+
 Usage:
-  wrok edit 42    - Edit task with ID 42`,
+  wrok edit 42                 - Edit task with ID 42
+  wrok edit "login"            - Edit the task whose title matches "login"
+  wrok edit 42 --notes-editor  - Edit the notes of task 42 in your editor
+  wrok edit 42 --priority high - Only change the priority`,
+	Example: `  wrok edit 42
+  wrok edit "login"
+  wrok edit 42 --notes-editor
+  wrok edit 42 --priority high --due "3 days" --add-tag infra --remove-tag wip --no-ui
+  wrok edit 42 --due none -p ""
+  wrok edit 42 --status review`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		initDB()
-		// Parse task ID
-		taskID, err := strconv.ParseUint(args[0], 10, 32)
+		// Resolve the task by ID or title
+		taskID, err := resolveTaskID(args[0], append(workflow.Open(), workflow.Done)...)
 		if err != nil {
-			fmt.Printf("Error: Invalid task ID '%s'. Please provide a valid numeric ID.\n", args[0])
+			fmt.Printf("Error: %v\n", err)
 			return
 		}
 
 		// Fetch existing task
-		task, err := db.GetTaskByID(uint(taskID))
+		task, err := db.GetTaskByID(taskID)
 		if err != nil {
 			fmt.Printf("Error: Task #%d not found.\n", taskID)
 			return
 		}
 
+		if notesEditor, _ := cmd.Flags().GetBool("notes-editor"); notesEditor {
+			editTaskNotes(task.ID, task.Note)
+			return
+		}
+
+		if noUI, _ := cmd.Flags().GetBool("no-ui"); noUI || editFieldFlagsGiven(cmd) {
+			editTaskFromFlags(cmd, task.ID)
+			return
+		}
+
 		// Create prefilled data from existing task
 		prefilled := make(map[string]string)
 		prefilled["title"] = task.Title
 		prefilled["project"] = task.Project
 		prefilled["jira"] = task.JiraID
 		prefilled["notes"] = task.Note
+		prefilled["url"] = task.URL
+		if task.EstimateSeconds > 0 {
+			prefilled["estimate"] = parser.FormatEstimate(time.Duration(task.EstimateSeconds) * time.Second)
+		}
 
 		// Convert priority to string
 		if task.Priority > 0 {
-			priorities := []string{"", "low", "medium", "high"}
-			if int(task.Priority) < len(priorities) {
-				prefilled["priority"] = priorities[task.Priority]
-			}
+			prefilled["priority"] = priority.Name(task.Priority)
 		}
 
 		// Convert tags to comma-separated string with # prefix for each tag
@@ -73,6 +103,197 @@ Usage:
 	},
 }
 
+// editTaskNotes opens a task's notes in the user's editor and saves them if changed
+func editTaskNotes(taskID uint, note string) {
+	edited, err := editor.Edit(note)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if edited == note {
+		fmt.Println("Notes unchanged.")
+		return
+	}
+
+	if _, err := db.SetTaskNote(taskID, edited); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("📝 Updated notes for task #%d\n", taskID)
+}
+
+// editFieldFlags are the edit flags that change a field of the task
+var editFieldFlags = []string{"title", "project", "tags", "add-tag", "remove-tag", "priority", "jira", "due", "url", "note", "estimate", "status"}
+
+// editFieldFlagsGiven reports whether any field flag was given
+func editFieldFlagsGiven(cmd *cobra.Command) bool {
+	for _, name := range editFieldFlags {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNone reports whether a flag value asks to clear its field
+func isNone(value string) bool {
+	value = strings.TrimSpace(value)
+	return value == "" || strings.EqualFold(value, "none")
+}
+
+// editTaskFromFlags changes the fields of a task given as flags, leaving the rest alone
+func editTaskFromFlags(cmd *cobra.Command, taskID uint) {
+	if !editFieldFlagsGiven(cmd) {
+		fmt.Println("Error: nothing to change, give fields like --priority high or --add-tag infra")
+		return
+	}
+
+	req, err := editRequestFromFlags(cmd, taskID)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	// Check the move first, so a refused status changes nothing
+	status, err := editStatusFromFlags(cmd, taskID)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	task, err := db.UpdateTask(req)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if status != "" {
+		if task, err = db.SetTaskStatus(task.ID, status); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+	}
+
+	fmt.Printf("✏️  Updated task #%d: %s\n", task.ID, task.Title)
+	if status != "" {
+		fmt.Printf("  Status: %s\n", task.Status)
+	}
+	if task.Project != "" {
+		fmt.Printf("  Project: %s\n", task.Project)
+	}
+	if len(task.Tags) > 0 {
+		var tagNames []string
+		for _, tag := range task.Tags {
+			tagNames = append(tagNames, tag.Name)
+		}
+		fmt.Printf("  Tags: %s\n", strings.Join(tagNames, ", "))
+	}
+	if task.Priority > 0 {
+		fmt.Printf("  Priority: %s\n", priority.Name(task.Priority))
+	}
+	if task.Due != nil {
+		fmt.Printf("  Due: %s\n", parser.FormatDueDate(task.Due))
+	}
+	if task.EstimateSeconds > 0 {
+		fmt.Printf("  Estimate: %s\n", parser.FormatEstimate(time.Duration(task.EstimateSeconds)*time.Second))
+	}
+}
+
+// editStatusFromFlags returns the status given with --status, or "" if none was
+// given or the task already has it
+func editStatusFromFlags(cmd *cobra.Command, taskID uint) (string, error) {
+	if !cmd.Flags().Changed("status") {
+		return "", nil
+	}
+	value, _ := cmd.Flags().GetString("status")
+	status, err := workflow.Parse(value)
+	if err != nil {
+		return "", err
+	}
+	task, err := db.GetTaskByID(taskID)
+	if err != nil {
+		return "", err
+	}
+	if task.Status == status {
+		return "", nil
+	}
+	return status, workflow.CheckMove(task.Status, status)
+}
+
+// editRequestFromFlags builds an update of only the fields given as flags
+func editRequestFromFlags(cmd *cobra.Command, taskID uint) (db.UpdateTaskRequest, error) {
+	flags := cmd.Flags()
+	req := db.UpdateTaskRequest{ID: taskID}
+	text := func(name string) *string {
+		if !flags.Changed(name) {
+			return nil
+		}
+		value, _ := flags.GetString(name)
+		value = strings.TrimSpace(value)
+		return &value
+	}
+
+	req.Title = text("title")
+	req.Project = text("project")
+	req.JiraID = text("jira")
+	req.URL = text("url")
+	req.Note = text("note")
+
+	if flags.Changed("tags") {
+		tags, _ := flags.GetStringSlice("tags")
+		req.Tags = &tags
+	}
+	req.AddTags, _ = flags.GetStringSlice("add-tag")
+	req.RemoveTags, _ = flags.GetStringSlice("remove-tag")
+
+	if value := text("priority"); value != nil {
+		if isNone(*value) {
+			*value = ""
+		} else if !priority.Valid(*value) {
+			return req, fmt.Errorf("invalid priority '%s'. Use: %s", *value, priority.Hint())
+		}
+		req.Priority = value
+	}
+
+	if value := text("due"); value != nil {
+		if isNone(*value) {
+			req.ClearDue = true
+		} else {
+			due, err := parser.ParseDueDate(*value)
+			if err != nil {
+				return req, fmt.Errorf("invalid due date '%s': %w", *value, err)
+			}
+			req.DueDate = due
+		}
+	}
+
+	if value := text("estimate"); value != nil {
+		var estimate time.Duration
+		if !isNone(*value) {
+			parsed, err := parser.ParseEstimate(*value)
+			if err != nil {
+				return req, fmt.Errorf("invalid estimate '%s': %w", *value, err)
+			}
+			estimate = parsed
+		}
+		req.Estimate = &estimate
+	}
+
+	return req, nil
+}
+
 func init() {
-	// Add flags if needed in the future
-}
\ No newline at end of file
+	editCmd.Flags().Bool("notes-editor", false, "Edit the task's notes in $VISUAL or $EDITOR")
+	editCmd.Flags().Bool("no-ui", false, "Change the fields given as flags without opening the interface")
+	editCmd.Flags().String("title", "", "New title")
+	editCmd.Flags().StringP("project", "p", "", "Project name (\"\" to remove)")
+	editCmd.Flags().StringSliceP("tags", "t", []string{}, "Replace all tags (comma-separated)")
+	editCmd.Flags().StringSlice("add-tag", []string{}, "Add tags (comma-separated or repeated)")
+	editCmd.Flags().StringSlice("remove-tag", []string{}, "Remove tags (comma-separated or repeated)")
+	editCmd.Flags().String("priority", "", "Priority: a level name or its number (none to remove)")
+	editCmd.Flags().String("jira", "", "JIRA ticket ID")
+	editCmd.Flags().String("due", "", "Due date: dd/mm/yyyy, X days, X hours, X weeks, X business days, friday (none to remove)")
+	editCmd.Flags().String("url", "", "Related URL")
+	editCmd.Flags().String("note", "", "Replace the notes")
+	editCmd.Flags().String("estimate", "", "Estimate, e.g. 4h or 1h30m (none to remove)")
+	editCmd.Flags().String("status", "", "Move to a status: todo, done, archived or one configured in [status]")
+}
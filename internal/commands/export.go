@@ -0,0 +1,257 @@
+package commands
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// exportUser is who the exported time entries belong to
+type exportUser struct {
+	Name  string
+	Email string
+}
+
+// exportFormat is a CSV column layout another time tracker can import
+type exportFormat struct {
+	header []string
+	row    func(session models.Session, user exportUser) []string
+}
+
+// exportFormats are the formats 'wrok export --format' knows
+var exportFormats = map[string]exportFormat{
+	// Toggl Track's CSV import: dates as YYYY-MM-DD, durations as HH:MM:SS
+	"toggl-csv": {
+		header: []string{"User", "Email", "Client", "Project", "Task", "Description", "Billable",
+			"Start date", "Start time", "End date", "End time", "Duration", "Tags"},
+		row: func(session models.Session, user exportUser) []string {
+			start, end := session.StartedAt, *session.FinishedAt
+			return []string{
+				user.Name, user.Email, "", session.Task.Project, "", session.Task.Title, "No",
+				start.Format("2006-01-02"), start.Format("15:04:05"),
+				end.Format("2006-01-02"), end.Format("15:04:05"),
+				formatClockDuration(end.Sub(start)), exportTags(session.Task, ", "),
+			}
+		},
+	},
+	// Clockify's CSV import: dates as MM/DD/YYYY, durations as HH:MM:SS and in hours
+	"clockify-csv": {
+		header: []string{"Project", "Client", "Description", "Task", "User", "Email", "Tags", "Billable",
+			"Start Date", "Start Time", "End Date", "End Time", "Duration (h)", "Duration (decimal)"},
+		row: func(session models.Session, user exportUser) []string {
+			start, end := session.StartedAt, *session.FinishedAt
+			return []string{
+				session.Task.Project, "", session.Task.Title, "", user.Name, user.Email,
+				exportTags(session.Task, ", "), "No",
+				start.Format("01/02/2006"), start.Format("15:04:05"),
+				end.Format("01/02/2006"), end.Format("15:04:05"),
+				formatClockDuration(end.Sub(start)), fmt.Sprintf("%.2f", end.Sub(start).Hours()),
+			}
+		},
+	},
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export tracked time for Toggl or Clockify, or tasks as org-mode or TaskPaper",
+	Long: `Export tracked sessions as CSV in the column layout Toggl Track or Clockify
+import, so the time can be uploaded to a team's tracker as is.
+
+Formats:
+  toggl-csv      Toggl Track CSV import (Start date 2024-01-31, 24h times)
+  clockify-csv   Clockify CSV import (Start Date 01/31/2024, 24h times)
+  org            Tasks as an org-mode outline: a heading per project, TODO/DONE
+                 headings with [#A] priorities, :tags:, DEADLINE and notes
+  taskpaper      Tasks as a TaskPaper outline: "project:" lines, "- task" lines
+                 with @tags, @priority(), @due() and @done()
+
+The org and taskpaper formats export the tasks that aren't archived, filtered
+by --project and --tag; 'wrok import --org' and '--taskpaper' read them back.
+
+Each session becomes one time entry: the task's title is the description and
+its project and tags carry over. Running sessions are left out. Times are in
+the display timezone. Both services match entries to an account by email, so
+pass --email (and --user for the name).
+
+Use --unlocked to leave out the sessions locked with 'wrok lock', e.g. the ones
+already reported, or --locked to export only those.`,
+	Example: `  wrok export --format toggl-csv --email me@example.com --last-week -o toggl.csv
+  wrok export --format clockify-csv --email me@example.com --this-month
+  wrok export --format clockify-csv --project web --period 30d > web.csv
+  wrok export --format org -o ~/org/wrok.org`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		formatName, _ := cmd.Flags().GetString("format")
+		project, _ := cmd.Flags().GetString("project")
+		tag, _ := cmd.Flags().GetString("tag")
+		output, _ := cmd.Flags().GetString("output")
+		name, _ := cmd.Flags().GetString("user")
+		email, _ := cmd.Flags().GetString("email")
+
+		if formatName == "" {
+			fmt.Printf("Error: specify an export format (--format %s)\n", strings.Join(exportFormatNames(), " or "))
+			return
+		}
+		if outline, ok := outlineFormats[formatName]; ok {
+			exportTasks(outline.write, strings.TrimPrefix(project, "@"), strings.TrimPrefix(tag, "#"), output)
+			return
+		}
+		format, ok := exportFormats[formatName]
+		if !ok {
+			fmt.Printf("Error: unknown format '%s', use one of: %s\n", formatName, strings.Join(exportFormatNames(), ", "))
+			return
+		}
+
+		locked, err := lockedFromFlags(cmd)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		query := db.TrackedTimeQuery{Project: strings.TrimPrefix(project, "@"), Tag: strings.TrimPrefix(tag, "#"), Locked: locked}
+		if period := periodFromFlags(cmd); period != "" {
+			from, to, err := parser.ParsePeriod(period, time.Now(), firstWeekday())
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			query.From, query.To = from, to
+		}
+
+		sessions, err := db.GetTrackedSessions(query)
+		if err != nil {
+			fmt.Printf("Error fetching sessions: %v\n", err)
+			return
+		}
+
+		out := io.Writer(os.Stdout)
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			defer f.Close()
+			out = f
+		}
+
+		written, err := writeExportCSV(out, format, sessions, exportUser{Name: name, Email: email})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if output != "" {
+			fmt.Printf("📤 Exported %d session(s) to %s\n", written, output)
+		}
+		if email == "" {
+			fmt.Fprintln(os.Stderr, "⚠️  No --email given; the import will ask which user the entries belong to")
+		}
+	},
+}
+
+// exportTasks writes the tasks that aren't archived, of a project and tag if
+// given, with write to output or stdout
+func exportTasks(write func(io.Writer, []models.Task) error, project, tag, output string) {
+	opts := db.TaskQueryOptions{HideArchived: true, Project: project, OrderBy: "id ASC"}
+	if tag != "" {
+		opts.Tags = []string{tag}
+	}
+	tasks, err := db.GetTasksWithOptions(opts)
+	if err != nil {
+		fmt.Printf("Error fetching tasks: %v\n", err)
+		return
+	}
+
+	out := io.Writer(os.Stdout)
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		defer f.Close()
+		out = f
+	}
+	if err := write(out, tasks); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if output != "" {
+		fmt.Printf("📤 Exported %d task(s) to %s\n", len(tasks), output)
+	}
+}
+
+// writeExportCSV writes the finished sessions in format and returns how many it wrote
+func writeExportCSV(out io.Writer, format exportFormat, sessions []models.Session, user exportUser) (int, error) {
+	w := csv.NewWriter(out)
+	if err := w.Write(format.header); err != nil {
+		return 0, err
+	}
+
+	written := 0
+	for _, session := range sessions {
+		if session.FinishedAt == nil {
+			continue
+		}
+		if err := w.Write(format.row(session, user)); err != nil {
+			return written, err
+		}
+		written++
+	}
+	w.Flush()
+	return written, w.Error()
+}
+
+// exportFormatNames returns the names of the export formats, sorted
+func exportFormatNames() []string {
+	var names []string
+	for name := range exportFormats {
+		names = append(names, name)
+	}
+	for name := range outlineFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// exportTags joins the names of a task's tags
+func exportTags(task models.Task, sep string) string {
+	names := make([]string, len(task.Tags))
+	for i, tag := range task.Tags {
+		names[i] = tag.Name
+	}
+	return strings.Join(names, sep)
+}
+
+// formatClockDuration formats a duration as HH:MM:SS, e.g. "01:05:09"
+func formatClockDuration(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
+
+func init() {
+	exportCmd.Flags().String("format", "", "Export format: toggl-csv, clockify-csv, org or taskpaper")
+	exportCmd.Flags().StringP("output", "o", "", "Write to this file instead of stdout")
+	exportCmd.Flags().String("project", "", "Only sessions (or tasks) of this project")
+	exportCmd.Flags().String("tag", "", "Only sessions of tasks (or tasks) with this tag")
+	exportCmd.Flags().Bool("locked", false, "Only locked sessions")
+	exportCmd.Flags().Bool("unlocked", false, "Only sessions that aren't locked")
+	exportCmd.Flags().String("user", "", "User name to put on every entry")
+	exportCmd.Flags().String("email", "", "Email of the account the entries belong to")
+	exportCmd.Flags().String("period", "", "Period: today, yesterday, this-week, last-week, this-month, last-month, this-year or e.g. 30d")
+	exportCmd.Flags().Bool("today", false, "Only today")
+	exportCmd.Flags().Bool("this-week", false, "Only this week")
+	exportCmd.Flags().Bool("last-week", false, "Only last week")
+	exportCmd.Flags().Bool("this-month", false, "Only this month")
+}
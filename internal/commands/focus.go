@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+var focusCmd = &cobra.Command{
+	Use:   "focus [@project] [#tag]",
+	Short: "Focus on a project or tag",
+	Long: `Set a persistent focus context.
+
+While a focus is set, 'ls', 'search' and 'stats' only show matching tasks
+and 'add' uses the focused project (and tag) unless others are given. The
+focus stays until it is replaced or cleared.
+
+The WROK_CONTEXT (same syntax as the arguments, e.g. "@web #api") and
+WROK_PROJECT environment variables override the stored focus, so a
+per-repo direnv file can scope wrok to the project at hand.
+
+Examples:
+  wrok focus @web            # Focus on project "web"
+  wrok focus '#backend'      # Focus on tag "backend" (quote # in the shell)
+  wrok focus @web '#api'     # Focus on both
+  wrok focus                 # Show the current focus
+  wrok focus --clear         # Clear the focus`,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		clear, _ := cmd.Flags().GetBool("clear")
+
+		if clear {
+			if err := db.ClearFocus(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fmt.Println("Focus cleared")
+			return
+		}
+
+		if len(args) == 0 {
+			focus, err := db.GetFocus()
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if env, ok := envFocus(focus); ok {
+				fmt.Printf("🎯 Focus: %s (from %s)\n", env, envFocusSource())
+				return
+			}
+			if !focus.IsSet() {
+				fmt.Println("No focus set. Use 'wrok focus @project' or 'wrok focus #tag'.")
+				return
+			}
+			fmt.Printf("🎯 Focus: %s\n", focus)
+			return
+		}
+
+		focus, err := parseFocusArgs(args)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if err := db.SetFocus(focus); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("🎯 Focus set: %s\n", focus)
+		if _, ok := envFocus(focus); ok {
+			fmt.Printf("Note: %s is set and overrides it in this shell\n", envFocusSource())
+		}
+	},
+}
+
+// parseFocusArgs turns "@project" / "#tag" arguments into a focus context
+func parseFocusArgs(args []string) (db.FocusContext, error) {
+	var focus db.FocusContext
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "@") && len(arg) > 1:
+			if focus.Project != "" {
+				return focus, fmt.Errorf("only one project can be focused")
+			}
+			focus.Project = arg[1:]
+		case strings.HasPrefix(arg, "#") && len(arg) > 1:
+			if focus.Tag != "" {
+				return focus, fmt.Errorf("only one tag can be focused")
+			}
+			focus.Tag = arg[1:]
+		default:
+			return focus, fmt.Errorf("invalid focus '%s', use @project or #tag", arg)
+		}
+	}
+	return focus, nil
+}
+
+// loadFocus returns the current focus context, ignoring read errors.
+// WROK_CONTEXT and WROK_PROJECT take precedence over the stored focus
+func loadFocus() db.FocusContext {
+	focus, err := db.GetFocus()
+	if err != nil {
+		focus = db.FocusContext{}
+	}
+	focus, _ = envFocus(focus)
+	return focus
+}
+
+// envFocus applies WROK_CONTEXT and then WROK_PROJECT on top of the stored focus.
+// ok is false when neither is set; an invalid WROK_CONTEXT is reported and ignored
+func envFocus(stored db.FocusContext) (focus db.FocusContext, ok bool) {
+	focus = stored
+	if context := strings.TrimSpace(os.Getenv("WROK_CONTEXT")); context != "" {
+		parsed, err := parseFocusArgs(strings.Fields(context))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring WROK_CONTEXT: %v\n", err)
+		} else {
+			focus, ok = parsed, true
+		}
+	}
+	if project := strings.TrimSpace(os.Getenv("WROK_PROJECT")); project != "" {
+		focus.Project = strings.TrimPrefix(project, "@")
+		ok = true
+	}
+	return focus, ok
+}
+
+// envFocusSource names the environment variables the focus comes from
+func envFocusSource() string {
+	var names []string
+	for _, name := range []string{"WROK_CONTEXT", "WROK_PROJECT"} {
+		if strings.TrimSpace(os.Getenv(name)) != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, " and ")
+}
+
+// applyFocus narrows query options to the current focus context
+func applyFocus(opts *db.TaskQueryOptions, focus db.FocusContext) {
+	if opts.Project == "" {
+		opts.Project = focus.Project
+	}
+	if focus.Tag != "" {
+		opts.Tags = append(opts.Tags, focus.Tag)
+	}
+}
+
+// applyFocusDefaults fills in the focused project and tag for a new task
+func applyFocusDefaults(project string, tags []string, focus db.FocusContext) (string, []string) {
+	if project == "" {
+		project = focus.Project
+	}
+	if focus.Tag != "" && !containsTag(tags, focus.Tag) {
+		tags = append(tags, focus.Tag)
+	}
+	return project, tags
+}
+
+// applyFocusToPrefilled fills in the focused project and tag for the add TUI
+func applyFocusToPrefilled(prefilled map[string]string, focus db.FocusContext) {
+	var tags []string
+	if prefilled["tags"] != "" {
+		tags = strings.Split(prefilled["tags"], ",")
+		for i := range tags {
+			tags[i] = strings.TrimSpace(tags[i])
+		}
+	}
+
+	project, tags := applyFocusDefaults(prefilled["project"], tags, focus)
+	if project != "" {
+		prefilled["project"] = project
+	}
+	if len(tags) > 0 {
+		prefilled["tags"] = strings.Join(tags, ", ")
+	}
+}
+
+// containsTag reports whether tags contains tag, ignoring case
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	focusCmd.Flags().Bool("clear", false, "Clear the current focus")
+}
@@ -0,0 +1,316 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/workflow"
+)
+
+// gitHookMarker identifies hook scripts written by wrok, so they can be replaced or removed
+const gitHookMarker = "# Installed by 'wrok git hook install'"
+
+// gitHookNames are the git hooks wrok installs
+var gitHookNames = []string{"post-checkout", "prepare-commit-msg"}
+
+var gitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "Link git branches and commits to tasks",
+	Long: `Link git branches and commits to tasks.
+
+A branch refers to a task when its name contains "wrok-42" (task #42) or the
+task's JIRA ID, e.g. "feature/wrok-42-login" or "fix/APP-123-crash".`,
+}
+
+var gitHookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Manage the git hooks of the current repository",
+}
+
+var gitHookInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the post-checkout and prepare-commit-msg hooks",
+	Long: `Install git hooks in the current repository:
+
+  post-checkout       after checking out a branch that refers to a task, suggest
+                      starting its timer. With auto_timer = true under [git] in
+                      the config, stop the running timer and start the task's
+  prepare-commit-msg  append the task reference (its JIRA ID, or wrok-42) to
+                      commit messages, taken from the branch or the running timer
+
+Existing hooks not written by wrok are left alone unless --force is given.`,
+	Example: `  wrok git hook install
+  wrok git hook install --force`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		force, _ := cmd.Flags().GetBool("force")
+
+		hooksDir, err := gitHooksDir()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		executable, err := os.Executable()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if err := os.MkdirAll(hooksDir, 0755); err != nil {
+			fmt.Printf("Error: failed to create %s: %v\n", hooksDir, err)
+			return
+		}
+
+		for _, name := range gitHookNames {
+			path := filepath.Join(hooksDir, name)
+			if existing, err := os.ReadFile(path); err == nil && !strings.Contains(string(existing), gitHookMarker) && !force {
+				fmt.Printf("⚠️  Skipped %s: a hook not written by wrok exists (use --force to replace it)\n", path)
+				continue
+			}
+
+			// The hook must never block a checkout or commit, so errors are swallowed
+			script := fmt.Sprintf("#!/bin/sh\n%s\n%q git hook %s \"$@\" || true\n", gitHookMarker, executable, name)
+			if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+				fmt.Printf("Error: failed to write %s: %v\n", path, err)
+				return
+			}
+			fmt.Printf("✅ Installed %s\n", path)
+		}
+	},
+}
+
+var gitHookUninstallCmd = &cobra.Command{
+	Use:     "uninstall",
+	Short:   "Remove the hooks installed by 'wrok git hook install'",
+	Example: `  wrok git hook uninstall`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		hooksDir, err := gitHooksDir()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		removed := 0
+		for _, name := range gitHookNames {
+			path := filepath.Join(hooksDir, name)
+			existing, err := os.ReadFile(path)
+			if err != nil || !strings.Contains(string(existing), gitHookMarker) {
+				continue
+			}
+			if err := os.Remove(path); err != nil {
+				fmt.Printf("Error: failed to remove %s: %v\n", path, err)
+				return
+			}
+			fmt.Printf("🗑️  Removed %s\n", path)
+			removed++
+		}
+		if removed == 0 {
+			fmt.Println("No wrok hooks installed in this repository.")
+		}
+	},
+}
+
+var gitPostCheckoutCmd = &cobra.Command{
+	Use:    "post-checkout <prev-head> <new-head> <branch-flag>",
+	Short:  "Run by the post-checkout hook",
+	Hidden: true,
+	Args:   cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		// File checkouts pass 0; only branch switches matter
+		if args[2] != "1" {
+			return
+		}
+		branch, err := gitCurrentBranch()
+		if err != nil || branch == "" {
+			return
+		}
+
+		initDB()
+		task, err := taskForBranch(branch)
+		if err != nil || task == nil {
+			return
+		}
+
+		active, err := db.GetActiveSession()
+		if err != nil {
+			fmt.Printf("wrok: %v\n", err)
+			return
+		}
+		if active != nil && active.TaskID == task.ID {
+			return
+		}
+
+		autoTimer := false
+		if cfg, err := config.Load(); err == nil {
+			autoTimer = cfg.Git.AutoTimer
+		}
+		if !autoTimer || !workflow.IsOpen(task.Status) {
+			fmt.Printf("wrok: branch %s is task #%d: %s\n", branch, task.ID, task.Title)
+			if workflow.IsOpen(task.Status) {
+				fmt.Printf("wrok: start tracking with 'wrok start %d --no-ui'\n", task.ID)
+			}
+			return
+		}
+
+		stopped, _, err := db.SwitchSession(task.ID)
+		if err != nil {
+			fmt.Printf("wrok: %v\n", err)
+			return
+		}
+		if stopped != nil {
+			fmt.Printf("wrok: ⏹️  stopped #%d after %s\n", stopped.TaskID, formatDuration(time.Duration(stopped.DurationSeconds)*time.Second))
+		}
+		fmt.Printf("wrok: ⏱️  started #%d: %s\n", task.ID, task.Title)
+	},
+}
+
+var gitPrepareCommitMsgCmd = &cobra.Command{
+	Use:    "prepare-commit-msg <message-file> [source] [sha]",
+	Short:  "Run by the prepare-commit-msg hook",
+	Hidden: true,
+	Args:   cobra.RangeArgs(1, 3),
+	Run: func(cmd *cobra.Command, args []string) {
+		// Merges, squashes and amends already have their message
+		if len(args) > 1 && (args[1] == "merge" || args[1] == "squash" || args[1] == "commit") {
+			return
+		}
+
+		initDB()
+		var task *models.Task
+		if branch, err := gitCurrentBranch(); err == nil && branch != "" {
+			task, _ = taskForBranch(branch)
+		}
+		if task == nil {
+			if active, err := db.GetActiveSession(); err == nil && active != nil {
+				task = &active.Task
+			}
+		}
+		if task == nil {
+			return
+		}
+
+		message, err := os.ReadFile(args[0])
+		if err != nil {
+			return
+		}
+		updated, changed := appendTaskReference(string(message), taskReference(*task))
+		if !changed {
+			return
+		}
+		if err := os.WriteFile(args[0], []byte(updated), 0644); err != nil {
+			fmt.Printf("wrok: failed to update commit message: %v\n", err)
+		}
+	},
+}
+
+// gitHooksDir returns the hooks directory of the current repository, honouring core.hooksPath
+func gitHooksDir() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return "", errors.New("not inside a git repository")
+	}
+	dir := strings.TrimSpace(string(out))
+	return filepath.Abs(dir)
+}
+
+// gitCurrentBranch returns the checked out branch, or "" for a detached HEAD
+func gitCurrentBranch() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return "", err
+	}
+	branch := strings.TrimSpace(string(out))
+	if branch == "HEAD" {
+		return "", nil
+	}
+	return branch, nil
+}
+
+// taskForBranch returns the task a branch name refers to, or nil if none.
+// A task ID ("wrok-42") wins over JIRA IDs; open tasks win over closed ones
+func taskForBranch(branch string) (*models.Task, error) {
+	taskID, jiraIDs := parser.ParseBranchRef(branch)
+	if taskID != 0 {
+		task, err := db.GetTaskByID(taskID)
+		if err != nil {
+			return nil, nil
+		}
+		return task, nil
+	}
+
+	for _, jiraID := range jiraIDs {
+		tasks, err := db.GetTasksByJiraID(jiraID)
+		if err != nil {
+			return nil, err
+		}
+		var match *models.Task
+		for i := range tasks {
+			if match == nil || (!workflow.IsOpen(match.Status) && workflow.IsOpen(tasks[i].Status)) {
+				match = &tasks[i]
+			}
+		}
+		if match != nil {
+			return match, nil
+		}
+	}
+	return nil, nil
+}
+
+// taskReference is how commits refer to a task: its JIRA ID, or wrok-<id>
+func taskReference(task models.Task) string {
+	if task.JiraID != "" {
+		return task.JiraID
+	}
+	return fmt.Sprintf("wrok-%d", task.ID)
+}
+
+// appendTaskReference adds a "Refs: <ref>" trailer to a commit message, above
+// git's comment lines. changed is false if the message already mentions ref
+func appendTaskReference(message, ref string) (updated string, changed bool) {
+	lines := strings.Split(message, "\n")
+	body := len(lines)
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			body = i
+			break
+		}
+	}
+
+	text := strings.TrimRight(strings.Join(lines[:body], "\n"), "\n ")
+	if strings.Contains(strings.ToUpper(text), strings.ToUpper(ref)) {
+		return message, false
+	}
+
+	trailer := "Refs: " + ref
+	if text != "" {
+		trailer = text + "\n\n" + trailer
+	} else {
+		// No message yet (editor commit): leave the first line free for the subject
+		trailer = "\n\n" + trailer
+	}
+	rest := strings.Join(lines[body:], "\n")
+	if rest != "" {
+		return trailer + "\n" + rest, true
+	}
+	return trailer + "\n", true
+}
+
+func init() {
+	gitHookInstallCmd.Flags().Bool("force", false, "Replace existing hooks not written by wrok")
+
+	gitHookCmd.AddCommand(gitHookInstallCmd)
+	gitHookCmd.AddCommand(gitHookUninstallCmd)
+	gitHookCmd.AddCommand(gitPostCheckoutCmd)
+	gitHookCmd.AddCommand(gitPrepareCommitMsgCmd)
+	gitCmd.AddCommand(gitHookCmd)
+}
@@ -0,0 +1,269 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+var goalCmd = &cobra.Command{
+	Use:   "goal",
+	Short: "Set weekly or monthly time goals and track progress",
+	Long: `Set weekly or monthly goals for tracked time, overall or per project or tag,
+and see how far along you are.
+
+Without a subcommand, shows every goal with this period's progress and how
+the last few periods went. 'wrok status', 'wrok stats' and the Stats screen of
+'wrok tui' show progress too. Each finished week or month is recorded, see
+'wrok goal history'.
+
+Examples:
+  wrok goal                       # Progress of all goals
+  wrok goal set 25h/week          # Overall weekly goal
+  wrok goal set 10h/week @web     # Goal for project "web"
+  wrok goal set 20h/month #review # Goal for tag "review"
+  wrok goal clear @web
+  wrok goal history`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		statuses, err := db.GetGoalStatuses(time.Now())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(statuses) == 0 {
+			fmt.Println("No goals set. Use 'wrok goal set 25h/week' to add one.")
+			return
+		}
+
+		fmt.Println("🎯 Goals")
+		for _, status := range statuses {
+			renderGoalStatus(status)
+			history, err := db.GetGoalHistory(status.Goal.ID, 8)
+			if err == nil && len(history) > 0 {
+				fmt.Printf("  %-14s last %d: %s\n", "", len(history), goalStreak(history))
+			}
+		}
+	},
+}
+
+var goalSetCmd = &cobra.Command{
+	Use:   "set <amount/period> [@project | #tag]",
+	Short: "Set a goal, e.g. 25h/week",
+	Example: `  wrok goal set 25h/week
+  wrok goal set 10h/week @web
+  wrok goal set 20h/month #review`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		amount, period, err := parser.ParseBudget(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		scope, target, err := parseGoalTarget(args[1:])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		goal, err := db.SetGoal(scope, target, amount, period)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("🎯 %s goal set to %s\n", db.GoalLabel(*goal), formatBudget(amount, period))
+		if statuses, err := db.GetGoalStatuses(time.Now()); err == nil {
+			for _, status := range statuses {
+				if status.Goal.ID == goal.ID {
+					renderGoalStatus(status)
+				}
+			}
+		}
+	},
+}
+
+var goalClearCmd = &cobra.Command{
+	Use:   "clear [@project | #tag]",
+	Short: "Remove a goal and its history",
+	Example: `  wrok goal clear          # The overall goal
+  wrok goal clear @web`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		scope, target, err := parseGoalTarget(args)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if err := db.ClearGoal(scope, target); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("🗑️  Removed the %s goal and its history\n", db.GoalLabel(models.Goal{Scope: scope, Target: target}))
+	},
+}
+
+var goalHistoryCmd = &cobra.Command{
+	Use:   "history [@project | #tag]",
+	Short: "Show how past weeks or months went against each goal",
+	Example: `  wrok goal history
+  wrok goal history @web --limit 26`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		statuses, err := db.GetGoalStatuses(time.Now())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(args) == 1 {
+			scope, target, err := parseGoalTarget(args)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			var matching []db.GoalStatus
+			for _, status := range statuses {
+				if status.Goal.Scope == scope && status.Goal.Target == target {
+					matching = append(matching, status)
+				}
+			}
+			if len(matching) == 0 {
+				fmt.Printf("No goal set for %s\n", args[0])
+				return
+			}
+			statuses = matching
+		}
+		if len(statuses) == 0 {
+			fmt.Println("No goals set. Use 'wrok goal set 25h/week' to add one.")
+			return
+		}
+
+		for i, status := range statuses {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("🎯 %s: %s\n", db.GoalLabel(status.Goal), formatBudget(status.Target, status.Goal.Period))
+			history, err := db.GetGoalHistory(status.Goal.ID, limit)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if len(history) == 0 {
+				fmt.Printf("  No finished %ss yet\n", status.Goal.Period)
+				continue
+			}
+			for _, period := range history {
+				mark := "❌"
+				if period.Met() {
+					mark = "✅"
+				}
+				tracked := time.Duration(period.TrackedSeconds) * time.Second
+				target := time.Duration(period.TargetSeconds) * time.Second
+				fmt.Printf("  %s %s  %s %s / %s\n", mark, formatGoalPeriod(status.Goal.Period, period.PeriodStart),
+					progressBar(ratio(tracked, target), 20), formatDuration(tracked), formatDuration(target))
+			}
+		}
+	},
+}
+
+// parseGoalTarget reads the optional @project or #tag argument of a goal command
+func parseGoalTarget(args []string) (scope, target string, err error) {
+	if len(args) == 0 {
+		return models.GoalOverall, "", nil
+	}
+	arg := args[0]
+	switch {
+	case strings.HasPrefix(arg, "@") && len(arg) > 1:
+		return models.GoalProject, arg[1:], nil
+	case strings.HasPrefix(arg, "#") && len(arg) > 1:
+		return models.GoalTag, arg[1:], nil
+	}
+	return "", "", fmt.Errorf("invalid goal target '%s', use @project or #tag", arg)
+}
+
+// renderGoalStatus prints a progress bar for one goal
+func renderGoalStatus(status db.GoalStatus) {
+	left := ""
+	if remaining := status.Target - status.Tracked; remaining > 0 {
+		left = fmt.Sprintf(", %s to go", formatDuration(remaining))
+	} else {
+		left = " ✅"
+	}
+	fmt.Printf("%-16s %s %s / %s this %s (%.0f%%%s)\n",
+		db.GoalLabel(status.Goal), progressBar(status.Ratio(), 20), formatDuration(status.Tracked), formatDuration(status.Target),
+		status.Goal.Period, status.Ratio()*100, left)
+}
+
+// printGoalProgress prints the progress of every goal under a heading, if any are set
+func printGoalProgress() {
+	statuses, err := db.GetGoalStatuses(time.Now())
+	if err != nil || len(statuses) == 0 {
+		return
+	}
+	fmt.Println("\n🎯 Goals")
+	for _, status := range statuses {
+		renderGoalStatus(status)
+	}
+}
+
+// goalStreak renders finished periods oldest first as ✓ (met) and · (missed)
+func goalStreak(history []models.GoalPeriod) string {
+	marks := make([]string, len(history))
+	met := 0
+	for i, period := range history {
+		mark := "·"
+		if period.Met() {
+			mark = "✓"
+			met++
+		}
+		marks[len(history)-1-i] = mark
+	}
+	return strings.Join(marks, " ") + " (" + strconv.Itoa(met) + " met)"
+}
+
+// formatGoalPeriod names a week by its first day, a month by its name
+func formatGoalPeriod(period string, start time.Time) string {
+	if period == parser.BudgetMonth {
+		return start.Format("Jan 2006  ")
+	}
+	return "wk " + start.Format("02/01/06")
+}
+
+// ratio returns part/whole, 0 when whole is 0
+func ratio(part, whole time.Duration) float64 {
+	if whole <= 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
+}
+
+// progressBar renders a filled share of width cells, capped at full
+func progressBar(ratio float64, width int) string {
+	filled := int(ratio * float64(width))
+	if filled > width {
+		filled = width
+	}
+	if filled < 0 {
+		filled = 0
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+func init() {
+	goalHistoryCmd.Flags().Int("limit", 12, "Number of past periods to show per goal")
+
+	goalCmd.AddCommand(goalSetCmd)
+	goalCmd.AddCommand(goalClearCmd)
+	goalCmd.AddCommand(goalHistoryCmd)
+}
@@ -2,112 +2,263 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/balkashynov/wrok/internal/plain"
 )
 
 var helpCmd = &cobra.Command{
-	Use:   "help",
-	Short: "Show comprehensive help for wrok",
-	Long:  `Display detailed help for all wrok commands and flags.`,
+	Use:   "help [command]",
+	Short: "Show help for wrok or one of its commands",
+	Long: `Without arguments, list every command with its flags and an example.
+With a command, show its full description, flags, subcommands and examples.`,
+	Example: `  wrok help
+  wrok help ls
+  wrok help session split`,
 	Run: func(cmd *cobra.Command, args []string) {
-		showCustomHelp()
+		if len(args) == 0 {
+			showOverview()
+			return
+		}
+
+		target, _, err := rootCmd.Find(args)
+		if err != nil || target == rootCmd || target.Name() != args[len(args)-1] && !target.HasAlias(args[len(args)-1]) {
+			fmt.Printf("Unknown command '%s'. Run 'wrok help' to see all commands.\n", strings.Join(args, " "))
+			return
+		}
+		showCommandHelp(target)
 	},
 }
 
-func showCustomHelp() {
-	fmt.Print(`
-██╗    ██╗██████╗  ██████╗ ██╗  ██╗
+// helpSection groups related commands in the help overview
+type helpSection struct {
+	title    string
+	commands []string // top-level command names, in display order
+}
+
+// helpSections orders the overview. Commands not listed here still appear, under "Other"
+var helpSections = []helpSection{
+	{title: "TASKS", commands: []string{"tui", "add", "quick", "edit", "tag", "breakdown", "ls", "search", "done", "undone", "snooze", "archive", "unarchive", "project", "pin", "unpin", "alias", "open", "file", "comment", "note", "history", "focus", "plan", "cal", "capacity", "import", "parse"}},
+	{title: "TIME TRACKING", commands: []string{"start", "stop", "switch", "pause", "resume", "attach", "status", "sessions", "session", "timesheet", "lock", "howlong", "budget", "goal"}},
+	{title: "REPORTS", commands: []string{"jira", "stats", "wrapup", "wrapped", "timeline", "digest", "export"}},
+	{title: "SETUP & MAINTENANCE", commands: []string{"init", "git", "doctor", "purge", "backup", "restore", "version", "completion"}},
+}
+
+// helpExtras holds reference text that isn't part of any flag, shown on a command's help page
+var helpExtras = map[string]string{
+	"ls": `KEYS:
+  ↑/↓           Navigate tasks
+  enter         Full-screen details and session history
+  /             Search
+  : / ctrl+p    Command palette (start 42, goto #87, filter project:web)
+  f             Sort (urgency by default)
+  e             Edit selected task
+  E             Edit selected task's notes in $EDITOR
+  s             Start/stop timer
+  d             Mark done/undone
+  a             Archive/unarchive
+  P             Pin/unpin
+  o             Open task URL / Jira issue
+  c             Copy task title
+  C             Copy task as a Markdown line: - [ ] title (APP-42, due 15/06)
+  Y             Copy Jira issue URL
+  S             Cycle status filter
+  z             Show/hide archived tasks
+  1-9           Show/hide tasks of a priority level (up to the highest configured)
+  O             Show only overdue tasks (o is taken by opening the URL)
+  g             Pick tags to filter by
+  ctrl+o        Switch project
+  esc/q         Quit`,
+}
+
+// helpLogo is the ASCII art logo heading the command overview
+const helpLogo = `██╗    ██╗██████╗  ██████╗ ██╗  ██╗
 ██║    ██║██╔══██╗██╔═══██╗██║ ██╔╝
 ██║ █╗ ██║██████╔╝██║   ██║█████╔╝
 ██║███╗██║██╔══██╗██║   ██║██╔═██╗
 ╚███╔███╔╝██║  ██║╚██████╔╝██║  ██╗
- ╚══╝╚══╝ ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝
+ ╚══╝╚══╝ ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝`
 
+// showOverview prints every command grouped by section, generated from the command tree
+func showOverview() {
+	if !plain.Enabled() {
+		fmt.Print("\n" + helpLogo + "\n")
+	}
+	fmt.Print(`
 wrok - CLI Todo + Time Tracker
+`)
 
-COMMANDS:
-
-  add <task>              Create a new task with smart parsing
-    -p, --project         Set project name
-    -t, --tags            Comma-separated tags
-    --jira                JIRA ticket ID
-    --prio                Priority: low|medium|high
-    --due                 Due date (dd/mm/yyyy, +5d, +2h)
-    --note                Additional notes
-    --no-ui               Skip interactive TUI
-
-    Smart syntax:
-      #hashtags     Auto-create tags
-      @project      Set project
-      +priority     Set priority (low/medium/high)
-      ABC-123       Link JIRA ticket
-      due:+5d       Set due date (5 days from now)
-
-    Example:
-      wrok add "Fix login bug #frontend @auth +high ABC-123 due:+2d"
-
-  ls                      List and manage tasks with interactive UI
-    --status              Filter by status: todo|done|archived
-    --project             Filter by project name
-    --tags                Filter by tags (comma-separated)
-    --today               Show only today's tasks
-    --week                Show this week's tasks
-    --no-ui               Simple text output
-    --json                JSON output
-
-    Quick actions:
-      ↑/↓           Navigate tasks
-      /             Search
-      f             Sort
-      e             Edit selected task
-      s             Start/stop timer
-      d             Mark done/undone
-      a             Archive/unarchive
-      esc/q         Quit
-
-  edit <id>               Edit an existing task
-    --no-ui               Edit via command line
-
-  done <id>               Mark task as completed
-  undone <id>             Mark task as todo
-
-  archive <id>            Archive completed task
-    --older-than          Archive tasks older than duration
-  unarchive <id>          Restore archived task
-
-  search <query>          Search tasks by title or content
-    --exact               Exact match
-    --prefix              Prefix match
-    --suffix              Suffix match
-    --fuzzy               Fuzzy search (default)
-
-  start <id>              Start tracking time on a task
-    --no-ui               Start without interactive timer
-  stop                    Stop current time tracking session
-  status                  Show current tracking status
-
-  jira                    Generate weekly timesheet for JIRA reporting
-  help                    Show this help
-
-Use --no-ui flag with any command for CLI-only mode.
+	listed := make(map[string]bool)
+	for _, section := range helpSections {
+		var commands []*cobra.Command
+		for _, name := range section.commands {
+			if cmd := findSubcommand(rootCmd, name); cmd != nil && cmd.IsAvailableCommand() {
+				commands = append(commands, cmd)
+				listed[name] = true
+			}
+		}
+		printHelpSection(section.title, commands)
+	}
 
-`)
+	var other []*cobra.Command
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.IsAvailableCommand() && !listed[cmd.Name()] {
+			other = append(other, cmd)
+		}
+	}
+	printHelpSection("OTHER", other)
+
+	fmt.Print("\nRun 'wrok help <command>' for a command's full description and examples.\n\n")
 }
 
-type helpSection struct {
-	title    string
-	commands []helpCommand
+// printHelpSection prints a titled list of commands with their flags and first example
+func printHelpSection(title string, commands []*cobra.Command) {
+	if len(commands) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%s:\n", title)
+	for _, cmd := range commands {
+		printCommandSummary(cmd)
+		for _, sub := range cmd.Commands() {
+			if sub.IsAvailableCommand() {
+				printCommandSummary(sub)
+			}
+		}
+	}
 }
 
-type helpCommand struct {
-	name        string
-	description string
-	examples    []string
-	flags       []helpFlag
+// printCommandSummary prints one command's usage line, flags and first example
+func printCommandSummary(cmd *cobra.Command) {
+	usage := strings.TrimPrefix(cmd.UseLine(), rootCmd.Name()+" ")
+	usage = strings.TrimSuffix(usage, " [flags]")
+	fmt.Printf("\n  %-30s %s\n", usage, cmd.Short)
+
+	for _, line := range flagLines(cmd) {
+		fmt.Printf("    %s\n", line)
+	}
+	if examples := commandExamples(cmd); len(examples) > 0 {
+		fmt.Printf("    e.g. %s\n", examples[0])
+	}
 }
 
-type helpFlag struct {
-	name        string
-	description string
-}
\ No newline at end of file
+// showCommandHelp prints the full help page of a command
+func showCommandHelp(cmd *cobra.Command) {
+	description := cmd.Long
+	if description == "" {
+		description = cmd.Short
+	}
+	fmt.Printf("\n%s\n", strings.TrimSpace(stripExamples(description)))
+
+	fmt.Printf("\nUSAGE:\n  %s\n", cmd.UseLine())
+	if len(cmd.Aliases) > 0 {
+		fmt.Printf("\nALIASES:\n  %s\n", strings.Join(cmd.Aliases, ", "))
+	}
+
+	if lines := flagLines(cmd); len(lines) > 0 {
+		fmt.Println("\nFLAGS:")
+		for _, line := range lines {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+
+	var subcommands []*cobra.Command
+	for _, sub := range cmd.Commands() {
+		if sub.IsAvailableCommand() {
+			subcommands = append(subcommands, sub)
+		}
+	}
+	if len(subcommands) > 0 {
+		fmt.Println("\nCOMMANDS:")
+		for _, sub := range subcommands {
+			fmt.Printf("  %-20s %s\n", sub.Name(), sub.Short)
+		}
+	}
+
+	if extra, ok := helpExtras[cmd.Name()]; ok && cmd.Parent() == rootCmd {
+		fmt.Printf("\n%s\n", extra)
+	}
+
+	if examples := commandExamples(cmd); len(examples) > 0 {
+		fmt.Println("\nEXAMPLES:")
+		for _, example := range examples {
+			fmt.Printf("  %s\n", example)
+		}
+	}
+	fmt.Println()
+}
+
+// findSubcommand returns the direct subcommand of parent called name, or nil
+func findSubcommand(parent *cobra.Command, name string) *cobra.Command {
+	for _, cmd := range parent.Commands() {
+		if cmd.Name() == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// flagLines formats a command's own flags, one per line, leaving out --help and hidden flags
+func flagLines(cmd *cobra.Command) []string {
+	var lines []string
+	cmd.NonInheritedFlags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden || flag.Name == "help" {
+			return
+		}
+
+		name := "    --" + flag.Name
+		if flag.Shorthand != "" {
+			name = "-" + flag.Shorthand + ", --" + flag.Name
+		}
+		if valueType := flagValueName(flag); valueType != "" {
+			name += " " + valueType
+		}
+		lines = append(lines, fmt.Sprintf("%-28s %s", name, flag.Usage))
+	})
+	return lines
+}
+
+// flagValueName returns the placeholder shown after a flag that takes a value
+func flagValueName(flag *pflag.Flag) string {
+	switch flag.Value.Type() {
+	case "bool":
+		return ""
+	case "stringSlice":
+		return "<list>"
+	case "int", "uint":
+		return "<n>"
+	default:
+		return "<" + flag.Value.Type() + ">"
+	}
+}
+
+// commandExamples returns a command's example invocations: its Example field, or
+// the "Examples:" block of its long description
+func commandExamples(cmd *cobra.Command) []string {
+	text := cmd.Example
+	if text == "" {
+		_, block, found := strings.Cut(cmd.Long, "Examples:")
+		if !found {
+			return nil
+		}
+		text = block
+	}
+
+	var examples []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			examples = append(examples, line)
+		}
+	}
+	return examples
+}
+
+// stripExamples removes the "Examples:" block from a long description, since
+// help pages print examples in their own section
+func stripExamples(long string) string {
+	before, _, _ := strings.Cut(long, "Examples:")
+	return before
+}
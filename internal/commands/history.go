@@ -0,0 +1,241 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/priority"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [task-id | title]",
+	Short: "Show the change history of a task, or of everything",
+	Long: `Show the audit log: every change wrok's database records, with when it was
+made, by which user and command, and the old and new value of each field.
+
+With a task, its whole history is shown oldest first: edits of every field,
+status changes, tags added and removed, comments, attachments, and its sessions
+being started, paused, resumed, stopped, edited and deleted. The whole log also
+has settings being changed and tags being renamed. Without one, the latest changes to
+anything are shown (--limit, 50 by default).
+
+The log is append-only and is written by the database itself, so it also
+records changes made by other tools.`,
+	Example: `  wrok history 42
+  wrok history "login bug" --json
+  wrok history --limit 20`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		limit, _ := cmd.Flags().GetInt("limit")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		var entries []models.AuditEntry
+		var err error
+		var task *models.Task
+		if len(args) == 1 {
+			taskID, resolveErr := resolveTaskID(args[0])
+			if resolveErr != nil {
+				fmt.Printf("Error: %v\n", resolveErr)
+				return
+			}
+			task, _ = db.GetTaskByID(taskID) // may be gone; its history stays
+			entries, err = db.GetTaskHistory(taskID)
+			if err == nil && len(entries) == 0 && task == nil {
+				err = fmt.Errorf("no task #%d in the history", taskID)
+			}
+		} else {
+			entries, err = db.GetRecentHistory(limit)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if asJSON {
+			if entries == nil {
+				entries = []models.AuditEntry{}
+			}
+			jsonBytes, err := json.MarshalIndent(entries, "", "  ")
+			if err != nil {
+				fmt.Printf("Error marshaling JSON: %v\n", err)
+				return
+			}
+			fmt.Println(string(jsonBytes))
+			return
+		}
+
+		if task != nil {
+			fmt.Printf("📜 History of task #%d: %s\n\n", task.ID, task.Title)
+		}
+		if len(entries) == 0 {
+			fmt.Println("No changes recorded yet.")
+			return
+		}
+		for _, entry := range entries {
+			who := entry.Actor
+			if who == "" {
+				who = "?"
+			}
+			subject := ""
+			if task == nil {
+				subject = auditSubject(entry) + " "
+			}
+			fmt.Printf("%s  %-10s %-14s %s%s\n", entry.CreatedAt.Format("02/01/2006 15:04"),
+				shortenText(who, 10), shortenText(entry.Source, 14), subject, describeAuditEntry(entry))
+		}
+	},
+}
+
+// auditSubject names what an entry of the whole log changed, e.g. "#42"
+func auditSubject(entry models.AuditEntry) string {
+	if entry.TaskID == nil {
+		return entry.Entity
+	}
+	return fmt.Sprintf("#%d", *entry.TaskID)
+}
+
+// describeAuditEntry says what a change was, e.g. `due: – → 17/10/2026 23:59`
+func describeAuditEntry(entry models.AuditEntry) string {
+	switch entry.Entity {
+	case "task":
+		switch {
+		case entry.Action == models.AuditCreate:
+			return fmt.Sprintf("✨ created %s", auditValue(entry.Field, entry.NewValue))
+		case entry.Action == models.AuditDelete:
+			return fmt.Sprintf("🗑️  purged %s", auditValue(entry.Field, entry.OldValue))
+		case entry.Field == "deleted_at" && entry.NewValue != nil:
+			return "🗑️  deleted"
+		case entry.Field == "deleted_at":
+			return "♻️  restored"
+		}
+	case "session":
+		label := fmt.Sprintf("session #%d", entry.EntityID)
+		switch entry.Action {
+		case models.AuditCreate:
+			return fmt.Sprintf("⏱️  %s started %s", label, auditValue(entry.Field, entry.NewValue))
+		case models.AuditDelete:
+			return fmt.Sprintf("⏱️  %s deleted (started %s)", label, auditValue(entry.Field, entry.OldValue))
+		}
+		if entry.Field == "deleted_at" && entry.NewValue != nil {
+			return fmt.Sprintf("⏱️  %s deleted", label)
+		}
+		return fmt.Sprintf("⏱️  %s %s", label, describeAuditChange(entry))
+	case "pause":
+		label := fmt.Sprintf("session #%d", entry.EntityID)
+		switch {
+		case entry.Action == models.AuditCreate:
+			return fmt.Sprintf("⏸️  %s paused %s", label, auditValue(entry.Field, entry.NewValue))
+		case entry.Action == models.AuditDelete:
+			return fmt.Sprintf("⏸️  %s break removed (paused %s)", label, auditValue(entry.Field, entry.OldValue))
+		case entry.Field == "ended_at" && entry.OldValue == nil:
+			return fmt.Sprintf("▶️  %s resumed %s", label, auditValue(entry.Field, entry.NewValue))
+		}
+		return fmt.Sprintf("⏸️  %s break %s", label, describeAuditChange(entry))
+	case "setting":
+		switch entry.Action {
+		case models.AuditCreate:
+			return fmt.Sprintf("⚙️  %s set to %s", entry.Field, auditValue(entry.Field, entry.NewValue))
+		case models.AuditDelete:
+			return fmt.Sprintf("⚙️  %s cleared (was %s)", entry.Field, auditValue(entry.Field, entry.OldValue))
+		}
+		return "⚙️  " + describeAuditChange(entry)
+	case "tag":
+		if entry.Action == models.AuditUpdate {
+			return fmt.Sprintf("🏷️  tag renamed: %s → %s", auditValue(entry.Field, entry.OldValue), auditValue(entry.Field, entry.NewValue))
+		}
+		if entry.Action == models.AuditCreate {
+			return "🏷️  tagged " + auditValue(entry.Field, entry.NewValue)
+		}
+		return "🏷️  untagged " + auditValue(entry.Field, entry.OldValue)
+	case "comment":
+		if entry.Action == models.AuditCreate {
+			return "💬 " + auditValue(entry.Field, entry.NewValue)
+		}
+		return "💬 comment removed: " + auditValue(entry.Field, entry.OldValue)
+	case "file":
+		if entry.Action == models.AuditCreate {
+			return "📎 attached " + auditValue(entry.Field, entry.NewValue)
+		}
+		return "📎 detached " + auditValue(entry.Field, entry.OldValue)
+	case "goal":
+		switch entry.Action {
+		case models.AuditCreate:
+			return "🎯 goal set: " + auditValue(entry.Field, entry.NewValue)
+		case models.AuditDelete:
+			return "🎯 goal removed: " + auditValue(entry.Field, entry.OldValue)
+		}
+		return "🎯 goal " + describeAuditChange(entry)
+	}
+	return "✏️  " + describeAuditChange(entry)
+}
+
+// describeAuditChange writes a field change as "field: old → new"
+func describeAuditChange(entry models.AuditEntry) string {
+	field := strings.TrimSuffix(strings.TrimSuffix(entry.Field, "_seconds"), "_at")
+	field = strings.ReplaceAll(field, "_", " ")
+	return fmt.Sprintf("%s: %s → %s", field, auditValue(entry.Field, entry.OldValue), auditValue(entry.Field, entry.NewValue))
+}
+
+// auditValue formats a logged value for reading: times in the display
+// timezone, durations, priorities by name, and free text quoted and cut short
+func auditValue(field string, value *string) string {
+	if value == nil || *value == "" {
+		return "–"
+	}
+	text := *value
+	switch {
+	case field == "due" || strings.HasSuffix(field, "_at") || field == "hidden_until":
+		for _, layout := range []string{"2006-01-02 15:04:05.999999999-07:00", "2006-01-02 15:04:05.999999999Z07:00", time.RFC3339Nano} {
+			if t, err := time.Parse(layout, text); err == nil {
+				return t.In(time.Local).Format("02/01/2006 15:04")
+			}
+		}
+	case field == "estimate_seconds" || field == "target_seconds":
+		if seconds, err := strconv.Atoi(text); err == nil {
+			if seconds == 0 {
+				return "–"
+			}
+			return formatDuration(time.Duration(seconds) * time.Second)
+		}
+	case field == "priority":
+		if value, err := strconv.Atoi(text); err == nil {
+			if name := priority.Name(value); name != "" {
+				return name
+			}
+			return "none"
+		}
+	case field == "pinned":
+		if text == "1" || text == "true" {
+			return "yes"
+		}
+		return "no"
+	case field == "task_id" || field == "parent_id":
+		return "#" + text
+	}
+	text = shortenText(strings.ReplaceAll(text, "\n", " "), 60)
+	if field == "title" || field == "note" || field == "body" {
+		return fmt.Sprintf("%q", text)
+	}
+	return text
+}
+
+// shortenText cuts s to width runes, ending in "..." when cut
+func shortenText(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+func init() {
+	historyCmd.Flags().Int("limit", 50, "Without a task, how many of the latest changes to show")
+	historyCmd.Flags().Bool("json", false, "Output the log entries as JSON")
+}
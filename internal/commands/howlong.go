@@ -0,0 +1,275 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+var howlongCmd = &cobra.Command{
+	Use:     "howlong [task | @project | #tag] [period]",
+	Aliases: []string{"time"},
+	Short:   "Show how long you worked on a task, project or tag",
+	Long: `Show the total time tracked on a task, project or tag, or on everything.
+
+The task can be an ID or part of its title, as with 'wrok start'. The query may
+end with a period: today, yesterday, this week, last week, this month, last
+month or this year. Without one, all tracked time counts. A running session
+counts up to now.
+
+Use --locked or --unlocked to count only sessions locked with 'wrok lock', or
+only those that aren't, e.g. the time not reported to a client yet.
+
+Use --json for the total and a per-task breakdown, or --seconds for just the
+number of seconds.`,
+	Example: `  wrok howlong "login bug"             # All time spent on the task
+  wrok howlong login bug this week
+  wrok howlong @clientA last month
+  wrok howlong #review --period 30d
+  wrok time --task 42 --this-week
+  wrok time @web --today --seconds
+  wrok howlong @clientA --unlocked     # Not reported yet`,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		taskID, _ := cmd.Flags().GetUint("task")
+		project, _ := cmd.Flags().GetString("project")
+		tag, _ := cmd.Flags().GetString("tag")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		secondsOutput, _ := cmd.Flags().GetBool("seconds")
+
+		query, period := parser.SplitPeriod(strings.Join(args, " "))
+		if flagPeriod := periodFromFlags(cmd); flagPeriod != "" {
+			period = flagPeriod
+		}
+
+		locked, err := lockedFromFlags(cmd)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		now := time.Now()
+		opts := db.TrackedTimeQuery{TaskID: taskID, Project: project, Tag: tag, Locked: locked}
+		if period != "" {
+			from, to, err := parser.ParsePeriod(period, now, firstWeekday())
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			opts.From, opts.To = from, to
+		}
+
+		if query != "" {
+			if taskID != 0 || project != "" || tag != "" {
+				fmt.Println("Error: give the task, project or tag either as an argument or with a flag, not both")
+				return
+			}
+			if err := parseTimeTarget(query, &opts); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		tracked, err := db.SumTrackedTime(opts, now)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		switch {
+		case secondsOutput:
+			fmt.Println(int(tracked.Total.Seconds()))
+		case jsonOutput:
+			renderTrackedTimeJSON(opts, period, tracked)
+		default:
+			renderTrackedTime(opts, period, tracked)
+		}
+	},
+}
+
+// periodFromFlags returns the period picked with --period or a shortcut flag, "" if none
+func periodFromFlags(cmd *cobra.Command) string {
+	shortcuts := []struct{ flag, period string }{
+		{"today", "today"},
+		{"this-week", "this week"},
+		{"last-week", "last week"},
+		{"this-month", "this month"},
+	}
+	for _, shortcut := range shortcuts {
+		if set, _ := cmd.Flags().GetBool(shortcut.flag); set {
+			return shortcut.period
+		}
+	}
+	period, _ := cmd.Flags().GetString("period")
+	return period
+}
+
+// parseTimeTarget reads the task, @project or #tag a howlong query asks about
+func parseTimeTarget(query string, opts *db.TrackedTimeQuery) error {
+	switch {
+	case strings.HasPrefix(query, "@") && len(query) > 1:
+		opts.Project = query[1:]
+		return nil
+	case strings.HasPrefix(query, "#") && len(query) > 1:
+		if _, err := strconv.ParseUint(query[1:], 10, 32); err != nil {
+			opts.Tag = query[1:]
+			return nil
+		}
+	}
+
+	id, err := resolveTaskID(query)
+	if err != nil {
+		return err
+	}
+	opts.TaskID = id
+	return nil
+}
+
+// timeTargetLabel names what a query adds up, e.g. "#42 Fix login", "@web" or "#review"
+func timeTargetLabel(opts db.TrackedTimeQuery) string {
+	var parts []string
+	if opts.TaskID != 0 {
+		label := fmt.Sprintf("#%d", opts.TaskID)
+		if task, err := db.GetTaskByID(opts.TaskID); err == nil {
+			label += " " + task.Title
+		}
+		parts = append(parts, label)
+	}
+	if opts.Project != "" {
+		parts = append(parts, "@"+opts.Project)
+	}
+	if opts.Tag != "" {
+		parts = append(parts, "#"+opts.Tag)
+	}
+	return strings.Join(parts, " ")
+}
+
+// periodLabel describes the period of a query for a sentence, e.g. "this week"
+func periodLabel(period string, from time.Time) string {
+	if period == "" {
+		return "in total"
+	}
+	name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(period)), "-", " ")
+	if _, err := parser.ParseSince(name); err == nil {
+		return "since " + from.Format("02/01/2006")
+	}
+	return name
+}
+
+// renderTrackedTime prints the total, and the tasks it's made of when there are several
+func renderTrackedTime(opts db.TrackedTimeQuery, period string, tracked db.TrackedTime) {
+	when := periodLabel(period, opts.From)
+	target := timeTargetLabel(opts)
+
+	if tracked.Sessions == 0 {
+		if target == "" {
+			fmt.Printf("No time tracked %s.\n", when)
+		} else {
+			fmt.Printf("No time tracked on %s %s.\n", target, when)
+		}
+		return
+	}
+
+	counts := fmt.Sprintf("%d session(s)", tracked.Sessions)
+	if len(tracked.Tasks) > 1 {
+		counts += fmt.Sprintf(", %d tasks", len(tracked.Tasks))
+	}
+	if target == "" {
+		fmt.Printf("⏱️  %s tracked %s (%s)\n", formatHoursMinutes(tracked.Total), when, counts)
+	} else {
+		fmt.Printf("⏱️  %s on %s %s (%s)\n", formatHoursMinutes(tracked.Total), target, when, counts)
+	}
+
+	if len(tracked.Tasks) > 1 {
+		for _, taskTime := range tracked.Tasks {
+			fmt.Printf("   %8s  #%d %s\n", formatHoursMinutes(taskTime.Duration), taskTime.Task.ID, taskTime.Task.Title)
+		}
+	}
+}
+
+// renderTrackedTimeJSON prints the total and the per-task breakdown as JSON
+func renderTrackedTimeJSON(opts db.TrackedTimeQuery, period string, tracked db.TrackedTime) {
+	type jsonTask struct {
+		ID      uint   `json:"id"`
+		Title   string `json:"title"`
+		Project string `json:"project"`
+		Seconds int    `json:"seconds"`
+	}
+	type jsonTrackedTime struct {
+		TaskID   uint       `json:"task_id,omitempty"`
+		Project  string     `json:"project,omitempty"`
+		Tag      string     `json:"tag,omitempty"`
+		Period   string     `json:"period,omitempty"`
+		From     *time.Time `json:"from,omitempty"`
+		To       *time.Time `json:"to,omitempty"`
+		Seconds  int        `json:"seconds"`
+		Hours    float64    `json:"hours"`
+		Sessions int        `json:"sessions"`
+		Tasks    []jsonTask `json:"tasks"`
+	}
+
+	output := jsonTrackedTime{
+		TaskID:   opts.TaskID,
+		Project:  opts.Project,
+		Tag:      opts.Tag,
+		Period:   period,
+		Seconds:  int(tracked.Total.Seconds()),
+		Hours:    float64(int(tracked.Total.Hours()*100)) / 100,
+		Sessions: tracked.Sessions,
+		Tasks:    []jsonTask{},
+	}
+	if !opts.From.IsZero() {
+		output.From = &opts.From
+	}
+	if !opts.To.IsZero() {
+		output.To = &opts.To
+	}
+	for _, taskTime := range tracked.Tasks {
+		output.Tasks = append(output.Tasks, jsonTask{
+			ID:      taskTime.Task.ID,
+			Title:   taskTime.Task.Title,
+			Project: taskTime.Task.Project,
+			Seconds: int(taskTime.Duration.Seconds()),
+		})
+	}
+
+	jsonBytes, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(jsonBytes))
+}
+
+// formatHoursMinutes formats a duration for reading, e.g. "3h 25m", "45m" or "0m"
+func formatHoursMinutes(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
+
+func init() {
+	howlongCmd.Flags().Uint("task", 0, "Time of this task")
+	howlongCmd.Flags().String("project", "", "Time of this project")
+	howlongCmd.Flags().String("tag", "", "Time of tasks with this tag")
+	howlongCmd.Flags().String("period", "", "Period: today, yesterday, this-week, last-week, this-month, last-month, this-year or e.g. 30d")
+	howlongCmd.Flags().Bool("today", false, "Only today")
+	howlongCmd.Flags().Bool("this-week", false, "Only this week")
+	howlongCmd.Flags().Bool("last-week", false, "Only last week")
+	howlongCmd.Flags().Bool("this-month", false, "Only this month")
+	howlongCmd.Flags().Bool("locked", false, "Only locked sessions")
+	howlongCmd.Flags().Bool("unlocked", false, "Only sessions that aren't locked")
+	howlongCmd.Flags().Bool("json", false, "Output as JSON")
+	howlongCmd.Flags().Bool("seconds", false, "Only print the number of seconds")
+}
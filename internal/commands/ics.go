@@ -0,0 +1,483 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+// iCalendar (RFC 5545) files as exported by Google Calendar, Outlook and
+// Apple Calendar. Only what 'wrok import ics' needs is read: the summary,
+// categories and times of VEVENTs, cancellations, and the common recurrence
+// rules with their exceptions
+
+// icsEvent is a VEVENT with its times resolved
+type icsEvent struct {
+	uid          string
+	summary      string
+	categories   []string
+	start        time.Time
+	end          time.Time
+	allDay       bool
+	cancelled    bool
+	rrule        string
+	exdates      []time.Time
+	exdays       []string   // EXDATEs given as dates, as yyyymmdd
+	recurrenceID *time.Time // set on an event that replaces one occurrence of a recurring one
+}
+
+// icsImport counts the events 'wrok import ics' left out
+type icsImport struct {
+	entries   []db.ImportedEntry
+	allDay    int // all-day events, which aren't meetings
+	cancelled int
+	unended   int // events that haven't ended yet
+}
+
+// icsProperty is a content line: NAME;PARAM=value:VALUE
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// readICSLines reads the content lines of an iCalendar file, unfolding the
+// lines continued on the next one
+func readICSLines(input io.Reader) ([]icsProperty, error) {
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	properties := make([]icsProperty, 0, len(lines))
+	for _, line := range lines {
+		// The value starts at the first colon outside a quoted parameter
+		colon, quoted := -1, false
+		for i, r := range line {
+			if r == '"' {
+				quoted = !quoted
+			} else if r == ':' && !quoted {
+				colon = i
+				break
+			}
+		}
+		if colon < 0 {
+			continue
+		}
+		parts := strings.Split(line[:colon], ";")
+		property := icsProperty{name: strings.ToUpper(parts[0]), params: make(map[string]string), value: line[colon+1:]}
+		for _, param := range parts[1:] {
+			if key, value, ok := strings.Cut(param, "="); ok {
+				property.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+			}
+		}
+		properties = append(properties, property)
+	}
+	return properties, nil
+}
+
+// readICS reads the events of an iCalendar file. Events that can't be read
+// are reported on stderr and skipped
+func readICS(input io.Reader, warn io.Writer) ([]icsEvent, error) {
+	properties, err := readICSLines(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []icsEvent
+	var event *icsEvent
+	var duration time.Duration
+	var hasEnd, hasDuration, ok bool
+	depth := 0 // components nested in the event, such as VALARM
+	for _, property := range properties {
+		switch {
+		case property.name == "BEGIN" && strings.EqualFold(property.value, "VEVENT"):
+			event, ok = &icsEvent{}, true
+			hasEnd, hasDuration, depth = false, false, 0
+			continue
+		case event == nil:
+			continue
+		case property.name == "BEGIN":
+			depth++
+			continue
+		case property.name == "END" && strings.EqualFold(property.value, "VEVENT"):
+			switch {
+			case !ok:
+			case event.start.IsZero():
+				fmt.Fprintf(warn, "event '%s': no start time, skipped\n", event.summary)
+			default:
+				if !hasEnd {
+					event.end = event.start
+					if hasDuration {
+						event.end = event.start.Add(duration)
+					} else if event.allDay {
+						event.end = event.start.AddDate(0, 0, 1)
+					}
+				}
+				events = append(events, *event)
+			}
+			event = nil
+			continue
+		case property.name == "END":
+			depth--
+			continue
+		case depth > 0:
+			continue
+		}
+
+		var err error
+		switch property.name {
+		case "UID":
+			event.uid = property.value
+		case "SUMMARY":
+			event.summary = unescapeICSText(property.value)
+		case "CATEGORIES":
+			for _, category := range splitICSList(property.value) {
+				if category = strings.TrimSpace(unescapeICSText(category)); category != "" {
+					event.categories = append(event.categories, category)
+				}
+			}
+		case "STATUS":
+			event.cancelled = strings.EqualFold(property.value, "CANCELLED")
+		case "DTSTART":
+			event.start, event.allDay, err = parseICSTime(property.value, property.params)
+		case "DTEND":
+			event.end, _, err = parseICSTime(property.value, property.params)
+			hasEnd = true
+		case "DURATION":
+			duration, err = parseICSDuration(property.value)
+			hasDuration = true
+		case "RRULE":
+			event.rrule = property.value
+		case "EXDATE":
+			for _, value := range strings.Split(property.value, ",") {
+				exdate, allDay, exErr := parseICSTime(value, property.params)
+				if exErr != nil {
+					err = exErr
+					break
+				}
+				if allDay {
+					event.exdays = append(event.exdays, exdate.Format("20060102"))
+				} else {
+					event.exdates = append(event.exdates, exdate)
+				}
+			}
+		case "RECURRENCE-ID":
+			var id time.Time
+			id, _, err = parseICSTime(property.value, property.params)
+			event.recurrenceID = &id
+		}
+		if err != nil {
+			fmt.Fprintf(warn, "event '%s': %s: %v, skipped\n", event.summary, property.name, err)
+			ok = false
+		}
+	}
+	return events, nil
+}
+
+// unescapeICSText undoes the escaping of TEXT values
+func unescapeICSText(value string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
+
+// splitICSList splits a comma separated value, leaving escaped commas alone
+func splitICSList(value string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+// parseICSTime parses a DATE or DATE-TIME value: UTC when it ends in Z, in
+// the TZID parameter's zone when there is one, and local time otherwise.
+// Zones Go doesn't know (such as Outlook's Windows names) are read as local
+// time. Times stay in their zone so recurrences keep its wall clock
+func parseICSTime(value string, params map[string]string) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, time.Local)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date '%s'", value)
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid time '%s'", value)
+		}
+		return t, false, nil
+	}
+
+	location := time.Local
+	if tzid := params["TZID"]; tzid != "" {
+		if loaded, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			location = loaded
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, location)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid time '%s'", value)
+	}
+	return t, false, nil
+}
+
+// parseICSDuration parses a DURATION value such as PT1H30M or P1D
+func parseICSDuration(value string) (time.Duration, error) {
+	rest := strings.TrimLeft(strings.TrimSpace(value), "+-")
+	if !strings.HasPrefix(rest, "P") || len(rest) < 3 {
+		return 0, fmt.Errorf("invalid duration '%s'", value)
+	}
+	rest = rest[1:]
+
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	var total time.Duration
+	number := ""
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case c == 'T':
+			units = map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+		case c >= '0' && c <= '9':
+			number += string(c)
+		default:
+			unit, known := units[c]
+			n, err := strconv.Atoi(number)
+			if !known || err != nil {
+				return 0, fmt.Errorf("invalid duration '%s'", value)
+			}
+			total += time.Duration(n) * unit
+			number = ""
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("invalid duration '%s'", value)
+	}
+	return total, nil
+}
+
+// icsOccurrences returns the starts of a recurring event up to before, in
+// order. FREQ=DAILY, WEEKLY (with BYDAY), MONTHLY and YEARLY are followed with
+// INTERVAL, COUNT and UNTIL; other rule parts are an error
+func icsOccurrences(event icsEvent, before time.Time) ([]time.Time, error) {
+	freq, interval, count := "", 1, 0
+	var until *time.Time
+	var byDay []time.Weekday
+	weekStart := time.Monday
+	weekdays := map[string]time.Weekday{
+		"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+		"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+	}
+
+	for _, part := range strings.Split(event.rrule, ";") {
+		key, value, _ := strings.Cut(part, "=")
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			freq = strings.ToUpper(value)
+		case "INTERVAL":
+			interval, err = strconv.Atoi(value)
+			if interval < 1 {
+				err = fmt.Errorf("invalid INTERVAL '%s'", value)
+			}
+		case "COUNT":
+			count, err = strconv.Atoi(value)
+		case "UNTIL":
+			var t time.Time
+			var allDay bool
+			t, allDay, err = parseICSTime(value, nil)
+			if allDay {
+				t = t.AddDate(0, 0, 1).Add(-time.Second)
+			}
+			until = &t
+		case "BYDAY":
+			for _, day := range strings.Split(strings.ToUpper(value), ",") {
+				weekday, known := weekdays[day]
+				if !known {
+					return nil, fmt.Errorf("unsupported BYDAY '%s'", value)
+				}
+				byDay = append(byDay, weekday)
+			}
+		case "WKST":
+			weekStart = weekdays[strings.ToUpper(value)]
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s '%s'", key, value)
+		}
+	}
+	if len(byDay) > 0 && freq != "WEEKLY" {
+		return nil, fmt.Errorf("BYDAY is only supported with FREQ=WEEKLY")
+	}
+
+	start := event.start
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	}
+	var starts []time.Time
+	add := func(t time.Time) bool {
+		if (until != nil && t.After(*until)) || !t.Before(before) || (count > 0 && len(starts) >= count) {
+			return false
+		}
+		starts = append(starts, t)
+		return true
+	}
+
+	const maxPeriods = 10000 // guards against rules that never produce an occurrence
+	switch freq {
+	case "DAILY":
+		for i := 0; i < maxPeriods && add(at(start.Year(), start.Month(), start.Day()+i*interval)); i++ {
+		}
+	case "WEEKLY":
+		if len(byDay) == 0 {
+			byDay = []time.Weekday{start.Weekday()}
+		}
+		// Days in the order they come in a week beginning on WKST
+		sort.Slice(byDay, func(i, j int) bool {
+			return (byDay[i]-weekStart+7)%7 < (byDay[j]-weekStart+7)%7
+		})
+		offset := (int(start.Weekday()) - int(weekStart) + 7) % 7
+		week := at(start.Year(), start.Month(), start.Day()-offset)
+	weeks:
+		for i := 0; i < maxPeriods; i++ {
+			for _, day := range byDay {
+				t := at(week.Year(), week.Month(), week.Day()+i*7*interval+(int(day)-int(weekStart)+7)%7)
+				if t.Before(start) {
+					continue
+				}
+				if !add(t) {
+					break weeks
+				}
+			}
+		}
+	case "MONTHLY", "YEARLY":
+		// Months without the day (the 31st, 29 February) are skipped
+		for i := 0; i < maxPeriods; i++ {
+			t := at(start.Year(), start.Month()+time.Month(i*interval), start.Day())
+			if freq == "YEARLY" {
+				t = at(start.Year()+i*interval, start.Month(), start.Day())
+			}
+			if t.Day() != start.Day() {
+				continue
+			}
+			if !add(t) {
+				break
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported FREQ '%s'", freq)
+	}
+	return starts, nil
+}
+
+// icsEntries turns the events starting in [from, to) into time entries for
+// project, one per occurrence of recurring events. Events that haven't ended
+// by now are left out, as are all-day and cancelled ones
+func icsEntries(events []icsEvent, from, to, now time.Time, project string, tags []string, warn io.Writer) icsImport {
+	var result icsImport
+
+	// Occurrences moved or cancelled on their own replace those of the series
+	replaced := make(map[string]bool)
+	for _, event := range events {
+		if event.recurrenceID != nil {
+			replaced[event.uid+"\x00"+strconv.FormatInt(event.recurrenceID.Unix(), 10)] = true
+		}
+	}
+
+	for _, event := range events {
+		starts := []time.Time{event.start}
+		if event.rrule != "" && event.recurrenceID == nil {
+			occurrences, err := icsOccurrences(event, to)
+			if err != nil {
+				fmt.Fprintf(warn, "event '%s': %v; only its first occurrence is imported\n", event.summary, err)
+			} else {
+				starts = occurrences
+			}
+		}
+
+		length := event.end.Sub(event.start)
+		for _, start := range starts {
+			if start.Before(from) || !start.Before(to) {
+				continue
+			}
+			if event.rrule != "" && event.recurrenceID == nil &&
+				(replaced[event.uid+"\x00"+strconv.FormatInt(start.Unix(), 10)] || icsExcluded(event, start)) {
+				continue
+			}
+			switch {
+			case event.allDay:
+				result.allDay++
+				continue
+			case event.cancelled:
+				result.cancelled++
+				continue
+			case length <= 0:
+				continue
+			case start.Add(length).After(now):
+				result.unended++
+				continue
+			}
+
+			title := event.summary
+			if title == "" {
+				title = "Meeting"
+			}
+			result.entries = append(result.entries, db.ImportedEntry{
+				Description: title,
+				Project:     project,
+				Tags:        append(append([]string(nil), tags...), event.categories...),
+				StartedAt:   start,
+				FinishedAt:  start.Add(length),
+			})
+		}
+	}
+
+	sort.SliceStable(result.entries, func(i, j int) bool {
+		return result.entries[i].StartedAt.Before(result.entries[j].StartedAt)
+	})
+	return result
+}
+
+// icsExcluded reports whether an occurrence is one of the event's EXDATEs
+func icsExcluded(event icsEvent, start time.Time) bool {
+	for _, exdate := range event.exdates {
+		if exdate.Equal(start) {
+			return true
+		}
+	}
+	day := start.Format("20060102")
+	for _, exday := range event.exdays {
+		if exday == day {
+			return true
+		}
+	}
+	return false
+}
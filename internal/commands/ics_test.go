@@ -0,0 +1,201 @@
+package commands
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseICSTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		value  string
+		params map[string]string
+		want   time.Time
+		allDay bool
+	}{
+		{"20260310T090000Z", nil, time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC), false},
+		{"20260310T090000", nil, time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local), false},
+		{"20260310T090000", map[string]string{"TZID": "Europe/Berlin"}, time.Date(2026, 3, 10, 9, 0, 0, 0, berlin), false},
+		{"20260310T090000", map[string]string{"TZID": "/Europe/Berlin"}, time.Date(2026, 3, 10, 9, 0, 0, 0, berlin), false},
+		{"20260310T090000", map[string]string{"TZID": "W. Europe Standard Time"}, time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local), false},
+		{"20260310", nil, time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local), true},
+		{" 20260310 ", map[string]string{"VALUE": "DATE"}, time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local), true},
+	}
+	for _, tt := range tests {
+		got, allDay, err := parseICSTime(tt.value, tt.params)
+		if err != nil {
+			t.Errorf("parseICSTime(%q, %v): %v", tt.value, tt.params, err)
+			continue
+		}
+		if !got.Equal(tt.want) || allDay != tt.allDay {
+			t.Errorf("parseICSTime(%q, %v) = %v, %v, want %v, %v", tt.value, tt.params, got, allDay, tt.want, tt.allDay)
+		}
+	}
+
+	for _, value := range []string{"", "2026031", "20261310", "20260310T250000Z", "20260310T0900", "tomorrow"} {
+		if _, _, err := parseICSTime(value, nil); err == nil {
+			t.Errorf("parseICSTime(%q) accepted an invalid time", value)
+		}
+	}
+}
+
+func TestParseICSDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"PT1H30M", 90 * time.Minute},
+		{"PT45S", 45 * time.Second},
+		{"P1D", 24 * time.Hour},
+		{"P1DT2H", 26 * time.Hour},
+		{"P2W", 14 * 24 * time.Hour},
+		{"+PT15M", 15 * time.Minute},
+		{" PT0S ", 0},
+	}
+	for _, tt := range tests {
+		got, err := parseICSDuration(tt.value)
+		if err != nil {
+			t.Errorf("parseICSDuration(%q): %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseICSDuration(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "P", "PT", "1H", "P1H", "PT1X", "PT1H30", "PTH", "T1H"} {
+		if _, err := parseICSDuration(value); err == nil {
+			t.Errorf("parseICSDuration(%q) accepted an invalid duration", value)
+		}
+	}
+}
+
+func TestReadICS(t *testing.T) {
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:standup",
+		"SUMMARY:Daily stand-up\\, team\\; all",
+		"CATEGORIES:team,sync\\,weekly, ",
+		"DTSTART;TZID=\"UTC\":20260310T090000",
+		"DURATION:PT15M",
+		"BEGIN:VALARM",
+		"DURATION:-PT5M",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Planning with a summary folded",
+		"  across lines",
+		"DTSTART:20260311T130000Z",
+		"DTEND:20260311T143000Z",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Offsite",
+		"DTSTART;VALUE=DATE:20260312",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:No start",
+		"DTEND:20260313T100000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Bad end",
+		"DTSTART:20260313T090000Z",
+		"DTEND:soon",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	var warnings bytes.Buffer
+	events, err := readICS(strings.NewReader(calendar), &warnings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+
+	standup := events[0]
+	if standup.summary != "Daily stand-up, team; all" || !slices.Equal(standup.categories, []string{"team", "sync,weekly"}) {
+		t.Errorf("stand-up: got %q with categories %q", standup.summary, standup.categories)
+	}
+	if start := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC); !standup.start.Equal(start) || !standup.end.Equal(start.Add(15*time.Minute)) {
+		t.Errorf("stand-up: got %v to %v, want 09:00 UTC for 15 minutes", standup.start, standup.end)
+	}
+
+	planning := events[1]
+	if planning.summary != "Planning with a summary folded across lines" || !planning.cancelled || planning.end.Sub(planning.start) != 90*time.Minute {
+		t.Errorf("planning: got %q, cancelled %v, %v long", planning.summary, planning.cancelled, planning.end.Sub(planning.start))
+	}
+
+	offsite := events[2]
+	if !offsite.allDay || offsite.end.Sub(offsite.start) != 24*time.Hour {
+		t.Errorf("offsite: got all-day %v, %v long, want a whole day", offsite.allDay, offsite.end.Sub(offsite.start))
+	}
+
+	for _, skipped := range []string{"'No start'", "'Bad end'"} {
+		if !strings.Contains(warnings.String(), skipped) {
+			t.Errorf("no warning for %s in %q", skipped, warnings.String())
+		}
+	}
+}
+
+func TestICSOccurrences(t *testing.T) {
+	// Tuesday 10 March 2026, 09:00
+	start := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	day := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 9, 0, 0, 0, time.UTC)
+	}
+	before := day(12, 31)
+
+	tests := []struct {
+		rrule string
+		start time.Time
+		want  []time.Time
+	}{
+		{"FREQ=DAILY;COUNT=3", start, []time.Time{day(3, 10), day(3, 11), day(3, 12)}},
+		{"FREQ=DAILY;INTERVAL=2;UNTIL=20260316", start, []time.Time{day(3, 10), day(3, 12), day(3, 14), day(3, 16)}},
+		{"FREQ=DAILY;UNTIL=20260312T090000Z", start, []time.Time{day(3, 10), day(3, 11), day(3, 12)}},
+		{"FREQ=WEEKLY;COUNT=3", start, []time.Time{day(3, 10), day(3, 17), day(3, 24)}},
+		// Monday is before the start, so the first week only has Wednesday and Friday
+		{"FREQ=WEEKLY;BYDAY=FR,MO,WE;COUNT=5", start, []time.Time{day(3, 11), day(3, 13), day(3, 16), day(3, 18), day(3, 20)}},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU;UNTIL=20260408", start, []time.Time{day(3, 10), day(3, 24), day(4, 7)}},
+		{"freq=monthly;count=3", start, []time.Time{day(3, 10), day(4, 10), day(5, 10)}},
+		// Months without a 31st are skipped
+		{"FREQ=MONTHLY;COUNT=3", day(3, 31), []time.Time{day(3, 31), day(5, 31), day(7, 31)}},
+		{"FREQ=YEARLY;COUNT=5", start, []time.Time{day(3, 10)}},
+		{"FREQ=DAILY", day(12, 29), []time.Time{day(12, 29), day(12, 30)}},
+	}
+	for _, tt := range tests {
+		got, err := icsOccurrences(icsEvent{start: tt.start, rrule: tt.rrule}, before)
+		if err != nil {
+			t.Errorf("icsOccurrences(%q): %v", tt.rrule, err)
+			continue
+		}
+		if !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+			t.Errorf("icsOccurrences(%q) = %v, want %v", tt.rrule, got, tt.want)
+		}
+	}
+
+	for _, rrule := range []string{
+		"",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=three",
+		"FREQ=DAILY;UNTIL=someday",
+		"FREQ=MONTHLY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=YEARLY;BYMONTH=3",
+	} {
+		if _, err := icsOccurrences(icsEvent{start: start, rrule: rrule}, before); err == nil {
+			t.Errorf("icsOccurrences(%q) accepted an unsupported rule", rrule)
+		}
+	}
+}
@@ -0,0 +1,430 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import tasks",
+	Long: `Import tasks from a file, or from stdin when no file is given.
+
+Formats:
+  --lines      One task per line, using the smart parsing syntax of 'wrok add'.
+               Blank lines are skipped and list markers ("- [ ]", "-", "*") are
+               stripped. The ID of every created task is printed on its own line.
+  --org        An org-mode file: TODO/DONE headings become tasks in the project
+               of the plain heading above them, with [#A] priorities, :tags:,
+               DEADLINE (or SCHEDULED) as due date and the text below as note.
+  --taskpaper  A TaskPaper file: "- " lines become tasks in the "project:" above
+               them, with @tags, @done, @due (or @defer), @priority() and notes.
+
+Org and TaskPaper tasks with the title and project of an existing task are
+skipped, so a file can be imported again; --dry-run shows what would change.
+
+To bring over tracked time from Toggl Track, see 'wrok import toggl'; for
+meetings in a calendar export, see 'wrok import ics'.
+
+Examples:
+  wrok import --lines notes.txt
+  grep -rh "TODO" src/ | wrok import --lines --project cleanup
+  wrok import --org ~/org/todo.org --dry-run
+  wrok import toggl --csv toggl-export.csv`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		lines, _ := cmd.Flags().GetBool("lines")
+		var formats []string
+		for _, name := range []string{"lines", "org", "taskpaper"} {
+			if enabled, _ := cmd.Flags().GetBool(name); enabled {
+				formats = append(formats, name)
+			}
+		}
+		if len(formats) != 1 {
+			fmt.Println("Error: specify one import format (--lines, --org or --taskpaper)")
+			return
+		}
+
+		input := io.Reader(os.Stdin)
+		if len(args) == 1 {
+			f, err := os.Open(args[0])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			defer f.Close()
+			input = f
+		}
+
+		if lines {
+			addTasksFromLines(cmd, input)
+			return
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		importOutline(outlineFormats[formats[0]].read, input, dryRun)
+	},
+}
+
+// importOutline creates the tasks of an org-mode or TaskPaper file
+func importOutline(read func(io.Reader) ([]db.ImportedTask, error), input io.Reader, dryRun bool) {
+	tasks, err := read(input)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	result, err := db.ImportTasks(tasks, dryRun)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	verb := "Imported"
+	if dryRun {
+		verb = "Would import"
+	}
+	fmt.Printf("📥 %s %d task(s), %d of them done\n", verb, result.Created, result.Done)
+	if result.Duplicates > 0 {
+		fmt.Printf("Skipped %d task(s) that already exist\n", result.Duplicates)
+	}
+}
+
+var importTogglCmd = &cobra.Command{
+	Use:   "toggl",
+	Short: "Import tracked time from a Toggl Track CSV export",
+	Long: `Import the time entries of a Toggl Track detailed report exported as CSV.
+
+Each entry becomes a session on the task whose title is the entry's description
+(ignoring case) in the same project. Tasks that don't exist yet are created
+with the entry's tags. Entries starting in the same second as a session wrok
+already has are skipped as duplicates, so an export can be imported again
+after adding to it. Times are read in the display timezone.`,
+	Example: `  wrok import toggl --csv Toggl_time_entries.csv --dry-run
+  wrok import toggl --csv Toggl_time_entries.csv`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		path, _ := cmd.Flags().GetString("csv")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if path == "" {
+			fmt.Println("Error: specify the Toggl export to import (--csv file)")
+			return
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		defer f.Close()
+
+		entries, err := readTogglCSV(f)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		result, err := db.ImportEntries(entries, dryRun)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		verb := "Imported"
+		if dryRun {
+			verb = "Would import"
+		}
+		fmt.Printf("📥 %s %d session(s): %d new task(s), %d existing task(s)\n",
+			verb, result.Sessions, result.TasksCreated, result.TasksMatched)
+		if result.Duplicates > 0 {
+			fmt.Printf("Skipped %d entry(s) already tracked\n", result.Duplicates)
+		}
+	},
+}
+
+var importICSCmd = &cobra.Command{
+	Use:   "ics [file]",
+	Short: "Import calendar meetings as sessions",
+	Long: `Import the events of an iCalendar (.ics) file, such as a Google Calendar or
+Outlook export, as finished sessions, so meeting time counts in the reports
+without logging it by hand. The file is read from stdin when none is given.
+
+Each event starting in the date range becomes a session on the task with the
+event's title in --project, created with the event's categories (and --tags)
+as tags when it doesn't exist yet. Recurring events give a session for every
+occurrence, minus the ones removed or moved. All-day and cancelled events, and
+events that haven't ended yet, are left out. Events starting in the same
+second as an existing session are skipped, so a calendar can be imported again.
+
+--from defaults to the start of this week and --to to today; both take today,
+yesterday, or a date as dd/mm/yyyy or yyyy-mm-dd.`,
+	Example: `  wrok import ics meetings.ics --as-sessions --project meetings
+  wrok import ics work.ics --as-sessions --from 01/10/2026 --to 31/10/2026 --dry-run
+  curl -s "$CALENDAR_URL" | wrok import ics --as-sessions -p meetings`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		asSessions, _ := cmd.Flags().GetBool("as-sessions")
+		project, _ := cmd.Flags().GetString("project")
+		tags, _ := cmd.Flags().GetStringSlice("tags")
+		fromValue, _ := cmd.Flags().GetString("from")
+		toValue, _ := cmd.Flags().GetString("to")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if !asSessions {
+			fmt.Println("Error: calendar events are imported as sessions; pass --as-sessions")
+			return
+		}
+
+		now := time.Now()
+		from := parser.StartOfWeek(now, firstWeekday())
+		if fromValue != "" {
+			day, err := parseDayFlag(fromValue, now)
+			if err != nil {
+				fmt.Printf("Error: --from: %v\n", err)
+				return
+			}
+			from = day
+		}
+		to, err := parseDayFlag(toValue, now)
+		if err != nil {
+			fmt.Printf("Error: --to: %v\n", err)
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+		if !from.Before(to) {
+			fmt.Println("Error: --from must not be after --to")
+			return
+		}
+
+		input := io.Reader(os.Stdin)
+		if len(args) == 1 {
+			f, err := os.Open(args[0])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			defer f.Close()
+			input = f
+		}
+		events, err := readICS(input, os.Stderr)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		found := icsEntries(events, from, to, now, project, tags, os.Stderr)
+		result, err := db.ImportEntries(found.entries, dryRun)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		verb := "Imported"
+		if dryRun {
+			verb = "Would import"
+		}
+		fmt.Printf("📥 %s %d meeting(s) from %s to %s: %d new task(s), %d existing task(s)\n",
+			verb, result.Sessions, from.Format("02/01/2006"), to.AddDate(0, 0, -1).Format("02/01/2006"),
+			result.TasksCreated, result.TasksMatched)
+		if result.Duplicates > 0 {
+			fmt.Printf("Skipped %d meeting(s) already tracked\n", result.Duplicates)
+		}
+		if found.unended > 0 {
+			fmt.Printf("Skipped %d meeting(s) that haven't ended yet\n", found.unended)
+		}
+		if found.allDay+found.cancelled > 0 {
+			fmt.Printf("Left out %d all-day and %d cancelled event(s)\n", found.allDay, found.cancelled)
+		}
+	},
+}
+
+// readTogglCSV reads the time entries of a Toggl Track CSV export. Columns are
+// found by their header; rows that can't be read are reported on stderr and skipped
+func readTogglCSV(input io.Reader) ([]db.ImportedEntry, error) {
+	r := csv.NewReader(input)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CSV header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	for _, required := range []string{"description", "start date", "start time"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("not a Toggl export: no '%s' column", required)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var entries []db.ImportedEntry
+	lineNo := 1
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		lineNo++
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "line %d: %v\n", lineNo, err)
+			continue
+		}
+
+		start, err := parseTogglTime(field(record, "start date"), field(record, "start time"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "line %d: invalid start: %v\n", lineNo, err)
+			continue
+		}
+		end, err := parseTogglTime(field(record, "end date"), field(record, "end time"))
+		if err != nil {
+			duration, durationErr := parseClockDuration(field(record, "duration"))
+			if durationErr != nil {
+				fmt.Fprintf(os.Stderr, "line %d: no valid end time or duration\n", lineNo)
+				continue
+			}
+			end = start.Add(duration)
+		}
+		if end.Before(start) {
+			fmt.Fprintf(os.Stderr, "line %d: ends before it starts\n", lineNo)
+			continue
+		}
+
+		var tags []string
+		for _, tag := range strings.Split(field(record, "tags"), ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		entries = append(entries, db.ImportedEntry{
+			Description: field(record, "description"),
+			Project:     field(record, "project"),
+			Tags:        tags,
+			StartedAt:   start,
+			FinishedAt:  end,
+		})
+	}
+	return entries, nil
+}
+
+// parseTogglTime parses a date (2024-01-31 or 01/31/2024) and a 24h time of a Toggl export
+func parseTogglTime(date, clock string) (time.Time, error) {
+	if date == "" || clock == "" {
+		return time.Time{}, fmt.Errorf("missing date or time")
+	}
+	for _, dateLayout := range []string{"2006-01-02", "01/02/2006"} {
+		for _, clockLayout := range []string{"15:04:05", "15:04"} {
+			if t, err := time.ParseInLocation(dateLayout+" "+clockLayout, date+" "+clock, time.Local); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("'%s %s'", date, clock)
+}
+
+// parseClockDuration parses a duration written as HH:MM:SS, e.g. "01:05:09"
+func parseClockDuration(input string) (time.Duration, error) {
+	var hours, minutes, seconds int
+	if _, err := fmt.Sscanf(input, "%d:%d:%d", &hours, &minutes, &seconds); err != nil {
+		return 0, fmt.Errorf("invalid duration '%s'", input)
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, nil
+}
+
+// addTasksFromLines creates one task per non-empty line, printing created IDs to
+// stdout and per-line problems to stderr so the output can be piped
+func addTasksFromLines(cmd *cobra.Command, input io.Reader) {
+	scanner := bufio.NewScanner(input)
+	lineNo := 0
+	created, failed := 0, 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := cleanTaskLine(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		parsed := parser.ParseTitle(line)
+		if len(parsed.Errors) > 0 {
+			fmt.Fprintf(os.Stderr, "line %d: %s\n", lineNo, strings.Join(parsed.Errors, ", "))
+			failed++
+			continue
+		}
+
+		req, err := buildCreateRequest(cmd, parsed)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "line %d: %v\n", lineNo, err)
+			failed++
+			continue
+		}
+
+		task, err := db.CreateTask(req)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "line %d: %v\n", lineNo, err)
+			failed++
+			continue
+		}
+
+		fmt.Println(task.ID)
+		created++
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+	}
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "Created %d task(s), skipped %d line(s)\n", created, failed)
+	}
+}
+
+// cleanTaskLine trims whitespace and common list markers from an input line
+func cleanTaskLine(line string) string {
+	line = strings.TrimSpace(line)
+	for _, marker := range []string{"- [ ]", "* [ ]", "-", "*"} {
+		if strings.HasPrefix(line, marker) {
+			line = strings.TrimSpace(strings.TrimPrefix(line, marker))
+			break
+		}
+	}
+	return line
+}
+
+func init() {
+	importCmd.Flags().Bool("lines", false, "Read one task per line")
+	importCmd.Flags().Bool("org", false, "Read an org-mode file")
+	importCmd.Flags().Bool("taskpaper", false, "Read a TaskPaper file")
+	importCmd.Flags().Bool("dry-run", false, "With --org or --taskpaper, show what would be imported without changing anything")
+	importCmd.Flags().StringP("project", "p", "", "Project for imported tasks")
+	importCmd.Flags().StringSliceP("tags", "t", []string{}, "Tags for imported tasks")
+	importCmd.Flags().StringP("priority", "", "", "Priority for imported tasks")
+	importCmd.Flags().StringP("due", "", "", "Due date for imported tasks")
+
+	importTogglCmd.Flags().String("csv", "", "Toggl Track CSV export to import")
+	importTogglCmd.Flags().Bool("dry-run", false, "Show what would be imported without changing anything")
+	importCmd.AddCommand(importTogglCmd)
+
+	importICSCmd.Flags().Bool("as-sessions", false, "Create a finished session for every meeting")
+	importICSCmd.Flags().StringP("project", "p", "", "Project of the meetings' tasks")
+	importICSCmd.Flags().StringSliceP("tags", "t", []string{}, "Tags for the meetings' new tasks")
+	importICSCmd.Flags().String("from", "", "First day to import (default: start of this week)")
+	importICSCmd.Flags().String("to", "today", "Last day to import")
+	importICSCmd.Flags().Bool("dry-run", false, "Show what would be imported without changing anything")
+	importCmd.AddCommand(importICSCmd)
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/tui"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Set up wrok",
+	Long: `Set up wrok with a short wizard.
+
+Asks for a color theme, the first day of the week, your Jira URL and a
+default project, writes them to ~/.wrok/config.toml, creates the database
+and optionally adds an example task. Running it again edits the settings.
+
+Examples:
+  wrok init          # Interactive wizard
+  wrok init --yes    # Accept defaults without the wizard`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		useDefaults, _ := cmd.Flags().GetBool("yes")
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		firstRun := !config.Exists()
+
+		defaults := tui.InitSettings{
+			Theme:          cfg.Theme,
+			WeekStart:      cfg.WeekStart,
+			JiraBaseURL:    cfg.Jira.BaseURL,
+			DefaultProject: cfg.DefaultProject,
+			SeedExample:    firstRun,
+		}
+
+		settings := &defaults
+		if !useDefaults {
+			tui.ApplyTheme(cfg.Theme)
+			settings, err = tui.RunInitTUI(defaults)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if settings == nil {
+				fmt.Println("❌ Setup cancelled.")
+				return
+			}
+		}
+
+		cfg.Theme = settings.Theme
+		cfg.WeekStart = settings.WeekStart
+		cfg.Jira.BaseURL = settings.JiraBaseURL
+		cfg.DefaultProject = settings.DefaultProject
+		if cfg.Theme == "" {
+			cfg.Theme = tui.ThemeNames[0]
+		}
+		if cfg.WeekStart == "" {
+			cfg.WeekStart = tui.WeekStartOptions[0]
+		}
+
+		if err := config.Save(cfg); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		path, _ := config.Path()
+		fmt.Printf("✅ Settings saved to %s\n", path)
+
+		initDB()
+
+		if settings.SeedExample {
+			task, err := db.CreateTask(db.CreateTaskRequest{
+				Title:    "Try wrok: start a timer on this task with 'wrok start'",
+				Project:  cfg.DefaultProject,
+				Tags:     []string{"example"},
+				Priority: "1", // the lowest level of any priority scheme
+			})
+			if err != nil {
+				fmt.Printf("Error creating example task: %v\n", err)
+				return
+			}
+			fmt.Printf("📝 Created example task #%d\n", task.ID)
+		}
+
+		fmt.Println()
+		fmt.Println("Next steps:")
+		fmt.Println("  wrok add \"Fix login bug #frontend @auth +high\"   Create a task")
+		fmt.Println("  wrok ls                                          Browse tasks")
+		fmt.Println("  wrok start <id>                                  Track time")
+		fmt.Println("  wrok help                                        All commands")
+	},
+}
+
+func init() {
+	initCmd.Flags().BoolP("yes", "y", false, "Accept defaults without the wizard")
+}
@@ -8,8 +8,17 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/config"
 	"github.com/balkashynov/wrok/internal/db"
 	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// Timesheet groupings for --group-by
+const (
+	groupByTask    = "task"
+	groupByProject = "project"
+	groupByTag     = "tag"
 )
 
 var jiraCmd = &cobra.Command{
@@ -18,27 +27,68 @@ var jiraCmd = &cobra.Command{
 	Long: `Show a weekly timesheet of tracked time grouped by day.
 
 Displays hours worked per day for the current calendar week, similar to Tempo interface.
-Only shows days where work was tracked.
+Only shows days where work was tracked. The week begins on the week_start day from
+the config file (Monday by default) and day names follow your locale.
+
+Rows are tasks by default; --group-by project or tag sums them per project or
+tag instead. A session of a task with several tags counts under each of them,
+so the Total row adds up each day's sessions once. --detail lists the sessions
+under each row, with their notes, for filling in Tempo entries.
+
+` + templateHelp + `
+Timesheet templates get .Start, .End, .Sessions (each with its Task), .Total
+and .Now, e.g. {{range .Sessions}}{{.Task.JiraID}},{{format "2006-01-02" .StartedAt}},{{hours (seconds .DurationSeconds)}}{{"\n"}}{{end}}
 
 Example output:
   Task                    Mon  Tue  Wed  Thu  Fri  Sat  Sun  Total
   APP-123 Fix login bug     2    3    1    -    -    -    -      6
   APP-456 Add new feature   -    1    2    4    1    -    -      8
   Total                     2    4    3    4    1    0    0     14`,
+	Example: `  wrok jira
+  wrok jira --group-by project
+  wrok jira --group-by tag --detail
+  wrok jira --template tempo.csv.tmpl`,
 	Run: func(cmd *cobra.Command, args []string) {
 		initDB()
-		if err := generateJiraTimesheet(); err != nil {
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		detail, _ := cmd.Flags().GetBool("detail")
+
+		groupBy = strings.ToLower(groupBy)
+		switch groupBy {
+		case groupByTask, groupByProject, groupByTag:
+		default:
+			fmt.Printf("Error: invalid --group-by '%s'. Use: task, project or tag\n", groupBy)
+			return
+		}
+
+		if path := templatePath(cmd); path != "" {
+			if err := renderTimesheetTemplate(path); err != nil {
+				fmt.Printf("Error: %v\n", err)
+			}
+			return
+		}
+
+		if err := generateJiraTimesheet(groupBy, detail); err != nil {
 			fmt.Printf("Error: %v\n", err)
 		}
 	},
 }
 
-// generateJiraTimesheet creates and displays the weekly timesheet
-func generateJiraTimesheet() error {
-	// Get current calendar week (Monday to Sunday)
+// timesheetRow is one row of the timesheet: a task, project or tag and its hours per day
+type timesheetRow struct {
+	key      string
+	task     *models.Task // set when grouping by task
+	dayHours map[time.Weekday]float64
+	sessions []models.Session
+}
+
+// generateJiraTimesheet creates and displays the weekly timesheet, one row per
+// task, project or tag
+func generateJiraTimesheet(groupBy string, detail bool) error {
+	// Get current calendar week, starting on the configured first day
 	now := time.Now()
 	weekStart := getWeekStart(now)
-	weekEnd := weekStart.AddDate(0, 0, 7).Add(-time.Second) // End of Sunday
+	weekEnd := weekStart.AddDate(0, 0, 7).Add(-time.Second) // End of the last day
 
 	// Get all sessions from this week
 	sessions, err := db.GetSessionsInRange(weekStart, weekEnd)
@@ -51,70 +101,103 @@ func generateJiraTimesheet() error {
 		return nil
 	}
 
-	// Group sessions by task and day
-	taskDayHours := make(map[string]map[time.Weekday]float64)
-	allTasks := make(map[string]*models.Task)
+	// Group sessions by row and day
+	rows := make(map[string]*timesheetRow)
+	dayHours := make(map[time.Weekday]float64) // each session once, whatever the grouping
 
 	for _, session := range sessions {
-		taskKey := formatTaskKey(session.Task)
 		weekday := session.StartedAt.Weekday()
-
-		if taskDayHours[taskKey] == nil {
-			taskDayHours[taskKey] = make(map[time.Weekday]float64)
-		}
-
 		hours := float64(session.DurationSeconds) / 3600.0
-		taskDayHours[taskKey][weekday] += hours
-		allTasks[taskKey] = &session.Task
+		dayHours[weekday] += hours
+
+		for _, key := range timesheetKeys(session.Task, groupBy) {
+			row := rows[key]
+			if row == nil {
+				row = &timesheetRow{key: key, dayHours: make(map[time.Weekday]float64)}
+				if groupBy == groupByTask {
+					task := session.Task
+					row.task = &task
+				}
+				rows[key] = row
+			}
+			row.dayHours[weekday] += hours
+			row.sessions = append(row.sessions, session)
+		}
 	}
 
 	// Calculate which days have any work
 	activeDays := make(map[time.Weekday]bool)
-	for _, dayHours := range taskDayHours {
-		for day, hours := range dayHours {
-			if hours > 0 {
-				activeDays[day] = true
-			}
+	for day, hours := range dayHours {
+		if hours > 0 {
+			activeDays[day] = true
 		}
 	}
 
 	// Generate and display the table
-	displayTimesheet(taskDayHours, allTasks, activeDays, weekStart)
+	displayTimesheet(sortTimesheetRows(rows), groupBy, detail, activeDays, dayHours, weekStart)
 
 	return nil
 }
 
-// getWeekStart returns the start of the calendar week (Monday) for the given time
-func getWeekStart(t time.Time) time.Time {
-	weekday := t.Weekday()
-	daysFromMonday := int(weekday - time.Monday)
-	if weekday == time.Sunday {
-		daysFromMonday = 6 // Sunday is 6 days from Monday
+// renderTimesheetTemplate renders this week's sessions through the --template file
+func renderTimesheetTemplate(path string) error {
+	now := time.Now()
+	weekStart := getWeekStart(now)
+	weekEnd := weekStart.AddDate(0, 0, 7)
+
+	sessions, err := db.GetSessionsInRange(weekStart, weekEnd.Add(-time.Second))
+	if err != nil {
+		return fmt.Errorf("failed to get sessions: %w", err)
 	}
 
-	weekStart := t.AddDate(0, 0, -daysFromMonday)
-	// Set to start of day
-	return time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, weekStart.Location())
+	data := reportTemplateData{Start: weekStart, End: weekEnd, Sessions: sessions, Now: now}
+	for _, session := range sessions {
+		data.Total += time.Duration(session.DurationSeconds) * time.Second
+	}
+	renderTemplate(path, data)
+	return nil
 }
 
-// formatTaskKey creates a display key for the task
-func formatTaskKey(task models.Task) string {
-	if task.JiraID != "" {
-		return fmt.Sprintf("%s %s", task.JiraID, task.Title)
+// timesheetKeys returns the rows a task's sessions count towards
+func timesheetKeys(task models.Task, groupBy string) []string {
+	switch groupBy {
+	case groupByProject:
+		if task.Project == "" {
+			return []string{"(no project)"}
+		}
+		return []string{"@" + task.Project}
+	case groupByTag:
+		if len(task.Tags) == 0 {
+			return []string{"(no tag)"}
+		}
+		var keys []string
+		for _, tag := range task.Tags {
+			keys = append(keys, "#"+tag.Name)
+		}
+		return keys
 	}
-	return fmt.Sprintf("#%d %s", task.ID, task.Title)
+	return []string{formatTaskKey(task)}
 }
 
-// displayTimesheet outputs the formatted timesheet table
-func displayTimesheet(taskDayHours map[string]map[time.Weekday]float64, allTasks map[string]*models.Task, activeDays map[time.Weekday]bool, weekStart time.Time) {
-	// Sort tasks by JIRA ID first, then by task ID
-	var taskKeys []string
-	for taskKey := range taskDayHours {
-		taskKeys = append(taskKeys, taskKey)
+// sortTimesheetRows orders tasks by JIRA ID first, then by task ID; projects
+// and tags by name, with the untagged or unassigned row last
+func sortTimesheetRows(rows map[string]*timesheetRow) []*timesheetRow {
+	var sorted []*timesheetRow
+	for _, row := range rows {
+		sorted = append(sorted, row)
 	}
-	sort.Slice(taskKeys, func(i, j int) bool {
-		task1 := allTasks[taskKeys[i]]
-		task2 := allTasks[taskKeys[j]]
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].task == nil || sorted[j].task == nil {
+			iNone := strings.HasPrefix(sorted[i].key, "(")
+			jNone := strings.HasPrefix(sorted[j].key, "(")
+			if iNone != jNone {
+				return jNone
+			}
+			return strings.ToLower(sorted[i].key) < strings.ToLower(sorted[j].key)
+		}
+
+		task1 := sorted[i].task
+		task2 := sorted[j].task
 
 		// Sort by JIRA ID first if both have JIRA
 		if task1.JiraID != "" && task2.JiraID != "" {
@@ -130,18 +213,62 @@ func displayTimesheet(taskDayHours map[string]map[time.Weekday]float64, allTasks
 		// Both non-JIRA, sort by ID
 		return task1.ID < task2.ID
 	})
+	return sorted
+}
 
-	// Determine which days to show (only active days)
-	var daysToShow []time.Weekday
-	dayNames := []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
-	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+// printTimesheetSessions lists the sessions of a timesheet row under it: their
+// day, times, duration, task (unless the row is the task) and note
+func printTimesheetSessions(row *timesheetRow, groupBy string) {
+	for _, session := range row.sessions {
+		line := fmt.Sprintf("    %s %s", parser.DayLabel(session.StartedAt.Weekday()), session.StartedAt.Format("15:04"))
+		if session.FinishedAt != nil {
+			line += "–" + session.FinishedAt.Format("15:04")
+		}
+		line += fmt.Sprintf("  %-5s", formatDuration(time.Duration(session.DurationSeconds)*time.Second))
+		if groupBy != groupByTask {
+			line += "  " + formatTaskKey(session.Task)
+		}
+		if session.Note != "" {
+			line += "  — " + session.Note
+		}
+		fmt.Println(line)
+	}
+}
 
-	for i, weekday := range weekdays {
+// getWeekStart returns the start of the calendar week for the given time,
+// using the first day of the week from the config (Monday by default)
+func getWeekStart(t time.Time) time.Time {
+	return parser.StartOfWeek(t, firstWeekday())
+}
+
+// firstWeekday returns the configured first day of the week
+func firstWeekday() time.Weekday {
+	cfg, err := config.Load()
+	if err != nil {
+		return time.Monday
+	}
+	return cfg.FirstWeekday()
+}
+
+// formatTaskKey creates a display key for the task
+func formatTaskKey(task models.Task) string {
+	if task.JiraID != "" {
+		return fmt.Sprintf("%s %s", task.JiraID, task.Title)
+	}
+	return fmt.Sprintf("#%d %s", task.ID, task.Title)
+}
+
+// displayTimesheet outputs the formatted timesheet table. dayHours has each
+// day's hours counting every session once, for the Total row of tag groupings
+func displayTimesheet(rows []*timesheetRow, groupBy string, detail bool, activeDays map[time.Weekday]bool, dayHours map[time.Weekday]float64, weekStart time.Time) {
+	// Determine which days to show (only active days), in week order
+	var daysToShow []time.Weekday
+	for _, weekday := range parser.WeekDays(weekStart.Weekday()) {
 		if activeDays[weekday] {
 			daysToShow = append(daysToShow, weekday)
 		} else {
 			// Also show if it's a weekday and we have some work this week
-			if i < 5 && len(activeDays) > 0 { // Mon-Fri
+			if weekday != time.Saturday && weekday != time.Sunday && len(activeDays) > 0 {
 				daysToShow = append(daysToShow, weekday)
 			}
 		}
@@ -149,9 +276,9 @@ func displayTimesheet(taskDayHours map[string]map[time.Weekday]float64, allTasks
 
 	// Calculate column widths
 	maxTaskNameWidth := 20
-	for _, taskKey := range taskKeys {
-		if len(taskKey) > maxTaskNameWidth {
-			maxTaskNameWidth = len(taskKey)
+	for _, row := range rows {
+		if len(row.key) > maxTaskNameWidth {
+			maxTaskNameWidth = len(row.key)
 		}
 	}
 	if maxTaskNameWidth > 40 {
@@ -162,10 +289,16 @@ func displayTimesheet(taskDayHours map[string]map[time.Weekday]float64, allTasks
 	totalColumnWidth := 7
 
 	// Print header
-	fmt.Printf("%-*s", maxTaskNameWidth, "Task")
+	header := "Task"
+	switch groupBy {
+	case groupByProject:
+		header = "Project"
+	case groupByTag:
+		header = "Tag"
+	}
+	fmt.Printf("%-*s", maxTaskNameWidth, header)
 	for _, weekday := range daysToShow {
-		dayIndex := (int(weekday) - 1 + 7) % 7 // Convert to 0-6 with Monday=0
-		fmt.Printf("  %*s", dayColumnWidth-2, dayNames[dayIndex])
+		fmt.Printf("  %*s", dayColumnWidth-2, parser.DayLabel(weekday))
 	}
 	fmt.Printf("  %*s\n", totalColumnWidth-2, "Total")
 
@@ -181,11 +314,9 @@ func displayTimesheet(taskDayHours map[string]map[time.Weekday]float64, allTasks
 	weekTotals := make(map[time.Weekday]float64)
 	grandTotal := 0.0
 
-	for _, taskKey := range taskKeys {
-		dayHours := taskDayHours[taskKey]
-
+	for _, row := range rows {
 		// Truncate task name if too long
-		displayTaskKey := taskKey
+		displayTaskKey := row.key
 		if len(displayTaskKey) > maxTaskNameWidth {
 			displayTaskKey = displayTaskKey[:maxTaskNameWidth-3] + "..."
 		}
@@ -194,7 +325,7 @@ func displayTimesheet(taskDayHours map[string]map[time.Weekday]float64, allTasks
 
 		taskTotal := 0.0
 		for _, weekday := range daysToShow {
-			hours := dayHours[weekday]
+			hours := row.dayHours[weekday]
 			if hours > 0 {
 				roundedHours := math.Ceil(hours)
 				fmt.Printf("  %*d", dayColumnWidth-2, int(roundedHours))
@@ -207,6 +338,20 @@ func displayTimesheet(taskDayHours map[string]map[time.Weekday]float64, allTasks
 
 		fmt.Printf("  %*d\n", totalColumnWidth-2, int(taskTotal))
 		grandTotal += taskTotal
+
+		if detail {
+			printTimesheetSessions(row, groupBy)
+		}
+	}
+
+	// Tags overlap, so their rows can't simply be added up
+	if groupBy == groupByTag {
+		weekTotals = make(map[time.Weekday]float64)
+		grandTotal = 0
+		for _, weekday := range daysToShow {
+			weekTotals[weekday] = math.Ceil(dayHours[weekday])
+			grandTotal += weekTotals[weekday]
+		}
 	}
 
 	// Print total row
@@ -232,4 +377,9 @@ func displayTimesheet(taskDayHours map[string]map[time.Weekday]float64, allTasks
 	fmt.Printf("\nWeek of %s to %s\n",
 		weekStart.Format("Jan 2"),
 		weekStart.AddDate(0, 0, 6).Format("Jan 2, 2006"))
-}
\ No newline at end of file
+}
+func init() {
+	jiraCmd.Flags().String("group-by", groupByTask, "Rows of the timesheet: task, project or tag")
+	jiraCmd.Flags().Bool("detail", false, "List the sessions under each row, with their notes")
+	addTemplateFlag(jiraCmd)
+}
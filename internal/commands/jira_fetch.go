@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/jira"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+var jiraFetchCmd = &cobra.Command{
+	Use:   "fetch [JIRA-ID]",
+	Short: "Pull summary, status and assignee from Jira into linked tasks",
+	Long: `Pull an issue's summary, status and assignee from Jira into the tasks
+linked to it. Without a JIRA ID, every linked task is refreshed.
+
+The status and assignee show up in the task details. When the issue summary
+differs from a task's title it is suggested; --sync-titles replaces the
+titles instead.
+
+Needs [jira] base_url in the config and an API token in WROK_JIRA_TOKEN (or
+[jira] token). For Jira Cloud also set [jira] email; without it the token is
+sent as a personal access token (Jira Server/Data Center).
+
+Examples:
+  wrok jira fetch APP-42
+  wrok jira fetch APP-42 --sync-titles
+  wrok jira fetch --sync-titles     # Every linked task`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		syncTitles, _ := cmd.Flags().GetBool("sync-titles")
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		client, err := jira.NewClient(cfg.Jira)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if len(args) == 1 {
+			jiraID, err := parser.NormalizeJiraID(args[0])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fetchJiraIssue(client, jiraID, syncTitles)
+			return
+		}
+
+		tasks, err := db.GetJiraLinkedTasks()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(tasks) == 0 {
+			fmt.Println("No tasks are linked to Jira issues.")
+			return
+		}
+
+		// One request per issue, however many tasks share it
+		byIssue := make(map[string][]models.Task)
+		var order []string
+		for _, task := range tasks {
+			key, err := parser.NormalizeJiraID(task.JiraID)
+			if err != nil {
+				continue // not a key Jira would know
+			}
+			if _, ok := byIssue[key]; !ok {
+				order = append(order, key)
+			}
+			byIssue[key] = append(byIssue[key], task)
+		}
+
+		failed := 0
+		for _, key := range order {
+			issue, err := client.GetIssue(key)
+			if err != nil {
+				fmt.Printf("❌ %s: %v\n", key, err)
+				failed++
+				continue
+			}
+			for _, task := range byIssue[key] {
+				applyJiraIssue(task, *issue, syncTitles)
+			}
+		}
+		fmt.Printf("\n🔄 Refreshed %d issue(s)", len(order)-failed)
+		if failed > 0 {
+			fmt.Printf(", %d failed", failed)
+		}
+		fmt.Println()
+	},
+}
+
+// fetchJiraIssue pulls one issue and applies it to every task linked to it
+func fetchJiraIssue(client *jira.Client, jiraID string, syncTitles bool) {
+	issue, err := client.GetIssue(jiraID)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("🎫 %s: %s\n", issue.Key, issue.Summary)
+	fmt.Printf("   %s · %s\n", issue.Status, formatAssignee(issue.Assignee))
+
+	tasks, err := db.GetTasksByJiraID(jiraID)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if len(tasks) == 0 {
+		fmt.Printf("\nNo task is linked to %s. Create one with:\n  wrok add %q %s\n", jiraID, issue.Summary, jiraID)
+		return
+	}
+
+	fmt.Println()
+	for _, task := range tasks {
+		applyJiraIssue(task, *issue, syncTitles)
+	}
+}
+
+// applyJiraIssue stores an issue's status and assignee on a task, and its
+// summary as the title when syncTitles is set; otherwise a differing summary is suggested
+func applyJiraIssue(task models.Task, issue jira.Issue, syncTitles bool) {
+	info := db.JiraIssueInfo{Status: issue.Status, Assignee: issue.Assignee}
+	retitle := issue.Summary != "" && issue.Summary != task.Title
+	if retitle && syncTitles {
+		info.Title = issue.Summary
+	}
+
+	if _, err := db.ApplyJiraIssue(task.ID, info, time.Now()); err != nil {
+		fmt.Printf("❌ #%d: %v\n", task.ID, err)
+		return
+	}
+
+	switch {
+	case retitle && syncTitles:
+		fmt.Printf("✅ #%d %s → %q [%s]\n", task.ID, issue.Key, issue.Summary, issue.Status)
+	case retitle:
+		fmt.Printf("✅ #%d %s [%s]\n", task.ID, task.Title, issue.Status)
+		fmt.Printf("   💡 Jira calls it %q (--sync-titles to use that)\n", issue.Summary)
+	default:
+		fmt.Printf("✅ #%d %s [%s]\n", task.ID, task.Title, issue.Status)
+	}
+}
+
+// formatAssignee returns the assignee's name, or "unassigned"
+func formatAssignee(assignee string) string {
+	if assignee == "" {
+		return "unassigned"
+	}
+	return assignee
+}
+
+func init() {
+	jiraFetchCmd.Flags().Bool("sync-titles", false, "Replace task titles with the issue summaries")
+
+	jiraCmd.AddCommand(jiraFetchCmd)
+}
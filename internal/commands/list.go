@@ -3,21 +3,42 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
-	tea "github.com/charmbracelet/bubbletea"
 	"github.com/balkashynov/wrok/internal/db"
 	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/priority"
+	"github.com/balkashynov/wrok/internal/parser"
 	"github.com/balkashynov/wrok/internal/tui"
+	"github.com/balkashynov/wrok/internal/workflow"
 )
 
 var listCmd = &cobra.Command{
 	Use:     "ls",
 	Aliases: []string{"list"},
 	Short:   "List tasks",
-	Long:    "List tasks with optional filters and formats. Opens interactive TUI by default, use --no-ui for simple output.",
+	Long: `List tasks with optional filters and formats. Opens interactive TUI by default, use --no-ui for simple output.
+
+Plain output (--no-ui, --format table, --columns, --template) shows the first
+100 tasks in the chosen order; --limit changes that and --limit 0 shows them
+all. When tasks were left out, a note on stderr says how many. JSON output
+(--json, --format json or alfred) is for scripts and lists every task unless
+--limit is given.
+
+--format alfred prints the script filter JSON of Alfred and Raycast: arg is
+the task ID, holding cmd gives "start <id>" and alt "done <id>", and icons are
+looked up as icons/<status>.png (todo, done, archived, running) in the workflow.
+
+` + templateHelp + `
+Templates get .Tasks (each with its Tags) and .Now.`,
+	Example: `  wrok ls
+  wrok ls --status todo --project auth --no-ui
+  wrok ls --columns id,title,due,time
+  wrok ls --status todo --format alfred
+  wrok ls --status todo --template todo.org.tmpl`,
 	Run: func(cmd *cobra.Command, args []string) {
 		initDB()
 		// Get flags
@@ -26,29 +47,134 @@ var listCmd = &cobra.Command{
 		status, _ := cmd.Flags().GetString("status")
 		project, _ := cmd.Flags().GetString("project")
 		tags, _ := cmd.Flags().GetStringSlice("tags")
+		limit, _ := cmd.Flags().GetInt("limit")
+		noFocus, _ := cmd.Flags().GetBool("no-focus")
+		showAll, _ := cmd.Flags().GetBool("all")
+		showSnoozed, _ := cmd.Flags().GetBool("snoozed")
+		orderName, _ := cmd.Flags().GetString("order")
+		
+		columnSpec, _ := cmd.Flags().GetString("columns")
+		format, _ := cmd.Flags().GetString("format")
+		
+		// --format alfred feeds Alfred/Raycast script filters; it is plain output like --json
+		alfredOutput := false
+		switch format {
+		case "":
+		case "table":
+			noUI = true
+		case "json":
+			jsonOutput = true
+		case "alfred":
+			alfredOutput, jsonOutput = true, true
+		default:
+			fmt.Printf("Error: invalid format '%s', must be one of: table, json, alfred\n", format)
+			return
+		}
+		
+		if status != "" && status != "open" {
+			parsed, err := workflow.Parse(status)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			status = parsed
+		}
+
+		order, ok := listOrders[orderName]
+		if !ok {
+			fmt.Printf("Error: invalid order '%s', must be one of: id, urgency, due, priority, created\n", orderName)
+			return
+		}
+		
+		// Picking columns only makes sense for plain output
+		var columns []string
+		if cmd.Flags().Changed("columns") {
+			var err error
+			columns, err = parseColumns(columnSpec)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			noUI = true
+		}
+		tmplPath := templatePath(cmd)
+		if tmplPath != "" {
+			noUI = true
+		}
 		
 		// Build query options
 		opts := db.TaskQueryOptions{
 			Status:  status,
 			Project: project,
 			Tags:    tags,
-			OrderBy: "id DESC", // newest first by default
+			OrderBy: order.orderBy,
+			Limit:   limit,
+		}
+		
+		// Tasks snoozed with --hide stay out of sight until the snooze ends
+		opts.HideSnoozed = !showSnoozed
+		
+		// The TUI hides archived tasks unless asked for them ('z' toggles them back)
+		interactive := !noUI && !jsonOutput
+		if (interactive || jsonOutput) && !cmd.Flags().Changed("limit") {
+			opts.Limit = 0 // the TUI loads a page at a time anyway; scripts get everything
+		}
+		if interactive && !showAll {
+			opts.HideArchived = true
+		}
+		
+		// Narrow to the focus context unless asked not to
+		focus := db.FocusContext{}
+		if !noFocus {
+			focus = loadFocus()
+			applyFocus(&opts, focus)
 		}
 		
-		// Get tasks with filtering
-		tasks, err := db.GetTasksWithOptions(opts)
+		// The TUI picks up where it was left, unless filters are given or --fresh
+		var state *tui.ListState
+		requested := opts
+		if interactive && !listFiltersGiven(cmd) {
+			if saved, ok := tui.LoadListState(); ok && saved.Focus == focus.String() {
+				state = &saved
+				state.ApplyFilters(&opts)
+			}
+		}
+		
+		// Get tasks with filtering; the TUI loads them a page at a time itself
+		tasks, count, err := listTasks(opts, interactive)
 		if err != nil {
 			fmt.Printf("Error fetching tasks: %v\n", err)
 			return
 		}
+		if state != nil && count == 0 {
+			// The saved filters match nothing any more
+			state, opts = nil, requested
+			if tasks, count, err = listTasks(opts, interactive); err != nil {
+				fmt.Printf("Error fetching tasks: %v\n", err)
+				return
+			}
+		}
+		
+		if tmplPath != "" {
+			renderTemplate(tmplPath, taskTemplateData{Tasks: tasks, Now: time.Now()})
+			return
+		}
 		
-		if len(tasks) == 0 {
+		if count == 0 {
+			if focus.IsSet() && !jsonOutput {
+				fmt.Printf("No tasks found in focus %s. Use --no-focus or 'wrok focus --clear' to see everything.\n", focus)
+				return
+			}
 			if noUI || jsonOutput {
-				if jsonOutput {
+				if alfredOutput {
+					renderAlfred(nil)
+				} else if jsonOutput {
 					fmt.Println("[]")
 				} else {
 					fmt.Println("No tasks found.")
 				}
+			} else if opts.HideArchived {
+				fmt.Println("No tasks found outside the archive. Use --all to include archived tasks.")
 			} else {
 				fmt.Println("No tasks found. Use 'wrok add \"task description\"' to create your first task.")
 			}
@@ -56,30 +182,97 @@ var listCmd = &cobra.Command{
 		}
 		
 		// Check if we should open TUI or use non-UI mode
-		if noUI || jsonOutput {
-			if jsonOutput {
+		if !interactive {
+			if alfredOutput {
+				renderAlfred(tasks)
+			} else if jsonOutput {
 				renderJSON(tasks)
 			} else {
-				renderTable(tasks)
+				if focus.IsSet() {
+					fmt.Printf("🎯 Focus: %s (use --no-focus to see everything)\n\n", focus)
+				}
+				if columns != nil {
+					if err := renderColumns(tasks, columns); err != nil {
+						fmt.Printf("Error: %v\n", err)
+					}
+				} else {
+					renderTable(tasks)
+				}
 			}
+			printLimitNote(opts, count)
 		} else {
 			// Launch interactive TUI
-			runInteractiveList(tasks)
+			// The TUI sorts by urgency unless an order was asked for
+			sortField, sortDirection := "urgency", "desc"
+			if cmd.Flags().Changed("order") {
+				sortField, sortDirection = order.sortField, order.sortDirection
+			} else if state != nil {
+				sortField, sortDirection = state.SortField, state.SortDirection
+			}
+			runInteractiveList(opts, sortField, sortDirection, state, focus)
 		}
 	},
 }
 
+// defaultListLimit is the page plain 'wrok ls' prints unless --limit says otherwise
+const defaultListLimit = 100
+
+// listOrder maps an --order value to a SQL ordering and the matching TUI sort
+type listOrder struct {
+	orderBy       string
+	sortField     string
+	sortDirection string
+}
+
+// listOrders are the values accepted by ls --order
+var listOrders = map[string]listOrder{
+	"id":       {"id DESC", "id", "desc"},
+	"urgency":  {db.OrderUrgency, "urgency", "desc"},
+	"due":      {"due IS NULL, due ASC", "due", "asc"},
+	"priority": {"priority DESC, id DESC", "priority", "desc"},
+	"created":  {"created_at DESC", "created_at", "desc"},
+}
+
+// printLimitNote says on stderr when --limit left tasks out of a plain listing,
+// so JSON on stdout stays parseable
+func printLimitNote(opts db.TaskQueryOptions, shown int) {
+	if opts.Limit == 0 || shown < opts.Limit {
+		return
+	}
+	opts.Limit = 0
+	total, err := db.CountTasks(opts)
+	if err != nil || total <= shown {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\nShowing %d of %d tasks; use --limit 0 for all.\n", shown, total)
+}
+
+// listTasks returns the tasks matching opts and how many there are. With
+// countOnly only the count is fetched, for the TUI, which loads its own pages
+func listTasks(opts db.TaskQueryOptions, countOnly bool) ([]models.Task, int, error) {
+	if countOnly {
+		count, err := db.CountTasks(opts)
+		return nil, count, err
+	}
+	tasks, err := db.GetTasksWithOptions(opts)
+	return tasks, len(tasks), err
+}
+
 // renderJSON outputs tasks as JSON
 func renderJSON(tasks []models.Task) {
 	// Create a simplified structure for JSON output
 	type JsonTask struct {
 		ID       uint      `json:"id"`
+		Alias    string    `json:"alias,omitempty"`
 		Title    string    `json:"title"`
 		Status   string    `json:"status"`
 		Project  string    `json:"project"`
 		Priority string    `json:"priority"`
+		Pinned   bool      `json:"pinned"`
 		JiraID   string    `json:"jira_id,omitempty"`
+		JiraStatus string `json:"jira_status,omitempty"`
 		Due      *time.Time `json:"due,omitempty"`
+		ParentID *uint     `json:"parent_id,omitempty"`
 		Tags     []string  `json:"tags"`
 		Notes    string    `json:"notes,omitempty"`
 		CreatedAt time.Time `json:"created_at"`
@@ -95,20 +288,20 @@ func renderJSON(tasks []models.Task) {
 		}
 		
 		// Priority display
-		priorities := []string{"", "low", "medium", "high"}
-		priorityStr := ""
-		if task.Priority > 0 && task.Priority < len(priorities) {
-			priorityStr = priorities[task.Priority]
-		}
+		priorityStr := priority.Name(task.Priority)
 		
 		jsonTask := JsonTask{
 			ID:        task.ID,
+			Alias:     task.Alias,
 			Title:     task.Title,
 			Status:    task.Status,
 			Project:   task.Project,
 			Priority:  priorityStr,
+			Pinned:    task.Pinned,
 			JiraID:    task.JiraID,
+			JiraStatus: task.JiraStatus,
 			Due:       task.Due,
+			ParentID:  task.ParentID,
 			Tags:      tagNames,
 			Notes:     task.Note,
 			CreatedAt: task.CreatedAt,
@@ -129,9 +322,17 @@ func renderJSON(tasks []models.Task) {
 // renderTable outputs tasks as a formatted table optimized for 80-column terminals
 func renderTable(tasks []models.Task) {
 	// Fixed column widths for 80-character terminals
-	// Format: ID(4) TITLE(35) PROJECT(12) PRIORITY(8) TAGS(12) STATUS(6) = 77 chars + separators
-	fmt.Printf("%-4s %-35s %-12s %-8s %-12s %s\n", "ID", "TITLE", "PROJECT", "PRIORITY", "TAGS", "STATUS")
-	fmt.Println(strings.Repeat("-", 80))
+	// Format: ID(4) TITLE(29) PROJECT(12) PRIORITY(8) TAGS(12) AGE(5) STATUS(6) = 76 chars + separators
+	fmt.Printf("%-4s %-29s %-12s %-8s %-12s %-5s %s\n", "ID", "TITLE", "PROJECT", "PRIORITY", "TAGS", "AGE", "STATUS")
+	fmt.Println(strings.Repeat("-", 82))
+	
+	// Age is measured from the last edit or session; a failed lookup just falls back to UpdatedAt
+	now := time.Now()
+	lastActivity, err := db.GetLastActivity(tasks, now)
+	if err != nil {
+		lastActivity = map[uint]time.Time{}
+	}
+	staleCount := 0
 	
 	// Print each task
 	for _, task := range tasks {
@@ -143,16 +344,23 @@ func renderTable(tasks []models.Task) {
 		tagsStr := strings.Join(tagNames, ",")
 		
 		// Priority display
-		priorities := []string{"", "low", "med", "high"}
-		priorityStr := ""
-		if task.Priority > 0 && task.Priority < len(priorities) {
-			priorityStr = priorities[task.Priority]
-		}
+		priorityStr := priority.Short(task.Priority)
 		
 		// Truncate long fields
 		title := task.Title
-		if len(title) > 33 {
-			title = title[:30] + "..."
+		if task.Alias != "" {
+			title = "[" + task.Alias + "] " + title
+		}
+		titleWidth := 29
+		if task.Pinned {
+			// Leave room for the marker; fmt pads by runes and the pin is double-width
+			if len(title) > 24 {
+				title = title[:21] + "..."
+			}
+			title = "📌 " + title
+			titleWidth--
+		} else if len(title) > 27 {
+			title = title[:24] + "..."
 		}
 		
 		project := task.Project
@@ -164,31 +372,78 @@ func renderTable(tasks []models.Task) {
 			tagsStr = tagsStr[:7] + "..."
 		}
 		
-		fmt.Printf("%-4d %-35s %-12s %-8s %-12s %s\n", 
+		last, ok := lastActivity[task.ID]
+		if !ok {
+			last = task.UpdatedAt
+		}
+		ageStr := parser.FormatAge(now.Sub(last))
+		if db.IsStale(task, last, now) {
+			ageStr += "!"
+			staleCount++
+		}
+		
+		fmt.Printf("%-4d %-*s %-12s %-8s %-12s %-5s %s\n", 
 			task.ID, 
-			title, 
+			titleWidth, title, 
 			project, 
 			priorityStr, 
 			tagsStr,
+			ageStr,
 			task.Status)
 	}
+	
+	if staleCount > 0 {
+		fmt.Printf("\n! %d task(s) untouched for over %d days\n", staleCount, int(db.StaleAfter.Hours()/24))
+	}
 }
 
-// runInteractiveList launches the TUI for task listing
-func runInteractiveList(tasks []models.Task) {
-	model := tui.NewListModel(tasks)
+// listFiltersGiven reports whether ls was given filters or --fresh,
+// which start the TUI from them instead of the saved state
+func listFiltersGiven(cmd *cobra.Command) bool {
+	for _, name := range []string{"status", "project", "tags", "limit", "all", "fresh"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// runInteractiveList launches the TUI for task listing, restoring state if
+// given, and saves where it was left for next time
+func runInteractiveList(opts db.TaskQueryOptions, sortField, sortDirection string, state *tui.ListState, focus db.FocusContext) {
+	model := tui.NewListModelWithOptions(opts).WithSort(sortField, sortDirection)
+	if state != nil {
+		model = model.WithState(*state)
+	}
 	
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	final, err := tui.RunProgram(model)
+	if err != nil {
 		fmt.Printf("Error running TUI: %v\n", err)
 		return
 	}
+	
+	if list, ok := final.(tui.ListModel); ok {
+		saved := list.State()
+		saved.Focus = focus.String()
+		if err := tui.SaveListState(saved); err != nil {
+			fmt.Printf("Warning: could not save the list state: %v\n", err)
+		}
+	}
 }
 
 func init() {
 	listCmd.Flags().Bool("no-ui", false, "Disable interactive TUI, output plain table")
+	listCmd.Flags().Bool("no-focus", false, "Ignore the current focus context")
 	listCmd.Flags().Bool("json", false, "Output as JSON")
-	listCmd.Flags().StringP("status", "s", "", "Filter by status: todo, done, archived")
+	listCmd.Flags().String("format", "", "Output format: table, json, alfred (Alfred/Raycast script filter JSON)")
+	listCmd.Flags().Bool("all", false, "Include archived tasks in the interactive UI")
+	listCmd.Flags().Bool("snoozed", false, "Include tasks hidden by 'wrok snooze --hide'")
+	listCmd.Flags().Bool("fresh", false, "Open the interactive UI with the default sort and filters instead of where it was left")
+	listCmd.Flags().StringP("status", "s", "", "Filter by status: todo, done, archived, a configured one, or open (all but done and archived)")
 	listCmd.Flags().StringP("project", "p", "", "Filter by project")
 	listCmd.Flags().StringSliceP("tags", "t", []string{}, "Filter by tags (comma-separated)")
+	listCmd.Flags().IntP("limit", "l", defaultListLimit, "Most tasks to list in plain output (0 = all); JSON output and the interactive UI list every task unless given")
+	listCmd.Flags().String("order", "id", "Sort order: id, urgency, due, priority, created")
+	addTemplateFlag(listCmd)
+	listCmd.Flags().String("columns", "", "Comma-separated columns for plain output (e.g. id,title,due,time,project)")
 }
\ No newline at end of file
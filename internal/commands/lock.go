@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+var lockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Lock sessions that were already reported",
+	Long: `Lock the finished sessions started before a date, e.g. once their time has
+been reported to a client or billed.
+
+Locked sessions can't be edited, split, merged or deleted unless --force is
+given ('wrok session split --force', 'wrok sessions --force'). Reports can
+count only locked or only unlocked time with --locked and --unlocked.
+
+Use --unlock to lift the lock again, e.g. after locking the wrong period.
+
+Examples:
+  wrok lock --before 2024-06-01            # Everything up to the end of May
+  wrok lock --before 01/06/2024
+  wrok lock --before 2024-06-01 --unlock   # Undo it
+  wrok howlong --unlocked                  # Time not reported yet`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		beforeValue, _ := cmd.Flags().GetString("before")
+		unlock, _ := cmd.Flags().GetBool("unlock")
+
+		if beforeValue == "" {
+			fmt.Println("Error: --before is required (dd/mm/yyyy or yyyy-mm-dd)")
+			return
+		}
+		before, err := parser.ParseDay(beforeValue)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if unlock {
+			unlocked, err := db.UnlockSessionsBefore(before)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fmt.Printf("🔓 Unlocked %d session(s) started before %s\n", unlocked, before.Format("02/01/2006"))
+			return
+		}
+
+		locked, err := db.LockSessionsBefore(before)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if locked == 0 {
+			fmt.Printf("No unlocked sessions started before %s, nothing to lock\n", before.Format("02/01/2006"))
+			return
+		}
+		fmt.Printf("🔐 Locked %d session(s) started before %s\n", locked, before.Format("02/01/2006"))
+	},
+}
+
+// lockedFromFlags returns the --locked or --unlocked filter of a report, nil when
+// neither is set
+func lockedFromFlags(cmd *cobra.Command) (*bool, error) {
+	locked, _ := cmd.Flags().GetBool("locked")
+	unlocked, _ := cmd.Flags().GetBool("unlocked")
+	switch {
+	case locked && unlocked:
+		return nil, fmt.Errorf("use either --locked or --unlocked, not both")
+	case locked, unlocked:
+		return &locked, nil
+	}
+	return nil, nil
+}
+
+func init() {
+	lockCmd.Flags().String("before", "", "Lock sessions started before this day (dd/mm/yyyy or yyyy-mm-dd)")
+	lockCmd.Flags().Bool("unlock", false, "Unlock the sessions instead")
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/plain"
+)
+
+// nagTaskCount is how many tasks the due banner names
+const nagTaskCount = 3
+
+// printDueBanner reminds of overdue tasks and tasks due today after a command,
+// when nag = true is set in the config. It only follows commands that opened
+// the database, and goes to stderr only when that is a terminal, so scripts
+// and piped output never see it
+func printDueBanner() {
+	cfg, err := config.Load()
+	if err != nil || !cfg.Nag || db.DB == nil || !isTerminal(plain.Stderr()) {
+		return
+	}
+
+	due, err := db.GetDueSoon(time.Now())
+	if err != nil {
+		return
+	}
+	if banner := dueBanner(due); banner != "" {
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, banner)
+	}
+}
+
+// dueBanner sums up the tasks that are due, e.g.
+// "⏰ 2 overdue, 1 due today: #42 Fix login, #7 Send invoice, +1 more"
+func dueBanner(due db.DueSoon) string {
+	var counts []string
+	if len(due.Overdue) > 0 {
+		counts = append(counts, fmt.Sprintf("%d overdue", len(due.Overdue)))
+	}
+	if len(due.DueToday) > 0 {
+		counts = append(counts, fmt.Sprintf("%d due today", len(due.DueToday)))
+	}
+	if len(counts) == 0 {
+		return ""
+	}
+
+	tasks := append(append([]models.Task{}, due.Overdue...), due.DueToday...)
+	var names []string
+	for i, task := range tasks {
+		if i == nagTaskCount {
+			names = append(names, fmt.Sprintf("+%d more", len(tasks)-nagTaskCount))
+			break
+		}
+		title := task.Title
+		if len([]rune(title)) > 30 {
+			title = string([]rune(title)[:27]) + "..."
+		}
+		names = append(names, fmt.Sprintf("#%d %s", task.ID, title))
+	}
+
+	return fmt.Sprintf("⏰ %s: %s\n   'wrok snooze <id> 2d' pushes one out",
+		strings.Join(counts, ", "), strings.Join(names, ", "))
+}
+
+// isTerminal reports whether f is a terminal rather than a file or pipe
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+var noteCmd = &cobra.Command{
+	Use:   "note [task-id | title] [text...]",
+	Short: "Append a line to a task's notes or show them",
+	Long: `Append a timestamped line to a task's notes, without opening an editor.
+Without text, print the task's notes.
+
+Each line starts with the date and time it was added, e.g.
+"[14/03/2025 09:30] spoke to QA, repro found". Use 'wrok edit --notes-editor'
+to rewrite the notes.
+
+Examples:
+  wrok note 42 "spoke to QA, repro found"
+  wrok note 42 waiting on design
+  wrok note 42`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		taskID, err := resolveTaskID(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if len(args) == 1 {
+			task, err := db.GetTaskByID(taskID)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if strings.TrimSpace(task.Note) == "" {
+				fmt.Printf("Task #%d has no notes yet.\n", taskID)
+				return
+			}
+			fmt.Println(task.Note)
+			return
+		}
+
+		if _, err := db.AppendTaskNote(taskID, strings.Join(args[1:], " "), time.Now()); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("📝 Added a note to task #%d\n", taskID)
+	},
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/browser"
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open [task-id | title]",
+	Short: "Open a task's URL or attached file",
+	Long: `Open a task's URL in the default browser.
+
+Tasks without a URL open their Jira issue instead, using [jira] base_url from
+the config file. With --file, open one of the task's attached files (numbered
+as in the task details) with its default application.
+
+Examples:
+  wrok open 42
+  wrok open "login bug"
+  wrok open 42 --file 1`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		taskID, err := resolveTaskID(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if cmd.Flags().Changed("file") {
+			n, _ := cmd.Flags().GetInt("file")
+			file, err := db.GetTaskFile(taskID, n)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			if err := browser.Open(file.Path); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fmt.Printf("📎 Opened %s\n", file.Path)
+			return
+		}
+
+		task, err := db.GetTaskByID(taskID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		url, err := browser.TaskURL(*task, cfg.Jira.BaseURL)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if err := browser.Open(url); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("🔗 Opened %s\n", url)
+	},
+}
+
+var fileCmd = &cobra.Command{
+	Use:   "file",
+	Short: "Attach files to tasks",
+	Long: `Attach files to tasks. A task's files are listed, numbered, in its details
+and opened with 'wrok open <id> --file <n>'.
+
+Subcommands:
+  add    Attach a file to a task`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var fileAddCmd = &cobra.Command{
+	Use:   "add [task-id | title] [path]",
+	Short: "Attach a file to a task",
+	Long: `Attach a file to a task. The file's absolute path is stored, so relative
+paths are resolved against the current directory; use --copy to keep a copy
+under ~/.wrok/attachments instead, which outlives the original moving.
+
+Examples:
+  wrok file add 42 ./spec.pdf
+  wrok file add 42 ~/Downloads/logs.txt --copy`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		copyFile, _ := cmd.Flags().GetBool("copy")
+		attachFile(args[0], args[1], copyFile)
+	},
+}
+
+// attachFile attaches a file to the task named by taskArg
+func attachFile(taskArg, path string, copyFile bool) {
+	taskID, err := resolveTaskID(taskArg)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	file, err := db.AttachFile(taskID, path, copyFile)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	files, err := db.GetTaskFiles(taskID)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	action := "Attached"
+	if file.Copied {
+		action = "Copied"
+	}
+	fmt.Printf("📎 %s %s to task #%d (file %d)\n", action, file.Path, taskID, len(files))
+}
+
+func init() {
+	openCmd.Flags().Int("file", 0, "Open the n-th attached file instead of the URL")
+
+	fileAddCmd.Flags().Bool("copy", false, "Copy the file into ~/.wrok/attachments instead of referencing it")
+	fileCmd.AddCommand(fileAddCmd)
+}
@@ -0,0 +1,414 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/priority"
+)
+
+// Org-mode and TaskPaper files are outlines: projects are headings, tasks the
+// entries under them. Tasks without a project sit at the top level. Due dates
+// are written as days (due at the end of the day, as dd/mm/yyyy is in 'wrok
+// add'), with the time only when it is another one
+
+// outlineFormats are the task formats of 'wrok export' and 'wrok import'
+var outlineFormats = map[string]struct {
+	write func(out io.Writer, tasks []models.Task) error
+	read  func(input io.Reader) ([]db.ImportedTask, error)
+}{
+	"org":       {writeOrg, readOrg},
+	"taskpaper": {writeTaskPaper, readTaskPaper},
+}
+
+// tasksByProject returns the projects of tasks in order of first appearance,
+// the tasks without a project first, under ""
+func tasksByProject(tasks []models.Task) ([]string, map[string][]models.Task) {
+	byProject := make(map[string][]models.Task)
+	projects := []string{""}
+	for _, task := range tasks {
+		if _, ok := byProject[task.Project]; !ok && task.Project != "" {
+			projects = append(projects, task.Project)
+		}
+		byProject[task.Project] = append(byProject[task.Project], task)
+	}
+	return projects, byProject
+}
+
+// isEndOfDay reports whether t is a due date without a time of its own
+func isEndOfDay(t time.Time) bool {
+	return t.Hour() == 23 && t.Minute() == 59
+}
+
+// endOfDay returns the due date for a day given without a time
+func endOfDay(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 59, 0, day.Location())
+}
+
+// orgDate writes t as an org timestamp body, e.g. "2026-10-18 Sun" or "2026-10-18 Sun 14:00"
+func orgDate(t time.Time) string {
+	if isEndOfDay(t) {
+		return t.Format("2006-01-02 Mon")
+	}
+	return t.Format("2006-01-02 Mon 15:04")
+}
+
+// writeOrg writes tasks as an org-mode outline: a heading per project with a
+// TODO or DONE heading per task, its priority cookie ([#A] is the highest
+// level), tags, DEADLINE or CLOSED line and note
+func writeOrg(out io.Writer, tasks []models.Task) error {
+	w := bufio.NewWriter(out)
+	projects, byProject := tasksByProject(tasks)
+	for _, project := range projects {
+		level := "*"
+		if project != "" {
+			fmt.Fprintf(w, "* %s\n", project)
+			level = "**"
+		}
+		indent := strings.Repeat(" ", len(level)+1)
+		for _, task := range byProject[project] {
+			keyword := "TODO"
+			if task.Status == "done" {
+				keyword = "DONE"
+			}
+			heading := fmt.Sprintf("%s %s ", level, keyword)
+			if task.Priority > 0 && task.Priority <= priority.Max() {
+				heading += fmt.Sprintf("[#%c] ", 'A'+priority.Max()-task.Priority)
+			}
+			heading += task.Title
+			if names := exportTags(task, ":"); names != "" {
+				heading += " :" + names + ":"
+			}
+			fmt.Fprintln(w, heading)
+
+			var planning []string
+			if task.Status == "done" && task.DoneAt != nil {
+				planning = append(planning, "CLOSED: ["+task.DoneAt.Format("2006-01-02 Mon 15:04")+"]")
+			}
+			if task.Due != nil {
+				planning = append(planning, "DEADLINE: <"+orgDate(*task.Due)+">")
+			}
+			if len(planning) > 0 {
+				fmt.Fprintln(w, indent+strings.Join(planning, " "))
+			}
+			for _, line := range noteLines(task.Note) {
+				fmt.Fprintln(w, indent+line)
+			}
+		}
+	}
+	return w.Flush()
+}
+
+var (
+	orgHeadingRegex  = regexp.MustCompile(`^(\*+)\s+(.*)$`)
+	orgKeywordRegex  = regexp.MustCompile(`^(TODO|NEXT|WAITING|DONE|CANCELLED|CANCELED)\s+(.*)$`)
+	orgPriorityRegex = regexp.MustCompile(`^\[#([A-Za-z])\]\s*(.*)$`)
+	orgTagsRegex     = regexp.MustCompile(`^(.*?)\s+(:[^\s]+:)$`)
+	orgPlanningRegex = regexp.MustCompile(`(CLOSED|DEADLINE|SCHEDULED):\s*[<\[]([^>\]]+)[>\]]`)
+)
+
+// readOrg reads the TODO headings of an org-mode file as tasks. A task's
+// project is the nearest heading above it without a TODO keyword; DONE and
+// CANCELLED headings are completed tasks. DEADLINE (or else SCHEDULED) is the
+// due date, and the text under a task its note
+func readOrg(input io.Reader) ([]db.ImportedTask, error) {
+	var tasks []db.ImportedTask
+	var task *db.ImportedTask
+	var scheduled *time.Time
+	var note []string
+	var sections []string // titles of the plain headings above, by level
+
+	finish := func() {
+		if task == nil {
+			return
+		}
+		if task.Due == nil {
+			task.Due = scheduled
+		}
+		task.Note = strings.TrimSpace(strings.Join(note, "\n"))
+		tasks = append(tasks, *task)
+		task, scheduled, note = nil, nil, nil
+	}
+
+	scanner := bufio.NewScanner(input)
+	inDrawer := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := orgHeadingRegex.FindStringSubmatch(line); m != nil {
+			finish()
+			level, text := len(m[1]), strings.TrimSpace(m[2])
+			if len(sections) >= level {
+				sections = sections[:level-1]
+			}
+			k := orgKeywordRegex.FindStringSubmatch(text)
+			if k == nil {
+				for len(sections) < level-1 {
+					sections = append(sections, "")
+				}
+				title, _ := splitOrgTags(text)
+				sections = append(sections, title)
+				continue
+			}
+
+			task = &db.ImportedTask{Done: k[1] == "DONE" || strings.HasPrefix(k[1], "CANCEL")}
+			text = k[2]
+			if p := orgPriorityRegex.FindStringSubmatch(text); p != nil {
+				task.Priority = max(priority.Max()-int(strings.ToUpper(p[1])[0]-'A'), 1)
+				text = p[2]
+			}
+			task.Title, task.Tags = splitOrgTags(text)
+			for i := len(sections) - 1; i >= 0; i-- {
+				if sections[i] != "" {
+					task.Project = sections[i]
+					break
+				}
+			}
+			continue
+		}
+		if task == nil {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == ":PROPERTIES:" || trimmed == ":LOGBOOK:":
+			inDrawer = true
+			continue
+		case inDrawer:
+			inDrawer = trimmed != ":END:"
+			continue
+		}
+		if planning := orgPlanningRegex.FindAllStringSubmatch(trimmed, -1); planning != nil {
+			for _, p := range planning {
+				t, err := parseOrgDate(p[2])
+				if err != nil {
+					return nil, fmt.Errorf("task '%s': %w", task.Title, err)
+				}
+				switch p[1] {
+				case "CLOSED":
+					task.DoneAt = &t
+				case "DEADLINE":
+					task.Due = &t
+				case "SCHEDULED":
+					scheduled = &t
+				}
+			}
+			continue
+		}
+		note = append(note, trimmed)
+	}
+	finish()
+	return tasks, scanner.Err()
+}
+
+// splitOrgTags splits the trailing :tag1:tag2: off a heading
+func splitOrgTags(text string) (string, []string) {
+	m := orgTagsRegex.FindStringSubmatch(text)
+	if m == nil {
+		return strings.TrimSpace(text), nil
+	}
+	var tags []string
+	for _, tag := range strings.Split(strings.Trim(m[2], ":"), ":") {
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return strings.TrimSpace(m[1]), tags
+}
+
+// parseOrgDate parses an org timestamp body: "2026-10-18 Sun", with an optional time.
+// Repeaters and warning periods after it are ignored
+func parseOrgDate(value string) (time.Time, error) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+	day, err := time.ParseInLocation("2006-01-02", fields[0], time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date '%s'", value)
+	}
+	for _, field := range fields[1:] {
+		if clock, err := time.Parse("15:04", field); err == nil {
+			return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, time.Local), nil
+		}
+	}
+	return endOfDay(day), nil
+}
+
+// writeTaskPaper writes tasks as a TaskPaper outline: a "project:" line per
+// project with a "- task" line per task, its tags as @tag, @priority(name),
+// @due(date) and @done(date), and its note indented below
+func writeTaskPaper(out io.Writer, tasks []models.Task) error {
+	w := bufio.NewWriter(out)
+	projects, byProject := tasksByProject(tasks)
+	for _, project := range projects {
+		indent := ""
+		if project != "" {
+			fmt.Fprintf(w, "\n%s:\n", project)
+			indent = "\t"
+		}
+		for _, task := range byProject[project] {
+			line := indent + "- " + task.Title
+			for _, tag := range task.Tags {
+				line += " @" + strings.ReplaceAll(tag.Name, " ", "_")
+			}
+			if name := priority.Name(task.Priority); name != "" {
+				line += " @priority(" + name + ")"
+			}
+			if task.Due != nil {
+				line += " @due(" + taskPaperDate(*task.Due) + ")"
+			}
+			if task.Status == "done" {
+				done := "@done"
+				if task.DoneAt != nil {
+					done += "(" + task.DoneAt.Format("2006-01-02 15:04") + ")"
+				}
+				line += " " + done
+			}
+			fmt.Fprintln(w, line)
+			for _, noteLine := range noteLines(task.Note) {
+				fmt.Fprintln(w, indent+"\t"+noteLine)
+			}
+		}
+	}
+	return w.Flush()
+}
+
+// taskPaperDate writes a due date as "2026-10-18", or with the time when it has one
+func taskPaperDate(t time.Time) string {
+	if isEndOfDay(t) {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// taskPaperTagRegex matches a TaskPaper tag with an optional value: @due(2026-10-18)
+var taskPaperTagRegex = regexp.MustCompile(`(?:^|\s)@([\w-]+)(?:\(([^)]*)\))?`)
+
+// readTaskPaper reads the "- " lines of a TaskPaper file as tasks. Their project
+// is the "project:" line above them; @done marks completed tasks, @due (or
+// else @defer or @start) is the due date, @priority the priority, other tags
+// become tags, and the indented lines below a task its note
+func readTaskPaper(input io.Reader) ([]db.ImportedTask, error) {
+	var tasks []db.ImportedTask
+	var task *db.ImportedTask
+	var taskIndent int
+	var note []string
+	project, projectIndent := "", -1
+
+	finish := func() {
+		if task != nil {
+			task.Note = strings.TrimSpace(strings.Join(note, "\n"))
+			tasks = append(tasks, *task)
+		}
+		task, note = nil, nil
+	}
+
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if task != nil && indent > taskIndent && !strings.HasPrefix(trimmed, "- ") {
+			note = append(note, trimmed)
+			continue
+		}
+		if indent <= projectIndent {
+			project, projectIndent = "", -1
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "- "):
+			finish()
+			imported, err := parseTaskPaperTask(strings.TrimPrefix(trimmed, "- "))
+			if err != nil {
+				return nil, err
+			}
+			imported.Project = project
+			task, taskIndent = &imported, indent
+		case strings.HasSuffix(taskPaperTagRegex.ReplaceAllString(trimmed, ""), ":"):
+			finish()
+			project = strings.TrimSuffix(strings.TrimSpace(taskPaperTagRegex.ReplaceAllString(trimmed, "")), ":")
+			projectIndent = indent
+		default:
+			// A note line of a project, or stray text
+			finish()
+		}
+	}
+	finish()
+	return tasks, scanner.Err()
+}
+
+// parseTaskPaperTask reads the title and tags of a TaskPaper task line
+func parseTaskPaperTask(text string) (db.ImportedTask, error) {
+	task := db.ImportedTask{}
+	var deferred *time.Time
+	for _, m := range taskPaperTagRegex.FindAllStringSubmatch(text, -1) {
+		name, value := strings.ToLower(m[1]), strings.TrimSpace(m[2])
+		switch name {
+		case "done":
+			task.Done = true
+			if value != "" {
+				t, err := parseTaskPaperDate(value)
+				if err != nil {
+					return task, err
+				}
+				task.DoneAt = &t
+			}
+		case "due", "defer", "start":
+			t, err := parseTaskPaperDate(value)
+			if err != nil {
+				return task, err
+			}
+			if name == "due" {
+				task.Due = &t
+			} else {
+				deferred = &t
+			}
+		case "priority":
+			level, err := priority.Parse(value)
+			if err != nil {
+				return task, err
+			}
+			task.Priority = level
+		default:
+			task.Tags = append(task.Tags, m[1])
+		}
+	}
+	if task.Due == nil {
+		task.Due = deferred
+	}
+	task.Title = strings.TrimSpace(taskPaperTagRegex.ReplaceAllString(text, ""))
+	return task, nil
+}
+
+// parseTaskPaperDate parses "2026-10-18" or "2026-10-18 14:00"
+func parseTaskPaperDate(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02 15:04", value, time.Local); err == nil {
+		return t, nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date '%s', use yyyy-mm-dd", value)
+	}
+	return endOfDay(day), nil
+}
+
+// noteLines splits a note into lines, without the blank ones at either end
+func noteLines(note string) []string {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return nil
+	}
+	return strings.Split(note, "\n")
+}
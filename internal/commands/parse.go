@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+var parseCmd = &cobra.Command{
+	Use:   "parse <text>",
+	Short: "Show how 'wrok add' would read a task, without creating it",
+	Long: `Run the smart parsing of 'wrok add' on some text and print the fields it
+finds, without creating a task. Useful to learn the syntax, and for scripts to
+check input before adding it: the exit status is 1 when the text has errors,
+such as an unknown priority or an invalid due date.
+
+Syntax: #tag (or #tag1,tag2)  @project  +priority  APP-42  due:3days  est:4h (or ~4h)
+        https://link  note:"text"  // trailing note`,
+	Example: `  wrok parse "Fix bug #urgent @backend +high APP-42 due:3days ~2h"
+  wrok parse "Write docs due:31/12/2026" --json
+  wrok parse "Review PR https://github.com/org/repo/pull/42 // check the migration"
+  wrok parse "$line" >/dev/null && wrok add "$line"`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+
+		parsed := parser.ParseTitle(strings.Join(args, " "))
+		if jsonOutput {
+			renderParsedJSON(parsed)
+		} else {
+			renderParsed(parsed)
+		}
+		if len(parsed.Errors) > 0 {
+			exit(1)
+		}
+	},
+}
+
+// renderParsed prints the fields of a parsed task, one per line, then its errors
+func renderParsed(parsed parser.ParsedTask) {
+	orNone := func(value string) string {
+		if value == "" {
+			return "-"
+		}
+		return value
+	}
+
+	fmt.Printf("Title:    %s\n", orNone(parsed.Title))
+	fmt.Printf("Project:  %s\n", orNone(parsed.Project))
+	fmt.Printf("Tags:     %s\n", orNone(strings.Join(parsed.Tags, ", ")))
+	fmt.Printf("Priority: %s\n", orNone(parsed.Priority))
+	fmt.Printf("JIRA:     %s\n", orNone(parsed.JiraID))
+	due := "-"
+	if parsed.DueDate != nil {
+		due = parser.FormatDueDate(parsed.DueDate)
+	}
+	fmt.Printf("Due:      %s\n", due)
+	estimate := "-"
+	if parsed.Estimate > 0 {
+		estimate = parser.FormatEstimate(parsed.Estimate)
+	}
+	fmt.Printf("Estimate: %s\n", estimate)
+	fmt.Printf("URL:      %s\n", orNone(parsed.URL))
+	fmt.Printf("Note:     %s\n", strings.ReplaceAll(orNone(parsed.Note), "\n", "\n          "))
+
+	if parsed.Title == "" {
+		fmt.Println("⚠️  No title left; 'wrok add' needs one")
+	}
+	for _, err := range parsed.Errors {
+		fmt.Printf("❌ %s\n", err)
+	}
+}
+
+// renderParsedJSON prints a parsed task as JSON
+func renderParsedJSON(parsed parser.ParsedTask) {
+	type jsonParsed struct {
+		Title    string     `json:"title"`
+		Project  string     `json:"project"`
+		Tags     []string   `json:"tags"`
+		Priority string     `json:"priority"`
+		JiraID   string     `json:"jira_id"`
+		Due      *time.Time `json:"due"`
+		Estimate int        `json:"estimate_seconds"`
+		URL      string     `json:"url"`
+		Note     string     `json:"note"`
+		Valid    bool       `json:"valid"`
+		Errors   []string   `json:"errors"`
+	}
+
+	jsonBytes, err := json.MarshalIndent(jsonParsed{
+		Title:    parsed.Title,
+		Project:  parsed.Project,
+		Tags:     parsed.Tags,
+		Priority: parsed.Priority,
+		JiraID:   parsed.JiraID,
+		Due:      parsed.DueDate,
+		Estimate: int(parsed.Estimate.Seconds()),
+		URL:      parsed.URL,
+		Note:     parsed.Note,
+		Valid:    len(parsed.Errors) == 0,
+		Errors:   parsed.Errors,
+	}, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(jsonBytes))
+}
+
+func init() {
+	parseCmd.Flags().Bool("json", false, "Output as JSON")
+}
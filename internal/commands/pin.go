@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+var pinCmd = &cobra.Command{
+	Use:   "pin [task-id]",
+	Short: "Pin a task to the top of every listing",
+	Example: `  wrok pin 42`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		taskID, err := resolveTaskID(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		task, err := db.PinTask(taskID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("📌 Pinned task #%d: %s\n", task.ID, task.Title)
+	},
+}
+
+var unpinCmd = &cobra.Command{
+	Use:   "unpin [task-id]",
+	Short: "Unpin a task",
+	Example: `  wrok unpin 42`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		taskID, err := resolveTaskID(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		task, err := db.UnpinTask(taskID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("Unpinned task #%d: %s\n", task.ID, task.Title)
+	},
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/tui"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Plan the week by moving tasks onto days",
+	Long: `Open the week planner: one column of unscheduled (or overdue) open tasks
+next to a column per day of the week.
+
+Pick a task up with space, move it with ←/→ and drop it on a day with space
+or enter to make it due that day. A task that already had a due time keeps
+it; otherwise it is due at the end of the day. Dropping it on Unscheduled, or
+pressing u, clears the due date. [ and ] switch weeks.
+
+The week begins on the week_start day from the config file, and the current
+focus (see 'wrok focus') applies unless --no-focus is given.`,
+	Example: `  wrok plan
+  wrok plan --project web`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		project, _ := cmd.Flags().GetString("project")
+		noFocus, _ := cmd.Flags().GetBool("no-focus")
+
+		opts := db.TaskQueryOptions{Project: project}
+		if !noFocus {
+			applyFocus(&opts, loadFocus())
+		}
+
+		if err := tui.RunPlanTUI(opts, firstWeekday()); err != nil {
+			fmt.Printf("Error running TUI: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	planCmd.Flags().StringP("project", "p", "", "Only plan tasks of this project")
+	planCmd.Flags().Bool("no-focus", false, "Ignore the current focus context")
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+var projectCmd = &cobra.Command{
+	Use:     "project",
+	Aliases: []string{"projects"},
+	Short:   "List projects and archive finished ones",
+	Long: `List the projects in use with their number of todo, done and archived tasks.
+
+A project is archived once all of its tasks are archived, e.g. with
+'wrok project archive'. Archived projects are left out of this list, the
+project autocomplete of 'wrok add' and the filters of 'wrok tui' unless
+--all is given here.`,
+	Example: `  wrok project
+  wrok project --all
+  wrok project archive clientA --dry-run
+  wrok project archive clientA`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		all, _ := cmd.Flags().GetBool("all")
+
+		projects, err := db.GetProjects(all)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(projects) == 0 {
+			fmt.Println("No projects yet. Give a task one with 'wrok add \"title\" --project web'.")
+			return
+		}
+
+		fmt.Println("📁 Projects")
+		for _, project := range projects {
+			line := fmt.Sprintf("  %-20s %3d todo  %3d done  %3d archived", project.Name, project.Todo, project.Done, project.Archived)
+			if project.IsArchived() {
+				line += "  (archived)"
+			}
+			fmt.Println(line)
+		}
+	},
+}
+
+var projectArchiveCmd = &cobra.Command{
+	Use:   "archive <project>",
+	Short: "Archive every task of a project",
+	Long: `Archive all tasks of a project that aren't archived yet, in one go. A timer
+running on one of them is stopped. Once archived, the project no longer shows
+up in autocomplete and filters; unarchiving any of its tasks brings it back.
+
+Asks for confirmation unless --yes is given.`,
+	Example: `  wrok project archive clientA
+  wrok project archive @clientA --dry-run   # Only list the tasks it would archive
+  wrok project archive "old site" -y`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		name := strings.TrimPrefix(strings.Join(args, " "), "@")
+
+		project, tasks, err := db.GetProjectTasksToArchive(name)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(tasks) == 0 {
+			fmt.Printf("Project '%s' is already archived, nothing to do\n", project)
+			return
+		}
+
+		if dryRun {
+			fmt.Printf("Would archive %d task(s) of project '%s':\n", len(tasks), project)
+			for _, task := range tasks {
+				fmt.Printf("  #%d [%s] %s\n", task.ID, task.Status, task.Title)
+			}
+			return
+		}
+
+		if !confirm(cmd, fmt.Sprintf("Archive %d task(s) of project '%s'?", len(tasks), project)) {
+			fmt.Println("Archive cancelled.")
+			return
+		}
+
+		archived, err := db.ArchiveProject(project)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("🗃️  Archived project '%s' (%d task(s))\n", project, len(archived))
+	},
+}
+
+func init() {
+	projectCmd.Flags().Bool("all", false, "Include archived projects")
+
+	projectArchiveCmd.Flags().Bool("dry-run", false, "Show the tasks that would be archived without archiving them")
+	addYesFlag(projectArchiveCmd, "Archive without asking for confirmation")
+
+	projectCmd.AddCommand(projectArchiveCmd)
+}
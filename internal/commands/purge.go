@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Permanently remove deleted tasks and sessions",
+	Long: `Permanently remove tasks and sessions that were deleted a while ago.
+
+Deleted tasks and sessions are kept in the database until purged. Purging a task
+also removes all of its sessions and tag links.
+
+--older-than counts whole days: 0d purges everything deleted before today.
+
+Asks for confirmation unless --yes is given. Set [retention] purge_after in the
+config file to purge automatically on startup.
+
+Examples:
+  wrok purge                     # Deleted more than 90 days ago
+  wrok purge --older-than 30d
+  wrok purge --older-than 0d     # Everything deleted before today
+  wrok purge --dry-run           # Only show what would be removed
+  wrok purge --yes               # Don't ask, e.g. in scripts`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		olderThan, _ := cmd.Flags().GetString("older-than")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		cutoff, err := parser.ParseSince(olderThan)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		purgeable, err := db.CountPurgeable(cutoff)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if dryRun {
+			fmt.Printf("Would purge %d task(s) and %d session(s) deleted before %s\n",
+				purgeable.Tasks, purgeable.Sessions, cutoff.Format("02/01/2006"))
+			return
+		}
+		if purgeable.Total() == 0 {
+			fmt.Printf("Nothing deleted before %s, nothing to purge\n", cutoff.Format("02/01/2006"))
+			return
+		}
+
+		question := fmt.Sprintf("Permanently remove %d task(s) and %d session(s) deleted before %s?",
+			purgeable.Tasks, purgeable.Sessions, cutoff.Format("02/01/2006"))
+		if !confirm(cmd, question) {
+			fmt.Println("Purge cancelled.")
+			return
+		}
+
+		result, err := db.PurgeDeleted(cutoff)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("🗑️  Purged %d task(s) and %d session(s) deleted before %s\n",
+			result.Tasks, result.Sessions, cutoff.Format("02/01/2006"))
+	},
+}
+
+// applyRetention runs the config's purge policy; failures only warn so that
+// they never block the command being run
+func applyRetention(retention config.RetentionConfig) {
+	if retention.PurgeAfter == "" {
+		return
+	}
+
+	cutoff, err := parser.ParseSince(retention.PurgeAfter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring retention.purge_after: %v\n", err)
+		return
+	}
+
+	if _, err := db.RunRetention(cutoff, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: retention purge failed: %v\n", err)
+	}
+}
+
+func init() {
+	purgeCmd.Flags().String("older-than", "90d", "Only purge rows deleted longer ago than this (e.g. 30d, 12w, 6m)")
+	purgeCmd.Flags().Bool("dry-run", false, "Show what would be purged without removing anything")
+	addYesFlag(purgeCmd, "Purge without asking for confirmation")
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+// quickProject is where quick captures go when no default project is configured
+const quickProject = "inbox"
+
+var quickCmd = &cobra.Command{
+	Use:     "quick [thought]",
+	Aliases: []string{"q"},
+	Short:   "Capture a task instantly and print its ID",
+	Long: `Capture a task instantly, with no prompts and no TUI.
+
+The text is stored as the title as-is (no smart parsing), in the configured
+default project or "inbox", with no priority. Only the new task ID is printed,
+so the command can be bound to a global hotkey.
+
+Examples:
+  wrok quick "call the bank about the card"
+  wrok quick "$(pbpaste)"     # e.g. bound to a Hammerspoon or xdotool hotkey`,
+	Args: cobra.MinimumNArgs(1),
+	// A hotkey needs the exit status; main prints the error, once
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		initDB()
+		title := strings.TrimSpace(strings.Join(args, " "))
+		if title == "" {
+			return fmt.Errorf("nothing to capture")
+		}
+
+		project := defaultProject()
+		if project == "" {
+			project = quickProject
+		}
+
+		task, err := db.CreateTask(db.CreateTaskRequest{
+			Title:   title,
+			Project: project,
+		})
+		if err != nil {
+			return fmt.Errorf("error creating task: %w", err)
+		}
+
+		fmt.Println(task.ID)
+		return nil
+	},
+}
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+// maxTaskChoices caps how many candidates the disambiguation prompt lists
+const maxTaskChoices = 9
+
+// resolveTaskID turns a task argument into an ID. Numeric arguments (optionally
+// prefixed with #) are IDs and aliases (see 'wrok alias') stand for theirs;
+// anything else is fuzzy-matched against the titles of tasks with the given
+// statuses, asking which one was meant when several match
+func resolveTaskID(arg string, statuses ...string) (uint, error) {
+	if id, err := strconv.ParseUint(strings.TrimPrefix(arg, "#"), 10, 32); err == nil {
+		return uint(id), nil
+	}
+	if id, ok := resolveAlias(arg); ok {
+		return id, nil
+	}
+
+	matches, err := db.FindTasksByTitle(arg, statuses...)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case len(matches) == 0:
+		return 0, fmt.Errorf("no task matching '%s'", arg)
+	case len(matches) == 1:
+		return matches[0].ID, nil
+	case !stdinIsTerminal():
+		return 0, fmt.Errorf("'%s' matches %d tasks, use a task ID instead", arg, len(matches))
+	}
+
+	fmt.Printf("'%s' matches %d tasks:\n", arg, len(matches))
+	shown := matches
+	if len(shown) > maxTaskChoices {
+		shown = shown[:maxTaskChoices]
+	}
+	for i, task := range shown {
+		project := ""
+		if task.Project != "" {
+			project = " @" + task.Project
+		}
+		fmt.Printf("  %d) #%d %s%s\n", i+1, task.ID, task.Title, project)
+	}
+	if len(matches) > len(shown) {
+		fmt.Printf("  ... and %d more, narrow the search to see them\n", len(matches)-len(shown))
+	}
+
+	fmt.Printf("Which task? [1-%d] ", len(shown))
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	choice, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || choice < 1 || choice > len(shown) {
+		return 0, fmt.Errorf("no task selected")
+	}
+	return shown[choice-1].ID, nil
+}
+
+// maxTaskRange caps how many IDs a range like 12-18 may cover
+const maxTaskRange = 500
+
+// resolveTaskIDs turns task arguments into IDs. Arguments that are all IDs,
+// ranges ("12-18") or aliases give every ID they cover; otherwise they are one
+// title, resolved as by resolveTaskID
+func resolveTaskIDs(args []string, statuses ...string) ([]uint, error) {
+	var ids []uint
+	for _, arg := range args {
+		if id, ok := resolveAlias(arg); ok {
+			ids = append(ids, id)
+			continue
+		}
+		from, to, ok := parseTaskRange(arg)
+		if !ok {
+			id, err := resolveTaskID(strings.Join(args, " "), statuses...)
+			if err != nil {
+				return nil, err
+			}
+			return []uint{id}, nil
+		}
+		if to < from || to-from >= maxTaskRange {
+			return nil, fmt.Errorf("invalid range '%s'", arg)
+		}
+		for id := from; id <= to; id++ {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// resolveAlias returns the ID of the task with alias arg, if there is one
+func resolveAlias(arg string) (uint, bool) {
+	alias, err := db.NormalizeAlias(arg)
+	if err != nil {
+		return 0, false
+	}
+	task, err := db.GetTaskByAlias(alias)
+	if err != nil {
+		return 0, false
+	}
+	return task.ID, true
+}
+
+// parseTaskRange parses a task ID ("42", "#42") or a range of them ("12-18")
+func parseTaskRange(arg string) (from, to uint, ok bool) {
+	arg = strings.TrimPrefix(arg, "#")
+	first, last, isRange := strings.Cut(arg, "-")
+	if !isRange {
+		last = first
+	}
+	start, err := strconv.ParseUint(first, 10, 32)
+	if err != nil {
+		return 0, 0, false
+	}
+	end, err := strconv.ParseUint(strings.TrimPrefix(last, "#"), 10, 32)
+	if err != nil {
+		return 0, 0, false
+	}
+	return uint(start), uint(end), true
+}
+
+// stdinIsTerminal reports whether stdin is interactive, so it is safe to prompt
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
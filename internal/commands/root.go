@@ -1,8 +1,19 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	_ "time/tzdata" // timezone names work without a system zoneinfo database, e.g. on Windows
+
 	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/config"
 	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/hooks"
+	"github.com/balkashynov/wrok/internal/plain"
+	"github.com/balkashynov/wrok/internal/tui"
+	"github.com/balkashynov/wrok/internal/workflow"
 )
 
 var (
@@ -16,13 +27,129 @@ var rootCmd = &cobra.Command{
 	Short: "A CLI todo and time tracker",
 	Long: `wrok is a command-line tool that combines task management with time tracking.
 Track your tasks, monitor your time, and generate reports all from the terminal.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		applyTimezone()
+		applyFlagDefaults(cmd)
+		applyReadOnly(cmd)
+		db.SetAuditSource(cmd.CommandPath())
+	},
 }
 
+// configApplied guards against applying the config (and subscribing hooks) twice
+var configApplied bool
+
 // initDB initializes the database and panics on error
 func initDB() {
 	if err := db.Initialize(); err != nil {
+		if db.ReadOnly() {
+			// Nothing to open is a usage error here, not a crash
+			fmt.Printf("Error: %v\n", err)
+			exit(1)
+		}
 		panic(err) // For now, panic on DB init failure
 	}
+	for _, migration := range db.AppliedMigrations() {
+		fmt.Fprintf(os.Stderr, "🛠️  Database migrated to version %d: %s (%d rows changed)\n", migration.Version, migration.Name, migration.RowsAffected)
+	}
+	applyConfig()
+}
+
+// applyConfig applies the config file: theme, task/session hooks, the retention
+// policy, automatic backups and the check for dangling sessions
+func applyConfig() {
+	if configApplied {
+		return
+	}
+	configApplied = true
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	tui.ApplyTheme(cfg.Theme)
+	for _, problem := range workflow.Problems() {
+		fmt.Fprintf(os.Stderr, "Warning: [status] %s; ignored\n", problem)
+	}
+	if db.ReadOnly() {
+		return // Purges and automatic backups write, and hooks only follow changes
+	}
+	hooks.Register(cfg.Hooks)
+	applyRetention(cfg.Retention)
+	applyAutoBackup(cfg.Backup)
+	checkDanglingSessions(cfg)
+}
+
+// applyReadOnly makes the database read-only when --read-only or --db is given,
+// or WROK_READ_ONLY is set to anything but "0" or "false"
+func applyReadOnly(cmd *cobra.Command) {
+	enabled := false
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("WROK_READ_ONLY"))) {
+	case "", "0", "false", "no", "off":
+	default:
+		enabled = true
+	}
+	if flag := cmd.Flags().Lookup("read-only"); flag != nil && flag.Changed {
+		enabled, _ = cmd.Flags().GetBool("read-only")
+	}
+	db.SetReadOnly(enabled)
+
+	// Another database file, e.g. a backup, is only ever looked at
+	if path, _ := cmd.Flags().GetString("db"); path != "" {
+		db.SetDatabaseFile(path)
+	}
+}
+
+// applyTimezone makes the configured display timezone the local one, so times
+// are shown and days counted in it. Timestamps are stored in UTC either way
+func applyTimezone() {
+	cfg, err := config.Load()
+	if err != nil || cfg.Timezone == "" {
+		return // a broken config is reported by applyConfig
+	}
+	location, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring timezone: unknown timezone '%s'\n", cfg.Timezone)
+		return
+	}
+	time.Local = location
+}
+
+// applyFlagDefaults sets flags the user did not pass from the config's [defaults]
+// section, so configured defaults behave as if typed before the CLI flags
+func applyFlagDefaults(cmd *cobra.Command) {
+	cfg, err := config.Load()
+	if err != nil || len(cfg.Defaults) == 0 {
+		return
+	}
+
+	// Config keys use the command path below the root, e.g. "ls" or "stats.burndown"
+	path := strings.Fields(cmd.CommandPath())[1:]
+	if len(path) == 0 {
+		return
+	}
+	parent := strings.Join(path[:len(path)-1], ".")
+	names := append([]string{cmd.Name()}, cmd.Aliases...)
+
+	for _, name := range names {
+		command := name
+		if parent != "" {
+			command = parent + "." + name
+		}
+		for flagName, value := range cfg.FlagDefaults(command) {
+			flag := cmd.Flags().Lookup(flagName)
+			if flag == nil {
+				fmt.Fprintf(os.Stderr, "Warning: ignoring default %s.%s: unknown flag --%s\n", command, flagName, flagName)
+				continue
+			}
+			if flag.Changed {
+				continue
+			}
+			if err := cmd.Flags().Set(flagName, value); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: ignoring default %s.%s: %v\n", command, flagName, err)
+			}
+		}
+	}
 }
 
 // withDB wraps a command function to initialize the database first
@@ -42,23 +169,119 @@ func SetVersion(v, c, d string) {
 
 // Execute runs the root command
 func Execute() error {
-	return rootCmd.Execute()
+	applyPlain(os.Args[1:])
+	defer plain.Flush()
+
+	err := rootCmd.Execute()
+	db.Close()
+
+	// Let background hooks finish before the process exits
+	for _, hookErr := range hooks.Wait() {
+		fmt.Fprintf(os.Stderr, "Hook error: %v\n", hookErr)
+	}
+
+	if err == nil {
+		printDueBanner()
+	}
+
+	return err
+}
+
+// applyPlain turns on plain output when --plain is among args or the config
+// sets plain = true. It runs before cobra parses the flags, so help and
+// usage errors are plain too
+func applyPlain(args []string) {
+	enabled := false
+	if cfg, err := config.Load(); err == nil {
+		enabled = cfg.Plain
+	}
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		switch arg {
+		case "--plain", "--plain=true":
+			enabled = true
+		case "--plain=false":
+			enabled = false
+		}
+	}
+
+	if enabled {
+		plain.Enable()
+		plain.CaptureOutput()
+	}
+}
+
+// exit closes the database, flushes plain output and exits with code; use it instead of os.Exit
+func exit(code int) {
+	db.Close()
+	plain.Flush()
+	os.Exit(code)
 }
 
 func init() {
+	// Read by applyPlain before parsing; declared so cobra accepts and documents it
+	rootCmd.PersistentFlags().Bool("plain", false, "Plain output: no emoji, box-drawing art, logos or animation")
+	rootCmd.PersistentFlags().Bool("read-only", false, "Open the database read-only: nothing can be changed (or set WROK_READ_ONLY=1)")
+	rootCmd.PersistentFlags().String("db", "", "Look at another database file or backup (.db.gz) instead of ~/.wrok/wrok.db, read-only")
+
 	// Add subcommands here
+	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(addCmd)
+	rootCmd.AddCommand(quickCmd)
 	rootCmd.AddCommand(editCmd)
 	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(tuiCmd)
 	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(startCmd)
 	rootCmd.AddCommand(stopCmd)
+	rootCmd.AddCommand(switchCmd)
+	rootCmd.AddCommand(pauseCmd)
+	rootCmd.AddCommand(resumeCmd)
+	rootCmd.AddCommand(attachCmd)
 	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(sessionsCmd)
+	rootCmd.AddCommand(sessionCmd)
+	rootCmd.AddCommand(timesheetCmd)
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(budgetCmd)
+	rootCmd.AddCommand(goalCmd)
+	rootCmd.AddCommand(howlongCmd)
 	rootCmd.AddCommand(doneCmd)
 	rootCmd.AddCommand(undoneCmd)
+	rootCmd.AddCommand(snoozeCmd)
 	rootCmd.AddCommand(archiveCmd)
 	rootCmd.AddCommand(unarchiveCmd)
+	rootCmd.AddCommand(projectCmd)
+	rootCmd.AddCommand(pinCmd)
+	rootCmd.AddCommand(openCmd)
+	rootCmd.AddCommand(fileCmd)
+	rootCmd.AddCommand(commentCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(noteCmd)
+	rootCmd.AddCommand(unpinCmd)
+	rootCmd.AddCommand(aliasCmd)
+	rootCmd.AddCommand(focusCmd)
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(calCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(parseCmd)
+	rootCmd.AddCommand(gitCmd)
 	rootCmd.AddCommand(jiraCmd)
-	rootCmd.AddCommand(helpCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(wrapupCmd)
+	rootCmd.AddCommand(wrappedCmd)
+	rootCmd.AddCommand(timelineCmd)
+	rootCmd.AddCommand(capacityCmd)
+	rootCmd.AddCommand(breakdownCmd)
+	rootCmd.AddCommand(tagCmd)
+	rootCmd.AddCommand(digestCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(purgeCmd)
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+	rootCmd.SetHelpCommand(helpCmd)
 	rootCmd.AddCommand(versionCmd)
 }
\ No newline at end of file
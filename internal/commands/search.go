@@ -3,13 +3,14 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/balkashynov/wrok/internal/db"
 	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/priority"
+	"github.com/balkashynov/wrok/internal/query"
 )
 
 var searchCmd = &cobra.Command{
@@ -21,55 +22,133 @@ var searchCmd = &cobra.Command{
 - Suffix match
 - Fuzzy match (contains, lowest priority)
 
-Search is case insensitive and searches across title, project, tags, JIRA ID, notes, status, and priority.`,
+Search is case insensitive and searches across title, project, tags, JIRA ID, notes, status, and priority.
+The current focus (see 'wrok focus') applies unless --no-focus is given.
+
+Archived tasks are left out unless --include-archived is given or the status
+asked for is archived. --include-deleted also finds deleted tasks that
+'wrok purge' hasn't removed yet; the results say which scope they cover and
+mark archived and deleted tasks.
+
+The query can narrow the results with field operators; a leading "-" negates one:
+  project:web        in project web          tag:backend     tagged backend
+  status:todo        that status             jira:APP        JIRA ID starts with APP
+  priority:high      that priority (or none); priority>low, priority<high compare
+  due<7d             due within 7 days (or overdue); due>2w after two weeks from now
+  due:today          due that day: today, tomorrow, 3d, 31/12/2026 or 2026-12-31
+  due:none           no due date; due:any has one, due:overdue is past due
+Values with spaces are quoted: project:"client site". Put negated terms after
+"--" so they aren't read as flags.
+
+` + templateHelp + `
+Templates get .Tasks (each with its Tags), .Query and .Now.`,
+	Example: `  wrok search login
+  wrok search "release notes" --status todo
+  wrok search project:web tag:backend status:todo priority:high due<7d login
+  wrok search due:overdue -- -tag:wip
+  wrok search "rate limits" --include-archived --include-deleted`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		initDB()
-		query := args[0]
+		rawQuery := strings.Join(args, " ")
+		q, err := query.Parse(rawQuery)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			exit(1)
+		}
 		
 		// Get flags from list command (reuse the same filtering options)
 		status, _ := cmd.Flags().GetString("status")
 		project, _ := cmd.Flags().GetString("project")
 		tags, _ := cmd.Flags().GetStringSlice("tags")
-		priority, _ := cmd.Flags().GetString("priority")
+		priorityFilter, _ := cmd.Flags().GetString("priority")
 		jiraID, _ := cmd.Flags().GetString("jira")
 		orderBy, _ := cmd.Flags().GetString("order")
 		limit, _ := cmd.Flags().GetInt("limit")
 		jsonOutput, _ := cmd.Flags().GetBool("json")
+		noFocus, _ := cmd.Flags().GetBool("no-focus")
+		includeArchived, _ := cmd.Flags().GetBool("include-archived")
+		includeDeleted, _ := cmd.Flags().GetBool("include-deleted")
 
 		// Build query options
 		opts := db.TaskQueryOptions{
-			Status:   status,
-			Project:  project,
-			Tags:     tags,
-			JiraID:   jiraID,
-			Priority: priority,
-			OrderBy:  orderBy,
-			Limit:    limit,
+			Status:         status,
+			Project:        project,
+			Tags:           tags,
+			JiraID:         jiraID,
+			Priority:       priorityFilter,
+			OrderBy:        orderBy,
+			Limit:          limit,
+			IncludeDeleted: includeDeleted,
 		}
+		// Asking for a status, archived included, overrides the default scope
+		includeArchived = includeArchived || status == "archived" || hasStatusTerm(q)
+		opts.HideArchived = !includeArchived
+		scope := searchScope(includeArchived, includeDeleted)
 		
 		if orderBy == "" {
 			opts.OrderBy = "id DESC" // Default order
 		}
+		focus := db.FocusContext{}
+		if !noFocus {
+			focus = loadFocus()
+			applyFocus(&opts, focus)
+		}
 
 		// Perform search
-		tasks, err := db.SearchTasks(query, opts)
+		tasks, err := db.SearchTasks(q, opts)
 		if err != nil {
 			fmt.Printf("Error searching tasks: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Output results
-		if jsonOutput {
-			renderSearchJSON(tasks, query)
+		if path := templatePath(cmd); path != "" {
+			renderTemplate(path, taskTemplateData{Tasks: tasks, Query: rawQuery, Now: time.Now()})
+		} else if jsonOutput {
+			renderSearchJSON(tasks, rawQuery, scope)
 		} else {
-			renderSearchTable(tasks, query)
+			if focus.IsSet() {
+				fmt.Printf("🎯 Focus: %s (use --no-focus to search everything)\n\n", focus)
+			}
+			renderSearchTable(tasks, rawQuery, scope)
 		}
 	},
 }
 
+// hasStatusTerm reports whether the query picks tasks by status, which then
+// decides on archived tasks itself
+func hasStatusTerm(q query.Query) bool {
+	for _, term := range q.Terms {
+		if term.Field == query.FieldStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// searchScope names the kinds of tasks a search covers, open and done ones always
+func searchScope(archived, deleted bool) []string {
+	scope := []string{"active"}
+	if archived {
+		scope = append(scope, "archived")
+	}
+	if deleted {
+		scope = append(scope, "deleted")
+	}
+	return scope
+}
+
+// searchStatus is the status column of a result, marking deleted tasks
+func searchStatus(task models.Task) string {
+	if task.DeletedAt.Valid {
+		return task.Status + " (deleted)"
+	}
+	return task.Status
+}
+
 // renderSearchJSON outputs search results as JSON
-func renderSearchJSON(tasks []models.Task, query string) {
+func renderSearchJSON(tasks []models.Task, query string, scope []string) {
 	// Create a simplified structure for JSON output
 	type JsonTask struct {
 		ID       uint      `json:"id"`
@@ -77,7 +156,10 @@ func renderSearchJSON(tasks []models.Task, query string) {
 		Status   string    `json:"status"`
 		Project  string    `json:"project"`
 		Priority string    `json:"priority"`
+		Pinned   bool      `json:"pinned"`
+		Deleted  bool      `json:"deleted,omitempty"`
 		JiraID   string    `json:"jira_id,omitempty"`
+		JiraStatus string `json:"jira_status,omitempty"`
 		Due      *time.Time `json:"due,omitempty"`
 		Tags     []string  `json:"tags"`
 		Notes    string    `json:"notes,omitempty"`
@@ -87,6 +169,7 @@ func renderSearchJSON(tasks []models.Task, query string) {
 	
 	type SearchResult struct {
 		Query   string     `json:"query"`
+		Scope   []string   `json:"scope"`
 		Count   int        `json:"count"`
 		Tasks   []JsonTask `json:"tasks"`
 	}
@@ -100,11 +183,7 @@ func renderSearchJSON(tasks []models.Task, query string) {
 		}
 		
 		// Priority display
-		priorities := []string{"", "low", "medium", "high"}
-		priorityStr := ""
-		if task.Priority > 0 && task.Priority < len(priorities) {
-			priorityStr = priorities[task.Priority]
-		}
+		priorityStr := priority.Name(task.Priority)
 		
 		jsonTask := JsonTask{
 			ID:        task.ID,
@@ -112,7 +191,10 @@ func renderSearchJSON(tasks []models.Task, query string) {
 			Status:    task.Status,
 			Project:   task.Project,
 			Priority:  priorityStr,
+			Pinned:    task.Pinned,
+			Deleted:   task.DeletedAt.Valid,
 			JiraID:    task.JiraID,
+			JiraStatus: task.JiraStatus,
 			Due:       task.Due,
 			Tags:      tagNames,
 			Notes:     task.Note,
@@ -124,6 +206,7 @@ func renderSearchJSON(tasks []models.Task, query string) {
 	
 	result := SearchResult{
 		Query: query,
+		Scope: scope,
 		Count: len(tasks),
 		Tasks: jsonTasks,
 	}
@@ -138,8 +221,8 @@ func renderSearchJSON(tasks []models.Task, query string) {
 }
 
 // renderSearchTable outputs search results as a formatted table
-func renderSearchTable(tasks []models.Task, query string) {
-	fmt.Printf("Search results for '%s' (%d found):\n", query, len(tasks))
+func renderSearchTable(tasks []models.Task, query string, scope []string) {
+	fmt.Printf("Search results for '%s' (%d found in %s tasks):\n", query, len(tasks), strings.Join(scope, ", "))
 	if len(tasks) == 0 {
 		fmt.Println("No tasks found matching your search.")
 		return
@@ -162,15 +245,19 @@ func renderSearchTable(tasks []models.Task, query string) {
 		tagsStr := strings.Join(tagNames, ",")
 		
 		// Priority display
-		priorities := []string{"", "low", "med", "high"}
-		priorityStr := ""
-		if task.Priority > 0 && task.Priority < len(priorities) {
-			priorityStr = priorities[task.Priority]
-		}
+		priorityStr := priority.Short(task.Priority)
 		
 		// Truncate long fields
 		title := task.Title
-		if len(title) > 33 {
+		titleWidth := 35
+		if task.Pinned {
+			// Leave room for the marker; fmt pads by runes and the pin is double-width
+			if len(title) > 30 {
+				title = title[:27] + "..."
+			}
+			title = "📌 " + title
+			titleWidth--
+		} else if len(title) > 33 {
 			title = title[:30] + "..."
 		}
 		
@@ -183,24 +270,28 @@ func renderSearchTable(tasks []models.Task, query string) {
 			tagsStr = tagsStr[:7] + "..."
 		}
 		
-		fmt.Printf("%-4d %-35s %-12s %-8s %-12s %s\n", 
+		fmt.Printf("%-4d %-*s %-12s %-8s %-12s %s\n", 
 			task.ID, 
-			title, 
+			titleWidth, title, 
 			project, 
 			priorityStr, 
 			tagsStr,
-			task.Status)
+			searchStatus(task))
 	}
 }
 
 func init() {
 	// Add the same flags as list command
-	searchCmd.Flags().StringP("status", "s", "", "Filter by status (todo/done/archived)")
+	searchCmd.Flags().StringP("status", "s", "", "Filter by status (todo/done/archived, a configured one, or open)")
 	searchCmd.Flags().StringP("project", "p", "", "Filter by project")
 	searchCmd.Flags().StringSliceP("tags", "t", []string{}, "Filter by tags")
-	searchCmd.Flags().StringP("priority", "", "", "Filter by priority (low/medium/high)")
+	searchCmd.Flags().StringP("priority", "", "", "Filter by priority (a level name or number)")
 	searchCmd.Flags().StringP("jira", "j", "", "Filter by JIRA ID")
 	searchCmd.Flags().StringP("order", "o", "", "Order by (e.g., 'id DESC', 'created_at ASC')")
 	searchCmd.Flags().IntP("limit", "l", 0, "Limit number of results")
 	searchCmd.Flags().Bool("json", false, "Output as JSON")
+	searchCmd.Flags().Bool("no-focus", false, "Ignore the current focus context")
+	searchCmd.Flags().Bool("include-archived", false, "Also search archived tasks")
+	searchCmd.Flags().Bool("include-deleted", false, "Also search deleted tasks not purged yet")
+	addTemplateFlag(searchCmd)
 }
\ No newline at end of file
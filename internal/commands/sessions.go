@@ -0,0 +1,246 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/tui"
+)
+
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Browse tracked time sessions",
+	Long: `List time tracking sessions, newest first.
+
+Opens an interactive browser by default where sessions can be edited,
+split, merged or deleted. Use --no-ui or --json for plain output.
+
+Sessions locked with 'wrok lock' are marked 🔒 and can only be changed in a
+browser opened with --force.
+
+Examples:
+  wrok sessions                # All sessions
+  wrok sessions --task 42      # Sessions of task #42
+  wrok sessions --today        # Today's sessions
+  wrok sessions --week --json  # This week's sessions as JSON
+  wrok sessions --unlocked     # Sessions not reported yet`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		taskID, _ := cmd.Flags().GetUint("task")
+		today, _ := cmd.Flags().GetBool("today")
+		week, _ := cmd.Flags().GetBool("week")
+		noUI, _ := cmd.Flags().GetBool("no-ui")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		force, _ := cmd.Flags().GetBool("force")
+
+		locked, err := lockedFromFlags(cmd)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		opts := db.SessionQueryOptions{TaskID: taskID, Locked: locked}
+		now := time.Now()
+		switch {
+		case today:
+			from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+			opts.From = &from
+		case week:
+			from := getWeekStart(now)
+			opts.From = &from
+		}
+
+		sessions, err := db.GetSessions(opts)
+		if err != nil {
+			fmt.Printf("Error fetching sessions: %v\n", err)
+			return
+		}
+
+		switch {
+		case jsonOutput:
+			renderSessionsJSON(sessions)
+		case len(sessions) == 0:
+			fmt.Println("No sessions found.")
+		case noUI:
+			renderSessionsTable(sessions)
+		default:
+			if err := tui.RunSessionsTUI(sessions, opts, force); err != nil {
+				fmt.Printf("Error running TUI: %v\n", err)
+			}
+		}
+	},
+}
+
+var sessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "Change a single time tracking session",
+	Long: `Change a single time tracking session.
+
+Subcommands:
+  split    Split a session in two, optionally moving part to another task`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var sessionSplitCmd = &cobra.Command{
+	Use:   "split [session-id]",
+	Short: "Split a session in two",
+	Long: `Split a finished session in two at the given time.
+
+Use --move-to to reassign the part after the split to another task, e.g.
+when the timer kept running on the wrong task. A locked session is only
+split with --force.
+
+Examples:
+  wrok session split 12 --at 14:30                # Two sessions on the same task
+  wrok session split 12 --at 14:30 --move-to 42   # Time after 14:30 goes to task #42`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		sessionID, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			fmt.Printf("Error: invalid session ID '%s'\n", args[0])
+			return
+		}
+		atValue, _ := cmd.Flags().GetString("at")
+		moveTo, _ := cmd.Flags().GetUint("move-to")
+		force, _ := cmd.Flags().GetBool("force")
+
+		if atValue == "" {
+			fmt.Println("Error: --at is required (HH:MM or dd/mm/yyyy HH:MM)")
+			return
+		}
+
+		session, err := db.GetSessionByID(uint(sessionID))
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		at, err := parser.ParseClockTime(atValue, session.StartedAt)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		first, second, err := db.SplitSession(session.ID, at, moveTo, force)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("✂️  Split session #%d at %s\n", first.ID, at.Format("15:04"))
+		fmt.Printf("  #%d %s–%s %-6s task #%d: %s\n", first.ID,
+			first.StartedAt.Format("15:04"), first.FinishedAt.Format("15:04"),
+			formatDuration(sessionDuration(*first)), first.TaskID, first.Task.Title)
+		fmt.Printf("  #%d %s–%s %-6s task #%d: %s\n", second.ID,
+			second.StartedAt.Format("15:04"), second.FinishedAt.Format("15:04"),
+			formatDuration(sessionDuration(*second)), second.TaskID, second.Task.Title)
+	},
+}
+
+// sessionDuration returns the tracked time of a session, counting running sessions up to now
+func sessionDuration(session models.Session) time.Duration {
+	return session.Elapsed(time.Now())
+}
+
+// renderSessionsJSON outputs sessions as JSON
+func renderSessionsJSON(sessions []models.Session) {
+	type JsonSession struct {
+		ID              uint       `json:"id"`
+		TaskID          uint       `json:"task_id"`
+		TaskTitle       string     `json:"task_title"`
+		StartedAt       time.Time  `json:"started_at"`
+		FinishedAt      *time.Time `json:"finished_at"`
+		DurationSeconds int        `json:"duration_seconds"`
+		Note            string     `json:"note,omitempty"`
+		LockedAt        *time.Time `json:"locked_at,omitempty"`
+	}
+
+	jsonSessions := []JsonSession{}
+	for _, session := range sessions {
+		jsonSessions = append(jsonSessions, JsonSession{
+			ID:              session.ID,
+			TaskID:          session.TaskID,
+			TaskTitle:       session.Task.Title,
+			StartedAt:       session.StartedAt,
+			FinishedAt:      session.FinishedAt,
+			DurationSeconds: int(sessionDuration(session).Seconds()),
+			Note:            session.Note,
+			LockedAt:        session.LockedAt,
+		})
+	}
+
+	jsonBytes, err := json.MarshalIndent(jsonSessions, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling JSON: %v\n", err)
+		return
+	}
+
+	fmt.Println(string(jsonBytes))
+}
+
+// renderSessionsTable outputs sessions as a table for 80-column terminals
+func renderSessionsTable(sessions []models.Session) {
+	fmt.Printf("%-5s %-10s %-5s %-7s %-7s %s\n", "ID", "DATE", "START", "END", "TIME", "TASK / NOTE")
+	fmt.Println(strings.Repeat("-", 80))
+
+	var total time.Duration
+	for _, session := range sessions {
+		duration := sessionDuration(session)
+		total += duration
+
+		end := "running"
+		if session.FinishedAt != nil {
+			end = session.FinishedAt.Format("15:04")
+		}
+
+		task := fmt.Sprintf("#%d %s", session.TaskID, session.Task.Title)
+		if session.Locked() {
+			task = "🔒 " + task
+		}
+		if session.Note != "" {
+			task += " — " + session.Note
+		}
+		if len([]rune(task)) > 40 {
+			task = string([]rune(task)[:37]) + "..."
+		}
+
+		fmt.Printf("%-5d %-10s %-5s %-7s %-7s %s\n",
+			session.ID,
+			session.StartedAt.Format("Mon 02/01"),
+			session.StartedAt.Format("15:04"),
+			end,
+			formatDuration(duration),
+			task)
+	}
+
+	fmt.Println(strings.Repeat("-", 80))
+	fmt.Printf("%d session(s), %s total\n", len(sessions), formatDuration(total))
+}
+
+func init() {
+	sessionSplitCmd.Flags().String("at", "", "Split time: HH:MM (on the session's day) or dd/mm/yyyy HH:MM")
+	sessionSplitCmd.Flags().Uint("move-to", 0, "Assign the time after the split to this task")
+	sessionSplitCmd.Flags().Bool("force", false, "Split the session even if it is locked")
+	sessionCmd.AddCommand(sessionSplitCmd)
+
+	sessionsCmd.Flags().Uint("task", 0, "Only sessions of this task")
+	sessionsCmd.Flags().Bool("today", false, "Only today's sessions")
+	sessionsCmd.Flags().Bool("week", false, "Only this week's sessions")
+	sessionsCmd.Flags().Bool("no-ui", false, "Disable interactive TUI, output plain table")
+	sessionsCmd.Flags().Bool("json", false, "Output as JSON")
+	sessionsCmd.Flags().Bool("locked", false, "Only locked sessions")
+	sessionsCmd.Flags().Bool("unlocked", false, "Only sessions that aren't locked")
+	sessionsCmd.Flags().Bool("force", false, "Allow changing locked sessions in the browser")
+}
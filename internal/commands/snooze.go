@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze [task-id | title] [duration | date]",
+	Short: "Push a task's due date out",
+	Long: `Move a task's due date, e.g. when it came up overdue.
+
+The new due date is read like due: in 'wrok add': an amount counted from now
+(2d, 3 days, 1w, 24h), a business day count, a weekday or a date (dd/mm/yyyy).
+
+With --hide the task also drops out of 'wrok ls' and the TUI until the day it
+is due ('wrok ls --snoozed' still lists it). Snoozing again without --hide
+shows it again.
+
+Examples:
+  wrok snooze 42 2d           # Due in two days
+  wrok snooze 42 friday
+  wrok snooze 42 next business day
+  wrok snooze 42 1w --hide    # Out of sight until next week
+  wrok snooze 42 31/12/2026`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		hide, _ := cmd.Flags().GetBool("hide")
+
+		taskID, err := resolveTaskID(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		due, err := parser.ParseDueDate(strings.Join(args[1:], " "))
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		// Hidden until the start of the day it is due, so it is back in the list that morning
+		var hiddenUntil *time.Time
+		if hide {
+			day := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, due.Location())
+			hiddenUntil = &day
+		}
+
+		task, err := db.SnoozeTask(taskID, due, hiddenUntil)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("😴 Snoozed task #%d: %s\n", task.ID, task.Title)
+		fmt.Printf("   %s\n", parser.FormatDueDate(task.Due))
+		if task.HiddenUntil != nil {
+			fmt.Printf("   Hidden from the list until %s\n", task.HiddenUntil.Format("Mon 02/01/2006"))
+		}
+	},
+}
+
+func init() {
+	snoozeCmd.Flags().Bool("hide", false, "Hide the task from 'wrok ls' and the TUI until the day it is due")
+}
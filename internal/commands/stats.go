@@ -0,0 +1,289 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/workflow"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show statistics about your tasks",
+	Example: `  wrok stats
+  wrok stats burndown --since 3m`,
+	Long: `Show statistics about your tasks.
+
+Without a subcommand, shows the progress of your time goals (see 'wrok goal').
+
+Subcommands:
+  burndown    Open and completed tasks over time`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		statuses, err := db.GetGoalStatuses(time.Now())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(statuses) == 0 {
+			cmd.Help()
+			return
+		}
+
+		fmt.Println("🎯 Goals")
+		for _, status := range statuses {
+			renderGoalStatus(status)
+		}
+		fmt.Println("\nMore: wrok stats burndown, wrok goal history")
+	},
+}
+
+var burndownCmd = &cobra.Command{
+	Use:   "burndown",
+	Short: "Chart open and completed tasks over time",
+	Long: `Chart the number of open tasks and the tasks completed over time,
+to see whether a backlog is shrinking.
+
+Periods up to a month are shown per day, longer ones per week (starting on
+the week_start day from the config file).
+The current focus (see 'wrok focus') applies unless --no-focus is given.
+
+Examples:
+  wrok stats burndown --project website              # Last 4 weeks
+  wrok stats burndown --project website --since 3m   # Last 3 months
+  wrok stats burndown --since 30d                    # All projects`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		project, _ := cmd.Flags().GetString("project")
+		since, _ := cmd.Flags().GetString("since")
+		noFocus, _ := cmd.Flags().GetBool("no-focus")
+
+		start, err := parser.ParseSince(since)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		opts := db.TaskQueryOptions{Project: project}
+		focus := db.FocusContext{}
+		if !noFocus {
+			focus = loadFocus()
+			applyFocus(&opts, focus)
+		}
+
+		tasks, err := db.GetTasksWithOptions(opts)
+		if err != nil {
+			fmt.Printf("Error fetching tasks: %v\n", err)
+			return
+		}
+
+		points := computeBurndown(tasks, start, time.Now(), firstWeekday())
+
+		title := "all projects"
+		if opts.Project != "" {
+			title = "project " + opts.Project
+		}
+		if focus.Tag != "" {
+			title += " #" + focus.Tag
+		}
+		fmt.Printf("📉 Burndown for %s since %s\n\n", title, start.Format("02/01/2006"))
+
+		renderBurndown(points)
+	},
+}
+
+// burndownPoint holds the task counts for one day or week of a burndown chart
+type burndownPoint struct {
+	start     time.Time
+	end       time.Time
+	open      int // tasks open at the end of the period
+	completed int // tasks completed during the period
+	created   int // tasks created during the period
+}
+
+// computeBurndown buckets tasks into days (up to a month) or weeks between start and now;
+// weekly buckets begin on firstDay
+func computeBurndown(tasks []models.Task, start, now time.Time, firstDay time.Weekday) []burndownPoint {
+	step := 1
+	if now.Sub(start) > 31*24*time.Hour {
+		step = 7
+		start = parser.StartOfWeek(start, firstDay)
+	}
+
+	var points []burndownPoint
+	for bucketStart := start; bucketStart.Before(now); bucketStart = bucketStart.AddDate(0, 0, step) {
+		bucketEnd := bucketStart.AddDate(0, 0, step)
+		if bucketEnd.After(now) {
+			bucketEnd = now
+		}
+
+		point := burndownPoint{start: bucketStart, end: bucketEnd}
+		for _, task := range tasks {
+			closed := taskClosedAt(task)
+
+			if !task.CreatedAt.After(bucketEnd) && (closed == nil || closed.After(bucketEnd)) {
+				point.open++
+			}
+			if !task.CreatedAt.Before(bucketStart) && task.CreatedAt.Before(bucketEnd) {
+				point.created++
+			}
+			if task.DoneAt != nil && !task.DoneAt.Before(bucketStart) && task.DoneAt.Before(bucketEnd) {
+				point.completed++
+			}
+		}
+		points = append(points, point)
+	}
+
+	return points
+}
+
+// taskClosedAt returns when a task stopped being open, or nil if it still is
+func taskClosedAt(task models.Task) *time.Time {
+	if task.DoneAt != nil {
+		return task.DoneAt
+	}
+	if task.ArchivedAt != nil {
+		return task.ArchivedAt
+	}
+	if !workflow.IsOpen(task.Status) {
+		// Closed before timestamps were recorded
+		return &task.UpdatedAt
+	}
+	return nil
+}
+
+// Block characters used for partial bar heights and sparklines
+var chartBlocks = []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// renderBurndown prints a bar chart of open tasks with a completed sparkline and summary
+func renderBurndown(points []burndownPoint) {
+	if len(points) == 0 {
+		fmt.Println("Nothing to show for this period.")
+		return
+	}
+
+	const chartHeight = 10
+
+	maxOpen, maxCompleted, totalCompleted, totalCreated := 0, 0, 0, 0
+	for _, p := range points {
+		if p.open > maxOpen {
+			maxOpen = p.open
+		}
+		if p.completed > maxCompleted {
+			maxCompleted = p.completed
+		}
+		totalCompleted += p.completed
+		totalCreated += p.created
+	}
+
+	// Wide columns while they fit in 80 characters
+	colWidth := 2
+	if len(points) > 36 {
+		colWidth = 1
+	}
+	labelWidth := len(fmt.Sprintf("%d", maxOpen))
+	if labelWidth < 4 {
+		labelWidth = 4
+	}
+
+	fmt.Println("Open tasks")
+	for row := chartHeight; row >= 1; row-- {
+		label := ""
+		switch row {
+		case chartHeight:
+			label = fmt.Sprintf("%d", maxOpen)
+		case chartHeight / 2:
+			label = fmt.Sprintf("%d", maxOpen/2)
+		}
+
+		var line strings.Builder
+		for _, p := range points {
+			line.WriteRune(barBlock(p.open, maxOpen, chartHeight, row))
+			line.WriteString(strings.Repeat(" ", colWidth-1))
+		}
+		fmt.Printf("%*s ┤%s\n", labelWidth, label, strings.TrimRight(line.String(), " "))
+	}
+	fmt.Printf("%*s └%s\n", labelWidth, "0", strings.Repeat("─", len(points)*colWidth))
+
+	// Date labels under the first and last column
+	first := points[0].start.Format("02/01")
+	last := points[len(points)-1].start.Format("02/01")
+	gap := len(points)*colWidth - len(first) - len(last)
+	if gap < 1 {
+		gap = 1
+	}
+	fmt.Printf("%*s  %s%s%s\n\n", labelWidth, "", first, strings.Repeat(" ", gap), last)
+
+	// Completed tasks per period as a sparkline
+	var spark strings.Builder
+	for _, p := range points {
+		spark.WriteRune(barBlock(p.completed, maxCompleted, 1, 1))
+		spark.WriteString(strings.Repeat(" ", colWidth-1))
+	}
+	period := "day"
+	if points[0].end.Sub(points[0].start) > 24*time.Hour {
+		period = "week"
+	}
+	fmt.Printf("Completed per %s (max %d)\n", period, maxCompleted)
+	fmt.Printf("%*s  %s\n\n", labelWidth, "", strings.TrimRight(spark.String(), " "))
+
+	// Summary
+	startOpen := points[0].open - points[0].created + points[0].completed
+	endOpen := points[len(points)-1].open
+	days := points[len(points)-1].end.Sub(points[0].start).Hours() / 24
+	perWeek := 0.0
+	if days > 0 {
+		perWeek = float64(totalCompleted) / days * 7
+	}
+
+	fmt.Printf("Open:      %d → %d (%+d)\n", startOpen, endOpen, endOpen-startOpen)
+	fmt.Printf("Created:   %d\n", totalCreated)
+	fmt.Printf("Completed: %d (%.1f/week)\n", totalCompleted, perWeek)
+
+	switch {
+	case endOpen < startOpen:
+		fmt.Println("📉 Backlog is shrinking")
+	case endOpen > startOpen:
+		fmt.Println("📈 Backlog is growing")
+	default:
+		fmt.Println("➡️  Backlog is flat")
+	}
+}
+
+// barBlock returns the character for one row of a vertical bar scaled to max over height rows
+func barBlock(value, max, height, row int) rune {
+	if max == 0 || value == 0 {
+		return chartBlocks[0]
+	}
+
+	// Bar height in eighths of a row
+	eighths := value * height * 8 / max
+	if eighths == 0 {
+		eighths = 1
+	}
+
+	filled := eighths - (row-1)*8
+	switch {
+	case filled <= 0:
+		return chartBlocks[0]
+	case filled >= 8:
+		return chartBlocks[8]
+	default:
+		return chartBlocks[filled]
+	}
+}
+
+func init() {
+	burndownCmd.Flags().StringP("project", "p", "", "Project to chart")
+	burndownCmd.Flags().String("since", "4w", "Period to chart: e.g. 30d, 4w, 3m, 1y")
+	burndownCmd.Flags().Bool("no-focus", false, "Ignore the current focus context")
+
+	statsCmd.AddCommand(burndownCmd)
+}
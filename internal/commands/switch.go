@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/tui"
+	"github.com/balkashynov/wrok/internal/workflow"
+)
+
+var switchCmd = &cobra.Command{
+	Use:   "switch [task-id | title]",
+	Short: "Stop whatever is running and start tracking another task",
+	Long: `Stop the running session and start one on another task in a single step, so
+no time falls between them. The stopped session is summarised as by 'wrok stop'.
+With nothing running, switch simply starts the task.
+
+Unlike 'wrok start', switch doesn't ask before stopping the running session and
+doesn't open the timer unless --timer is given. max_focus only holds it back
+when nothing was running; --force starts anyway.`,
+	Example: `  wrok switch 42
+  wrok switch "code review"
+  wrok switch 42 --timer   # Open the timer on the new session`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		taskID, err := resolveTaskID(args[0], workflow.Open()...)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		timer, _ := cmd.Flags().GetBool("timer")
+		if force, _ := cmd.Flags().GetBool("force"); !force && !waitForBreak(taskID, !timer) {
+			return
+		}
+
+		stopped, session, err := db.SwitchSession(taskID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if stopped != nil {
+			reportStop(stopped)
+			fmt.Println()
+		}
+
+		if timer {
+			if err := tui.RunTimerTUI(session); err != nil {
+				fmt.Printf("Error: %v\n", err)
+			}
+			return
+		}
+		if stopped != nil {
+			fmt.Printf("🔀 Switched from #%d to #%d: %s\n", stopped.TaskID, session.TaskID, session.Task.Title)
+		} else {
+			fmt.Printf("⏱️  Started tracking time for task #%d: %s\n", session.TaskID, session.Task.Title)
+		}
+		fmt.Printf("Started at: %s\n", session.StartedAt.Format("15:04:05"))
+	},
+}
+
+func init() {
+	switchCmd.Flags().Bool("timer", false, "Open the timer on the new session")
+	switchCmd.Flags().Bool("force", false, "Start even when max_focus calls for a break")
+}
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/workflow"
+)
+
+var tagCmd = &cobra.Command{
+	Use:   "tag [task-id... | range | title]",
+	Short: "Add or remove tags on tasks, keeping the others",
+	Long: `Add or remove tags on one or more tasks without touching their other tags,
+which makes tag changes safe to script. Names match in any case, and adding a
+tag a task already has does nothing.
+
+Several IDs or a range like 12-18 change tasks in bulk, after asking for
+confirmation unless --yes is given. To replace a task's tags altogether, use
+'wrok edit <id> --tags a,b'.`,
+	Example: `  wrok tag 42 --add-tag review
+  wrok tag 12-18 21 --add-tag q3 --remove-tag wip --yes
+  wrok tag "login" --remove-tag blocked`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		add, _ := cmd.Flags().GetStringSlice("add-tag")
+		remove, _ := cmd.Flags().GetStringSlice("remove-tag")
+		if len(add) == 0 && len(remove) == 0 {
+			fmt.Println("Error: give tags to change with --add-tag or --remove-tag")
+			return
+		}
+
+		statuses := append(workflow.Open(), workflow.Done)
+		taskIDs, err := resolveTaskIDs(args, statuses...)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(taskIDs) > 1 {
+			tasks := confirmBulk(cmd, taskIDs, statuses, "Change the tags of %d task(s)?")
+			taskIDs = taskIDs[:0]
+			for _, task := range tasks {
+				taskIDs = append(taskIDs, task.ID)
+			}
+		}
+
+		for _, id := range taskIDs {
+			task, err := tagTask(id, add, remove)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+			fmt.Printf("🏷️  #%d %s: %s\n", task.ID, task.Title, formatTagList(task.Tags))
+		}
+	},
+}
+
+// tagTask removes and then adds tags on a task, leaving its other tags alone
+func tagTask(id uint, add, remove []string) (*models.Task, error) {
+	var task *models.Task
+	var err error
+	if len(remove) > 0 {
+		if task, err = db.RemoveTaskTags(id, remove); err != nil {
+			return nil, err
+		}
+	}
+	if len(add) > 0 {
+		if task, err = db.AddTaskTags(id, add); err != nil {
+			return nil, err
+		}
+	}
+	return task, nil
+}
+
+// formatTagList lists tags as "#a #b", or "no tags"
+func formatTagList(tags []models.Tag) string {
+	if len(tags) == 0 {
+		return "no tags"
+	}
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = "#" + tag.Name
+	}
+	return strings.Join(names, " ")
+}
+
+func init() {
+	tagCmd.Flags().StringSlice("add-tag", []string{}, "Add tags (comma-separated or repeated)")
+	tagCmd.Flags().StringSlice("remove-tag", []string{}, "Remove tags (comma-separated or repeated)")
+	addYesFlag(tagCmd, "Change several tasks without asking")
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/templates"
+)
+
+// templateHelp explains --template in the help of the commands that have it
+const templateHelp = `--template renders the output with a Go text/template file instead. Besides
+the fields below, templates can use hours, seconds, date, format, priority,
+tags, join, upper, lower, pad, truncate and json, e.g.
+  {{range .Tasks}}* TODO {{.Title}} {{if .Due}}DEADLINE: <{{format "2006-01-02" .Due}}>{{end}}
+  {{end}}`
+
+// taskTemplateData is what ls and search templates get
+type taskTemplateData struct {
+	Tasks []models.Task // with their tags
+	Query string        // the search query; empty for ls
+	Now   time.Time
+}
+
+// statusTemplateData is what status templates get
+type statusTemplateData struct {
+	Session *models.Session // the running session with its task; nil when idle
+	Elapsed time.Duration   // time worked in it, without breaks
+	Now     time.Time
+}
+
+// reportTemplateData is what jira timesheet templates get
+type reportTemplateData struct {
+	Start    time.Time        // first day of the week
+	End      time.Time        // first day of the next week
+	Sessions []models.Session // finished sessions of the week with their tasks, oldest first
+	Total    time.Duration
+	Now      time.Time
+}
+
+// addTemplateFlag adds --template to a command
+func addTemplateFlag(cmd *cobra.Command) {
+	cmd.Flags().String("template", "", "Render the output with this Go template file")
+}
+
+// templatePath returns the --template file, "" when not given
+func templatePath(cmd *cobra.Command) string {
+	path, _ := cmd.Flags().GetString("template")
+	return path
+}
+
+// renderTemplate writes the --template file filled with data to stdout,
+// exiting with an error when the template fails
+func renderTemplate(path string, data any) {
+	if err := templates.Execute(os.Stdout, path, data); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		exit(1)
+	}
+}
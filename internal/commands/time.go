@@ -2,39 +2,79 @@ package commands
 
 import (
 	"fmt"
-	"strconv"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/clipboard"
 	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
 	"github.com/balkashynov/wrok/internal/tui"
+	"github.com/balkashynov/wrok/internal/workflow"
 )
 
 var startCmd = &cobra.Command{
-	Use:   "start [task-id]",
+	Use:   "start [task-id | title]",
 	Short: "Start tracking time on a task",
 	Long: `Start tracking time on a task. Opens interactive timer by default, use --no-ui for simple start.
+The task can also be given by part of its title.
+
+With max_focus in the config (e.g. max_focus = "3h"), a session can't start
+once that much time has been worked without a break of min_break (15m unless
+set); gaps between sessions and pauses count as breaks. The timer shows the
+break counting down first, and --no-ui refuses. --force starts anyway.
+
+While another task is being tracked, start asks whether to stop it first.
+--switch stops it without asking, in the same step as starting the new one.
 
 Examples:
   wrok start 42        # Start timer with interactive UI
-  wrok start 42 --no-ui # Start timer without UI`,
+  wrok start 42 --no-ui # Start timer without UI
+  wrok start "login"   # Start the open task whose title matches "login"
+  wrok start 42 --force # Start even when max_focus calls for a break
+  wrok start 42 --switch # Stop the running session and start this one`,
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		initDB()
-		taskID, err := strconv.ParseUint(args[0], 10, 32)
+		taskID, err := resolveTaskID(args[0], workflow.Open()...)
 		if err != nil {
-			fmt.Printf("Error: invalid task ID '%s'\n", args[0])
+			fmt.Printf("Error: %v\n", err)
 			return
 		}
 
-		session, err := db.StartSession(uint(taskID))
+		// Check if --no-ui flag is set
+		noUI, _ := cmd.Flags().GetBool("no-ui")
+		switching, _ := cmd.Flags().GetBool("switch")
+		active, err := db.GetActiveSession()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if active != nil && active.TaskID != taskID && !switching {
+			if !confirmSwitch(cmd, active, taskID) {
+				return
+			}
+			switching = true
+		}
+		if force, _ := cmd.Flags().GetBool("force"); !force && !waitForBreak(taskID, noUI) {
+			return
+		}
+
+		var session *models.Session
+		if switching {
+			var stopped *models.Session
+			stopped, session, err = db.SwitchSession(taskID)
+			if stopped != nil {
+				reportStop(stopped)
+				fmt.Println()
+			}
+		} else {
+			session, err = db.StartSession(taskID)
+		}
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 			return
 		}
 
-		// Check if --no-ui flag is set
-		noUI, _ := cmd.Flags().GetBool("no-ui")
 		if noUI {
 			// Simple non-interactive start
 			fmt.Printf("⏱️  Started tracking time for task #%d: %s\n", session.TaskID, session.Task.Title)
@@ -51,23 +91,141 @@ Examples:
 var stopCmd = &cobra.Command{
 	Use:   "stop",
 	Short: "Stop tracking time",
+	Long: `Stop tracking time and summarise the session: its duration, the task's time
+today and in total, the project's time this week, and what is left of the
+project's budget and your goals.
+
+Examples:
+  wrok stop
+  wrok stop --copy    # Also copy the summary to the clipboard`,
 	Run: func(cmd *cobra.Command, args []string) {
 		initDB()
+		copySummary, _ := cmd.Flags().GetBool("copy")
+
 		session, err := db.StopActiveSession()
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 			return
 		}
 
+		summary := reportStop(session)
+		if copySummary && summary != "" {
+			if err := clipboard.Copy(summary); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fmt.Println("📋 Summary copied to the clipboard")
+		}
+	},
+}
+
+// reportStop prints the summary of a session that just stopped, with the
+// budget and daily limit warnings, and returns the summary; "" when it
+// couldn't be put together and only the duration was shown
+func reportStop(session *models.Session) string {
+	text := ""
+	summary, err := db.GetStopSummary(*session, time.Now())
+	if err != nil {
+		// The session is stopped either way; fall back to its duration
 		duration := time.Duration(session.DurationSeconds) * time.Second
 		fmt.Printf("⏹️  Stopped tracking time for task #%d: %s\n", session.TaskID, session.Task.Title)
 		fmt.Printf("Session duration: %s\n", formatDuration(duration))
+	} else {
+		text = summary.String()
+		fmt.Println(text)
+	}
+	printBudgetWarning(session.Task.Project)
+	printDailyLimitWarning()
+	return text
+}
+
+var pauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Pause the running timer for a break",
+	Long: `Pause the running session without stopping it. The break doesn't count
+towards the session's time; 'wrok resume' carries on where you left off.
+Stopping a paused session ends it where the break began.
+
+Examples:
+  wrok pause
+  wrok resume`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		session, err := db.PauseActiveSession()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("⏸️  Paused task #%d: %s\n", session.TaskID, session.Task.Title)
+		fmt.Printf("Tracked so far: %s\n", formatDuration(session.Elapsed(time.Now())))
+	},
+}
+
+var resumeCmd = &cobra.Command{
+	Use:     "resume",
+	Short:   "Resume the paused timer",
+	Example: `  wrok resume`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		session, err := db.ResumeActiveSession()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("▶️  Resumed task #%d: %s\n", session.TaskID, session.Task.Title)
+		fmt.Printf("Tracked so far: %s, breaks %s\n", formatDuration(session.Elapsed(time.Now())),
+			formatDuration(time.Duration(session.PausedSeconds)*time.Second))
+	},
+}
+
+var attachCmd = &cobra.Command{
+	Use:   "attach",
+	Short: "Reopen the timer for the running session",
+	Long: `Reopen the interactive timer for the session that is currently running,
+e.g. after leaving it with esc. Leaving again keeps the timer running.
+
+To attach a file to a task, use 'wrok file add <task> <path>'.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			return fmt.Errorf("attach takes no arguments; to attach a file to a task use 'wrok file add <task> <path>'")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		session, err := db.GetActiveSession()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if session == nil {
+			fmt.Println("No active time tracking session. Use 'wrok start <id>' to start one.")
+			return
+		}
+
+		if err := tui.RunTimerTUI(session); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
 	},
 }
 
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show current time tracking status",
+	Long: `Show the running session, its elapsed time and any budget, daily limit and
+goal warnings.
+
+` + templateHelp + `
+Templates get .Session (nil when no timer runs; .Session.Task is its task),
+.Elapsed and .Now, e.g. {{with .Session}}#{{.TaskID}} {{hours $.Elapsed}}{{end}}
+for a status bar.`,
+	Example: `  wrok status
+  wrok status --template statusbar.tmpl`,
 	Run: func(cmd *cobra.Command, args []string) {
 		initDB()
 		session, err := db.GetActiveSession()
@@ -76,21 +234,98 @@ var statusCmd = &cobra.Command{
 			return
 		}
 
+		if path := templatePath(cmd); path != "" {
+			data := statusTemplateData{Session: session, Now: time.Now()}
+			if session != nil {
+				data.Elapsed = session.Elapsed(data.Now)
+			}
+			renderTemplate(path, data)
+			return
+		}
+
 		if session == nil {
 			fmt.Println("No active time tracking session")
+			printDailyLimitWarning()
+			printGoalProgress()
 			return
 		}
 
-		elapsed := time.Since(session.StartedAt)
-		fmt.Printf("⏱️  Currently tracking: task #%d: %s\n", session.TaskID, session.Task.Title)
+		elapsed := session.Elapsed(time.Now())
+		if session.Paused() {
+			fmt.Printf("⏸️  Paused: task #%d: %s\n", session.TaskID, session.Task.Title)
+		} else {
+			fmt.Printf("⏱️  Currently tracking: task #%d: %s\n", session.TaskID, session.Task.Title)
+		}
 		fmt.Printf("Started at: %s\n", session.StartedAt.Format("15:04:05"))
 		fmt.Printf("Elapsed time: %s\n", formatDuration(elapsed))
+		if session.Paused() {
+			fmt.Printf("On a break since %s; 'wrok resume' to carry on\n", session.PausedAt.Format("15:04:05"))
+		}
+		printBudgetWarning(session.Task.Project)
+		printDailyLimitWarning()
+		printGoalProgress()
 	},
 }
 
 func init() {
 	// Add --no-ui flag to start command
 	startCmd.Flags().Bool("no-ui", false, "Start timer without interactive UI")
+	startCmd.Flags().Bool("force", false, "Start even when max_focus calls for a break")
+	startCmd.Flags().Bool("switch", false, "Stop the running session, if any, and start this one")
+	stopCmd.Flags().Bool("copy", false, "Copy the session summary to the clipboard")
+	addTemplateFlag(statusCmd)
+}
+
+// waitForBreak holds a new session back while max_focus calls for a break:
+// with the UI it counts the break down, without it it says how long is left.
+// It reports whether the session may start
+func waitForBreak(taskID uint, noUI bool) bool {
+	// Starting fails anyway while a session runs; let that error speak
+	if active, _ := db.GetActiveSession(); active != nil {
+		return true
+	}
+	now := time.Now()
+	streak, err := db.GetFocusStreak(now)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return false
+	}
+	if streak == nil || streak.BreakLeft(now) <= 0 {
+		return true
+	}
+	if noUI {
+		fmt.Println(streak.Warning(now))
+		fmt.Println("Use 'wrok start --force' to start anyway.")
+		return false
+	}
+
+	label := fmt.Sprintf("#%d", taskID)
+	if task, err := db.GetTaskByID(taskID); err == nil {
+		label += " " + task.Title
+	}
+	done, err := tui.RunBreakTUI(*streak, label)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return false
+	}
+	return done
+}
+
+// confirmSwitch asks whether to stop the session running on another task and
+// start taskID instead. Without a terminal it only explains how to switch
+func confirmSwitch(cmd *cobra.Command, active *models.Session, taskID uint) bool {
+	running := fmt.Sprintf("#%d %s (%s so far)", active.TaskID, active.Task.Title, formatDuration(active.Elapsed(time.Now())))
+	if !stdinIsTerminal() {
+		fmt.Printf("Error: already tracking %s. Stop it first with 'wrok stop', or pass --switch to stop it and start #%d.\n", running, taskID)
+		return false
+	}
+
+	fmt.Printf("⏱️  Already tracking %s.\n", running)
+	if !confirm(cmd, fmt.Sprintf("Stop it and start #%d instead?", taskID)) {
+		fmt.Println("Nothing changed.")
+		return false
+	}
+	return true
 }
 
 // formatDuration formats a duration in a human-readable way
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/tui"
+)
+
+var timelineCmd = &cobra.Command{
+	Use:   "timeline",
+	Short: "Show a day's sessions on an hour-by-hour timeline",
+	Long: `Draw the sessions of a day along an hour axis: one row per task, a bar for
+each session, colored by project, with the time per project underneath. Handy
+to work out where the day went.
+
+--day takes today (the default), yesterday, or a date as dd/mm/yyyy or
+yyyy-mm-dd. A running session is drawn up to now.`,
+	Example: `  wrok timeline
+  wrok timeline --day yesterday
+  wrok timeline --day 14/10/2026`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		dayValue, _ := cmd.Flags().GetString("day")
+
+		now := time.Now()
+		day, err := parseDayFlag(dayValue, now)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		// Sessions started the evening before may run into the day
+		sessions, err := db.GetTrackedSessions(db.TrackedTimeQuery{
+			From: day.AddDate(0, 0, -1),
+			To:   day.AddDate(0, 0, 1),
+		})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println(tui.RenderTimeline(day, sessions, now))
+	},
+}
+
+// parseDayFlag returns the start of the day a --day flag names: today, yesterday or a date
+func parseDayFlag(value string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+	return parser.ParseDay(value)
+}
+
+func init() {
+	timelineCmd.Flags().String("day", "today", "Day to show: today, yesterday, dd/mm/yyyy or yyyy-mm-dd")
+}
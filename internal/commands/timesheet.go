@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/tui"
+)
+
+var timesheetCmd = &cobra.Command{
+	Use:   "timesheet",
+	Short: "Edit a day's sessions as a timesheet",
+	Long: `Open the sessions of a day as an editable grid of task, start, end and note.
+Cells are edited in place, rows added and deleted, and nothing is stored until
+the sheet is saved (w): then every change is checked together and written in
+one transaction. Sessions may not overlap each other or sessions of other
+days, and only the running session may stay without an end; rows that overlap
+the one above are marked while editing.
+
+Times are HH:MM on the day; an end before the start is read as past midnight.
+--day takes today (the default), yesterday, or a date as dd/mm/yyyy or
+yyyy-mm-dd. Locked sessions can only be changed with --force.`,
+	Example: `  wrok timesheet
+  wrok timesheet --day yesterday
+  wrok timesheet --day 14/10/2026 --no-ui`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		dayValue, _ := cmd.Flags().GetString("day")
+		noUI, _ := cmd.Flags().GetBool("no-ui")
+		force, _ := cmd.Flags().GetBool("force")
+
+		day, err := parseDayFlag(dayValue, time.Now())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		sessions, err := db.GetTimesheet(day)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if noUI {
+			if len(sessions) == 0 {
+				fmt.Printf("No sessions on %s.\n", day.Format("Monday 02/01/2006"))
+				return
+			}
+			renderSessionsTable(sessions)
+			return
+		}
+		if err := tui.RunTimesheetTUI(day, sessions, force); err != nil {
+			fmt.Printf("Error running TUI: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	timesheetCmd.Flags().String("day", "today", "Day to edit: today, yesterday, dd/mm/yyyy or yyyy-mm-dd")
+	timesheetCmd.Flags().Bool("no-ui", false, "Print the day's sessions instead of editing them")
+	timesheetCmd.Flags().Bool("force", false, "Allow changing locked sessions")
+}
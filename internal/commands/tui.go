@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/tui"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Open the full-screen app: tasks, timer, stats, board and review",
+	Long: `Open wrok as a single full-screen application with five screens:
+
+  1 Tasks    the interactive task list from 'wrok ls'
+  2 Timer    the running timer (s stops it)
+  3 Stats    task counts and time tracked today and this week
+  4 Board    to do / in progress / done columns (d moves a card to done)
+  5 Review   today's tracked time, completed tasks and what is due
+
+Switch screens with tab, shift+tab or the number keys. The task list follows
+the current focus and hides archived tasks, like 'wrok ls'.`,
+	Example: `  wrok tui`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		opts := db.TaskQueryOptions{HideArchived: true, HideSnoozed: true, OrderBy: listOrders["id"].orderBy}
+		applyFocus(&opts, loadFocus())
+
+		if err := tui.RunShell(opts); err != nil {
+			fmt.Printf("Error running TUI: %v\n", err)
+		}
+	},
+}
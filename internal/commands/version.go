@@ -9,6 +9,7 @@ import (
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version information",
+	Example: `  wrok version`,
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Printf("wrok %s (commit: %s, built: %s)\n", version, commit, date)
 	},
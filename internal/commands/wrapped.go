@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/clipboard"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/plain"
+	"github.com/balkashynov/wrok/internal/tui"
+)
+
+var wrappedCmd = &cobra.Command{
+	Use:   "wrapped",
+	Short: "Sum up your week or month as a shareable card",
+	Long: `Sum up the current week (or month) of work: time tracked, top project,
+longest session, busiest day, completed tasks and the longest streak of days
+with time tracked. It prints a card for screenshots, or with --text a plain
+block to paste into a chat.`,
+	Example: `  wrok wrapped
+  wrok wrapped --month
+  wrok wrapped --last              # Last week, e.g. on a Monday morning
+  wrok wrapped --month --text --copy`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		week, _ := cmd.Flags().GetBool("week")
+		month, _ := cmd.Flags().GetBool("month")
+		last, _ := cmd.Flags().GetBool("last")
+		text, _ := cmd.Flags().GetBool("text")
+		copyText, _ := cmd.Flags().GetBool("copy")
+		if week && month {
+			fmt.Println("Error: use --week or --month, not both")
+			return
+		}
+
+		now := time.Now()
+		period, at := parser.BudgetWeek, now
+		switch {
+		case month && last:
+			at = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0)
+			period = parser.BudgetMonth
+		case month:
+			period = parser.BudgetMonth
+		case last:
+			at = now.AddDate(0, 0, -7)
+		}
+
+		wrapped, err := db.GetWrapped(period, at, now)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		// The card's layout relies on its emoji, so plain output gets the text
+		if text || plain.Enabled() {
+			fmt.Println(wrapped.String())
+		} else {
+			fmt.Println(tui.RenderWrappedCard(*wrapped))
+		}
+		if copyText {
+			if err := clipboard.Copy(wrapped.String()); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			fmt.Println("📋 Summary copied to the clipboard")
+		}
+	},
+}
+
+func init() {
+	wrappedCmd.Flags().Bool("week", false, "Sum up the week (the default)")
+	wrappedCmd.Flags().Bool("month", false, "Sum up the month instead of the week")
+	wrappedCmd.Flags().Bool("last", false, "Sum up the previous week or month")
+	wrappedCmd.Flags().Bool("text", false, "Print a plain text block instead of the card")
+	wrappedCmd.Flags().Bool("copy", false, "Copy the plain text block to the clipboard")
+}
@@ -0,0 +1,254 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+var wrapupCmd = &cobra.Command{
+	Use:   "wrapup",
+	Short: "Wrap up the working day",
+	Long: `Wrap up the working day.
+
+Stops any running timer, shows a summary of today's tracked time and
+completed tasks, asks which unfinished tasks due today (or overdue) should
+be rolled over to tomorrow, and appends a journal entry to
+~/.wrok/journal/YYYY-MM-DD.md. A rolled task keeps its due time of day.
+Without a terminal nothing is rolled unless --roll-all or --yes is given.
+
+Examples:
+  wrok wrapup              # Interactive wrap-up
+  wrok wrapup --roll-all   # Roll every unfinished task without asking
+  wrok wrapup --no-roll    # Skip the roll-over step`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initDB()
+		rollAll, _ := cmd.Flags().GetBool("roll-all")
+		noRoll, _ := cmd.Flags().GetBool("no-roll")
+
+		if err := runWrapup(cmd, rollAll, noRoll); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	},
+}
+
+// wrapupTaskTime holds the time tracked on a single task today
+type wrapupTaskTime struct {
+	task     models.Task
+	duration time.Duration
+}
+
+// runWrapup performs the end-of-day wrap-up flow
+func runWrapup(cmd *cobra.Command, rollAll, noRoll bool) error {
+	now := time.Now()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1).Add(-time.Second)
+
+	// 1. Stop any running timer
+	var stoppedSession *models.Session
+	if active, _ := db.GetActiveSession(); active != nil {
+		session, err := db.StopActiveSession()
+		if err != nil {
+			return fmt.Errorf("failed to stop active session: %w", err)
+		}
+		stoppedSession = session
+		duration := time.Duration(session.DurationSeconds) * time.Second
+		fmt.Printf("⏹️  Stopped tracking time for task #%d: %s (%s)\n\n", session.TaskID, session.Task.Title, formatDuration(duration))
+	}
+
+	// 2. Today's summary
+	sessions, err := db.GetSessionsInRange(dayStart, dayEnd)
+	if err != nil {
+		return fmt.Errorf("failed to get sessions: %w", err)
+	}
+	if stoppedSession != nil && stoppedSession.StartedAt.Before(dayStart) {
+		// Running since before midnight, so not in today's range; only its part since then counts
+		sessions = append(sessions, *stoppedSession)
+	}
+	completed, err := db.GetTasksCompletedInRange(dayStart, dayEnd)
+	if err != nil {
+		return fmt.Errorf("failed to get completed tasks: %w", err)
+	}
+
+	taskTimes := summarizeSessionsByTask(sessions, dayStart, now)
+	var total time.Duration
+	for _, tt := range taskTimes {
+		total += tt.duration
+	}
+
+	fmt.Printf("📊 Today (%s)\n", now.Format("Mon 02/01/2006"))
+	if len(taskTimes) == 0 {
+		fmt.Println("  No time tracked today.")
+	} else {
+		for _, tt := range taskTimes {
+			fmt.Printf("  %-8s #%d %s\n", formatDuration(tt.duration), tt.task.ID, tt.task.Title)
+		}
+		fmt.Printf("  Total: %s\n", formatDuration(total))
+	}
+	fmt.Println()
+
+	fmt.Printf("✅ Completed today: %d\n", len(completed))
+	for _, task := range completed {
+		fmt.Printf("  #%d %s\n", task.ID, task.Title)
+	}
+	fmt.Println()
+
+	// 3. Roll unfinished tasks due today (or overdue) to tomorrow
+	var rolled []models.Task
+	if !noRoll {
+		unfinished, err := db.GetOpenTasksDueBy(dayEnd)
+		if err != nil {
+			return fmt.Errorf("failed to get unfinished tasks: %w", err)
+		}
+
+		if len(unfinished) > 0 {
+			fmt.Printf("📅 Unfinished tasks due today or earlier: %d\n", len(unfinished))
+			tomorrow := dayStart.AddDate(0, 0, 1)
+
+			for _, task := range unfinished {
+				roll := rollAll
+				if !rollAll {
+					roll = confirm(cmd, fmt.Sprintf("  Roll #%d \"%s\" (%s) to tomorrow?", task.ID, task.Title, parser.FormatDueDate(task.Due)))
+				}
+
+				if roll {
+					due := rollDue(*task.Due, tomorrow)
+					updated, err := db.RescheduleTask(task.ID, &due)
+					if err != nil {
+						fmt.Printf("  Error rolling task #%d: %v\n", task.ID, err)
+						continue
+					}
+					rolled = append(rolled, *updated)
+				}
+			}
+
+			if len(rolled) > 0 {
+				fmt.Printf("  Rolled %d task(s) to %s\n", len(rolled), tomorrow.Format("02/01/2006"))
+			}
+			fmt.Println()
+		}
+	}
+
+	// 4. Write the journal entry
+	journalPath, err := writeJournalEntry(now, taskTimes, total, completed, rolled, stoppedSession)
+	if err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+	fmt.Printf("📝 Journal entry written to %s\n", journalPath)
+
+	return nil
+}
+
+// rollDue moves due to the day of tomorrow, keeping its time of day
+func rollDue(due, tomorrow time.Time) time.Time {
+	due = due.In(tomorrow.Location())
+	return time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), due.Hour(), due.Minute(), due.Second(), 0, tomorrow.Location())
+}
+
+// summarizeSessionsByTask groups sessions by task and sorts by time spent
+// between dayStart and now, counting a session still running up to now. Of a
+// session started before dayStart only the part since then counts
+func summarizeSessionsByTask(sessions []models.Session, dayStart, now time.Time) []wrapupTaskTime {
+	byTask := make(map[uint]*wrapupTaskTime)
+	for _, session := range sessions {
+		worked := session.Elapsed(now)
+		if session.StartedAt.Before(dayStart) {
+			end := now
+			if session.FinishedAt != nil && session.FinishedAt.Before(now) {
+				end = *session.FinishedAt
+			}
+			worked = min(worked, max(end.Sub(dayStart), 0))
+		}
+
+		tt, ok := byTask[session.TaskID]
+		if !ok {
+			tt = &wrapupTaskTime{task: session.Task}
+			byTask[session.TaskID] = tt
+		}
+		tt.duration += worked
+	}
+
+	var result []wrapupTaskTime
+	for _, tt := range byTask {
+		result = append(result, *tt)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].duration > result[j].duration
+	})
+
+	return result
+}
+
+// writeJournalEntry appends the wrap-up summary to today's journal file
+func writeJournalEntry(now time.Time, taskTimes []wrapupTaskTime, total time.Duration, completed, rolled []models.Task, stopped *models.Session) (string, error) {
+	dataDir, err := db.DataDir()
+	if err != nil {
+		return "", err
+	}
+
+	journalDir := filepath.Join(dataDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## Wrap-up %s\n\n", now.Format("15:04")))
+
+	if stopped != nil {
+		b.WriteString(fmt.Sprintf("Stopped timer on #%d %s\n\n", stopped.TaskID, stopped.Task.Title))
+	}
+
+	b.WriteString(fmt.Sprintf("### Time tracked (%s)\n\n", formatDuration(total)))
+	if len(taskTimes) == 0 {
+		b.WriteString("- nothing tracked\n")
+	}
+	for _, tt := range taskTimes {
+		b.WriteString(fmt.Sprintf("- %s #%d %s\n", formatDuration(tt.duration), tt.task.ID, tt.task.Title))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(fmt.Sprintf("### Completed (%d)\n\n", len(completed)))
+	for _, task := range completed {
+		b.WriteString(fmt.Sprintf("- [x] #%d %s\n", task.ID, task.Title))
+	}
+	if len(completed) == 0 {
+		b.WriteString("- none\n")
+	}
+	b.WriteString("\n")
+
+	if len(rolled) > 0 {
+		b.WriteString(fmt.Sprintf("### Rolled to tomorrow (%d)\n\n", len(rolled)))
+		for _, task := range rolled {
+			b.WriteString(fmt.Sprintf("- [ ] #%d %s\n", task.ID, task.Title))
+		}
+		b.WriteString("\n")
+	}
+
+	journalPath := filepath.Join(journalDir, now.Format("2006-01-02")+".md")
+	f, err := os.OpenFile(journalPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(b.String()); err != nil {
+		return "", err
+	}
+
+	return journalPath, nil
+}
+
+func init() {
+	wrapupCmd.Flags().Bool("roll-all", false, "Roll all unfinished tasks to tomorrow without asking")
+	wrapupCmd.Flags().Bool("no-roll", false, "Skip rolling unfinished tasks to tomorrow")
+	addYesFlag(wrapupCmd, "Roll all unfinished tasks to tomorrow without asking")
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// TestSummarizeSessionsAcrossMidnight checks that of sessions started
+// yesterday only the time since midnight counts toward today
+func TestSummarizeSessionsAcrossMidnight(t *testing.T) {
+	dayStart := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	now := dayStart.Add(18 * time.Hour)
+	at := func(offset time.Duration) *time.Time {
+		t := dayStart.Add(offset)
+		return &t
+	}
+
+	sessions := []models.Session{
+		// 23:00 to 01:00, stopped by wrapup: one hour today
+		{TaskID: 1, Task: models.Task{ID: 1}, StartedAt: *at(-time.Hour), FinishedAt: at(time.Hour), DurationSeconds: 7200},
+		// 22:00 to 00:30 with a two hour break before midnight: half an hour today
+		{TaskID: 2, Task: models.Task{ID: 2}, StartedAt: *at(-2 * time.Hour), FinishedAt: at(30 * time.Minute), DurationSeconds: 1800, PausedSeconds: 7200},
+		// 09:00 to 10:00 today, counted whole
+		{TaskID: 2, Task: models.Task{ID: 2}, StartedAt: *at(9 * time.Hour), FinishedAt: at(10 * time.Hour), DurationSeconds: 3600},
+		// Running since 17:00
+		{TaskID: 3, Task: models.Task{ID: 3}, StartedAt: *at(17 * time.Hour)},
+	}
+	want := map[uint]time.Duration{1: time.Hour, 2: 90 * time.Minute, 3: time.Hour}
+
+	got := summarizeSessionsByTask(sessions, dayStart, now)
+	if len(got) != len(want) {
+		t.Fatalf("got %d tasks, want %d", len(got), len(want))
+	}
+	for _, tt := range got {
+		if tt.duration != want[tt.task.ID] {
+			t.Errorf("task #%d: got %v, want %v", tt.task.ID, tt.duration, want[tt.task.ID])
+		}
+	}
+}
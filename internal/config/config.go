@@ -0,0 +1,257 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config holds user settings read from ~/.wrok/config.toml
+type Config struct {
+	Theme          string              `toml:"theme"`                     // accent color theme (purple, blue, green, orange)
+	WeekStart      string              `toml:"week_start"`                // first day of the week (monday, sunday, saturday)
+	Timezone       string              `toml:"timezone,omitempty"`        // display timezone, e.g. "Europe/Berlin"; empty uses the system's
+	DefaultProject string              `toml:"default_project,omitempty"` // project for new tasks without one
+	DailyLimit     string              `toml:"daily_limit,omitempty"`     // e.g. "8h"; warn once more than this is tracked in a day
+	MaxFocus       string              `toml:"max_focus,omitempty"`       // e.g. "3h"; focus without a break after which a new session must wait
+	MinBreak       string              `toml:"min_break,omitempty"`       // break that ends a focus streak and that max_focus insists on; 15m when unset
+	Capacity       string              `toml:"capacity,omitempty"`        // e.g. "30h"; task work available per week, for 'wrok capacity'
+	DanglingAfter  string              `toml:"dangling_after,omitempty"`  // e.g. "16h"; a session running longer is dangling; 16h when unset
+	OnDangling     string              `toml:"on_dangling,omitempty"`     // "ask" (default) to offer closing dangling sessions, "close" to close them, "off"
+	Holidays       []string            `toml:"holidays,omitempty"`        // days off (dd/mm/yyyy or yyyy-mm-dd) business-day due dates skip
+	Plain          bool                `toml:"plain,omitempty"`           // no emoji, box-drawing art, logos or shimmer (like --plain)
+	Nag            bool                `toml:"nag,omitempty"`             // after each command, remind of overdue tasks and tasks due today
+	Jira           JiraConfig          `toml:"jira"`
+	Hooks          HooksConfig         `toml:"hooks"`
+	Git            GitConfig           `toml:"git"`
+	Priority       PriorityConfig      `toml:"priority"`
+	Status         StatusConfig        `toml:"status"`
+	Defaults       map[string]any      `toml:"defaults,omitempty"` // per-command flag defaults, e.g. ls.status = "todo"
+	Budgets        map[string]string   `toml:"budgets,omitempty"`  // time budget per project, e.g. clientA = "10h/week"
+	Retention      RetentionConfig     `toml:"retention"`
+	Backup         BackupConfig        `toml:"backup"`
+	SMTP           SMTPConfig          `toml:"smtp"`
+	Transcription  TranscriptionConfig `toml:"transcription"`
+	LLM            LLMConfig           `toml:"llm"`
+	Tags           TagsConfig          `toml:"tags"`
+}
+
+// TagsConfig is the naming policy tags are normalized to when created
+type TagsConfig struct {
+	Lowercase bool   `toml:"lowercase,omitempty"` // store tags in lowercase
+	Separator string `toml:"separator,omitempty"` // "-" or "_": words are joined with it, the other one and spaces are replaced
+}
+
+// LLMConfig holds the chat model 'wrok breakdown' asks; unset, the feature is off
+type LLMConfig struct {
+	URL    string `toml:"url,omitempty"`     // OpenAI-compatible chat completions endpoint, e.g. https://api.openai.com/v1/chat/completions
+	Model  string `toml:"model,omitempty"`   // e.g. gpt-4o-mini or llama3.1 on a local server
+	APIKey string `toml:"api_key,omitempty"` // bearer token; WROK_LLM_KEY takes precedence
+}
+
+// TranscriptionConfig holds the speech-to-text endpoint 'wrok add --from-audio' uses
+type TranscriptionConfig struct {
+	URL    string `toml:"url,omitempty"`     // OpenAI-compatible endpoint, e.g. https://api.openai.com/v1/audio/transcriptions
+	Model  string `toml:"model,omitempty"`   // whisper-1 when unset
+	APIKey string `toml:"api_key,omitempty"` // bearer token; WROK_TRANSCRIPTION_KEY takes precedence
+}
+
+// SMTPConfig holds the mail server 'wrok digest --email' sends through
+type SMTPConfig struct {
+	Host     string `toml:"host,omitempty"`     // e.g. smtp.gmail.com
+	Port     int    `toml:"port,omitempty"`     // 587 (STARTTLS) when unset; 465 connects over TLS
+	Username string `toml:"username,omitempty"` // login; empty sends without authenticating
+	Password string `toml:"password,omitempty"` // WROK_SMTP_PASSWORD takes precedence
+	From     string `toml:"from,omitempty"`     // sender address; defaults to Username
+}
+
+// BackupConfig controls automatic database backups
+type BackupConfig struct {
+	Auto string `toml:"auto,omitempty"` // "daily" or "weekly"; empty disables automatic backups
+	Keep int    `toml:"keep,omitempty"` // automatic backups kept; 7 when unset
+	Dir  string `toml:"dir,omitempty"`  // where backups go; ~/.wrok/backups when unset
+}
+
+// RetentionConfig controls how long deleted tasks and sessions are kept
+type RetentionConfig struct {
+	PurgeAfter string `toml:"purge_after,omitempty"` // e.g. "90d"; empty keeps deleted rows forever
+}
+
+// JiraConfig holds the Jira connection settings
+type JiraConfig struct {
+	BaseURL string `toml:"base_url,omitempty"` // e.g. https://company.atlassian.net
+	Strict  bool   `toml:"strict,omitempty"`   // refuse JIRA IDs not in XXX-123 format instead of storing them as-is
+	Email   string `toml:"email,omitempty"`    // account for Jira Cloud API tokens; empty sends the token as a bearer token (Jira Server/Data Center)
+	Token   string `toml:"token,omitempty"`    // API token or personal access token; WROK_JIRA_TOKEN takes precedence
+}
+
+// PriorityConfig defines the priority scheme
+type PriorityConfig struct {
+	Levels []PriorityLevel `toml:"levels,omitempty"` // lowest first; empty means low/medium/high
+}
+
+// PriorityLevel is one priority of the scheme
+type PriorityLevel struct {
+	Name  string `toml:"name"`            // e.g. "urgent" or "P0"
+	Short string `toml:"short,omitempty"` // name in table columns (at most 8 characters); defaults to Name
+	Color string `toml:"color,omitempty"` // #RRGGBB or ANSI 256 code; defaults by rank (red, amber, grey)
+}
+
+// StatusConfig defines the task statuses beyond todo, done and archived, and
+// which moves between statuses are allowed
+type StatusConfig struct {
+	States      []StatusState       `toml:"states,omitempty"`      // extra open statuses in workflow order, e.g. doing, review; todo/done/archived here only set a color
+	Transitions map[string][]string `toml:"transitions,omitempty"` // statuses each status may move to; a status without an entry may move to any
+}
+
+// StatusState is one status of the workflow
+type StatusState struct {
+	Name  string `toml:"name"`            // e.g. "doing" or "blocked"
+	Color string `toml:"color,omitempty"` // #RRGGBB or ANSI 256 code
+}
+
+// GitConfig controls what the hooks installed by 'wrok git hook install' do
+type GitConfig struct {
+	AutoTimer bool `toml:"auto_timer,omitempty"` // start/stop timers on branch checkout instead of only suggesting the task
+}
+
+// HooksConfig holds shell commands and webhook URLs run on task/session events
+type HooksConfig struct {
+	OnCreate  string   `toml:"on_create,omitempty"`  // shell command run when a task is created
+	OnDone    string   `toml:"on_done,omitempty"`    // shell command run when a task is completed
+	OnArchive string   `toml:"on_archive,omitempty"` // shell command run when a task is archived
+	OnStart   string   `toml:"on_start,omitempty"`   // shell command run when a session starts
+	OnStop    string   `toml:"on_stop,omitempty"`    // shell command run when a session stops
+	Webhooks  []string `toml:"webhooks,omitempty"`   // URLs that receive every event as a JSON POST
+}
+
+// loaded caches the config for the lifetime of the process
+var loaded *Config
+
+// Dir returns the wrok data directory (~/.wrok)
+func Dir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".wrok"), nil
+}
+
+// Path returns the path to the config file
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.toml"), nil
+}
+
+// Load reads the config file, returning defaults if it does not exist
+func Load() (*Config, error) {
+	if loaded != nil {
+		return loaded, nil
+	}
+
+	path, err := Path()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config path: %w", err)
+	}
+
+	cfg := &Config{}
+	if _, err := toml.DecodeFile(path, cfg); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	loaded = cfg
+	return cfg, nil
+}
+
+// FirstWeekday returns the configured first day of the week (WeekStart), Monday by default
+func (c *Config) FirstWeekday() time.Weekday {
+	switch strings.ToLower(c.WeekStart) {
+	case "sunday":
+		return time.Sunday
+	case "saturday":
+		return time.Saturday
+	default:
+		return time.Monday
+	}
+}
+
+// Exists reports whether the config file has been created
+func Exists() bool {
+	path, err := Path()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// Save writes cfg to the config file, creating the data directory if needed
+func Save(cfg *Config) error {
+	path, err := Path()
+	if err != nil {
+		return fmt.Errorf("failed to get config path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create wrok directory: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if err := toml.NewEncoder(f).Encode(cfg); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	loaded = cfg
+	return nil
+}
+
+// FlagDefaults returns the [defaults] entries for a command, keyed by flag name.
+// command is the dotted command path, e.g. "ls" or "stats.burndown"
+func (c *Config) FlagDefaults(command string) map[string]string {
+	flat := make(map[string]string)
+	flattenDefaults("", c.Defaults, flat)
+
+	defaults := make(map[string]string)
+	prefix := command + "."
+	for key, value := range flat {
+		flag := strings.TrimPrefix(key, prefix)
+		if flag != key && !strings.Contains(flag, ".") {
+			defaults[flag] = value
+		}
+	}
+	return defaults
+}
+
+// flattenDefaults turns nested TOML tables into dotted keys with string values;
+// arrays become comma-separated lists, as slice flags expect
+func flattenDefaults(prefix string, values map[string]any, out map[string]string) {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			flattenDefaults(key, v, out)
+		case []any:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			out[key] = strings.Join(items, ",")
+		default:
+			out[key] = fmt.Sprint(v)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"time"
+
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/workflow"
+)
+
+// StaleAfter is how long an open task can go untouched before it is flagged as stale
+const StaleAfter = 14 * 24 * time.Hour
+
+// GetLastActivity returns when each task was last touched: edited, or worked on in a
+// session. A running session counts as activity right now
+func GetLastActivity(tasks []models.Task, now time.Time) (map[uint]time.Time, error) {
+	activity := make(map[uint]time.Time, len(tasks))
+	for _, task := range tasks {
+		activity[task.ID] = task.UpdatedAt
+	}
+
+	sessions, err := getTaskSessions(tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		last := now
+		if session.FinishedAt != nil {
+			last = *session.FinishedAt
+		}
+		if last.After(activity[session.TaskID]) {
+			activity[session.TaskID] = last
+		}
+	}
+
+	return activity, nil
+}
+
+// GetTrackedTime returns the total time logged against each task, counting a running
+// session up to now. The finished sessions' totals are cached (see aggregates)
+func GetTrackedTime(tasks []models.Task, now time.Time) (map[uint]time.Duration, error) {
+	tracked := make(map[uint]time.Duration, len(tasks))
+	if len(tasks) == 0 {
+		return tracked, nil
+	}
+
+	totals, err := finishedTaskTotals()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+		if total, ok := totals[task.ID]; ok {
+			tracked[task.ID] = total
+		}
+	}
+
+	var running []models.Session
+	err = DB.Where("task_id IN ? AND finished_at IS NULL", ids).Find(&running).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range running {
+		tracked[session.TaskID] += session.Elapsed(now)
+	}
+
+	return tracked, nil
+}
+
+// getTaskSessions loads the sessions of the given tasks without their task records
+func getTaskSessions(tasks []models.Task) ([]models.Session, error) {
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	var sessions []models.Session
+	err := DB.Select("task_id", "started_at", "finished_at", "duration_seconds").
+		Where("task_id IN ?", ids).
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// IsStale reports whether an open task has gone untouched for longer than StaleAfter
+func IsStale(task models.Task, lastActivity time.Time, now time.Time) bool {
+	return workflow.IsOpen(task.Status) && now.Sub(lastActivity) > StaleAfter
+}
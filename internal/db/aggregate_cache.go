@@ -0,0 +1,102 @@
+package db
+
+import (
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// Sums of tracked time go over every session in their range, and the status
+// line, list columns and Stats screen ask for them again on every refresh and
+// keystroke. aggregates keeps them for as long as the database is unchanged:
+// a write from this process drops them right away (see watchWrites), one from
+// another process when ChangeStamp moves. Only finished sessions are summed
+// ahead; a running session is added each time, so totals stay live
+var aggregates struct {
+	sync.Mutex
+	stamp  string
+	values map[string]interface{}
+}
+
+// cachedAggregate returns the value kept under key, or loads and keeps it
+func cachedAggregate[T any](key string, load func() (T, error)) (T, error) {
+	stamp, err := ChangeStamp()
+	if err != nil {
+		return load()
+	}
+
+	aggregates.Lock()
+	if aggregates.values == nil || aggregates.stamp != stamp {
+		aggregates.stamp = stamp
+		aggregates.values = make(map[string]interface{})
+	}
+	if value, ok := aggregates.values[key]; ok {
+		aggregates.Unlock()
+		return value.(T), nil
+	}
+	aggregates.Unlock()
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	aggregates.Lock()
+	// A write while loading has dropped the values; this one may predate it
+	if aggregates.values != nil && aggregates.stamp == stamp {
+		aggregates.values[key] = value
+	}
+	aggregates.Unlock()
+	return value, nil
+}
+
+// dropAggregates forgets every kept value
+func dropAggregates(*gorm.DB) {
+	aggregates.Lock()
+	aggregates.values = nil
+	aggregates.Unlock()
+}
+
+// watchWrites drops the kept aggregates after every write this process makes,
+// so they never lag behind it, however coarse the file timestamps are
+func watchWrites(conn *gorm.DB) error {
+	callbacks := conn.Callback()
+	if err := callbacks.Create().After("gorm:create").Register("wrok:drop_aggregates", dropAggregates); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("wrok:drop_aggregates", dropAggregates); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register("wrok:drop_aggregates", dropAggregates); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register("wrok:drop_aggregates", dropAggregates)
+}
+
+// finishedTaskTotals returns the time of the finished sessions of every task,
+// by task ID
+func finishedTaskTotals() (map[uint]time.Duration, error) {
+	return cachedAggregate("task totals", func() (map[uint]time.Duration, error) {
+		var rows []struct {
+			TaskID  uint
+			Seconds int64
+		}
+		err := DB.Model(&models.Session{}).
+			Select("task_id, SUM(duration_seconds) AS seconds").
+			Where("finished_at IS NOT NULL").
+			Group("task_id").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+
+		totals := make(map[uint]time.Duration, len(rows))
+		for _, row := range rows {
+			totals[row.TaskID] = time.Duration(row.Seconds) * time.Second
+		}
+		return totals, nil
+	})
+}
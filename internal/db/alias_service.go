@@ -0,0 +1,69 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// aliasRegex is what an alias looks like: it starts with a letter, so it can't be
+// taken for an ID or a range like 12-18
+var aliasRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}$`)
+
+// NormalizeAlias lowercases an alias and checks that it is valid
+func NormalizeAlias(alias string) (string, error) {
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	if !aliasRegex.MatchString(alias) {
+		return "", fmt.Errorf("invalid alias '%s': use up to 32 letters, digits, - and _, starting with a letter", alias)
+	}
+	return alias, nil
+}
+
+// SetTaskAlias gives a task an alias, replacing its previous one; an empty
+// alias removes it. Aliases are unique among tasks
+func SetTaskAlias(id uint, alias string) (*models.Task, error) {
+	task, err := GetTaskByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if alias != "" {
+		if alias, err = NormalizeAlias(alias); err != nil {
+			return nil, err
+		}
+		if other, err := GetTaskByAlias(alias); err == nil && other.ID != task.ID {
+			return nil, fmt.Errorf("alias '%s' is already used by task #%d", alias, other.ID)
+		}
+	}
+
+	err = withRetry(func() error {
+		return DB.Model(task).Update("alias", alias).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	task.Alias = alias
+	return task, nil
+}
+
+// GetTaskByAlias returns the task with the given alias, in any letter case
+func GetTaskByAlias(alias string) (*models.Task, error) {
+	var tasks []models.Task
+	err := DB.Preload("Tags").Where("alias = ?", strings.ToLower(strings.TrimSpace(alias))).Limit(1).Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("no task has the alias '%s'", alias)
+	}
+	return &tasks[0], nil
+}
+
+// GetAliasedTasks returns the tasks that have an alias, by alias
+func GetAliasedTasks() ([]models.Task, error) {
+	var tasks []models.Task
+	err := DB.Where("alias <> ''").Order("alias").Find(&tasks).Error
+	return tasks, err
+}
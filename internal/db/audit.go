@@ -0,0 +1,76 @@
+package db
+
+import (
+	"os"
+	"os/user"
+
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// The audit log is written by triggers (migrations 0004 and 0006); all wrok
+// adds is who made each change. auditSource names the command, set once it is known
+
+var auditSource = "wrok"
+
+// SetAuditSource sets the command the audit log credits this process's
+// changes to, e.g. "wrok done"
+func SetAuditSource(source string) {
+	auditSource = source
+}
+
+// auditActor returns the OS user running wrok
+func auditActor() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	return os.Getenv("USER")
+}
+
+// recordActor registers gorm callbacks that put the actor and source in the
+// audit_actor row before every write, in the write's own transaction, so the
+// triggers it fires pick them up
+func recordActor(conn *gorm.DB) error {
+	actor := auditActor()
+	setActor := func(tx *gorm.DB) {
+		if tx.Error != nil {
+			return
+		}
+		_, err := tx.Statement.ConnPool.ExecContext(tx.Statement.Context,
+			"UPDATE audit_actor SET actor = ?, source = ? WHERE id = 1", actor, auditSource)
+		if err != nil {
+			tx.AddError(err)
+		}
+	}
+
+	callbacks := conn.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("wrok:audit_actor", setActor); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("wrok:audit_actor", setActor); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("wrok:audit_actor", setActor); err != nil {
+		return err
+	}
+	return callbacks.Raw().Before("gorm:raw").Register("wrok:audit_actor", setActor)
+}
+
+// GetTaskHistory returns every change to a task and its sessions, breaks, tags,
+// comments and attachments, oldest first
+func GetTaskHistory(taskID uint) ([]models.AuditEntry, error) {
+	var entries []models.AuditEntry
+	err := DB.Where("task_id = ?", taskID).Order("id ASC").Find(&entries).Error
+	return entries, err
+}
+
+// GetRecentHistory returns the last limit changes to anything, oldest first
+func GetRecentHistory(limit int) ([]models.AuditEntry, error) {
+	var entries []models.AuditEntry
+	err := DB.Order("id DESC").Limit(limit).Find(&entries).Error
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, err
+}
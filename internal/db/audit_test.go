@@ -0,0 +1,109 @@
+package db
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// auditRows returns the rows of entries for entity, action and field
+func auditRows(entries []models.AuditEntry, entity, action, field string) []models.AuditEntry {
+	var rows []models.AuditEntry
+	for _, entry := range entries {
+		if entry.Entity == entity && entry.Action == action && entry.Field == field {
+			rows = append(rows, entry)
+		}
+	}
+	return rows
+}
+
+// TestAuditSessionBreaksAndEdits pauses, resumes, stops and edits a session
+// and checks the breaks and the changed durations are in the task's history
+func TestAuditSessionBreaksAndEdits(t *testing.T) {
+	task, err := CreateTask(CreateTaskRequest{Title: "audit breaks"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := StartSession(task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := PauseActiveSession(); err != nil {
+		t.Fatal(err)
+	}
+	// Backdate the break so resuming adds a minute of paused time
+	pausedAt := time.Now().Add(-time.Minute)
+	if err := DB.Model(&models.Session{}).Where("id = ?", session.ID).Update("paused_at", pausedAt).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := DB.Model(&models.SessionPause{}).Where("session_id = ?", session.ID).Update("started_at", pausedAt).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ResumeActiveSession(); err != nil {
+		t.Fatal(err)
+	}
+	stopped, err := StopActiveSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited, err := UpdateSession(session.ID, stopped.StartedAt.Add(-time.Hour), stopped.FinishedAt, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := GetTaskHistory(task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows := auditRows(entries, "pause", models.AuditCreate, "started_at"); len(rows) != 1 || rows[0].EntityID != session.ID {
+		t.Errorf("pause: got %d rows, want 1 for session #%d", len(rows), session.ID)
+	}
+	if rows := auditRows(entries, "pause", models.AuditUpdate, "ended_at"); len(rows) != 1 || rows[0].OldValue != nil {
+		t.Errorf("resume: got %d rows, want 1 ending the break", len(rows))
+	}
+	if rows := auditRows(entries, "session", models.AuditUpdate, "paused_seconds"); len(rows) != 1 || rows[0].NewValue == nil || *rows[0].NewValue == "0" {
+		t.Errorf("paused_seconds: got %d rows, want 1 adding the break", len(rows))
+	}
+	rows := auditRows(entries, "session", models.AuditUpdate, "duration_seconds")
+	want := strconv.Itoa(edited.DurationSeconds)
+	if len(rows) == 0 || rows[len(rows)-1].NewValue == nil || *rows[len(rows)-1].NewValue != want {
+		t.Errorf("duration_seconds: got %d rows, want the last to be the edited %ss", len(rows), want)
+	}
+}
+
+// TestAuditSettingsAndTagRenames checks that settings and tag renames, which
+// belong to no task, are in the whole log
+func TestAuditSettingsAndTagRenames(t *testing.T) {
+	if err := SetSetting("audit.test", "one"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetSetting("audit.test", "two"); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteSetting("audit.test"); err != nil {
+		t.Fatal(err)
+	}
+	task, err := CreateTask(CreateTaskRequest{Title: "audit tags", Tags: []string{"audit-old"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := DB.Model(&models.Tag{}).Where("id = ?", task.Tags[0].ID).Update("name", "audit-new").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := GetRecentHistory(20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, action := range []string{models.AuditCreate, models.AuditUpdate, models.AuditDelete} {
+		if rows := auditRows(entries, "setting", action, "audit.test"); len(rows) != 1 || rows[0].TaskID != nil {
+			t.Errorf("setting %s: got %d rows, want 1 with no task", action, len(rows))
+		}
+	}
+	rows := auditRows(entries, "tag", models.AuditUpdate, "name")
+	if len(rows) != 1 || rows[0].OldValue == nil || *rows[0].OldValue != "audit-old" || *rows[0].NewValue != "audit-new" {
+		t.Errorf("tag rename: got %d rows, want audit-old → audit-new", len(rows))
+	}
+}
@@ -0,0 +1,321 @@
+package db
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Backups are gzipped SQLite files named after the time they were taken.
+// Automatic backups carry "auto" in their name so rotation never removes a
+// backup made by hand
+const (
+	backupPrefix     = "wrok-"
+	autoBackupPrefix = "wrok-auto-"
+	backupSuffix     = ".db.gz"
+	backupTimeLayout = "20060102-150405"
+)
+
+// sqliteHeader starts every SQLite database file
+const sqliteHeader = "SQLite format 3\x00"
+
+// BackupDir returns where backups go by default (~/.wrok/backups)
+func BackupDir() (string, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "backups"), nil
+}
+
+// backupName returns the file name of a backup taken at t
+func backupName(prefix string, t time.Time) string {
+	return prefix + t.Format(backupTimeLayout) + backupSuffix
+}
+
+// Backup writes a compressed copy of the database to dest and returns its path.
+// dest may be a directory (or empty for BackupDir), in which case the file is
+// named after the current time. The copy is taken with VACUUM INTO, which reads
+// a consistent snapshot, so other wrok processes can keep running
+func Backup(dest string) (string, error) {
+	return backupTo(dest, backupPrefix, time.Now())
+}
+
+// backupTo writes a backup named with prefix into dest
+func backupTo(dest, prefix string, now time.Time) (string, error) {
+	if dest == "" {
+		dir, err := BackupDir()
+		if err != nil {
+			return "", err
+		}
+		dest = dir
+	}
+	if info, err := os.Stat(dest); (err == nil && info.IsDir()) || strings.HasSuffix(dest, string(os.PathSeparator)) || filepath.Ext(dest) == "" {
+		dest = filepath.Join(dest, backupName(prefix, now))
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	// Snapshot into a plain file next to the destination, then compress it
+	snapshot := dest + ".tmp"
+	os.Remove(snapshot)
+	defer os.Remove(snapshot)
+	if err := withRetry(func() error { return DB.Set(readOnlySafe, true).Exec("VACUUM INTO ?", snapshot).Error }); err != nil {
+		return "", fmt.Errorf("failed to snapshot database: %w", err)
+	}
+
+	if err := compressFile(snapshot, dest); err != nil {
+		os.Remove(dest)
+		return "", err
+	}
+	return dest, nil
+}
+
+// compressFile gzips src into dest
+func compressFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dest, err)
+	}
+	defer out.Close()
+
+	zw := gzip.NewWriter(out)
+	zw.Name = "wrok.db"
+	if _, err := io.Copy(zw, in); err != nil {
+		return fmt.Errorf("failed to write %s: %w", dest, err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", dest, err)
+	}
+	return out.Close()
+}
+
+// Restore replaces the database with a backup, gzipped or plain. The current
+// database is backed up first; its path is returned so the restore can be undone.
+// The restored database is migrated to the current schema. It refuses while
+// another process has the database open, and holds the session lock so no
+// session starts in between
+func Restore(src string) (previous string, err error) {
+	if readOnly {
+		return "", ErrReadOnly
+	}
+	unlock, err := lockSessions()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	dbPath, err := getDatabasePath()
+	if err != nil {
+		return "", err
+	}
+
+	// Unpack next to the database so the final rename stays on one filesystem
+	staged := dbPath + ".restore"
+	defer os.Remove(staged)
+	if err := unpackBackup(src, staged); err != nil {
+		return "", err
+	}
+	if err := checkBackup(staged); err != nil {
+		return "", fmt.Errorf("%s is not a usable wrok backup: %w", src, err)
+	}
+
+	dir, err := BackupDir()
+	if err != nil {
+		return "", err
+	}
+	previous, err = backupTo(dir, "wrok-pre-restore-", time.Now())
+	if err != nil {
+		return "", fmt.Errorf("failed to back up the current database: %w", err)
+	}
+
+	if err := Close(); err != nil {
+		return previous, err
+	}
+	if err := checkNotInUse(dbPath); err != nil {
+		os.Remove(previous)
+		return "", errors.Join(err, Initialize())
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(dbPath + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return previous, err
+		}
+	}
+	if err := os.Rename(staged, dbPath); err != nil {
+		return previous, fmt.Errorf("failed to replace database: %w", err)
+	}
+
+	return previous, Initialize()
+}
+
+// checkNotInUse fails when another connection has the database at path open.
+// Such a process would go on writing through its WAL into the file being
+// replaced, so restoring under it loses its writes or corrupts the new file.
+// The probe takes an exclusive lock without waiting; closing it, as the last
+// connection, checkpoints and removes the WAL
+func checkNotInUse(path string) error {
+	conn, err := gorm.Open(sqlite.Open(path+"?_pragma=busy_timeout(0)&_pragma=locking_mode(EXCLUSIVE)&_pragma=journal_mode(WAL)"),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		return err
+	}
+	sqlDB, err := conn.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := conn.Exec("BEGIN EXCLUSIVE; COMMIT").Error; err != nil {
+		if isBusy(err) {
+			return errors.New("the database is open in another wrok process (a timer or list?); close it and try again")
+		}
+		return err
+	}
+	return nil
+}
+
+// unpackBackup writes the database inside src to dest, decompressing gzip files
+func unpackBackup(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	var reader io.Reader = in
+	magic := make([]byte, 2)
+	if n, _ := io.ReadFull(in, magic); n == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		in.Seek(0, io.SeekStart)
+		zr, err := gzip.NewReader(in)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", src, err)
+		}
+		defer zr.Close()
+		reader = zr
+	} else {
+		in.Seek(0, io.SeekStart)
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, reader); err != nil {
+		return fmt.Errorf("failed to read %s: %w", src, err)
+	}
+	return out.Close()
+}
+
+// checkBackup verifies that path is an intact SQLite database with wrok's tables
+func checkBackup(path string) error {
+	header := make([]byte, len(sqliteHeader))
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	_, err = io.ReadFull(file, header)
+	file.Close()
+	if err != nil || string(header) != sqliteHeader {
+		return errors.New("not an SQLite database")
+	}
+
+	conn, err := gorm.Open(sqlite.Open(path), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		return err
+	}
+	if sqlDB, err := conn.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	var result string
+	if err := conn.Raw("PRAGMA integrity_check").Scan(&result).Error; err != nil {
+		return err
+	}
+	if result != "ok" {
+		return fmt.Errorf("integrity check failed: %s", result)
+	}
+	if !conn.Migrator().HasTable("tasks") || !conn.Migrator().HasTable("sessions") {
+		return errors.New("no tasks or sessions table")
+	}
+	return nil
+}
+
+// RunAutoBackup backs the database up into dir when the newest automatic
+// backup there is older than the interval ("daily" or "weekly"), then removes
+// all but the keep newest automatic backups. It returns the new backup's path,
+// or "" when none was due
+func RunAutoBackup(dir, interval string, keep int, now time.Time) (string, error) {
+	if dir == "" {
+		var err error
+		if dir, err = BackupDir(); err != nil {
+			return "", err
+		}
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var due time.Time
+	switch strings.ToLower(interval) {
+	case "daily":
+		due = today
+	case "weekly":
+		due = today.AddDate(0, 0, -6)
+	default:
+		return "", fmt.Errorf("invalid backup interval '%s', use daily or weekly", interval)
+	}
+
+	backups := autoBackups(dir)
+	if len(backups) > 0 {
+		newest := strings.TrimSuffix(strings.TrimPrefix(backups[len(backups)-1], autoBackupPrefix), backupSuffix)
+		if taken, err := time.ParseInLocation(backupTimeLayout, newest, now.Location()); err == nil && !taken.Before(due) {
+			return "", nil
+		}
+	}
+
+	path, err := backupTo(dir, autoBackupPrefix, now)
+	if err != nil {
+		return "", err
+	}
+
+	backups = autoBackups(dir)
+	if keep > 0 && len(backups) > keep {
+		for _, name := range backups[:len(backups)-keep] {
+			os.Remove(filepath.Join(dir, name))
+		}
+	}
+	return path, nil
+}
+
+// autoBackups lists the automatic backups in dir, oldest first
+func autoBackups(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, autoBackupPrefix) && strings.HasSuffix(name, backupSuffix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names) // the timestamp sorts chronologically
+	return names
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// TestRestoreRefusesWhileInUse restores while a second connection, as another
+// wrok process would, has the database open, and checks the restore is refused
+// without touching the database, then goes through once that connection closes
+func TestRestoreRefusesWhileInUse(t *testing.T) {
+	task, err := CreateTask(CreateTaskRequest{Title: "restore while open"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	backup, err := Backup(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := DB.Model(&models.Task{}).Where("id = ?", task.ID).Update("title", "changed after backup").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := getDatabasePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := gorm.Open(sqlite.Open(dataSource(path)), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherDB, err := other.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer otherDB.Close()
+	var count int64
+	if err := other.Model(&models.Task{}).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	previous, err := Restore(backup)
+	if err == nil {
+		t.Fatal("restored while another connection had the database open")
+	}
+	if previous != "" {
+		t.Errorf("refused restore left a pre-restore backup %q", previous)
+	}
+	if stored, err := GetTaskByID(task.ID); err != nil || stored.Title != "changed after backup" {
+		t.Errorf("after the refused restore: got %v, %v, want the task unchanged", stored, err)
+	}
+
+	otherDB.Close()
+	if _, err := Restore(backup); err != nil {
+		t.Fatal(err)
+	}
+	if stored, err := GetTaskByID(task.ID); err != nil || stored.Title != "restore while open" {
+		t.Errorf("after the restore: got %v, %v, want the title from the backup", stored, err)
+	}
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// Budget usage thresholds that trigger warnings
+const (
+	BudgetWarnRatio = 0.8
+	BudgetOverRatio = 1.0
+)
+
+// BudgetStatus is a project's time budget and how much of it has been tracked this period
+type BudgetStatus struct {
+	Project     string
+	Limit       time.Duration
+	Period      string // parser.BudgetWeek or parser.BudgetMonth
+	PeriodStart time.Time
+	Used        time.Duration
+}
+
+// Ratio returns the share of the budget used, 1.0 meaning exactly used up
+func (b BudgetStatus) Ratio() float64 {
+	if b.Limit <= 0 {
+		return 0
+	}
+	return float64(b.Used) / float64(b.Limit)
+}
+
+// Warning returns a message once 80% of the budget is used, or "" below that
+func (b BudgetStatus) Warning() string {
+	ratio := b.Ratio()
+	switch {
+	case ratio >= BudgetOverRatio:
+		return fmt.Sprintf("🚨 @%s is over its %s %sly budget: %s tracked (%.0f%%)",
+			b.Project, formatHours(b.Limit), b.Period, formatHours(b.Used), ratio*100)
+	case ratio >= BudgetWarnRatio:
+		return fmt.Sprintf("⚠️  @%s has used %s of its %s %sly budget (%.0f%%)",
+			b.Project, formatHours(b.Used), formatHours(b.Limit), b.Period, ratio*100)
+	}
+	return ""
+}
+
+// formatHours renders a duration as hours with one decimal, e.g. "8.5h", or as minutes below an hour
+func formatHours(d time.Duration) string {
+	if d < time.Hour {
+		return fmt.Sprintf("%.0fm", d.Minutes())
+	}
+	return fmt.Sprintf("%.1fh", d.Hours())
+}
+
+// GetBudgetStatus returns the budget status of a project, or nil if it has no budget
+func GetBudgetStatus(project string, now time.Time) (*BudgetStatus, error) {
+	if project == "" {
+		return nil, nil
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	spec, ok := cfg.Budgets[project]
+	if !ok {
+		return nil, nil
+	}
+	return budgetStatus(project, spec, now, cfg.FirstWeekday())
+}
+
+// GetBudgetStatuses returns the status of every configured budget, sorted by project
+func GetBudgetStatuses(now time.Time) ([]BudgetStatus, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []BudgetStatus
+	for project, spec := range cfg.Budgets {
+		status, err := budgetStatus(project, spec, now, cfg.FirstWeekday())
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Project < statuses[j].Project
+	})
+	return statuses, nil
+}
+
+// budgetStatus parses a budget spec and sums the project's time tracked in the current period
+func budgetStatus(project, spec string, now time.Time, firstDay time.Weekday) (*BudgetStatus, error) {
+	limit, period, err := parser.ParseBudget(spec)
+	if err != nil {
+		return nil, fmt.Errorf("budget for %s: %w", project, err)
+	}
+
+	start := budgetPeriodStart(period, now, firstDay)
+
+	used, err := SumTrackedTime(TrackedTimeQuery{Project: project, From: start}, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BudgetStatus{
+		Project:     project,
+		Limit:       limit,
+		Period:      period,
+		PeriodStart: start,
+		Used:        used.Total,
+	}, nil
+}
+
+// budgetPeriodStart returns the start of the week (beginning on firstDay) or month containing now
+func budgetPeriodStart(period string, now time.Time, firstDay time.Weekday) time.Time {
+	if period == parser.BudgetMonth {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		return today.AddDate(0, 0, 1-today.Day())
+	}
+	return parser.StartOfWeek(now, firstDay)
+}
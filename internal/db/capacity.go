@@ -0,0 +1,139 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// workdaysPerWeek spreads the weekly capacity over the days it is worked
+const workdaysPerWeek = 5
+
+// CapacityTask is an open task due within the planned days
+type CapacityTask struct {
+	Task      models.Task
+	Remaining time.Duration // estimate minus the time already tracked, never below zero
+	Needed    time.Duration // Remaining plus that of every task due no later, the work due by its due date
+	Available time.Duration // capacity left from now until the end of its due day
+}
+
+// Fits reports whether the work due by the task's due date fits the capacity left before it
+func (t CapacityTask) Fits() bool {
+	return t.Needed <= t.Available
+}
+
+// CapacityPlan weighs the estimated work due in the coming days against the capacity left
+type CapacityPlan struct {
+	Start       time.Time     // now
+	End         time.Time     // start of the first day after the plan
+	Weekly      time.Duration // configured weekly capacity
+	Available   time.Duration // capacity of the working days left, less what was tracked today
+	Committed   time.Duration // remaining estimates of the open tasks due before End, overdue ones included
+	Tasks       []CapacityTask
+	Unestimated []models.Task // open tasks due before End without an estimate, soonest first
+}
+
+// Over reports whether more work is due than there is capacity for
+func (p CapacityPlan) Over() bool {
+	return p.Committed > p.Available
+}
+
+// Ratio returns the share of the capacity committed, 1.0 meaning exactly full
+func (p CapacityPlan) Ratio() float64 {
+	if p.Available <= 0 {
+		if p.Committed > 0 {
+			return 1
+		}
+		return 0
+	}
+	return float64(p.Committed) / float64(p.Available)
+}
+
+// ParseCapacity parses the weekly capacity setting, e.g. "30h"
+func ParseCapacity(spec string) (time.Duration, error) {
+	capacity, err := parser.ParseEstimate(spec)
+	if err != nil {
+		return 0, fmt.Errorf("capacity: %w", err)
+	}
+	if capacity > 7*24*time.Hour {
+		return 0, fmt.Errorf("capacity: %s is more than a week", spec)
+	}
+	return capacity, nil
+}
+
+// GetCapacityPlan weighs the open tasks due within days days from now (overdue
+// ones included) against a weekly capacity spread evenly over the working days,
+// weekends and holidays excluded. Tasks are worked in due date order; each
+// gets the capacity left until the end of its due day. Time tracked today
+// counts against today's share
+func GetCapacityPlan(weekly time.Duration, days int, now time.Time) (*CapacityPlan, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	plan := &CapacityPlan{Start: now, End: today.AddDate(0, 0, days), Weekly: weekly}
+
+	tracked, err := SumTrackedTime(TrackedTimeQuery{From: today}, now)
+	if err != nil {
+		return nil, err
+	}
+	daily := weekly / workdaysPerWeek
+	// availableBy returns the capacity from now until the end of day
+	availableBy := func(day time.Time) time.Duration {
+		var available time.Duration
+		for d := today; !d.After(day); d = d.AddDate(0, 0, 1) {
+			if _, off := parser.NonWorkingDay(d); off {
+				continue
+			}
+			share := daily
+			if d.Equal(today) {
+				share = max(daily-tracked.Total, 0)
+			}
+			available += share
+		}
+		return available
+	}
+	plan.Available = availableBy(plan.End.AddDate(0, 0, -1))
+
+	tasks, err := GetOpenTasksDueBy(plan.End.Add(-time.Second))
+	if err != nil {
+		return nil, err
+	}
+	spent, err := GetTrackedTime(tasks, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		if task.EstimateSeconds == 0 {
+			plan.Unestimated = append(plan.Unestimated, task)
+			continue
+		}
+		remaining := max(time.Duration(task.EstimateSeconds)*time.Second-spent[task.ID], 0)
+		plan.Committed += remaining
+		dueAt := task.Due.In(now.Location())
+		due := time.Date(dueAt.Year(), dueAt.Month(), dueAt.Day(), 0, 0, 0, 0, now.Location())
+		var available time.Duration
+		if !due.Before(today) {
+			available = availableBy(due)
+		}
+		plan.Tasks = append(plan.Tasks, CapacityTask{
+			Task:      task,
+			Remaining: remaining,
+			Needed:    plan.Committed,
+			Available: available,
+		})
+	}
+	// Tasks due the same day share that day's deadline
+	for i := len(plan.Tasks) - 2; i >= 0; i-- {
+		if sameDay(plan.Tasks[i].Task.Due, plan.Tasks[i+1].Task.Due) {
+			plan.Tasks[i].Needed = plan.Tasks[i+1].Needed
+		}
+	}
+	return plan, nil
+}
+
+// sameDay reports whether two due dates fall on the same local day
+func sameDay(a, b *time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// Kinds of entries in a task's activity feed
+const (
+	ActivityCreated = "created"
+	ActivityStatus  = models.CommentStatus
+	ActivityComment = models.CommentNote
+	ActivitySession = "session"
+)
+
+// ActivityItem is one entry of a task's history
+type ActivityItem struct {
+	At   time.Time
+	Kind string // one of the Activity* kinds
+	Text string
+}
+
+// AddComment adds a user comment to a task
+func AddComment(taskID uint, body string) (*models.Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, fmt.Errorf("comment cannot be empty")
+	}
+	if _, err := GetTaskByID(taskID); err != nil {
+		return nil, err
+	}
+
+	comment := models.Comment{TaskID: taskID, Kind: models.CommentNote, Body: body}
+	if err := withRetry(func() error { return DB.Create(&comment).Error }); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// recordStatusChange adds a status change to a task's history. The change itself
+// has already been saved, so a failure here is not reported
+func recordStatusChange(taskID uint, from, to string) {
+	comment := statusChangeComment(taskID, from, to)
+	withRetry(func() error { return DB.Create(&comment).Error })
+}
+
+// statusChangeComment is the timeline entry of a task moving from one status to another
+func statusChangeComment(taskID uint, from, to string) models.Comment {
+	return models.Comment{
+		TaskID: taskID,
+		Kind:   models.CommentStatus,
+		Body:   fmt.Sprintf("%s → %s", from, to),
+	}
+}
+
+// GetComments returns a task's comments and status changes, oldest first
+func GetComments(taskID uint) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := DB.Where("task_id = ?", taskID).Order("created_at ASC, id ASC").Find(&comments).Error
+	return comments, err
+}
+
+// GetTaskActivity returns a task's history oldest first: its creation, status
+// changes, comments and sessions
+func GetTaskActivity(taskID uint) ([]ActivityItem, error) {
+	task, err := GetTaskByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := GetComments(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := GetSessionsForTask(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []ActivityItem{{At: task.CreatedAt, Kind: ActivityCreated, Text: "created"}}
+	for _, comment := range comments {
+		items = append(items, ActivityItem{At: comment.CreatedAt, Kind: comment.Kind, Text: comment.Body})
+	}
+	for _, session := range sessions {
+		text := "started a session (running)"
+		if session.FinishedAt != nil {
+			text = fmt.Sprintf("tracked %s", formatHours(time.Duration(session.DurationSeconds)*time.Second))
+		}
+		if session.Note != "" {
+			text += ": " + session.Note
+		}
+		items = append(items, ActivityItem{At: session.StartedAt, Kind: ActivitySession, Text: text})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].At.Before(items[j].At)
+	})
+	return items, nil
+}
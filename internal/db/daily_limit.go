@@ -0,0 +1,55 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// DailyLimitStatus is the configured daily work limit and the time tracked today
+type DailyLimitStatus struct {
+	Limit   time.Duration
+	Tracked time.Duration
+}
+
+// Over reports whether today's tracked time has passed the limit
+func (s DailyLimitStatus) Over() bool {
+	return s.Tracked > s.Limit
+}
+
+// Warning returns a gentle nudge to stop once the limit is passed, or "" before that
+func (s DailyLimitStatus) Warning() string {
+	if !s.Over() {
+		return ""
+	}
+	return fmt.Sprintf("🌙 %s tracked today, past your %s daily limit. Time to call it a day?",
+		formatHours(s.Tracked), formatHours(s.Limit))
+}
+
+// GetDailyLimitStatus returns today's tracked time against daily_limit from
+// the config, or nil when no limit is set. Sessions count on the day they started
+func GetDailyLimitStatus(now time.Time) (*DailyLimitStatus, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.DailyLimit == "" {
+		return nil, nil
+	}
+	limit, err := parser.ParseEstimate(cfg.DailyLimit)
+	if err != nil {
+		return nil, fmt.Errorf("daily_limit: %w", err)
+	}
+	if limit > 24*time.Hour {
+		return nil, fmt.Errorf("daily_limit: %s is more than a day", cfg.DailyLimit)
+	}
+
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	tracked, err := SumTrackedTime(TrackedTimeQuery{From: dayStart}, now)
+	if err != nil {
+		return nil, err
+	}
+	return &DailyLimitStatus{Limit: limit, Tracked: tracked.Total}, nil
+}
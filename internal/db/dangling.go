@@ -0,0 +1,132 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// defaultDanglingAfter is how long a session runs before it counts as
+// forgotten when dangling_after is unset
+const defaultDanglingAfter = 16 * time.Hour
+
+// saneSessionLength is how long a forgotten session is assumed to have lasted
+// when daily_limit is unset
+const saneSessionLength = 8 * time.Hour
+
+// DanglingSession is a session left running for longer than dangling_after,
+// with the end time it most likely had
+type DanglingSession struct {
+	Session models.Session
+	End     time.Time
+}
+
+// DanglingAfter returns dangling_after from the config, 16h when unset
+func DanglingAfter() (time.Duration, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return 0, err
+	}
+	if cfg.DanglingAfter == "" {
+		return defaultDanglingAfter, nil
+	}
+	after, err := parser.ParseEstimate(cfg.DanglingAfter)
+	if err != nil {
+		return 0, fmt.Errorf("dangling_after: %w", err)
+	}
+	return after, nil
+}
+
+// GetDanglingSessions returns the unfinished sessions started more than
+// olderThan before now, each with the end SaneSessionEnd proposes
+func GetDanglingSessions(olderThan time.Duration, now time.Time) ([]DanglingSession, error) {
+	stale, err := GetStaleActiveSessions(olderThan)
+	if err != nil {
+		return nil, err
+	}
+	dangling := make([]DanglingSession, len(stale))
+	for i, session := range stale {
+		dangling[i] = DanglingSession{Session: session, End: SaneSessionEnd(session, now)}
+	}
+	return dangling, nil
+}
+
+// SaneSessionEnd guesses when a forgotten session really ended: a workday
+// (daily_limit, or 8h) after it started, but not past the end of that day,
+// now, or the start of the break it was left paused in
+func SaneSessionEnd(session models.Session, now time.Time) time.Time {
+	length := saneSessionLength
+	if limit, err := GetDailyLimitStatus(now); err == nil && limit != nil {
+		length = limit.Limit
+	}
+	end := session.StartedAt.Add(length)
+	if day := endOfDay(session.StartedAt); end.After(day) {
+		end = day
+	}
+	if end.After(now) {
+		end = now
+	}
+	if session.PausedAt != nil && session.PausedAt.Before(end) {
+		end = *session.PausedAt
+	}
+	return end
+}
+
+// CloseSessionAt finishes a running session at end instead of now. Breaks
+// after end are dropped and one still open ends at end
+func CloseSessionAt(id uint, end time.Time) (*models.Session, error) {
+	unlock, err := lockSessions()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var session models.Session
+	if err := DB.Preload("Task").First(&session, id).Error; err != nil {
+		return nil, fmt.Errorf("session #%d not found", id)
+	}
+	if session.FinishedAt != nil {
+		return nil, fmt.Errorf("session #%d is already finished", id)
+	}
+	if !end.After(session.StartedAt) || end.After(time.Now()) {
+		return nil, fmt.Errorf("the end time must be after the session started (%s) and not in the future",
+			session.StartedAt.Format("02/01/2006 15:04"))
+	}
+
+	err = withRetry(func() error {
+		return DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("session_id = ? AND started_at >= ?", session.ID, end).Delete(&models.SessionPause{}).Error; err != nil {
+				return err
+			}
+			err := tx.Model(&models.SessionPause{}).
+				Where("session_id = ? AND (ended_at IS NULL OR ended_at > ?)", session.ID, end).
+				Update("ended_at", end).Error
+			if err != nil {
+				return err
+			}
+			var pauses []models.SessionPause
+			if err := tx.Where("session_id = ?", session.ID).Find(&pauses).Error; err != nil {
+				return err
+			}
+
+			paused := pausedBetween(pauses, session.StartedAt, end)
+			session.PausedSeconds = int(paused.Seconds())
+			session.PausedAt = nil
+			session.DurationSeconds = int((end.Sub(session.StartedAt) - paused).Seconds())
+			session.FinishedAt = &end
+			return tx.Omit("Task").Save(&session).Error
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	emit(Event{Name: EventSessionStop, Task: &session.Task, Session: &session})
+
+	return &session, nil
+}
@@ -1,14 +1,17 @@
 package db
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/glebarez/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/balkashynov/wrok/internal/config"
 	"github.com/balkashynov/wrok/internal/models"
 )
 
@@ -20,51 +23,128 @@ func Initialize() error {
 	if err != nil {
 		return fmt.Errorf("failed to get database path: %w", err)
 	}
+	if databaseFile != "" {
+		if dbPath, err = stageDatabaseFile(databaseFile); err != nil {
+			return err
+		}
+	}
 
-	// Ensure the directory exists
-	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+	if readOnly {
+		// Read-only mode never creates anything
+		if _, err := os.Stat(dbPath); err != nil {
+			return fmt.Errorf("no database to open read-only: %w", err)
+		}
+	} else if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
 		return fmt.Errorf("failed to create wrok directory: %w", err)
 	}
 
-	// Open database connection
-	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{
+	// Open database connection; WAL and a busy timeout let several wrok processes share it
+	db, err := gorm.Open(sqlite.Open(dataSource(dbPath)), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent), // Quiet by default
 	})
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	if err := openUTC(db); err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if err := watchWrites(db); err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if err := refuseWrites(db); err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
 	DB = db
 
-	// Run auto-migrations
-	if err := runMigrations(); err != nil {
+	// A read-only database can't be migrated, so it must be up to date already
+	if readOnly {
+		applied = nil
+		outdated, err := schemaOutdated()
+		if err != nil {
+			return fmt.Errorf("failed to read the schema: %w", err)
+		}
+		if outdated {
+			return errors.New("the database needs migrating to this version of wrok: run any wrok command once without --read-only")
+		}
+		return nil
+	}
+
+	// Bring the schema up to date; a new database has nothing worth reporting
+	fresh := !DB.Migrator().HasTable(&models.Task{})
+	applied, err = runMigrations()
+	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
+	if fresh {
+		applied = nil
+	}
+	if err := recordActor(DB); err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
 
 	return nil
 }
 
+// DataDir returns the wrok data directory (~/.wrok)
+func DataDir() (string, error) {
+	return config.Dir()
+}
+
 // getDatabasePath returns the path to the SQLite database file
 func getDatabasePath() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	dataDir, err := DataDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(homeDir, ".wrok", "wrok.db"), nil
+	return filepath.Join(dataDir, "wrok.db"), nil
 }
 
-// runMigrations creates/updates the database schema
-func runMigrations() error {
-	return DB.AutoMigrate(
+// ChangeStamp returns a value that changes whenever the database is written, by
+// this process or another one. With WAL, writes land in the -wal file first
+func ChangeStamp() (string, error) {
+	dbPath, err := getDatabasePath()
+	if err != nil {
+		return "", err
+	}
+
+	var stamp strings.Builder
+	for _, path := range []string{dbPath, dbPath + "-wal"} {
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&stamp, "%d:%d;", info.Size(), info.ModTime().UnixNano())
+	}
+	return stamp.String(), nil
+}
+
+// schemaModels returns every model that has a table in the database
+func schemaModels() []interface{} {
+	return []interface{}{
 		&models.Task{},
 		&models.Tag{},
 		&models.TaskTag{},
 		&models.Session{},
-	)
+		&models.SessionPause{},
+		&models.Setting{},
+		&models.TaskFile{},
+		&models.Comment{},
+		&models.Goal{},
+		&models.GoalPeriod{},
+		&models.AuditEntry{},
+	}
 }
 
-// Close closes the database connection
+// Close closes the database connection, and removes the scratch copy of a
+// database opened with SetDatabaseFile
 func Close() error {
+	if stagedFile != "" {
+		defer os.Remove(stagedFile)
+	}
 	if DB != nil {
 		sqlDB, err := DB.DB()
 		if err != nil {
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"sort"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// digestLookahead is how far ahead the digest lists upcoming deadlines
+const digestLookahead = 7 * 24 * time.Hour
+
+// ProjectTime is the time tracked on one project; Name is "" for tasks without one
+type ProjectTime struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Digest is the weekly summary 'wrok digest' renders through its template
+type Digest struct {
+	Start     time.Time // first day of the week
+	End       time.Time // first day of the next week
+	Total     time.Duration
+	Sessions  int
+	Projects  []ProjectTime // most time first
+	Completed []models.Task // done in the week, in the order they were finished
+	Upcoming  []models.Task // todo tasks due within a week of now, soonest first
+	Overdue   []models.Task // todo tasks past their due date, oldest first
+}
+
+// GetDigest sums up the week containing at: time per project, tasks completed,
+// and the deadlines coming up from now. A running session counts up to now
+func GetDigest(at, now time.Time) (*Digest, error) {
+	firstDay := time.Monday
+	if cfg, err := config.Load(); err == nil {
+		firstDay = cfg.FirstWeekday()
+	}
+	start := budgetPeriodStart(parser.BudgetWeek, at, firstDay)
+	d := &Digest{Start: start, End: goalPeriodEnd(parser.BudgetWeek, start)}
+
+	sessions, err := GetTrackedSessions(TrackedTimeQuery{From: d.Start, To: d.End})
+	if err != nil {
+		return nil, err
+	}
+	byProject := make(map[string]time.Duration)
+	for _, session := range sessions {
+		duration := session.Elapsed(now)
+		d.Total += duration
+		d.Sessions++
+		byProject[session.Task.Project] += duration
+	}
+	for name, duration := range byProject {
+		d.Projects = append(d.Projects, ProjectTime{Name: name, Duration: duration})
+	}
+	sort.Slice(d.Projects, func(i, j int) bool {
+		if d.Projects[i].Duration != d.Projects[j].Duration {
+			return d.Projects[i].Duration > d.Projects[j].Duration
+		}
+		return d.Projects[i].Name < d.Projects[j].Name
+	})
+
+	if d.Completed, err = GetTasksCompletedInRange(d.Start, d.End.Add(-time.Second)); err != nil {
+		return nil, err
+	}
+
+	due, err := GetOpenTasksDueBy(now.Add(digestLookahead))
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range due {
+		if task.Due.Before(now) {
+			d.Overdue = append(d.Overdue, task)
+		} else {
+			d.Upcoming = append(d.Upcoming, task)
+		}
+	}
+	return d, nil
+}
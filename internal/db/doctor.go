@@ -0,0 +1,222 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/models"
+	"gorm.io/gorm"
+)
+
+// DoctorReport holds the results of a database health check
+type DoctorReport struct {
+	OrphanedSessions []models.Session // sessions whose task no longer exists
+	StaleSessions    []models.Session // active sessions running for too long
+	DanglingTaskTags []models.TaskTag // join rows pointing at missing tasks or tags
+	DuplicateTags    [][]models.Tag   // groups of tags differing only by case (or separators, under [tags] separator)
+	MisnamedTags     []models.Tag     // tags whose name breaks the [tags] naming policy
+	SchemaDrift      []string         // missing tables/columns, pending migrations
+	SchemaVersion    int              // highest applied migration
+}
+
+// HasIssues reports whether any check found a problem
+func (r *DoctorReport) HasIssues() bool {
+	return len(r.OrphanedSessions) > 0 ||
+		len(r.StaleSessions) > 0 ||
+		len(r.DanglingTaskTags) > 0 ||
+		len(r.DuplicateTags) > 0 ||
+		len(r.MisnamedTags) > 0 ||
+		len(r.SchemaDrift) > 0
+}
+
+// RunDoctor inspects the database for inconsistencies
+func RunDoctor(staleAfter time.Duration) (*DoctorReport, error) {
+	report := &DoctorReport{}
+
+	// Sessions pointing at missing (or soft-deleted) tasks
+	err := DB.Where("task_id NOT IN (?)", DB.Model(&models.Task{}).Select("id")).
+		Order("id ASC").
+		Find(&report.OrphanedSessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to check orphaned sessions: %w", err)
+	}
+
+	// Active sessions older than the threshold
+	stale, err := GetStaleActiveSessions(staleAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check stale sessions: %w", err)
+	}
+	report.StaleSessions = stale
+
+	// task_tags rows whose task or tag is gone
+	err = DB.Table("task_tags").
+		Where("task_id NOT IN (?) OR tag_id NOT IN (?)",
+			DB.Table("tasks").Select("id").Where("deleted_at IS NULL"),
+			DB.Table("tags").Select("id")).
+		Find(&report.DanglingTaskTags).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to check task tags: %w", err)
+	}
+
+	// Tags that are the same tag by tagKey, and tags named against the policy
+	var tags []models.Tag
+	if err := DB.Order("id ASC").Find(&tags).Error; err != nil {
+		return nil, fmt.Errorf("failed to check tags: %w", err)
+	}
+	groups := make(map[string][]models.Tag)
+	var order []string
+	for _, tag := range tags {
+		key := tagKey(tag.Name)
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], tag)
+	}
+	for _, key := range order {
+		if len(groups[key]) > 1 {
+			report.DuplicateTags = append(report.DuplicateTags, groups[key])
+		}
+		// Merging keeps the oldest tag, so that is the one that may need renaming
+		if kept := groups[key][0]; kept.Name != NormalizeTagName(kept.Name) {
+			report.MisnamedTags = append(report.MisnamedTags, kept)
+		}
+	}
+
+	// Schema drift: every model table and column must exist, every migration must have run
+	report.SchemaDrift, err = checkSchemaDrift()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check schema: %w", err)
+	}
+	current, latest, err := SchemaVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check schema version: %w", err)
+	}
+	report.SchemaVersion = current
+	if current < latest {
+		report.SchemaDrift = append(report.SchemaDrift, fmt.Sprintf("schema at version %d, migrations up to %d pending", current, latest))
+	}
+
+	return report, nil
+}
+
+// checkSchemaDrift compares the models with the actual database schema
+func checkSchemaDrift() ([]string, error) {
+	var drift []string
+	migrator := DB.Migrator()
+
+	for _, model := range schemaModels() {
+		stmt := &gorm.Statement{DB: DB}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(model) {
+			drift = append(drift, fmt.Sprintf("missing table %s", table))
+			continue
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			if !migrator.HasColumn(model, field.DBName) {
+				drift = append(drift, fmt.Sprintf("missing column %s.%s", table, field.DBName))
+			}
+		}
+	}
+
+	return drift, nil
+}
+
+// FixDoctorIssues repairs the problems found by RunDoctor
+func FixDoctorIssues(report *DoctorReport) error {
+	// Schema first so the remaining fixes run against a complete schema
+	if len(report.SchemaDrift) > 0 {
+		if _, err := runMigrations(); err != nil {
+			return fmt.Errorf("failed to migrate schema: %w", err)
+		}
+	}
+
+	return DB.Transaction(func(tx *gorm.DB) error {
+		for _, session := range report.OrphanedSessions {
+			if err := tx.Unscoped().Delete(&models.Session{}, session.ID).Error; err != nil {
+				return fmt.Errorf("failed to delete orphaned session #%d: %w", session.ID, err)
+			}
+		}
+
+		for _, session := range report.StaleSessions {
+			finished := endOfDay(session.StartedAt)
+			if finished.After(time.Now()) {
+				finished = time.Now()
+			}
+			// A session left paused ends where its break began
+			if session.PausedAt != nil && session.PausedAt.Before(finished) {
+				finished = *session.PausedAt
+			}
+			err := tx.Model(&models.Session{}).Where("id = ?", session.ID).Updates(map[string]interface{}{
+				"finished_at":      finished,
+				"duration_seconds": int(finished.Sub(session.StartedAt).Seconds()) - session.PausedSeconds,
+				"paused_at":        nil,
+			}).Error
+			if err != nil {
+				return fmt.Errorf("failed to close stale session #%d: %w", session.ID, err)
+			}
+			err = tx.Model(&models.SessionPause{}).Where("session_id = ? AND ended_at IS NULL", session.ID).Update("ended_at", finished).Error
+			if err != nil {
+				return fmt.Errorf("failed to close stale session #%d: %w", session.ID, err)
+			}
+		}
+
+		for _, tt := range report.DanglingTaskTags {
+			if err := tx.Where("task_id = ? AND tag_id = ?", tt.TaskID, tt.TagID).Delete(&models.TaskTag{}).Error; err != nil {
+				return fmt.Errorf("failed to delete dangling task tag: %w", err)
+			}
+		}
+
+		for _, group := range report.DuplicateTags {
+			if err := mergeTags(tx, group[0], group[1:]); err != nil {
+				return err
+			}
+		}
+
+		// After merging, the normalized names are free to take
+		for _, tag := range report.MisnamedTags {
+			name := NormalizeTagName(tag.Name)
+			if err := tx.Model(&models.Tag{}).Where("id = ?", tag.ID).Update("name", name).Error; err != nil {
+				return fmt.Errorf("failed to rename tag %q to %q: %w", tag.Name, name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// mergeTags moves all task associations of the duplicates onto keep and removes the duplicates
+func mergeTags(tx *gorm.DB, keep models.Tag, duplicates []models.Tag) error {
+	for _, dup := range duplicates {
+		// Re-point associations, skipping tasks that already carry the kept tag
+		err := tx.Exec(`INSERT OR IGNORE INTO task_tags (task_id, tag_id)
+			SELECT task_id, ? FROM task_tags WHERE tag_id = ?`, keep.ID, dup.ID).Error
+		if err != nil {
+			return fmt.Errorf("failed to merge tag %q into %q: %w", dup.Name, keep.Name, err)
+		}
+		if err := tx.Where("tag_id = ?", dup.ID).Delete(&models.TaskTag{}).Error; err != nil {
+			return fmt.Errorf("failed to merge tag %q into %q: %w", dup.Name, keep.Name, err)
+		}
+		if err := tx.Delete(&models.Tag{}, dup.ID).Error; err != nil {
+			return fmt.Errorf("failed to delete tag %q: %w", dup.Name, err)
+		}
+	}
+	return nil
+}
+
+// Vacuum rebuilds the SQLite file, reclaiming unused space
+func Vacuum() error {
+	return DB.Exec("VACUUM").Error
+}
+
+// endOfDay returns 23:59:59 on the day of t
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, t.Location())
+}
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// Event names emitted when tasks and sessions change
+const (
+	EventTaskCreated  = "task.created"
+	EventTaskDone     = "task.done"
+	EventTaskArchived = "task.archived"
+	EventSessionStart = "session.started"
+	EventSessionStop  = "session.stopped"
+)
+
+// Event describes a change to a task or session
+type Event struct {
+	Name    string
+	Task    *models.Task
+	Session *models.Session // set for session events
+}
+
+// eventHandlers are called, in order, for every emitted event
+var eventHandlers []func(Event)
+
+// Subscribe registers fn to be called after every task/session event
+func Subscribe(fn func(Event)) {
+	eventHandlers = append(eventHandlers, fn)
+}
+
+// emit notifies all subscribers of an event
+func emit(event Event) {
+	for _, handler := range eventHandlers {
+		handler(event)
+	}
+}
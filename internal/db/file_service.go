@@ -0,0 +1,121 @@
+package db
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// AttachFile attaches the file at path to a task. With copyFile the file is copied
+// into ~/.wrok/attachments/<task-id>/ so the attachment survives the original moving
+func AttachFile(taskID uint, path string, copyFile bool) (*models.TaskFile, error) {
+	if _, err := GetTaskByID(taskID); err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path '%s': %w", path, err)
+	}
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot attach '%s': %w", path, err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("cannot attach '%s': is a directory", path)
+	}
+
+	if copyFile {
+		absPath, err = copyAttachment(taskID, absPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	file := models.TaskFile{TaskID: taskID, Path: absPath, Copied: copyFile}
+	if err := withRetry(func() error { return DB.Create(&file).Error }); err != nil {
+		return nil, err
+	}
+
+	return &file, nil
+}
+
+// GetTaskFiles returns the files attached to a task, oldest first. Their position
+// in the list (from 1) is the number used by 'wrok open <id> --file <n>'
+func GetTaskFiles(taskID uint) ([]models.TaskFile, error) {
+	var files []models.TaskFile
+	err := DB.Where("task_id = ?", taskID).Order("id ASC").Find(&files).Error
+	return files, err
+}
+
+// GetTaskFile returns the n-th (from 1) file attached to a task
+func GetTaskFile(taskID uint, n int) (*models.TaskFile, error) {
+	files, err := GetTaskFiles(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("task #%d has no attached files", taskID)
+	}
+	if n < 1 || n > len(files) {
+		return nil, fmt.Errorf("task #%d has %d attached file(s), no file %d", taskID, len(files), n)
+	}
+	return &files[n-1], nil
+}
+
+// attachmentsDir returns the directory holding copies of a task's attachments
+func attachmentsDir(taskID uint) (string, error) {
+	dataDir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "attachments", strconv.FormatUint(uint64(taskID), 10)), nil
+}
+
+// copyAttachment copies src into the task's attachments directory, numbering the
+// name ("spec (2).pdf") if a file with the same name was attached before
+func copyAttachment(taskID uint, src string) (string, error) {
+	dir, err := attachmentsDir(taskID)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create attachments directory: %w", err)
+	}
+
+	base := filepath.Base(src)
+	ext := filepath.Ext(base)
+	dst := filepath.Join(dir, base)
+	for n := 2; ; n++ {
+		if _, err := os.Stat(dst); os.IsNotExist(err) {
+			break
+		}
+		dst = filepath.Join(dir, fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(base, ext), n, ext))
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return "", fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return "", fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return "", fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+	if err := out.Close(); err != nil {
+		return "", fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+
+	return dst, nil
+}
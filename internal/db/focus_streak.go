@@ -0,0 +1,120 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// defaultMinBreak is the break that ends a focus streak when min_break is unset
+const defaultMinBreak = 15 * time.Minute
+
+// FocusStreak is the time worked without a proper break, against max_focus
+type FocusStreak struct {
+	Limit   time.Duration // max_focus
+	Break   time.Duration // min_break: a gap or pause at least this long ends the streak
+	Focused time.Duration // worked since the last break
+	Resting bool          // no session is running, or it is paused
+	Since   time.Time     // when the current rest began; zero while working
+}
+
+// Over reports whether the streak has reached max_focus
+func (s FocusStreak) Over() bool {
+	return s.Focused >= s.Limit
+}
+
+// BreakLeft returns how much longer the break must last before a new session
+// may start: nothing until the streak is over, the whole break while working
+func (s FocusStreak) BreakLeft(now time.Time) time.Duration {
+	if !s.Over() {
+		return 0
+	}
+	if !s.Resting {
+		return s.Break
+	}
+	return max(s.Since.Add(s.Break).Sub(now), 0)
+}
+
+// Warning explains why a new session has to wait, or "" when it doesn't
+func (s FocusStreak) Warning(now time.Time) string {
+	left := s.BreakLeft(now)
+	if left <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("🧘 %s of focus without a break (max_focus is %s). Take a break: %s to go.",
+		formatHours(s.Focused), formatHours(s.Limit), formatHours(left.Round(time.Minute)))
+}
+
+// GetFocusStreak returns the time worked since the last break of at least
+// min_break, across sessions, or nil when max_focus isn't set. Gaps between
+// sessions and pauses within them both count as breaks
+func GetFocusStreak(now time.Time) (*FocusStreak, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxFocus == "" {
+		return nil, nil
+	}
+	limit, err := parser.ParseEstimate(cfg.MaxFocus)
+	if err != nil {
+		return nil, fmt.Errorf("max_focus: %w", err)
+	}
+	streak := &FocusStreak{Limit: limit, Break: defaultMinBreak, Resting: true, Since: now}
+	if cfg.MinBreak != "" {
+		if streak.Break, err = parser.ParseEstimate(cfg.MinBreak); err != nil {
+			return nil, fmt.Errorf("min_break: %w", err)
+		}
+	}
+
+	// A streak can't outlast a day, so that is as far back as it needs looking
+	var sessions []models.Session
+	err = DB.Where("started_at >= ? OR finished_at IS NULL", now.Add(-24*time.Hour)).
+		Order("started_at DESC").Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk back from now until a gap or a pause is long enough to be a break
+	next := now
+	for i, session := range sessions {
+		end := now
+		switch {
+		case session.FinishedAt != nil:
+			end = *session.FinishedAt
+		case session.PausedAt != nil:
+			end = *session.PausedAt
+		}
+		if i == 0 {
+			streak.Resting = session.FinishedAt != nil || session.PausedAt != nil
+			streak.Since = end
+			if !streak.Resting {
+				streak.Since = time.Time{}
+			}
+		}
+		if next.Sub(end) >= streak.Break {
+			break
+		}
+
+		pauses, err := GetSessionPauses(session.ID)
+		if err != nil {
+			return nil, err
+		}
+		var restEnded *time.Time
+		for _, pause := range pauses {
+			if pause.EndedAt != nil && pause.EndedAt.Sub(pause.StartedAt) >= streak.Break {
+				restEnded = pause.EndedAt
+			}
+		}
+		if restEnded != nil {
+			streak.Focused += end.Sub(*restEnded) - pausedBetween(pauses, *restEnded, end)
+			break
+		}
+		streak.Focused += session.Elapsed(now)
+		next = session.StartedAt
+	}
+	return streak, nil
+}
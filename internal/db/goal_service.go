@@ -0,0 +1,210 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// maxGoalHistory is how many finished periods a goal's history is filled in for at most
+const maxGoalHistory = 52
+
+// GoalStatus is a goal and the time tracked towards it in the current period
+type GoalStatus struct {
+	Goal        models.Goal
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Target      time.Duration
+	Tracked     time.Duration
+}
+
+// Ratio returns the share of the goal reached, 1.0 meaning exactly reached
+func (g GoalStatus) Ratio() float64 {
+	if g.Target <= 0 {
+		return 0
+	}
+	return float64(g.Tracked) / float64(g.Target)
+}
+
+// GoalLabel names what a goal counts: "overall", "@project" or "#tag"
+func GoalLabel(goal models.Goal) string {
+	switch goal.Scope {
+	case models.GoalProject:
+		return "@" + goal.Target
+	case models.GoalTag:
+		return "#" + goal.Target
+	}
+	return "overall"
+}
+
+// SetGoal creates or replaces the goal for a scope and target
+func SetGoal(scope, target string, amount time.Duration, period string) (*models.Goal, error) {
+	var goal models.Goal
+	err := DB.Where("scope = ? AND target = ?", scope, target).First(&goal).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	goal.Scope = scope
+	goal.Target = target
+	goal.TargetSeconds = int(amount.Seconds())
+	goal.Period = period
+
+	if err := withRetry(func() error { return DB.Save(&goal).Error }); err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+// ClearGoal removes a goal together with its history
+func ClearGoal(scope, target string) error {
+	var goal models.Goal
+	if err := DB.Where("scope = ? AND target = ?", scope, target).First(&goal).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("no %s goal set", GoalLabel(models.Goal{Scope: scope, Target: target}))
+		}
+		return err
+	}
+
+	return withRetry(func() error {
+		return DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("goal_id = ?", goal.ID).Delete(&models.GoalPeriod{}).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&goal).Error
+		})
+	})
+}
+
+// GetGoalStatuses returns every goal with its progress in the current period,
+// overall first, then projects and tags by name. Periods that ended since the
+// last call are recorded in the goal history first
+func GetGoalStatuses(now time.Time) ([]GoalStatus, error) {
+	var goals []models.Goal
+	if err := DB.Order("scope ASC, target ASC").Find(&goals).Error; err != nil {
+		return nil, err
+	}
+
+	firstDay := time.Monday
+	if cfg, err := config.Load(); err == nil {
+		firstDay = cfg.FirstWeekday()
+	}
+
+	var statuses []GoalStatus
+	for _, goal := range goals {
+		if err := recordGoalHistory(goal, now, firstDay); err != nil {
+			return nil, err
+		}
+
+		start := budgetPeriodStart(goal.Period, now, firstDay)
+		tracked, err := goalTrackedTime(goal, start, now, now)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, GoalStatus{
+			Goal:        goal,
+			PeriodStart: start,
+			PeriodEnd:   goalPeriodEnd(goal.Period, start),
+			Target:      time.Duration(goal.TargetSeconds) * time.Second,
+			Tracked:     tracked,
+		})
+	}
+	return statuses, nil
+}
+
+// GetGoalHistory returns the finished periods of a goal, newest first
+func GetGoalHistory(goalID uint, limit int) ([]models.GoalPeriod, error) {
+	var periods []models.GoalPeriod
+	query := DB.Where("goal_id = ?", goalID).Order("period_start DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&periods).Error; err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+// recordGoalHistory stores the result of every period of a goal that ended
+// before now and isn't recorded yet, going back to the period the goal was set in
+func recordGoalHistory(goal models.Goal, now time.Time, firstDay time.Weekday) error {
+	current := budgetPeriodStart(goal.Period, now, firstDay)
+	first := budgetPeriodStart(goal.Period, goal.CreatedAt.In(now.Location()), firstDay)
+
+	var recorded []time.Time
+	if err := DB.Model(&models.GoalPeriod{}).Where("goal_id = ?", goal.ID).Pluck("period_start", &recorded).Error; err != nil {
+		return err
+	}
+	done := make(map[int64]bool)
+	for _, start := range recorded {
+		done[start.Unix()] = true
+	}
+
+	var missing []models.GoalPeriod
+	for start := current; len(missing) < maxGoalHistory; {
+		start = goalPeriodBefore(goal.Period, start)
+		if start.Before(first) {
+			break
+		}
+		if done[start.Unix()] {
+			continue
+		}
+
+		end := goalPeriodEnd(goal.Period, start)
+		tracked, err := goalTrackedTime(goal, start, end, now)
+		if err != nil {
+			return err
+		}
+		missing = append(missing, models.GoalPeriod{
+			GoalID:         goal.ID,
+			PeriodStart:    start,
+			PeriodEnd:      end,
+			TargetSeconds:  goal.TargetSeconds,
+			TrackedSeconds: int(tracked.Seconds()),
+		})
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return withRetry(func() error { return DB.Create(&missing).Error })
+}
+
+// goalPeriodEnd returns when the week or month starting at start ends
+func goalPeriodEnd(period string, start time.Time) time.Time {
+	if period == parser.BudgetMonth {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 7)
+}
+
+// goalPeriodBefore returns the start of the week or month before the one starting at start
+func goalPeriodBefore(period string, start time.Time) time.Time {
+	if period == parser.BudgetMonth {
+		return start.AddDate(0, -1, 0)
+	}
+	return start.AddDate(0, 0, -7)
+}
+
+// goalTrackedTime sums the time tracked towards a goal in sessions started
+// between start and end; a running session counts up to now
+func goalTrackedTime(goal models.Goal, start, end, now time.Time) (time.Duration, error) {
+	query := TrackedTimeQuery{From: start, To: end}
+	switch goal.Scope {
+	case models.GoalProject:
+		query.Project = goal.Target
+	case models.GoalTag:
+		query.Tag = goal.Target
+	}
+
+	tracked, err := SumTrackedTime(query, now)
+	if err != nil {
+		return 0, err
+	}
+	return tracked.Total, nil
+}
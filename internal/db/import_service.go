@@ -0,0 +1,197 @@
+package db
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// untitledEntry is the task title for imported entries without a description
+const untitledEntry = "(no description)"
+
+// ImportedEntry is a time entry from another time tracker
+type ImportedEntry struct {
+	Description string
+	Project     string
+	Tags        []string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+}
+
+// ImportResult counts what ImportEntries did (or would do)
+type ImportResult struct {
+	Sessions     int // sessions created
+	Duplicates   int // entries skipped because a session already starts at the same second
+	TasksCreated int
+	TasksMatched int // existing tasks that got sessions
+}
+
+// ImportEntries creates a session for every entry, on the task with the entry's
+// description as title (case-insensitively) and the same project. Missing tasks
+// are created with the entry's tags. Entries starting in the same second as an
+// existing session are skipped, so importing the same file twice is harmless.
+// With dryRun nothing is written
+func ImportEntries(entries []ImportedEntry, dryRun bool) (*ImportResult, error) {
+	result := &ImportResult{}
+	taskIDs := make(map[string]uint) // 0 for tasks a dry run would create
+	matched := make(map[uint]bool)
+	seen := make(map[int64]bool)
+
+	for _, entry := range entries {
+		start := entry.StartedAt.Truncate(time.Second)
+		if seen[start.Unix()] {
+			result.Duplicates++
+			continue
+		}
+		seen[start.Unix()] = true
+
+		var existing int64
+		err := DB.Model(&models.Session{}).
+			Where("started_at >= ? AND started_at < ?", start, start.Add(time.Second)).
+			Count(&existing).Error
+		if err != nil {
+			return nil, err
+		}
+		if existing > 0 {
+			result.Duplicates++
+			continue
+		}
+
+		title := strings.TrimSpace(entry.Description)
+		if title == "" {
+			title = untitledEntry
+		}
+		key := strings.ToLower(title) + "\x00" + entry.Project
+		taskID, known := taskIDs[key]
+		if !known {
+			task, err := findImportTask(title, entry.Project)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case task != nil:
+				taskID = task.ID
+			case dryRun:
+				result.TasksCreated++
+			default:
+				created, err := CreateTask(CreateTaskRequest{Title: title, Project: entry.Project, Tags: entry.Tags})
+				if err != nil {
+					return nil, err
+				}
+				taskID = created.ID
+				result.TasksCreated++
+			}
+			taskIDs[key] = taskID
+			if task != nil && !matched[task.ID] {
+				matched[task.ID] = true
+				result.TasksMatched++
+			}
+		}
+
+		result.Sessions++
+		if dryRun {
+			continue
+		}
+		finished := entry.FinishedAt.Truncate(time.Second)
+		session := models.Session{
+			TaskID:          taskID,
+			StartedAt:       start,
+			FinishedAt:      &finished,
+			DurationSeconds: int(finished.Sub(start).Seconds()),
+		}
+		if err := withRetry(func() error { return DB.Create(&session).Error }); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// findImportTask returns the task an imported entry belongs to, or nil if there is
+// none. Open tasks win over closed ones
+func findImportTask(title, project string) (*models.Task, error) {
+	var tasks []models.Task
+	err := DB.Where("LOWER(title) = LOWER(?) AND project = ?", title, project).
+		Order("CASE WHEN status NOT IN ('done', 'archived') THEN 0 ELSE 1 END, id ASC").
+		Limit(1).
+		Find(&tasks).Error
+	if err != nil || len(tasks) == 0 {
+		return nil, err
+	}
+	return &tasks[0], nil
+}
+
+// ImportedTask is a task read from another todo format (org-mode, TaskPaper)
+type ImportedTask struct {
+	Title    string
+	Project  string
+	Tags     []string
+	Priority int // a level of the priority scheme, 0 for none
+	Due      *time.Time
+	Note     string
+	Done     bool
+	DoneAt   *time.Time // when it was completed, if the file says; now otherwise
+}
+
+// TaskImportResult counts what ImportTasks did (or would do)
+type TaskImportResult struct {
+	Created    int
+	Done       int // created tasks that were already completed
+	Duplicates int // tasks skipped because one with the same title and project exists
+}
+
+// ImportTasks creates the imported tasks, skipping those with the title
+// (case-insensitively) and project of an existing task, so importing the same
+// file twice is harmless. With dryRun nothing is written
+func ImportTasks(tasks []ImportedTask, dryRun bool) (*TaskImportResult, error) {
+	result := &TaskImportResult{}
+	seen := make(map[string]bool)
+
+	for _, imported := range tasks {
+		key := strings.ToLower(imported.Title) + "\x00" + imported.Project
+		existing, err := findImportTask(imported.Title, imported.Project)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil || seen[key] {
+			result.Duplicates++
+			continue
+		}
+		seen[key] = true
+
+		result.Created++
+		if imported.Done {
+			result.Done++
+		}
+		if dryRun {
+			continue
+		}
+
+		task, err := CreateTask(CreateTaskRequest{
+			Title:    imported.Title,
+			Project:  imported.Project,
+			Tags:     imported.Tags,
+			Priority: strconv.Itoa(imported.Priority),
+			Note:     imported.Note,
+			DueDate:  imported.Due,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !imported.Done {
+			continue
+		}
+		doneAt := time.Now()
+		if imported.DoneAt != nil {
+			doneAt = *imported.DoneAt
+		}
+		err = withRetry(func() error {
+			return DB.Model(task).Updates(map[string]interface{}{"status": "done", "done_at": doneAt}).Error
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,181 @@
+package db
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// benchTasks is how many tasks the benchmark database holds: the size at which
+// the default 'wrok ls' should still return its first page in under 50ms
+const benchTasks = 50000
+
+var (
+	benchOnce sync.Once
+	benchErr  error
+)
+
+// TestMain runs the package against a fresh database in a scratch home directory
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "wrok-bench-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv("HOME", home)
+	if err := Initialize(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	Close()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
+
+// seedBenchDB fills the database on first use with benchTasks tasks across
+// 20 projects, each with two of 50 tags
+func seedBenchDB(tb testing.TB) {
+	tb.Helper()
+	benchOnce.Do(func() {
+		benchErr = DB.Exec(`
+			INSERT INTO tags (name)
+			WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 50)
+			SELECT 'tag' || i FROM n;
+
+			INSERT INTO tasks (title, project, status, priority, pinned, created_at, updated_at, due)
+			WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < ?)
+			SELECT 'Task ' || i, 'project' || (i % 20),
+				CASE WHEN i % 3 = 0 THEN 'done' ELSE 'todo' END, i % 4, i % 500 = 0,
+				datetime('now', '-' || (i % 365) || ' days') || '+00:00',
+				datetime('now', '-' || (i % 365) || ' days') || '+00:00',
+				CASE WHEN i % 4 = 0 THEN datetime('now', '+' || (i % 30) || ' days') || '+00:00' END
+			FROM n;
+
+			INSERT INTO task_tags (task_id, tag_id)
+			SELECT id, 1 + id % 50 FROM tasks
+			UNION ALL SELECT id, 1 + (id + 7) % 50 FROM tasks;`, benchTasks).Error
+	})
+	if benchErr != nil {
+		tb.Fatal(benchErr)
+	}
+	if b, ok := tb.(*testing.B); ok {
+		b.ResetTimer()
+	}
+}
+
+// listBudget is how long the first page of a listing may take at benchTasks
+// tasks. The test allows listBudgetSlack times that, for slow or busy CI machines
+const (
+	listBudget      = 50 * time.Millisecond
+	listBudgetSlack = 2
+)
+
+// TestListBudget fails when the first page of plain 'wrok ls' or of the TUI
+// takes more than the budget, counting the fastest of a few runs
+func TestListBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("seeds the 50k task benchmark database")
+	}
+	seedBenchDB(t)
+
+	listings := []struct {
+		name string
+		list func() error
+	}{
+		{"wrok ls", func() error {
+			_, err := GetTasksWithOptions(TaskQueryOptions{OrderBy: "id DESC", Limit: 100, HideSnoozed: true})
+			return err
+		}},
+		{"TUI first page", func() error {
+			opts := TaskQueryOptions{OrderBy: OrderUrgency, HideArchived: true, HideSnoozed: true}
+			if _, err := CountTasks(opts); err != nil {
+				return err
+			}
+			opts.Limit = 40
+			ids, err := TaskIDs(opts)
+			if err != nil {
+				return err
+			}
+			_, err = GetTasksByIDs(ids)
+			return err
+		}},
+	}
+	for _, listing := range listings {
+		fastest := time.Duration(-1)
+		for run := 0; run < 5; run++ {
+			start := time.Now()
+			if err := listing.list(); err != nil {
+				t.Fatalf("%s: %v", listing.name, err)
+			}
+			if took := time.Since(start); fastest < 0 || took < fastest {
+				fastest = took
+			}
+		}
+		if fastest > listBudget*listBudgetSlack {
+			t.Errorf("%s took %v at %d tasks, over the %v budget", listing.name, fastest, benchTasks, listBudget)
+		}
+	}
+}
+
+// BenchmarkListDefault is the first page of plain 'wrok ls': newest first
+func BenchmarkListDefault(b *testing.B) {
+	seedBenchDB(b)
+	for i := 0; i < b.N; i++ {
+		tasks, err := GetTasksWithOptions(TaskQueryOptions{OrderBy: "id DESC", Limit: 100, HideSnoozed: true})
+		if err != nil || len(tasks) != 100 {
+			b.Fatalf("got %d tasks: %v", len(tasks), err)
+		}
+	}
+}
+
+// BenchmarkListFiltered lists the first page of open tasks with a tag in one project, by due date
+func BenchmarkListFiltered(b *testing.B) {
+	seedBenchDB(b)
+	opts := TaskQueryOptions{Status: "todo", Project: "project3", Tags: []string{"tag4"}, OrderBy: "due IS NULL, due ASC", Limit: 100, HideSnoozed: true}
+	for i := 0; i < b.N; i++ {
+		if _, err := GetTasksWithOptions(opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkListPageIDs is what the TUI runs on open: how many tasks match, and
+// the first page of them by urgency
+func BenchmarkListPageIDs(b *testing.B) {
+	seedBenchDB(b)
+	opts := TaskQueryOptions{OrderBy: OrderUrgency, HideArchived: true, HideSnoozed: true}
+	for i := 0; i < b.N; i++ {
+		if _, err := CountTasks(opts); err != nil {
+			b.Fatal(err)
+		}
+		page := opts
+		page.Limit = 40
+		ids, err := TaskIDs(page)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := GetTasksByIDs(ids); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkLoadTags loads the tags of 1000 tasks, two batches
+func BenchmarkLoadTags(b *testing.B) {
+	seedBenchDB(b)
+	var tasks []models.Task
+	if err := DB.Order("id").Limit(1000).Find(&tasks).Error; err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := loadTagsForTasks(tasks); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
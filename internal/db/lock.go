@@ -0,0 +1,92 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// busyTimeout is how long SQLite itself waits on a locked database before giving up
+const busyTimeout = 5 * time.Second
+
+// Retry and lock timings for concurrent wrok processes (e.g. a timer and a list TUI)
+const (
+	retryAttempts = 5
+	retryBackoff  = 50 * time.Millisecond
+	lockWait      = 5 * time.Second
+	lockStaleAge  = 30 * time.Second
+)
+
+// connectionPragmas are appended to the database path: WAL lets readers and a
+// writer work side by side, busy_timeout makes writers queue instead of failing
+func connectionPragmas() string {
+	return fmt.Sprintf("?_pragma=busy_timeout(%d)&_pragma=journal_mode(WAL)", busyTimeout.Milliseconds())
+}
+
+// isBusy reports whether err means another process holds the database lock
+func isBusy(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "database is locked") ||
+		strings.Contains(msg, "SQLITE_BUSY") ||
+		strings.Contains(msg, "database table is locked")
+}
+
+// withRetry runs a write, retrying with backoff while the database is busy
+func withRetry(fn func() error) error {
+	var err error
+	delay := retryBackoff
+	for attempt := 0; attempt < retryAttempts; attempt++ {
+		err = fn()
+		if !isBusy(err) {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+	return fmt.Errorf("database is busy, try again: %w", err)
+}
+
+// lockSessions takes the cross-process session lock (~/.wrok/sessions.lock) so
+// that checking for an active session and starting one happen as a single step.
+// The returned function releases the lock. In read-only mode the lock is
+// never taken, since nothing may be started anyway
+func lockSessions() (func(), error) {
+	if readOnly {
+		return nil, ErrReadOnly
+	}
+	dataDir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dataDir, "sessions.lock")
+
+	deadline := time.Now().Add(lockWait)
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(file, "%d\n", os.Getpid())
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create session lock: %w", err)
+		}
+
+		// A lock left behind by a crashed process is taken over
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > lockStaleAge {
+			os.Remove(path)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("another wrok process is starting a session (lock: %s)", path)
+		}
+		time.Sleep(retryBackoff)
+	}
+}
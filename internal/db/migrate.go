@@ -0,0 +1,205 @@
+package db
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Schema changes happen in two steps. AutoMigrate first creates the tables and
+// columns the models declare; it never drops or rewrites anything. Then every
+// pending file in migrations/ runs once, in version order, for what AutoMigrate
+// can't do: backfilling or moving data, indexes, triggers, virtual tables.
+// Applied versions are recorded in the schema_version table.
+//
+// A migration is named NNNN_description.sql, runs in a transaction and must
+// never be edited once released; fix mistakes with a new migration.
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migration is one versioned SQL migration
+type Migration struct {
+	Version int
+	Name    string // description from the file name, e.g. "session history index"
+	SQL     string
+}
+
+// MigrationRecord is a row of the schema_version table
+type MigrationRecord struct {
+	Version      int       `gorm:"primaryKey;autoIncrement:false"`
+	Name         string    `gorm:"not null"`
+	AppliedAt    time.Time `gorm:"not null"`
+	RowsAffected int64     // rows the migration inserted, updated or deleted
+}
+
+// TableName keeps the table name singular, as is usual for version tables
+func (MigrationRecord) TableName() string {
+	return "schema_version"
+}
+
+// applied holds the migrations run by the last Initialize
+var applied []MigrationRecord
+
+// AppliedMigrations returns the migrations the last Initialize ran, so the
+// caller can report them. Empty when the schema was already up to date
+func AppliedMigrations() []MigrationRecord {
+	return applied
+}
+
+// Migrations returns the embedded migrations in version order
+func Migrations() ([]Migration, error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		file := entry.Name()
+		prefix, description, ok := strings.Cut(strings.TrimSuffix(file, ".sql"), "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must look like 0001_description.sql", file)
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, file, version)
+		}
+		seen[version] = file
+
+		sql, err := migrationFiles.ReadFile(path.Join("migrations", file))
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, Migration{
+			Version: version,
+			Name:    strings.ReplaceAll(description, "_", " "),
+			SQL:     string(sql),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// SchemaVersion returns the highest applied migration version and the
+// highest embedded one; they differ while migrations are pending
+func SchemaVersion() (current, latest int, err error) {
+	if DB.Migrator().HasTable(&MigrationRecord{}) {
+		err = DB.Model(&MigrationRecord{}).Select("COALESCE(MAX(version), 0)").Scan(&current).Error
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	migrations, err := Migrations()
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].Version
+	}
+	return current, latest, nil
+}
+
+// schemaOutdated reports whether the database lacks a table or column of the
+// models, or a migration, which Initialize would add
+func schemaOutdated() (bool, error) {
+	current, latest, err := SchemaVersion()
+	if err != nil {
+		return false, err
+	}
+	if current < latest {
+		return true, nil
+	}
+
+	migrator := DB.Migrator()
+	for _, model := range schemaModels() {
+		if !migrator.HasTable(model) {
+			return true, nil
+		}
+		stmt := &gorm.Statement{DB: DB}
+		if err := stmt.Parse(model); err != nil {
+			return false, err
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" && !migrator.HasColumn(model, field.DBName) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// runMigrations brings the schema up to date and returns the SQL migrations it applied
+func runMigrations() ([]MigrationRecord, error) {
+	if err := DB.AutoMigrate(schemaModels()...); err != nil {
+		return nil, err
+	}
+	if err := DB.AutoMigrate(&MigrationRecord{}); err != nil {
+		return nil, err
+	}
+
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var records []MigrationRecord
+	if err := DB.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	done := make(map[int]bool)
+	for _, record := range records {
+		done[record.Version] = true
+	}
+
+	var ran []MigrationRecord
+	for _, migration := range migrations {
+		if done[migration.Version] {
+			continue
+		}
+		record, err := applyMigration(migration)
+		if err != nil {
+			return ran, fmt.Errorf("migration %04d (%s): %w", migration.Version, migration.Name, err)
+		}
+		ran = append(ran, record)
+	}
+	return ran, nil
+}
+
+// applyMigration runs one migration and records it, all or nothing
+func applyMigration(migration Migration) (MigrationRecord, error) {
+	record := MigrationRecord{Version: migration.Version, Name: migration.Name}
+
+	err := withRetry(func() error {
+		return DB.Transaction(func(tx *gorm.DB) error {
+			// total_changes() counts every row changed on this connection, across
+			// all the statements of the file and the triggers they fire
+			var before, after int64
+			if err := tx.Raw("SELECT total_changes()").Scan(&before).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(migration.SQL).Error; err != nil {
+				return err
+			}
+			if err := tx.Raw("SELECT total_changes()").Scan(&after).Error; err != nil {
+				return err
+			}
+
+			record.RowsAffected = after - before
+			record.AppliedAt = time.Now()
+			return tx.Create(&record).Error
+		})
+	})
+	return record, err
+}
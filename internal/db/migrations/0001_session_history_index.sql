@@ -0,0 +1,2 @@
+-- A task's session history is read in start order (details view, time reports)
+CREATE INDEX IF NOT EXISTS idx_sessions_task_started ON sessions(task_id, started_at);
@@ -0,0 +1,48 @@
+-- Timestamps used to be stored with the offset of the timezone they were written
+-- in, so rows from different timezones didn't compare or sort as text. Rewrite
+-- them in UTC, keeping the fractional seconds (offsets are whole minutes)
+
+UPDATE tasks SET created_at = strftime('%Y-%m-%d %H:%M:%S', created_at) || substr(created_at, 20, length(created_at) - 25) || '+00:00'
+  WHERE created_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND created_at NOT LIKE '%+00:00';
+UPDATE tasks SET updated_at = strftime('%Y-%m-%d %H:%M:%S', updated_at) || substr(updated_at, 20, length(updated_at) - 25) || '+00:00'
+  WHERE updated_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND updated_at NOT LIKE '%+00:00';
+UPDATE tasks SET deleted_at = strftime('%Y-%m-%d %H:%M:%S', deleted_at) || substr(deleted_at, 20, length(deleted_at) - 25) || '+00:00'
+  WHERE deleted_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND deleted_at NOT LIKE '%+00:00';
+UPDATE tasks SET due = strftime('%Y-%m-%d %H:%M:%S', due) || substr(due, 20, length(due) - 25) || '+00:00'
+  WHERE due GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND due NOT LIKE '%+00:00';
+UPDATE tasks SET done_at = strftime('%Y-%m-%d %H:%M:%S', done_at) || substr(done_at, 20, length(done_at) - 25) || '+00:00'
+  WHERE done_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND done_at NOT LIKE '%+00:00';
+UPDATE tasks SET archived_at = strftime('%Y-%m-%d %H:%M:%S', archived_at) || substr(archived_at, 20, length(archived_at) - 25) || '+00:00'
+  WHERE archived_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND archived_at NOT LIKE '%+00:00';
+UPDATE tasks SET jira_synced_at = strftime('%Y-%m-%d %H:%M:%S', jira_synced_at) || substr(jira_synced_at, 20, length(jira_synced_at) - 25) || '+00:00'
+  WHERE jira_synced_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND jira_synced_at NOT LIKE '%+00:00';
+
+UPDATE sessions SET created_at = strftime('%Y-%m-%d %H:%M:%S', created_at) || substr(created_at, 20, length(created_at) - 25) || '+00:00'
+  WHERE created_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND created_at NOT LIKE '%+00:00';
+UPDATE sessions SET updated_at = strftime('%Y-%m-%d %H:%M:%S', updated_at) || substr(updated_at, 20, length(updated_at) - 25) || '+00:00'
+  WHERE updated_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND updated_at NOT LIKE '%+00:00';
+UPDATE sessions SET deleted_at = strftime('%Y-%m-%d %H:%M:%S', deleted_at) || substr(deleted_at, 20, length(deleted_at) - 25) || '+00:00'
+  WHERE deleted_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND deleted_at NOT LIKE '%+00:00';
+UPDATE sessions SET started_at = strftime('%Y-%m-%d %H:%M:%S', started_at) || substr(started_at, 20, length(started_at) - 25) || '+00:00'
+  WHERE started_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND started_at NOT LIKE '%+00:00';
+UPDATE sessions SET finished_at = strftime('%Y-%m-%d %H:%M:%S', finished_at) || substr(finished_at, 20, length(finished_at) - 25) || '+00:00'
+  WHERE finished_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND finished_at NOT LIKE '%+00:00';
+
+UPDATE comments SET created_at = strftime('%Y-%m-%d %H:%M:%S', created_at) || substr(created_at, 20, length(created_at) - 25) || '+00:00'
+  WHERE created_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND created_at NOT LIKE '%+00:00';
+
+UPDATE task_files SET created_at = strftime('%Y-%m-%d %H:%M:%S', created_at) || substr(created_at, 20, length(created_at) - 25) || '+00:00'
+  WHERE created_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND created_at NOT LIKE '%+00:00';
+
+UPDATE goals SET created_at = strftime('%Y-%m-%d %H:%M:%S', created_at) || substr(created_at, 20, length(created_at) - 25) || '+00:00'
+  WHERE created_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND created_at NOT LIKE '%+00:00';
+UPDATE goals SET updated_at = strftime('%Y-%m-%d %H:%M:%S', updated_at) || substr(updated_at, 20, length(updated_at) - 25) || '+00:00'
+  WHERE updated_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND updated_at NOT LIKE '%+00:00';
+
+UPDATE goal_periods SET period_start = strftime('%Y-%m-%d %H:%M:%S', period_start) || substr(period_start, 20, length(period_start) - 25) || '+00:00'
+  WHERE period_start GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND period_start NOT LIKE '%+00:00';
+UPDATE goal_periods SET period_end = strftime('%Y-%m-%d %H:%M:%S', period_end) || substr(period_end, 20, length(period_end) - 25) || '+00:00'
+  WHERE period_end GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND period_end NOT LIKE '%+00:00';
+
+UPDATE schema_version SET applied_at = strftime('%Y-%m-%d %H:%M:%S', applied_at) || substr(applied_at, 20, length(applied_at) - 25) || '+00:00'
+  WHERE applied_at GLOB '????-??-?? ??:??:??*[+-][0-9][0-9]:[0-9][0-9]' AND applied_at NOT LIKE '%+00:00';
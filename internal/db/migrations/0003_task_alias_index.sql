@@ -0,0 +1,3 @@
+-- Task aliases ('wrok alias') are unique among live tasks; tasks without one
+-- have an empty or NULL alias
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_alias ON tasks(alias) WHERE alias <> '' AND deleted_at IS NULL;
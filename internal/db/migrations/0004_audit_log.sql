@@ -0,0 +1,116 @@
+-- The audit log: every change to tasks, sessions, task tags, comments,
+-- attachments and goals is written to audit_log by these triggers, one row per
+-- changed field with its old and new value, so it also covers writes made
+-- outside wrok's own code paths. Before each of its writes wrok puts the OS
+-- user and the command in the single audit_actor row (see db/audit.go), so
+-- other programs' writes are logged with whatever it last held. The log itself
+-- is append-only
+
+CREATE TABLE IF NOT EXISTS audit_actor (
+  id INTEGER PRIMARY KEY CHECK (id = 1),
+  actor TEXT,
+  source TEXT
+);
+INSERT OR IGNORE INTO audit_actor (id) VALUES (1);
+
+CREATE TRIGGER IF NOT EXISTS audit_log_no_update BEFORE UPDATE ON audit_log BEGIN
+  SELECT RAISE(ABORT, 'the audit log is append-only');
+END;
+CREATE TRIGGER IF NOT EXISTS audit_log_no_delete BEFORE DELETE ON audit_log BEGIN
+  SELECT RAISE(ABORT, 'the audit log is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_tasks_insert AFTER INSERT ON tasks BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'task', NEW.id, NEW.id, 'create', 'title', NULL, NEW.title);
+END;
+CREATE TRIGGER IF NOT EXISTS audit_tasks_update AFTER UPDATE ON tasks BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  SELECT strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'task', NEW.id, NEW.id, 'update', field, old_value, new_value FROM (
+    SELECT 'title' AS field, OLD.title AS old_value, NEW.title AS new_value
+    UNION ALL SELECT 'project', OLD.project, NEW.project
+    UNION ALL SELECT 'status', OLD.status, NEW.status
+    UNION ALL SELECT 'priority', OLD.priority, NEW.priority
+    UNION ALL SELECT 'pinned', OLD.pinned, NEW.pinned
+    UNION ALL SELECT 'due', OLD.due, NEW.due
+    UNION ALL SELECT 'hidden_until', OLD.hidden_until, NEW.hidden_until
+    UNION ALL SELECT 'estimate_seconds', OLD.estimate_seconds, NEW.estimate_seconds
+    UNION ALL SELECT 'parent_id', OLD.parent_id, NEW.parent_id
+    UNION ALL SELECT 'alias', OLD.alias, NEW.alias
+    UNION ALL SELECT 'jira_id', OLD.jira_id, NEW.jira_id
+    UNION ALL SELECT 'url', OLD.url, NEW.url
+    UNION ALL SELECT 'note', OLD.note, NEW.note
+    UNION ALL SELECT 'jira_status', OLD.jira_status, NEW.jira_status
+    UNION ALL SELECT 'jira_assignee', OLD.jira_assignee, NEW.jira_assignee
+    UNION ALL SELECT 'deleted_at', OLD.deleted_at, NEW.deleted_at
+  ) WHERE old_value IS NOT new_value;
+END;
+CREATE TRIGGER IF NOT EXISTS audit_tasks_delete AFTER DELETE ON tasks BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'task', OLD.id, OLD.id, 'delete', 'title', OLD.title, NULL);
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_sessions_insert AFTER INSERT ON sessions BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'session', NEW.id, NEW.task_id, 'create', 'started_at', NULL, NEW.started_at);
+END;
+CREATE TRIGGER IF NOT EXISTS audit_sessions_update AFTER UPDATE ON sessions BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  SELECT strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'session', NEW.id, NEW.task_id, 'update', field, old_value, new_value FROM (
+    SELECT 'task_id' AS field, OLD.task_id AS old_value, NEW.task_id AS new_value
+    UNION ALL SELECT 'started_at', OLD.started_at, NEW.started_at
+    UNION ALL SELECT 'finished_at', OLD.finished_at, NEW.finished_at
+    UNION ALL SELECT 'note', OLD.note, NEW.note
+    UNION ALL SELECT 'paused_at', OLD.paused_at, NEW.paused_at
+    UNION ALL SELECT 'locked_at', OLD.locked_at, NEW.locked_at
+    UNION ALL SELECT 'deleted_at', OLD.deleted_at, NEW.deleted_at
+  ) WHERE old_value IS NOT new_value;
+END;
+CREATE TRIGGER IF NOT EXISTS audit_sessions_delete AFTER DELETE ON sessions BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'session', OLD.id, OLD.task_id, 'delete', 'started_at', OLD.started_at, NULL);
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_task_tags_insert AFTER INSERT ON task_tags BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'tag', NEW.tag_id, NEW.task_id, 'create', 'tags', NULL, (SELECT name FROM tags WHERE id = NEW.tag_id));
+END;
+CREATE TRIGGER IF NOT EXISTS audit_task_tags_delete AFTER DELETE ON task_tags BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'tag', OLD.tag_id, OLD.task_id, 'delete', 'tags', (SELECT name FROM tags WHERE id = OLD.tag_id), NULL);
+END;
+
+-- Status comments repeat the task's status change, which is logged already
+CREATE TRIGGER IF NOT EXISTS audit_comments_insert AFTER INSERT ON comments WHEN NEW.kind IS NOT 'status' BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'comment', NEW.id, NEW.task_id, 'create', 'body', NULL, NEW.body);
+END;
+CREATE TRIGGER IF NOT EXISTS audit_comments_delete AFTER DELETE ON comments WHEN OLD.kind IS NOT 'status' BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'comment', OLD.id, OLD.task_id, 'delete', 'body', OLD.body, NULL);
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_task_files_insert AFTER INSERT ON task_files BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'file', NEW.id, NEW.task_id, 'create', 'path', NULL, NEW.path);
+END;
+CREATE TRIGGER IF NOT EXISTS audit_task_files_delete AFTER DELETE ON task_files BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'file', OLD.id, OLD.task_id, 'delete', 'path', OLD.path, NULL);
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_goals_insert AFTER INSERT ON goals BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'goal', NEW.id, NULL, 'create', 'target', NULL, NEW.scope || ':' || NEW.target || ' ' || NEW.target_seconds || 's/' || NEW.period);
+END;
+CREATE TRIGGER IF NOT EXISTS audit_goals_update AFTER UPDATE ON goals BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  SELECT strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'goal', NEW.id, NULL, 'update', field, old_value, new_value FROM (
+    SELECT 'target_seconds' AS field, OLD.target_seconds AS old_value, NEW.target_seconds AS new_value
+    UNION ALL SELECT 'period', OLD.period, NEW.period
+  ) WHERE old_value IS NOT new_value;
+END;
+CREATE TRIGGER IF NOT EXISTS audit_goals_delete AFTER DELETE ON goals BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'goal', OLD.id, NULL, 'delete', 'target', OLD.scope || ':' || OLD.target || ' ' || OLD.target_seconds || 's/' || OLD.period, NULL);
+END;
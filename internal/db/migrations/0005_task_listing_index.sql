@@ -0,0 +1,3 @@
+-- 'wrok ls' lists live tasks pinned first, newest first; with this index the
+-- first page is read in order instead of sorting the whole table
+CREATE INDEX IF NOT EXISTS idx_tasks_listing ON tasks(deleted_at, pinned, id);
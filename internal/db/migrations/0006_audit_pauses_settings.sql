@@ -0,0 +1,54 @@
+-- Widens the audit log (0004): session updates also log duration_seconds and
+-- paused_seconds, and breaks (session_pauses), settings and tag renames get
+-- triggers of their own. A break is logged under its session's ID and task;
+-- settings and tag renames belong to no task, and a setting's key is its field
+
+DROP TRIGGER IF EXISTS audit_sessions_update;
+CREATE TRIGGER audit_sessions_update AFTER UPDATE ON sessions BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  SELECT strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'session', NEW.id, NEW.task_id, 'update', field, old_value, new_value FROM (
+    SELECT 'task_id' AS field, OLD.task_id AS old_value, NEW.task_id AS new_value
+    UNION ALL SELECT 'started_at', OLD.started_at, NEW.started_at
+    UNION ALL SELECT 'finished_at', OLD.finished_at, NEW.finished_at
+    UNION ALL SELECT 'duration_seconds', OLD.duration_seconds, NEW.duration_seconds
+    UNION ALL SELECT 'note', OLD.note, NEW.note
+    UNION ALL SELECT 'paused_at', OLD.paused_at, NEW.paused_at
+    UNION ALL SELECT 'paused_seconds', OLD.paused_seconds, NEW.paused_seconds
+    UNION ALL SELECT 'locked_at', OLD.locked_at, NEW.locked_at
+    UNION ALL SELECT 'deleted_at', OLD.deleted_at, NEW.deleted_at
+  ) WHERE old_value IS NOT new_value;
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_session_pauses_insert AFTER INSERT ON session_pauses BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'pause', NEW.session_id, (SELECT task_id FROM sessions WHERE id = NEW.session_id), 'create', 'started_at', NULL, NEW.started_at);
+END;
+CREATE TRIGGER IF NOT EXISTS audit_session_pauses_update AFTER UPDATE ON session_pauses BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  SELECT strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'pause', NEW.session_id, (SELECT task_id FROM sessions WHERE id = NEW.session_id), 'update', field, old_value, new_value FROM (
+    SELECT 'started_at' AS field, OLD.started_at AS old_value, NEW.started_at AS new_value
+    UNION ALL SELECT 'ended_at', OLD.ended_at, NEW.ended_at
+  ) WHERE old_value IS NOT new_value;
+END;
+CREATE TRIGGER IF NOT EXISTS audit_session_pauses_delete AFTER DELETE ON session_pauses BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'pause', OLD.session_id, (SELECT task_id FROM sessions WHERE id = OLD.session_id), 'delete', 'started_at', OLD.started_at, NULL);
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_settings_insert AFTER INSERT ON settings BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'setting', NULL, NULL, 'create', NEW.key, NULL, NEW.value);
+END;
+CREATE TRIGGER IF NOT EXISTS audit_settings_update AFTER UPDATE ON settings WHEN OLD.key IS NOT NEW.key OR OLD.value IS NOT NEW.value BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'setting', NULL, NULL, 'update', NEW.key, OLD.value, NEW.value);
+END;
+CREATE TRIGGER IF NOT EXISTS audit_settings_delete AFTER DELETE ON settings BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'setting', NULL, NULL, 'delete', OLD.key, OLD.value, NULL);
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_tags_rename AFTER UPDATE OF name ON tags WHEN OLD.name IS NOT NEW.name BEGIN
+  INSERT INTO audit_log (created_at, actor, source, entity, entity_id, task_id, action, field, old_value, new_value)
+  VALUES (strftime('%Y-%m-%d %H:%M:%f', 'now') || '+00:00', (SELECT actor FROM audit_actor WHERE id = 1), (SELECT source FROM audit_actor WHERE id = 1), 'tag', NEW.id, NULL, 'update', 'name', OLD.name, NEW.name);
+END;
@@ -0,0 +1,6 @@
+-- The urgency order of 'wrok ls' looks up the tasks that can make its first
+-- page by pinned state, priority and due date; idx_tasks_urgency answers that
+-- without reading the table. idx_tasks_visible counts the open, unsnoozed
+-- tasks the list pages through and narrows status and project filters
+CREATE INDEX IF NOT EXISTS idx_tasks_urgency ON tasks(pinned, priority, due, status);
+CREATE INDEX IF NOT EXISTS idx_tasks_visible ON tasks(deleted_at, status, hidden_until, project);
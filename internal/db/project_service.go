@@ -0,0 +1,142 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// ProjectSummary counts the tasks of a project by status
+type ProjectSummary struct {
+	Name     string
+	Todo     int
+	Done     int
+	Archived int
+}
+
+// IsArchived reports whether every task of the project is archived
+func (p ProjectSummary) IsArchived() bool {
+	return p.Todo == 0 && p.Done == 0
+}
+
+// GetProjects returns the projects in use with their task counts, most tasks first.
+// Projects whose tasks are all archived are left out unless includeArchived is set
+func GetProjects(includeArchived bool) ([]ProjectSummary, error) {
+	query := DB.Model(&models.Task{}).
+		Select("project AS name, " +
+			"SUM(CASE WHEN status NOT IN ('done', 'archived') THEN 1 ELSE 0 END) AS todo, " +
+			"SUM(CASE WHEN status = 'done' THEN 1 ELSE 0 END) AS done, " +
+			"SUM(CASE WHEN status = 'archived' THEN 1 ELSE 0 END) AS archived").
+		Where("project != ''").
+		Group("project").
+		Order("COUNT(*) DESC, project ASC")
+	if !includeArchived {
+		query = query.Having("SUM(CASE WHEN status != 'archived' THEN 1 ELSE 0 END) > 0")
+	}
+
+	var projects []ProjectSummary
+	err := query.Scan(&projects).Error
+	return projects, err
+}
+
+// findProjectName returns the project name as stored, matching name case-insensitively
+func findProjectName(name string) (string, error) {
+	var names []string
+	err := DB.Model(&models.Task{}).
+		Where("project != '' AND LOWER(project) = LOWER(?)", name).
+		Distinct("project").
+		Pluck("project", &names).Error
+	if err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("no project named '%s'", name)
+	}
+	return names[0], nil
+}
+
+// GetProjectTasksToArchive returns the project's tasks that aren't archived yet,
+// and the project name as stored
+func GetProjectTasksToArchive(name string) (string, []models.Task, error) {
+	project, err := findProjectName(name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var tasks []models.Task
+	err = DB.Where("project = ? AND status != ?", project, "archived").
+		Order("id ASC").
+		Find(&tasks).Error
+	return project, tasks, err
+}
+
+// ArchiveProject archives every task of a project that isn't archived yet, in one
+// transaction. A timer running on one of them is stopped first
+func ArchiveProject(name string) ([]models.Task, error) {
+	project, tasks, err := GetProjectTasksToArchive(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("project '%s' is already archived", project)
+	}
+
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	now := time.Now()
+	var stopped *models.Session
+	err = withRetry(func() error {
+		stopped = nil
+		return DB.Transaction(func(tx *gorm.DB) error {
+			var active models.Session
+			err := tx.Where("finished_at IS NULL AND task_id IN ?", ids).Preload("Task").Limit(1).Find(&active).Error
+			if err != nil {
+				return fmt.Errorf("failed to find the running session: %w", err)
+			}
+			if active.ID != 0 {
+				if err := finishSession(tx, &active, now); err != nil {
+					return fmt.Errorf("failed to stop the running session: %w", err)
+				}
+				stopped = &active
+			}
+
+			err = tx.Model(&models.Task{}).
+				Where("id IN ?", ids).
+				Updates(map[string]interface{}{"status": "archived", "archived_at": now}).Error
+			if err != nil {
+				return fmt.Errorf("failed to archive tasks: %w", err)
+			}
+
+			for _, task := range tasks {
+				comment := models.Comment{
+					TaskID: task.ID,
+					Kind:   models.CommentStatus,
+					Body:   fmt.Sprintf("%s → %s", task.Status, "archived"),
+				}
+				if err := tx.Create(&comment).Error; err != nil {
+					return fmt.Errorf("failed to record status change: %w", err)
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if stopped != nil {
+		emit(Event{Name: EventSessionStop, Task: &stopped.Task, Session: stopped})
+	}
+	for i := range tasks {
+		tasks[i].Status = "archived"
+		tasks[i].ArchivedAt = &now
+		emit(Event{Name: EventTaskArchived, Task: &tasks[i]})
+	}
+	return tasks, nil
+}
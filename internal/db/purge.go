@@ -0,0 +1,131 @@
+package db
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// lastPurgeKey is the setting that records when the retention policy last ran
+const lastPurgeKey = "retention.last_purge"
+
+// PurgeResult counts the rows a purge removed (or would remove)
+type PurgeResult struct {
+	Tasks    int64 // soft-deleted tasks
+	Sessions int64 // soft-deleted sessions plus every session of a purged task
+}
+
+// Total returns the number of tasks and sessions together
+func (r PurgeResult) Total() int64 {
+	return r.Tasks + r.Sessions
+}
+
+// deletedTasksBefore selects the ids of tasks soft-deleted before cutoff
+func deletedTasksBefore(tx *gorm.DB, cutoff time.Time) *gorm.DB {
+	return tx.Unscoped().Model(&models.Task{}).
+		Select("id").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff)
+}
+
+// purgeableSessions selects sessions soft-deleted before cutoff or belonging to a purged task
+func purgeableSessions(tx *gorm.DB, cutoff time.Time) *gorm.DB {
+	return tx.Unscoped().Model(&models.Session{}).
+		Where("(deleted_at IS NOT NULL AND deleted_at < ?) OR task_id IN (?)", cutoff, deletedTasksBefore(tx, cutoff))
+}
+
+// CountPurgeable reports what PurgeDeleted would remove, without removing anything
+func CountPurgeable(cutoff time.Time) (*PurgeResult, error) {
+	result := &PurgeResult{}
+	if err := deletedTasksBefore(DB, cutoff).Count(&result.Tasks).Error; err != nil {
+		return nil, fmt.Errorf("failed to count deleted tasks: %w", err)
+	}
+	if err := purgeableSessions(DB, cutoff).Count(&result.Sessions).Error; err != nil {
+		return nil, fmt.Errorf("failed to count deleted sessions: %w", err)
+	}
+	return result, nil
+}
+
+// PurgeDeleted permanently removes tasks soft-deleted before cutoff, together with their
+// sessions and their breaks, tag links, attached files and comments, and sessions soft-deleted before cutoff
+func PurgeDeleted(cutoff time.Time) (*PurgeResult, error) {
+	result := &PurgeResult{}
+	var taskIDs []uint
+
+	err := withRetry(func() error {
+		*result = PurgeResult{}
+		return DB.Transaction(func(tx *gorm.DB) error {
+			if err := deletedTasksBefore(tx, cutoff).Pluck("id", &taskIDs).Error; err != nil {
+				return fmt.Errorf("failed to find deleted tasks: %w", err)
+			}
+
+			// Sessions first: they are selected through the tasks about to go,
+			// and their breaks before them
+			if err := tx.Where("session_id IN (?)", purgeableSessions(tx, cutoff).Select("id")).Delete(&models.SessionPause{}).Error; err != nil {
+				return fmt.Errorf("failed to purge session breaks: %w", err)
+			}
+			sessions := purgeableSessions(tx, cutoff).Delete(&models.Session{})
+			if sessions.Error != nil {
+				return fmt.Errorf("failed to purge sessions: %w", sessions.Error)
+			}
+			result.Sessions = sessions.RowsAffected
+
+			if err := tx.Where("task_id IN (?)", deletedTasksBefore(tx, cutoff)).Delete(&models.TaskTag{}).Error; err != nil {
+				return fmt.Errorf("failed to purge task tags: %w", err)
+			}
+
+			if err := tx.Where("task_id IN (?)", deletedTasksBefore(tx, cutoff)).Delete(&models.TaskFile{}).Error; err != nil {
+				return fmt.Errorf("failed to purge attached files: %w", err)
+			}
+
+			if err := tx.Where("task_id IN (?)", deletedTasksBefore(tx, cutoff)).Delete(&models.Comment{}).Error; err != nil {
+				return fmt.Errorf("failed to purge comments: %w", err)
+			}
+
+			tasks := tx.Unscoped().
+				Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+				Delete(&models.Task{})
+			if tasks.Error != nil {
+				return fmt.Errorf("failed to purge tasks: %w", tasks.Error)
+			}
+			result.Tasks = tasks.RowsAffected
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Copies made by 'wrok file add --copy' go with their task
+	for _, id := range taskIDs {
+		if dir, err := attachmentsDir(id); err == nil {
+			os.RemoveAll(dir)
+		}
+	}
+
+	return result, nil
+}
+
+// RunRetention purges rows deleted before cutoff at most once a day, so the
+// configured policy can be applied on every startup without slowing commands down
+func RunRetention(cutoff time.Time, now time.Time) (*PurgeResult, error) {
+	if last, err := GetSetting(lastPurgeKey); err == nil && last != "" {
+		if lastRun, err := time.Parse(time.RFC3339, last); err == nil && now.Sub(lastRun) < 24*time.Hour {
+			return &PurgeResult{}, nil
+		}
+	}
+
+	result, err := PurgeDeleted(cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SetSetting(lastPurgeKey, now.Format(time.RFC3339)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// readOnly is set by SetReadOnly before Initialize
+var readOnly bool
+
+// ErrReadOnly is returned by every change while the database is read-only
+var ErrReadOnly = errors.New("read-only mode: nothing can be changed (started with --read-only, --db or WROK_READ_ONLY)")
+
+// readOnlySafe marks a statement that runs in read-only mode because it leaves
+// the database alone, like the VACUUM INTO of a backup
+const readOnlySafe = "wrok:read_only_safe"
+
+// SetReadOnly makes the database read-only for the rest of the run: it is opened
+// without migrating it or switching it to WAL, and every write fails with
+// ErrReadOnly. Call it before Initialize
+func SetReadOnly(enabled bool) {
+	readOnly = enabled
+}
+
+// databaseFile is the database or backup opened instead of ~/.wrok/wrok.db,
+// and stagedFile the scratch copy of it that is actually read
+var databaseFile, stagedFile string
+
+// SetDatabaseFile makes Initialize open the database or backup (gzipped or
+// plain) at path instead of ~/.wrok/wrok.db, read-only. Call it before Initialize
+func SetDatabaseFile(path string) {
+	databaseFile = path
+	readOnly = path != "" || readOnly
+}
+
+// stageDatabaseFile copies the database or backup at path into a scratch file,
+// unpacked and migrated to the current schema, and returns the copy's path. So
+// an old backup can be read without changing it. Close removes the copy
+func stageDatabaseFile(path string) (string, error) {
+	file, err := os.CreateTemp("", "wrok-inspect-*.db")
+	if err != nil {
+		return "", fmt.Errorf("failed to copy %s: %w", path, err)
+	}
+	file.Close()
+	stagedFile = file.Name()
+
+	if err := unpackBackup(path, stagedFile); err != nil {
+		return "", err
+	}
+	if err := checkBackup(stagedFile); err != nil {
+		return "", fmt.Errorf("%s is not a usable wrok database: %w", path, err)
+	}
+
+	conn, err := gorm.Open(sqlite.Open(fmt.Sprintf("%s?_pragma=busy_timeout(%d)", stagedFile, busyTimeout.Milliseconds())), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	if sqlDB, err := conn.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+	if err := openUTC(conn); err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	// runMigrations works on DB; the copy takes its place while it runs
+	previous := DB
+	DB = conn
+	_, err = runMigrations()
+	DB = previous
+	if err != nil {
+		return "", fmt.Errorf("failed to migrate a copy of %s: %w", path, err)
+	}
+	return stagedFile, nil
+}
+
+// ReadOnly reports whether the database is read-only
+func ReadOnly() bool {
+	return readOnly
+}
+
+// dataSource returns the connection string for the database at path. A
+// read-only connection opens the file as it is, so a backup can be inspected
+// without changing it
+func dataSource(path string) string {
+	if readOnly {
+		return fmt.Sprintf("file:%s?mode=ro&_pragma=busy_timeout(%d)", path, busyTimeout.Milliseconds())
+	}
+	return path + connectionPragmas()
+}
+
+// refuseWrites registers gorm callbacks that fail writes in read-only mode
+// before they reach SQLite, with an error that says why
+func refuseWrites(conn *gorm.DB) error {
+	callbacks := conn.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("wrok:read_only", refuseWrite); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("wrok:read_only", refuseWrite); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("wrok:read_only", refuseWrite); err != nil {
+		return err
+	}
+	return callbacks.Raw().Before("gorm:raw").Register("wrok:read_only", refuseWrite)
+}
+
+// refuseWrite fails the statement of tx in read-only mode, unless it is marked readOnlySafe
+func refuseWrite(tx *gorm.DB) {
+	if !readOnly {
+		return
+	}
+	if safe, _ := tx.Get(readOnlySafe); safe == true {
+		return
+	}
+	tx.AddError(ErrReadOnly)
+}
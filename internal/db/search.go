@@ -0,0 +1,273 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/priority"
+	"github.com/balkashynov/wrok/internal/query"
+)
+
+// Search matches rank from exact (0) over prefix and suffix to substring (3)
+const noMatch = 4
+
+// searchColumns are the task columns free search text is looked for in
+var searchColumns = []string{"CAST(id AS TEXT)", "alias", "title", "project", "jira_id", "note", "status"}
+
+// SearchTasks returns the tasks matching the filters of opts and a search
+// query. Field terms become SQL conditions; the free text must appear in the
+// ID, alias, title, project, JIRA ID, notes, status, priority or a tag of a task and
+// ranks the results: exact matches first, then prefix, suffix and substring
+// matches. Pinned tasks come first throughout
+func SearchTasks(q query.Query, opts TaskQueryOptions) ([]models.Task, error) {
+	now := time.Now()
+	dbQuery, rank, rankArgs, err := searchFilters(q, opts, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.OrderBy == OrderUrgency {
+		ids, err := urgencyOrder(dbQuery, now, opts.Offset, opts.Limit)
+		if err != nil {
+			return nil, err
+		}
+		tx := DB
+		if opts.IncludeDeleted {
+			tx = DB.Unscoped()
+		}
+		return tasksByIDs(tx, ids)
+	}
+
+	var tasks []models.Task
+	if err := searchOrder(dbQuery, rank, rankArgs, opts).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	if err := loadTagsForTasks(tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// SearchTaskIDs returns the IDs of the tasks SearchTasks would return, in the
+// same order, without loading them
+func SearchTaskIDs(q query.Query, opts TaskQueryOptions) ([]uint, error) {
+	now := time.Now()
+	dbQuery, rank, rankArgs, err := searchFilters(q, opts, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.OrderBy == OrderUrgency {
+		return urgencyOrder(dbQuery, now, opts.Offset, opts.Limit)
+	}
+
+	var ids []uint
+	if err := searchOrder(dbQuery, rank, rankArgs, opts).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CountSearchTasks returns how many tasks SearchTasks would return, at most opts.Limit
+func CountSearchTasks(q query.Query, opts TaskQueryOptions) (int, error) {
+	dbQuery, _, _, err := searchFilters(q, opts, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := dbQuery.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	if opts.Limit > 0 && count > int64(opts.Limit) {
+		count = int64(opts.Limit)
+	}
+	return int(count), nil
+}
+
+// searchFilters starts a query on the tasks matching the filters of opts and
+// the field terms and free text of q. It also returns the SQL ranking the text
+// matches and its arguments, "" when there is no free text
+func searchFilters(q query.Query, opts TaskQueryOptions, now time.Time) (*gorm.DB, string, []interface{}, error) {
+	dbQuery := taskFilters(opts)
+
+	for _, term := range q.Terms {
+		condition, args, err := termCondition(term, now)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		if term.Negate {
+			// NULL columns (no due date) don't match the term, so they match its negation
+			condition = "NOT COALESCE((" + condition + "), 0)"
+		}
+		dbQuery = dbQuery.Where(condition, args...)
+	}
+
+	rank, rankArgs := textRank(strings.ToLower(strings.TrimSpace(q.Text)))
+	if rank != "" {
+		dbQuery = dbQuery.Where(rank+" < ?", append(append([]interface{}{}, rankArgs...), noMatch)...)
+	}
+	return dbQuery, rank, rankArgs, nil
+}
+
+// searchOrder orders a search: pinned tasks first, then by text rank and
+// opts.OrderBy, with the limit and offset of opts
+func searchOrder(dbQuery *gorm.DB, rank string, rankArgs []interface{}, opts TaskQueryOptions) *gorm.DB {
+	order := "pinned DESC"
+	if rank != "" {
+		order += ", " + rank
+	}
+	if opts.OrderBy != "" {
+		order += ", " + opts.OrderBy
+	}
+	dbQuery = dbQuery.Clauses(clause.OrderBy{Expression: clause.Expr{SQL: order, Vars: rankArgs, WithoutParentheses: true}})
+	if opts.Limit > 0 {
+		dbQuery = dbQuery.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		dbQuery = dbQuery.Offset(opts.Offset)
+	}
+	return dbQuery
+}
+
+// termCondition turns a field term into a SQL condition on the tasks table
+func termCondition(term query.Term, now time.Time) (string, []interface{}, error) {
+	value := term.Value
+	switch term.Field {
+	case query.FieldProject:
+		return "LOWER(project) = LOWER(?)", []interface{}{value}, nil
+	case query.FieldTag:
+		tagged := DB.Table("task_tags").
+			Select("task_id").
+			Joins("JOIN tags ON task_tags.tag_id = tags.id").
+			Where("LOWER(tags.name) = LOWER(?)", value)
+		return "id IN (?)", []interface{}{tagged}, nil
+	case query.FieldStatus:
+		return "status = ?", []interface{}{strings.ToLower(value)}, nil
+	case query.FieldJira:
+		return "jira_id LIKE ?", []interface{}{value + "%"}, nil
+	case query.FieldPriority:
+		return priorityCondition(term)
+	case query.FieldDue:
+		return dueCondition(term, now)
+	}
+	return "", nil, fmt.Errorf("unknown search field '%s'", term.Field)
+}
+
+// priorityCondition compares the priority with a level name or number; "none"
+// is no priority, which < and > leave out
+func priorityCondition(term query.Term) (string, []interface{}, error) {
+	level := 0
+	if !strings.EqualFold(term.Value, "none") {
+		parsed, err := priority.Parse(term.Value)
+		if err != nil {
+			return "", nil, err
+		}
+		level = parsed
+	}
+
+	switch term.Op {
+	case query.OpLess:
+		return "priority > 0 AND priority < ?", []interface{}{level}, nil
+	case query.OpMore:
+		return "priority > ?", []interface{}{level}, nil
+	}
+	return "priority = ?", []interface{}{level}, nil
+}
+
+// dueCondition compares the due date with a day or moment (see query.DueSpan):
+// due:3d is due that day, due<3d before it and due>3d after it
+func dueCondition(term query.Term, now time.Time) (string, []interface{}, error) {
+	switch strings.ToLower(term.Value) {
+	case "none":
+		return "due IS NULL", nil, nil
+	case "any":
+		return "due IS NOT NULL", nil, nil
+	case "overdue":
+		return "due < ?", []interface{}{now}, nil
+	}
+
+	start, end, err := query.DueSpan(term.Value, now)
+	if err != nil {
+		return "", nil, err
+	}
+	switch term.Op {
+	case query.OpLess:
+		return "due < ?", []interface{}{start}, nil
+	case query.OpMore:
+		return "due >= ?", []interface{}{end}, nil
+	}
+	return "due >= ? AND due < ?", []interface{}{start, end}, nil
+}
+
+// textRank returns a SQL expression ranking how well a task matches text, from
+// 0 (a field equals it) to noMatch, with its arguments. Empty text gives ""
+func textRank(text string) (string, []interface{}) {
+	if text == "" {
+		return "", nil
+	}
+
+	var args []interface{}
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text)
+	columnRank := func(column string) string {
+		args = append(args, text, escaped+"%", "%"+escaped, "%"+escaped+"%")
+		return fmt.Sprintf(`CASE WHEN LOWER(%[1]s) = ? THEN 0 WHEN LOWER(%[1]s) LIKE ? ESCAPE '\' THEN 1 `+
+			`WHEN LOWER(%[1]s) LIKE ? ESCAPE '\' THEN 2 WHEN LOWER(%[1]s) LIKE ? ESCAPE '\' THEN 3 ELSE 4 END`, column)
+	}
+
+	var ranks []string
+	for _, column := range searchColumns {
+		ranks = append(ranks, columnRank(column))
+	}
+	ranks = append(ranks, "COALESCE((SELECT MIN("+columnRank("tags.name")+") FROM task_tags "+
+		"JOIN tags ON tags.id = task_tags.tag_id WHERE task_tags.task_id = tasks.id), 4)")
+
+	// Priority names come from the config, so their ranks are worked out here
+	levelsByRank := make(map[int][]int)
+	for level := 1; level <= priority.Max(); level++ {
+		best := noMatch
+		for _, name := range []string{priority.Name(level), priority.Short(level), strconv.Itoa(level)} {
+			if r := matchRank(strings.ToLower(name), text); r < best {
+				best = r
+			}
+		}
+		if best < noMatch {
+			levelsByRank[best] = append(levelsByRank[best], level)
+		}
+	}
+	if len(levelsByRank) > 0 {
+		priorityRank := "CASE"
+		for r := 0; r < noMatch; r++ {
+			if levels, ok := levelsByRank[r]; ok {
+				values := make([]string, len(levels))
+				for i, level := range levels {
+					values[i] = strconv.Itoa(level)
+				}
+				priorityRank += fmt.Sprintf(" WHEN priority IN (%s) THEN %d", strings.Join(values, ", "), r)
+			}
+		}
+		ranks = append(ranks, priorityRank+" ELSE 4 END")
+	}
+
+	return "MIN(" + strings.Join(ranks, ", ") + ")", args
+}
+
+// matchRank ranks field against text as textRank does in SQL
+func matchRank(field, text string) int {
+	switch {
+	case field == text:
+		return 0
+	case strings.HasPrefix(field, text):
+		return 1
+	case strings.HasSuffix(field, text):
+		return 2
+	case strings.Contains(field, text):
+		return 3
+	}
+	return noMatch
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// LockSessionsBefore locks the finished sessions started before the given time,
+// e.g. once they have been reported to a client, and returns how many it locked.
+// Sessions that are already locked keep their lock time
+func LockSessionsBefore(before time.Time) (int64, error) {
+	var locked int64
+	err := withRetry(func() error {
+		result := DB.Model(&models.Session{}).
+			Where("started_at < ? AND finished_at IS NOT NULL AND locked_at IS NULL", before).
+			Update("locked_at", time.Now())
+		locked = result.RowsAffected
+		return result.Error
+	})
+	return locked, err
+}
+
+// UnlockSessionsBefore unlocks the sessions started before the given time and
+// returns how many it unlocked
+func UnlockSessionsBefore(before time.Time) (int64, error) {
+	var unlocked int64
+	err := withRetry(func() error {
+		result := DB.Model(&models.Session{}).
+			Where("started_at < ? AND locked_at IS NOT NULL", before).
+			Update("locked_at", nil)
+		unlocked = result.RowsAffected
+		return result.Error
+	})
+	return unlocked, err
+}
+
+// checkUnlocked refuses changes to a locked session unless forced
+func checkUnlocked(session *models.Session, force bool) error {
+	if session.LockedAt == nil || force {
+		return nil
+	}
+	return fmt.Errorf("session #%d was locked on %s; use --force to change it anyway",
+		session.ID, session.LockedAt.Format("02/01/2006"))
+}
+
+// whereLocked keeps only the locked, or only the unlocked, sessions of a query on table
+func whereLocked(query *gorm.DB, table string, locked bool) *gorm.DB {
+	if locked {
+		return query.Where(table + ".locked_at IS NOT NULL")
+	}
+	return query.Where(table + ".locked_at IS NULL")
+}
@@ -1,10 +1,12 @@
 package db
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/balkashynov/wrok/internal/models"
+	"gorm.io/gorm"
 )
 
 // StartSession starts a new time tracking session for a task
@@ -15,12 +17,19 @@ func StartSession(taskID uint) (*models.Session, error) {
 		return nil, fmt.Errorf("task #%d not found", taskID)
 	}
 
+	// Hold the session lock so a concurrent start can't slip in between the check and the insert
+	unlock, err := lockSessions()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	// Check if there's already an active session
 	var activeSession models.Session
-	err := DB.Where("finished_at IS NULL").First(&activeSession).Error
+	err = DB.Where("finished_at IS NULL").First(&activeSession).Error
 	if err == nil {
 		// There's already an active session
-		return nil, fmt.Errorf("session already active for task #%d. Stop it first with 'wrok stop'", activeSession.TaskID)
+		return nil, fmt.Errorf("session already active for task #%d. Stop it first with 'wrok stop', or use 'wrok start --switch'", activeSession.TaskID)
 	}
 
 	// Create new session
@@ -29,16 +38,68 @@ func StartSession(taskID uint) (*models.Session, error) {
 		StartedAt: time.Now(),
 	}
 
-	if err := DB.Create(&session).Error; err != nil {
+	if err := withRetry(func() error { return DB.Create(&session).Error }); err != nil {
 		return nil, err
 	}
 
 	// Load the task relationship
 	DB.Preload("Task").First(&session, session.ID)
 
+	emit(Event{Name: EventSessionStart, Task: &session.Task, Session: &session})
+
 	return &session, nil
 }
 
+// SwitchSession stops the running session, if any, and starts one on another
+// task in a single step, so no moment goes untracked and no concurrent start
+// slips in between. stopped is nil when nothing was running
+func SwitchSession(taskID uint) (stopped *models.Session, started *models.Session, err error) {
+	var task models.Task
+	if err := DB.First(&task, taskID).Error; err != nil {
+		return nil, nil, fmt.Errorf("task #%d not found", taskID)
+	}
+
+	unlock, err := lockSessions()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+
+	var active models.Session
+	if err := DB.Where("finished_at IS NULL").Preload("Task").First(&active).Error; err == nil {
+		if active.TaskID == taskID {
+			return nil, nil, fmt.Errorf("already tracking time on task #%d", taskID)
+		}
+		stopped = &active
+	}
+
+	session := models.Session{TaskID: taskID}
+	err = withRetry(func() error {
+		return DB.Transaction(func(tx *gorm.DB) error {
+			now := time.Now()
+			if stopped != nil {
+				if err := finishSession(tx, stopped, now); err != nil {
+					return err
+				}
+			}
+			session = models.Session{TaskID: taskID, StartedAt: now}
+			return tx.Create(&session).Error
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	DB.Preload("Task").First(&session, session.ID)
+
+	if stopped != nil {
+		emit(Event{Name: EventSessionStop, Task: &stopped.Task, Session: stopped})
+	}
+	emit(Event{Name: EventSessionStart, Task: &session.Task, Session: &session})
+
+	return stopped, &session, nil
+}
+
 // StopActiveSession stops the currently active session
 func StopActiveSession() (*models.Session, error) {
 	var session models.Session
@@ -51,28 +112,151 @@ func StopActiveSession() (*models.Session, error) {
 
 	// Stop the session
 	now := time.Now()
+	err = withRetry(func() error {
+		return DB.Transaction(func(tx *gorm.DB) error {
+			return finishSession(tx, &session, now)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	emit(Event{Name: EventSessionStop, Task: &session.Task, Session: &session})
+
+	return &session, nil
+}
+
+// finishSession ends a running session at now, ending its break if it is
+// paused, and saves it. Its duration leaves the breaks out
+func finishSession(tx *gorm.DB, session *models.Session, now time.Time) error {
+	if session.PausedAt != nil {
+		if err := endPause(tx, session, now); err != nil {
+			return err
+		}
+	}
+	session.DurationSeconds = int(session.Elapsed(now).Seconds())
 	session.FinishedAt = &now
-	session.DurationSeconds = int(now.Sub(session.StartedAt).Seconds())
+	return tx.Omit("Task").Save(session).Error
+}
+
+// endPause ends the break of a paused session at now, adding it to the
+// session's paused time. The caller saves the session
+func endPause(tx *gorm.DB, session *models.Session, now time.Time) error {
+	session.PausedSeconds += int(now.Sub(*session.PausedAt).Seconds())
+	session.PausedAt = nil
+	return tx.Model(&models.SessionPause{}).
+		Where("session_id = ? AND ended_at IS NULL", session.ID).
+		Update("ended_at", now).Error
+}
+
+// PauseActiveSession puts the running session on a break: it stays open, but
+// the time until it is resumed doesn't count
+func PauseActiveSession() (*models.Session, error) {
+	var session models.Session
+	if err := DB.Where("finished_at IS NULL").Preload("Task").First(&session).Error; err != nil {
+		return nil, fmt.Errorf("no active session found")
+	}
+	if session.Paused() {
+		return nil, fmt.Errorf("the session for task #%d is already paused. Resume it with 'wrok resume'", session.TaskID)
+	}
+
+	now := time.Now()
+	err := withRetry(func() error {
+		return DB.Transaction(func(tx *gorm.DB) error {
+			session.PausedAt = &now
+			if err := tx.Omit("Task").Save(&session).Error; err != nil {
+				return err
+			}
+			return tx.Create(&models.SessionPause{SessionID: session.ID, StartedAt: now}).Error
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
 
-	if err := DB.Save(&session).Error; err != nil {
+// ResumeActiveSession ends the break of the paused session
+func ResumeActiveSession() (*models.Session, error) {
+	var session models.Session
+	if err := DB.Where("finished_at IS NULL").Preload("Task").First(&session).Error; err != nil {
+		return nil, fmt.Errorf("no active session found")
+	}
+	if !session.Paused() {
+		return nil, fmt.Errorf("the session for task #%d isn't paused", session.TaskID)
+	}
+
+	err := withRetry(func() error {
+		return DB.Transaction(func(tx *gorm.DB) error {
+			if err := endPause(tx, &session, time.Now()); err != nil {
+				return err
+			}
+			return tx.Omit("Task").Save(&session).Error
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return &session, nil
 }
 
+// GetSessionPauses returns the breaks taken during a session, oldest first
+func GetSessionPauses(sessionID uint) ([]models.SessionPause, error) {
+	var pauses []models.SessionPause
+	err := DB.Where("session_id = ?", sessionID).Order("started_at ASC").Find(&pauses).Error
+	return pauses, err
+}
+
+// pausedBetween returns how much of the breaks of a session falls between from and to
+func pausedBetween(pauses []models.SessionPause, from, to time.Time) time.Duration {
+	var paused time.Duration
+	for _, pause := range pauses {
+		start, end := pause.StartedAt, to
+		if pause.EndedAt != nil && pause.EndedAt.Before(to) {
+			end = *pause.EndedAt
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(start) {
+			paused += end.Sub(start)
+		}
+	}
+	return paused
+}
+
 // GetActiveSession returns the currently active session, if any
 func GetActiveSession() (*models.Session, error) {
 	var session models.Session
 	
 	err := DB.Where("finished_at IS NULL").Preload("Task").First(&session).Error
-	if err != nil {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil // No active session is not an error
 	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active session: %w", err)
+	}
 
 	return &session, nil
 }
 
+// GetStaleActiveSessions returns unfinished sessions started longer ago than olderThan
+func GetStaleActiveSessions(olderThan time.Duration) ([]models.Session, error) {
+	var sessions []models.Session
+
+	err := DB.Where("finished_at IS NULL AND started_at < ?", time.Now().Add(-olderThan)).
+		Preload("Task").
+		Order("started_at ASC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
 // GetSessionsInRange returns all sessions within the specified date range
 func GetSessionsInRange(startTime, endTime time.Time) ([]models.Session, error) {
 	var sessions []models.Session
@@ -90,6 +274,259 @@ func GetSessionsInRange(startTime, endTime time.Time) ([]models.Session, error)
 	return sessions, nil
 }
 
+// GetSessionsForTask returns all sessions of a task, newest first
+func GetSessionsForTask(taskID uint) ([]models.Session, error) {
+	var sessions []models.Session
+
+	err := DB.Where("task_id = ?", taskID).
+		Order("started_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// SessionQueryOptions holds options for querying sessions
+type SessionQueryOptions struct {
+	TaskID uint       // Filter by task (0 = all tasks)
+	From   *time.Time // Sessions started at or after
+	To     *time.Time // Sessions started at or before
+	Locked *bool      // Only locked (true) or unlocked (false) sessions; nil for both
+}
+
+// GetSessions returns sessions matching the options, including a running one, newest first
+func GetSessions(opts SessionQueryOptions) ([]models.Session, error) {
+	var sessions []models.Session
+
+	query := DB.Preload("Task")
+	if opts.TaskID != 0 {
+		query = query.Where("task_id = ?", opts.TaskID)
+	}
+	if opts.From != nil {
+		query = query.Where("started_at >= ?", *opts.From)
+	}
+	if opts.To != nil {
+		query = query.Where("started_at <= ?", *opts.To)
+	}
+	if opts.Locked != nil {
+		query = whereLocked(query, "sessions", *opts.Locked)
+	}
+
+	if err := query.Order("started_at DESC").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// GetSessionByID retrieves a session with its task
+func GetSessionByID(id uint) (*models.Session, error) {
+	var session models.Session
+
+	if err := DB.Preload("Task").First(&session, id).Error; err != nil {
+		return nil, fmt.Errorf("session #%d not found", id)
+	}
+
+	return &session, nil
+}
+
+// UpdateSession changes the times and note of a session and recalculates its duration.
+// A nil finishedAt keeps a running session running. A locked session is only changed when forced
+func UpdateSession(id uint, startedAt time.Time, finishedAt *time.Time, note string, force bool) (*models.Session, error) {
+	session, err := GetSessionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUnlocked(session, force); err != nil {
+		return nil, err
+	}
+
+	if finishedAt == nil && session.FinishedAt != nil {
+		return nil, fmt.Errorf("session #%d is finished and needs an end time", id)
+	}
+	if finishedAt != nil && !finishedAt.After(startedAt) {
+		return nil, fmt.Errorf("end time must be after start time")
+	}
+	if startedAt.After(time.Now()) {
+		return nil, fmt.Errorf("start time is in the future")
+	}
+
+	session.StartedAt = startedAt
+	session.FinishedAt = finishedAt
+	session.Note = note
+	if finishedAt != nil {
+		// Breaks outside the new times no longer count against it
+		pauses, err := GetSessionPauses(id)
+		if err != nil {
+			return nil, err
+		}
+		session.PausedSeconds = int(pausedBetween(pauses, startedAt, *finishedAt).Seconds())
+		session.DurationSeconds = int(finishedAt.Sub(startedAt).Seconds()) - session.PausedSeconds
+	}
+
+	if err := DB.Omit("Task").Save(session).Error; err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// SplitSession splits a finished session in two at the given time. The second
+// part is assigned to moveToTaskID, or stays on the same task when it is 0.
+// A locked session is only split when forced, and both parts stay locked
+func SplitSession(id uint, at time.Time, moveToTaskID uint, force bool) (*models.Session, *models.Session, error) {
+	first, err := GetSessionByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkUnlocked(first, force); err != nil {
+		return nil, nil, err
+	}
+
+	if first.FinishedAt == nil {
+		return nil, nil, fmt.Errorf("session #%d is still running, stop it first", id)
+	}
+	if !at.After(first.StartedAt) || !at.Before(*first.FinishedAt) {
+		return nil, nil, fmt.Errorf("split time must be between %s and %s",
+			first.StartedAt.Format("15:04"), first.FinishedAt.Format("15:04"))
+	}
+
+	taskID := first.TaskID
+	if moveToTaskID != 0 {
+		if _, err := GetTaskByID(moveToTaskID); err != nil {
+			return nil, nil, err
+		}
+		taskID = moveToTaskID
+	}
+
+	// Each part keeps the breaks taken during it
+	pauses, err := GetSessionPauses(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	firstPaused := int(pausedBetween(pauses, first.StartedAt, at).Seconds())
+	secondPaused := int(pausedBetween(pauses, at, *first.FinishedAt).Seconds())
+
+	second := &models.Session{
+		TaskID:          taskID,
+		StartedAt:       at,
+		FinishedAt:      first.FinishedAt,
+		DurationSeconds: int(first.FinishedAt.Sub(at).Seconds()) - secondPaused,
+		PausedSeconds:   secondPaused,
+		Note:            first.Note,
+		LockedAt:        first.LockedAt,
+	}
+
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		end := at
+		first.FinishedAt = &end
+		first.PausedSeconds = firstPaused
+		first.DurationSeconds = int(at.Sub(first.StartedAt).Seconds()) - firstPaused
+		if err := tx.Omit("Task").Save(first).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(second).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.SessionPause{}).
+			Where("session_id = ? AND started_at >= ?", first.ID, at).
+			Update("session_id", second.ID).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	DB.Preload("Task").First(second, second.ID)
+
+	return first, second, nil
+}
+
+// MergeSessions merges two finished sessions of the same task into one spanning both.
+// The time between them becomes a break, so only the time worked in either counts.
+// Locked sessions are only merged when forced
+func MergeSessions(id, otherID uint, force bool) (*models.Session, error) {
+	session, err := GetSessionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	other, err := GetSessionByID(otherID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkUnlocked(session, force); err != nil {
+		return nil, err
+	}
+	if err := checkUnlocked(other, force); err != nil {
+		return nil, err
+	}
+
+	if session.TaskID != other.TaskID {
+		return nil, fmt.Errorf("sessions #%d and #%d belong to different tasks", id, otherID)
+	}
+	if session.FinishedAt == nil || other.FinishedAt == nil {
+		return nil, fmt.Errorf("running sessions cannot be merged, stop the timer first")
+	}
+
+	// Keep the earlier session and extend it over the later one
+	if other.StartedAt.Before(session.StartedAt) {
+		session, other = other, session
+	}
+	var gap *models.SessionPause
+	if other.StartedAt.After(*session.FinishedAt) {
+		gap = &models.SessionPause{StartedAt: *session.FinishedAt, EndedAt: &other.StartedAt}
+		session.PausedSeconds += int(other.StartedAt.Sub(*session.FinishedAt).Seconds())
+	}
+	if other.FinishedAt.After(*session.FinishedAt) {
+		session.FinishedAt = other.FinishedAt
+	}
+	session.PausedSeconds += other.PausedSeconds
+	if session.LockedAt == nil {
+		session.LockedAt = other.LockedAt
+	}
+	session.DurationSeconds = int(session.FinishedAt.Sub(session.StartedAt).Seconds()) - session.PausedSeconds
+	if session.Note == "" {
+		session.Note = other.Note
+	} else if other.Note != "" && other.Note != session.Note {
+		session.Note = session.Note + "; " + other.Note
+	}
+
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Task").Save(session).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.SessionPause{}).Where("session_id = ?", other.ID).Update("session_id", session.ID).Error; err != nil {
+			return err
+		}
+		if gap != nil {
+			gap.SessionID = session.ID
+			if err := tx.Create(gap).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&models.Session{}, other.ID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// DeleteSession removes a session; a locked one only when forced
+func DeleteSession(id uint, force bool) error {
+	session, err := GetSessionByID(id)
+	if err != nil {
+		return err
+	}
+	if err := checkUnlocked(session, force); err != nil {
+		return err
+	}
+	return DB.Delete(&models.Session{}, id).Error
+}
+
 // GetTaskByID retrieves a task by ID
 func GetTaskByID(id uint) (*models.Task, error) {
 	var task models.Task
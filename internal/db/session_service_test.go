@@ -0,0 +1,48 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// TestMergeSessionsWithGap merges two sessions an hour apart and checks the hour
+// between them is a break, not worked time
+func TestMergeSessionsWithGap(t *testing.T) {
+	task, err := CreateTask(CreateTaskRequest{Title: "merge gap"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now().Add(-4 * time.Hour).Truncate(time.Second)
+	sessions := []*models.Session{
+		{TaskID: task.ID, StartedAt: start, DurationSeconds: 1800},
+		{TaskID: task.ID, StartedAt: start.Add(90 * time.Minute), DurationSeconds: 2400},
+	}
+	for _, session := range sessions {
+		finished := session.StartedAt.Add(time.Duration(session.DurationSeconds) * time.Second)
+		session.FinishedAt = &finished
+		if err := DB.Create(session).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	merged, err := MergeSessions(sessions[1].ID, sessions[0].ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.ID != sessions[0].ID || !merged.FinishedAt.Equal(*sessions[1].FinishedAt) {
+		t.Errorf("got session #%d to %v, want #%d to %v", merged.ID, merged.FinishedAt, sessions[0].ID, sessions[1].FinishedAt)
+	}
+	if merged.DurationSeconds != 1800+2400 || merged.PausedSeconds != 3600 {
+		t.Errorf("got %ds worked and %ds paused, want 4200s and 3600s", merged.DurationSeconds, merged.PausedSeconds)
+	}
+
+	var pauses []models.SessionPause
+	if err := DB.Where("session_id = ?", merged.ID).Find(&pauses).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(pauses) != 1 || !pauses[0].StartedAt.Equal(*sessions[0].FinishedAt) || pauses[0].EndedAt == nil || !pauses[0].EndedAt.Equal(sessions[1].StartedAt) {
+		t.Errorf("got breaks %+v, want one over the gap", pauses)
+	}
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/balkashynov/wrok/internal/models"
+	"gorm.io/gorm"
+)
+
+// GetSetting returns the value stored under key, or "" if it is not set
+func GetSetting(key string) (string, error) {
+	var settings []models.Setting
+	if err := DB.Where("key = ?", key).Limit(1).Find(&settings).Error; err != nil {
+		return "", err
+	}
+	if len(settings) == 0 {
+		return "", nil
+	}
+	return settings[0].Value, nil
+}
+
+// SetSetting stores value under key, replacing any previous value
+func SetSetting(key, value string) error {
+	return DB.Save(&models.Setting{Key: key, Value: value}).Error
+}
+
+// DeleteSetting removes key
+func DeleteSetting(key string) error {
+	return DB.Where("key = ?", key).Delete(&models.Setting{}).Error
+}
+
+// Settings keys for the focus context
+const (
+	settingFocusProject = "focus.project"
+	settingFocusTag     = "focus.tag"
+)
+
+// FocusContext is the persistent project/tag filter set by `wrok focus`
+type FocusContext struct {
+	Project string
+	Tag     string
+}
+
+// IsSet reports whether any focus filter is active
+func (f FocusContext) IsSet() bool {
+	return f.Project != "" || f.Tag != ""
+}
+
+// String renders the focus as it is typed on the command line, e.g. "@web #backend"
+func (f FocusContext) String() string {
+	var parts []string
+	if f.Project != "" {
+		parts = append(parts, "@"+f.Project)
+	}
+	if f.Tag != "" {
+		parts = append(parts, "#"+f.Tag)
+	}
+	return strings.Join(parts, " ")
+}
+
+// GetFocus returns the current focus context
+func GetFocus() (FocusContext, error) {
+	project, err := GetSetting(settingFocusProject)
+	if err != nil {
+		return FocusContext{}, err
+	}
+	tag, err := GetSetting(settingFocusTag)
+	if err != nil {
+		return FocusContext{}, err
+	}
+	return FocusContext{Project: project, Tag: tag}, nil
+}
+
+// SetFocus stores the focus context, replacing the previous one
+func SetFocus(focus FocusContext) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		for key, value := range map[string]string{
+			settingFocusProject: focus.Project,
+			settingFocusTag:     focus.Tag,
+		} {
+			if value == "" {
+				if err := tx.Where("key = ?", key).Delete(&models.Setting{}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Save(&models.Setting{Key: key, Value: value}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ClearFocus removes the focus context
+func ClearFocus() error {
+	return SetFocus(FocusContext{})
+}
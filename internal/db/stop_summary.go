@@ -0,0 +1,134 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// StopSummary is what a stopped session added up to, printed by 'wrok stop'
+// and the timer
+type StopSummary struct {
+	Session     models.Session
+	TaskToday   time.Duration // the task's tracked time today, this session included
+	TaskTotal   time.Duration // the task's tracked time ever
+	ProjectWeek time.Duration // the project's tracked time this week; 0 without a project
+	Budget      *BudgetStatus // the project's budget, if it has one
+	Goals       []GoalStatus  // goals the session counts towards
+}
+
+// GetStopSummary aggregates the tracked time around a finished session
+func GetStopSummary(session models.Session, now time.Time) (*StopSummary, error) {
+	summary := &StopSummary{Session: session}
+
+	firstDay := time.Monday
+	if cfg, err := config.Load(); err == nil {
+		firstDay = cfg.FirstWeekday()
+	}
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekStart := parser.StartOfWeek(now, firstDay)
+
+	var err error
+	if summary.TaskToday, err = trackedSince("sessions.task_id = ?", session.TaskID, dayStart); err != nil {
+		return nil, err
+	}
+	if summary.TaskTotal, err = trackedSince("sessions.task_id = ?", session.TaskID, time.Time{}); err != nil {
+		return nil, err
+	}
+
+	project := session.Task.Project
+	if project != "" {
+		if summary.ProjectWeek, err = trackedSince("tasks.project = ?", project, weekStart); err != nil {
+			return nil, err
+		}
+		if summary.Budget, err = GetBudgetStatus(project, now); err != nil {
+			return nil, err
+		}
+	}
+
+	task, err := GetTaskByID(session.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]bool)
+	for _, tag := range task.Tags {
+		tags[tag.Name] = true
+	}
+	goals, err := GetGoalStatuses(now)
+	if err != nil {
+		return nil, err
+	}
+	for _, goal := range goals {
+		switch {
+		case goal.Goal.Scope == models.GoalOverall,
+			goal.Goal.Scope == models.GoalProject && goal.Goal.Target == project && project != "",
+			goal.Goal.Scope == models.GoalTag && tags[goal.Goal.Target]:
+			summary.Goals = append(summary.Goals, goal)
+		}
+	}
+
+	return summary, nil
+}
+
+// trackedSince sums the finished sessions matching condition (on sessions
+// joined with their tasks) that started at or after start
+func trackedSince(condition string, value interface{}, start time.Time) (time.Duration, error) {
+	var seconds int64
+	err := DB.Model(&models.Session{}).
+		Joins("JOIN tasks ON tasks.id = sessions.task_id").
+		Where(condition, value).
+		Where("sessions.finished_at IS NOT NULL AND sessions.started_at >= ?", start).
+		Select("COALESCE(SUM(sessions.duration_seconds), 0)").
+		Scan(&seconds).Error
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// String renders the summary as a few aligned lines, fit for a terminal or the clipboard
+func (s StopSummary) String() string {
+	session := s.Session
+	duration := time.Duration(session.DurationSeconds) * time.Second
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "⏹️  Stopped task #%d: %s\n", session.TaskID, session.Task.Title)
+	line := func(label, value string) {
+		fmt.Fprintf(&b, "   %-12s %s\n", label, value)
+	}
+
+	span := session.StartedAt.Format("15:04")
+	if session.FinishedAt != nil {
+		span += "–" + session.FinishedAt.Format("15:04")
+	}
+	if session.PausedSeconds > 0 {
+		span += fmt.Sprintf(", %s of breaks left out", formatHours(time.Duration(session.PausedSeconds)*time.Second))
+	}
+	line("Session", fmt.Sprintf("%s (%s)", formatHours(duration), span))
+	line("Task", fmt.Sprintf("%s today · %s in total", formatHours(s.TaskToday), formatHours(s.TaskTotal)))
+	if project := session.Task.Project; project != "" {
+		line("@"+project, fmt.Sprintf("%s this week", formatHours(s.ProjectWeek)))
+	}
+
+	if s.Budget != nil {
+		if left := s.Budget.Limit - s.Budget.Used; left > 0 {
+			line("Budget", fmt.Sprintf("%s left of %s this %s", formatHours(left), formatHours(s.Budget.Limit), s.Budget.Period))
+		} else {
+			line("Budget", fmt.Sprintf("%s over %s this %s", formatHours(-left), formatHours(s.Budget.Limit), s.Budget.Period))
+		}
+	}
+	for _, goal := range s.Goals {
+		label := "Goal " + GoalLabel(goal.Goal)
+		if left := goal.Target - goal.Tracked; left > 0 {
+			line(label, fmt.Sprintf("%s to go this %s", formatHours(left), goal.Goal.Period))
+		} else {
+			line(label, fmt.Sprintf("reached this %s ✅", goal.Goal.Period))
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/balkashynov/wrok/internal/config"
+)
+
+// tagSeparators are the word separators [tags] separator may pick
+var tagSeparators = map[string]string{"-": "_", "_": "-"}
+
+// tagPolicy returns the [tags] naming policy, the zero policy when the config can't be read
+func tagPolicy() config.TagsConfig {
+	cfg, err := config.Load()
+	if err != nil {
+		return config.TagsConfig{}
+	}
+	return cfg.Tags
+}
+
+// NormalizeTagName applies the [tags] naming policy of the config to a tag
+// name: lowercase = true lowercases it, and separator = "-" or "_" joins its
+// words with that, replacing the other one and spaces. Without a policy the
+// name is only trimmed
+func NormalizeTagName(name string) string {
+	policy := tagPolicy()
+	name = strings.TrimSpace(name)
+	if policy.Lowercase {
+		name = strings.ToLower(name)
+	}
+	if other, ok := tagSeparators[policy.Separator]; ok {
+		name = strings.Join(strings.Fields(strings.ReplaceAll(name, other, " ")), policy.Separator)
+	}
+	return name
+}
+
+// tagKey identifies a tag whatever its case and separators: names with the
+// same key are the same tag
+func tagKey(name string) string {
+	return strings.ToLower(NormalizeTagName(name))
+}
+
+// tagKeySQL is the SQL expression of tagKey over tags.name, for lookups
+func tagKeySQL() string {
+	policy := tagPolicy()
+	expr := "LOWER(TRIM(name))"
+	if other, ok := tagSeparators[policy.Separator]; ok {
+		expr = "REPLACE(REPLACE(" + expr + ", '" + other + "', '" + policy.Separator + "'), ' ', '" + policy.Separator + "')"
+	}
+	return expr
+}
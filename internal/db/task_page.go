@@ -0,0 +1,163 @@
+package db
+
+import (
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/workflow"
+)
+
+// TaskIDs returns the IDs of the tasks matching opts in their order (pinned
+// first, then opts.OrderBy or urgency) without loading the tasks, so a list
+// can hold thousands of them and load only the page on screen with GetTasksByIDs
+func TaskIDs(opts TaskQueryOptions) ([]uint, error) {
+	query := taskFilters(opts)
+
+	if opts.OrderBy == OrderUrgency {
+		return urgencyOrder(query, time.Now(), opts.Offset, opts.Limit)
+	}
+
+	query = query.Order("pinned DESC")
+	if opts.OrderBy != "" {
+		query = query.Order(opts.OrderBy)
+	}
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		query = query.Offset(opts.Offset)
+	}
+
+	var ids []uint
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CountTasks returns how many tasks match the filters of opts, at most opts.Limit
+func CountTasks(opts TaskQueryOptions) (int, error) {
+	var count int64
+	if err := taskFilters(opts).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	if opts.Limit > 0 && count > int64(opts.Limit) {
+		count = int64(opts.Limit)
+	}
+	return int(count), nil
+}
+
+// GetTasksByIDs loads the tasks with the given IDs and their tags, in the
+// order of ids. IDs of tasks deleted in the meantime are skipped
+func GetTasksByIDs(ids []uint) ([]models.Task, error) {
+	return tasksByIDs(DB, ids)
+}
+
+// tasksByIDs is GetTasksByIDs on a session of choice, such as an unscoped one
+// that also loads soft-deleted tasks
+func tasksByIDs(tx *gorm.DB, ids []uint) ([]models.Task, error) {
+	byID := make(map[uint]models.Task, len(ids))
+	for start := 0; start < len(ids); start += tagBatchSize {
+		end := min(start+tagBatchSize, len(ids))
+
+		var batch []models.Task
+		if err := tx.Where("id IN ?", ids[start:end]).Find(&batch).Error; err != nil {
+			return nil, err
+		}
+		for _, task := range batch {
+			byID[task.ID] = task
+		}
+	}
+
+	tasks := make([]models.Task, 0, len(ids))
+	for _, id := range ids {
+		if task, ok := byID[id]; ok {
+			tasks = append(tasks, task)
+		}
+	}
+	if err := loadTagsForTasks(tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetTaskTitles returns the titles of the tasks with the given IDs
+func GetTaskTitles(ids []uint) (map[uint]string, error) {
+	titles := make(map[uint]string, len(ids))
+	for start := 0; start < len(ids); start += tagBatchSize {
+		end := min(start+tagBatchSize, len(ids))
+
+		var rows []models.Task
+		if err := DB.Select("id", "title").Where("id IN ?", ids[start:end]).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			titles[row.ID] = row.Title
+		}
+	}
+	return titles, nil
+}
+
+// urgencyOrder returns the IDs of the tasks query matches, most urgent first
+// (see SortByUrgency), from offset and at most limit of them (0 = all). The
+// score is computed in SQL. For a page, a few likely urgent tasks the query
+// matches (the newest and those due first) are scored first: the page can only
+// hold tasks at least as urgent as the least urgent of the best offset+limit of
+// them, so only the candidates for that are sorted
+func urgencyOrder(query *gorm.DB, now time.Time, offset, limit int) ([]uint, error) {
+	query = query.Session(&gorm.Session{})
+	urgency, urgencyArgs := urgencySQL(now)
+	scored := func(tx *gorm.DB) *gorm.DB {
+		return tx.Select("id, "+urgency+" AS urgency", urgencyArgs...)
+	}
+
+	var rows []struct {
+		ID      uint
+		Urgency float64
+	}
+	if limit > 0 {
+		wanted := offset + limit
+		if err := scored(query).Order("pinned DESC, id DESC").Limit(wanted).Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		if len(rows) == wanted {
+			newest := rows
+			rows = nil
+			// Picked in a subquery of its own so SQLite walks the due index
+			// rather than the whole table the filters match
+			dueFirst := "id IN (SELECT id FROM tasks WHERE due IS NOT NULL AND status NOT IN (?, ?) ORDER BY due LIMIT ?)"
+			err := scored(query).Where(dueFirst, workflow.Done, workflow.Archived, wanted).Scan(&rows).Error
+			if err != nil {
+				return nil, err
+			}
+
+			best := make(map[uint]float64, 2*wanted)
+			for _, row := range append(newest, rows...) {
+				best[row.ID] = row.Urgency
+			}
+			scores := make([]float64, 0, len(best))
+			for _, score := range best {
+				scores = append(scores, score)
+			}
+			sort.Sort(sort.Reverse(sort.Float64Slice(scores)))
+
+			// A little slack for SQLite's millisecond julianday()
+			candidates, candidateArgs := urgencyCandidatesSQL(scores[wanted-1]-1e-6, now)
+			query = query.Where(candidates, candidateArgs...)
+		}
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	rows = nil
+	if err := scored(query).Order("urgency DESC, id DESC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	return ids, nil
+}
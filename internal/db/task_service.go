@@ -5,8 +5,13 @@ import (
 	"strings"
 	"time"
 
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/config"
 	"github.com/balkashynov/wrok/internal/models"
 	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/priority"
+	"github.com/balkashynov/wrok/internal/workflow"
 )
 
 // CreateTaskRequest holds the data needed to create a new task
@@ -14,11 +19,33 @@ type CreateTaskRequest struct {
 	Title    string
 	Project  string
 	Tags     []string
-	Priority string // can be "low/medium/high" or "1/2/3" or empty for no priority
+	Priority string // a level name from the priority scheme, its number, or empty for no priority
 	JiraID   string
 	URL      string
 	Note     string
 	DueDate  *time.Time
+	Estimate time.Duration // 0 for none
+	ParentID uint          // the task this is a step of; 0 for none
+}
+
+// normalizeJiraID uppercases a valid JIRA ID. Invalid IDs are kept as-is, or
+// refused when [jira] strict is set in the config
+func normalizeJiraID(jiraID string) (string, error) {
+	if jiraID == "" || parser.IsValidJiraFormat(jiraID) {
+		return parser.NormalizeJiraID(jiraID)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return "", err
+	}
+	if !cfg.Jira.Strict {
+		return jiraID, nil
+	}
+	if suggestion, ok := parser.SuggestJiraID(jiraID); ok {
+		return "", fmt.Errorf("invalid JIRA ID '%s', did you mean %s?", jiraID, suggestion)
+	}
+	return "", fmt.Errorf("invalid JIRA ID '%s'. Use: XXX-111 (letters-numbers)", jiraID)
 }
 
 // CreateTask creates a new task with tags
@@ -26,17 +53,9 @@ func CreateTask(req CreateTaskRequest) (*models.Task, error) {
 	// Parse priority (optional)
 	priority := parsePriority(req.Priority)
 	
-	// Normalize JIRA ID if provided and valid format
-	normalizedJiraID := ""
-	if req.JiraID != "" {
-		if parser.IsValidJiraFormat(req.JiraID) {
-			// Only normalize valid JIRA IDs
-			normalized, _ := parser.NormalizeJiraID(req.JiraID)
-			normalizedJiraID = normalized
-		} else {
-			// Keep invalid JIRA IDs as-is without uppercasing
-			normalizedJiraID = req.JiraID
-		}
+	normalizedJiraID, err := normalizeJiraID(req.JiraID)
+	if err != nil {
+		return nil, err
 	}
 	
 	// Create the task
@@ -49,6 +68,11 @@ func CreateTask(req CreateTaskRequest) (*models.Task, error) {
 		URL:      req.URL,
 		Note:     req.Note,
 		Due:      req.DueDate,
+		
+		EstimateSeconds: int(req.Estimate.Seconds()),
+	}
+	if req.ParentID != 0 {
+		task.ParentID = &req.ParentID
 	}
 
 	// Process tags
@@ -61,115 +85,275 @@ func CreateTask(req CreateTaskRequest) (*models.Task, error) {
 	}
 
 	// Save task to database
-	if err := DB.Create(&task).Error; err != nil {
+	if err := withRetry(func() error { return DB.Create(&task).Error }); err != nil {
 		return nil, err
 	}
 
+	emit(Event{Name: EventTaskCreated, Task: &task})
+
 	return &task, nil
 }
 
-// UpdateTaskRequest holds the data needed to update an existing task
+// UpdateTaskRequest holds changes to an existing task. Nil fields are left as
+// they are, so callers only pass what the user touched
 type UpdateTaskRequest struct {
-	ID       uint
-	Title    string
-	Project  string
-	Tags     []string
-	Priority string // can be "low/medium/high" or "1/2/3" or empty for no priority
-	JiraID   string
-	URL      string
-	Note     string
-	DueDate  *time.Time
+	ID         uint
+	Title      *string
+	Project    *string
+	Tags       *[]string // replaces all tags
+	AddTags    []string  // applied after Tags
+	RemoveTags []string
+	Priority   *string // a level name from the priority scheme, its number, or empty for no priority
+	JiraID     *string
+	URL        *string
+	Note       *string
+	DueDate    *time.Time
+	ClearDue   bool // remove the due date; DueDate is then ignored
+	Estimate   *time.Duration // 0 for none
 }
 
-// UpdateTask updates an existing task with new data
+// UpdateTask applies the changes of req to an existing task. Only the columns
+// of the given fields are written, and tag edits are applied to the tags the
+// task has at that moment, so concurrent edits of other fields don't clobber
+// each other
 func UpdateTask(req UpdateTaskRequest) (*models.Task, error) {
-	// Get the existing task
-	task, err := GetTaskByID(req.ID)
+	updates := make(map[string]interface{})
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, fmt.Errorf("title cannot be empty")
+		}
+		updates["title"] = title
+	}
+	if req.Project != nil {
+		updates["project"] = strings.TrimSpace(*req.Project)
+	}
+	if req.Priority != nil {
+		updates["priority"] = parsePriority(*req.Priority)
+	}
+	if req.JiraID != nil {
+		normalizedJiraID, err := normalizeJiraID(*req.JiraID)
+		if err != nil {
+			return nil, err
+		}
+		updates["jira_id"] = normalizedJiraID
+	}
+	if req.URL != nil {
+		updates["url"] = *req.URL
+	}
+	if req.Note != nil {
+		updates["note"] = *req.Note
+	}
+	if req.ClearDue {
+		updates["due"] = nil
+	} else if req.DueDate != nil {
+		updates["due"] = req.DueDate
+	}
+	if req.Estimate != nil {
+		updates["estimate_seconds"] = int(req.Estimate.Seconds())
+	}
+	tagsChanged := req.Tags != nil || len(req.AddTags) > 0 || len(req.RemoveTags) > 0
+
+	err := withRetry(func() error {
+		return DB.Transaction(func(tx *gorm.DB) error {
+			var task models.Task
+			if err := tx.Preload("Tags").First(&task, req.ID).Error; err != nil {
+				return fmt.Errorf("task #%d not found", req.ID)
+			}
+
+			if len(updates) > 0 {
+				if err := tx.Model(&task).Updates(updates).Error; err != nil {
+					return err
+				}
+			}
+			if !tagsChanged {
+				return nil
+			}
+
+			// A full tag list replaces the set; additions and removals alone
+			// touch only the tags they name
+			if req.Tags != nil {
+				tags, err := findOrCreateTagsTx(tx, editTagNames(*req.Tags, req.AddTags, req.RemoveTags))
+				if err != nil {
+					return err
+				}
+				return tx.Model(&task).Association("Tags").Replace(tags)
+			}
+			if err := removeTaskTagsTx(tx, &task, req.RemoveTags); err != nil {
+				return err
+			}
+			return addTaskTagsTx(tx, &task, editTagNames(nil, req.AddTags, req.RemoveTags))
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse priority (optional)
-	priority := parsePriority(req.Priority)
-	
-	// Normalize JIRA ID if provided and valid format
-	normalizedJiraID := ""
-	if req.JiraID != "" {
-		if parser.IsValidJiraFormat(req.JiraID) {
-			// Only normalize valid JIRA IDs
-			normalized, _ := parser.NormalizeJiraID(req.JiraID)
-			normalizedJiraID = normalized
-		} else {
-			// Keep invalid JIRA IDs as-is without uppercasing
-			normalizedJiraID = req.JiraID
+	return GetTaskByID(req.ID)
+}
+
+// AddTaskTags adds tags to a task and keeps the ones it has; a tag it already
+// has, in any case, is left alone
+func AddTaskTags(taskID uint, names []string) (*models.Task, error) {
+	return changeTaskTags(taskID, func(tx *gorm.DB, task *models.Task) error {
+		return addTaskTagsTx(tx, task, names)
+	})
+}
+
+// RemoveTaskTags takes tags off a task, matching their names in any case, and
+// keeps the rest
+func RemoveTaskTags(taskID uint, names []string) (*models.Task, error) {
+	return changeTaskTags(taskID, func(tx *gorm.DB, task *models.Task) error {
+		return removeTaskTagsTx(tx, task, names)
+	})
+}
+
+// changeTaskTags runs change on a task with its current tags in a transaction
+func changeTaskTags(taskID uint, change func(tx *gorm.DB, task *models.Task) error) (*models.Task, error) {
+	err := withRetry(func() error {
+		return DB.Transaction(func(tx *gorm.DB) error {
+			var task models.Task
+			if err := tx.Preload("Tags").First(&task, taskID).Error; err != nil {
+				return fmt.Errorf("task #%d not found", taskID)
+			}
+			if err := change(tx, &task); err != nil {
+				return err
+			}
+			// The tag links alone don't touch the task row
+			return tx.Model(&task).Update("updated_at", time.Now()).Error
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetTaskByID(taskID)
+}
+
+// addTaskTagsTx links the named tags the task doesn't have yet, creating them as needed
+func addTaskTagsTx(tx *gorm.DB, task *models.Task, names []string) error {
+	has := make(map[string]bool)
+	for _, tag := range task.Tags {
+		has[tagKey(tag.Name)] = true
+	}
+	var added []string
+	for _, name := range editTagNames(nil, names, nil) {
+		if !has[tagKey(name)] {
+			added = append(added, name)
 		}
 	}
-	
-	// Update task fields
-	task.Title = req.Title
-	task.Project = req.Project
-	task.Priority = priority
-	task.JiraID = normalizedJiraID
-	task.URL = req.URL
-	task.Note = req.Note
-	task.Due = req.DueDate
+	if len(added) == 0 {
+		return nil
+	}
+	tags, err := findOrCreateTagsTx(tx, added)
+	if err != nil {
+		return err
+	}
+	return tx.Model(task).Association("Tags").Append(tags)
+}
 
-	// Process tags - clear existing and set new ones
-	if len(req.Tags) > 0 {
-		tags, err := findOrCreateTags(req.Tags)
-		if err != nil {
-			return nil, err
+// removeTaskTagsTx unlinks the task's tags whose names match names by tagKey
+func removeTaskTagsTx(tx *gorm.DB, task *models.Task, names []string) error {
+	removed := make(map[string]bool)
+	for _, name := range names {
+		removed[tagKey(name)] = true
+	}
+	var tags []models.Tag
+	for _, tag := range task.Tags {
+		if removed[tagKey(tag.Name)] {
+			tags = append(tags, tag)
 		}
-		task.Tags = tags
-	} else {
-		// Clear all tags if none provided
-		task.Tags = []models.Tag{}
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return tx.Model(task).Association("Tags").Delete(tags)
+}
+
+// noteStampLayout is the timestamp in front of lines added by AppendTaskNote
+const noteStampLayout = "02/01/2006 15:04"
+
+// AppendTaskNote adds a line stamped with at to the end of a task's notes. It
+// is appended in SQL, so notes edited meanwhile by another process are kept
+func AppendTaskNote(taskID uint, text string, at time.Time) (*models.Task, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("note cannot be empty")
+	}
+	if _, err := GetTaskByID(taskID); err != nil {
+		return nil, err
 	}
 
-	// Save updated task to database
-	if err := DB.Save(&task).Error; err != nil {
+	line := fmt.Sprintf("[%s] %s", at.Format(noteStampLayout), text)
+	err := withRetry(func() error {
+		return DB.Model(&models.Task{ID: taskID}).
+			Update("note", gorm.Expr("CASE WHEN COALESCE(note, '') = '' THEN ? ELSE note || ? END", line, "\n"+line)).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return task, nil
+	return GetTaskByID(taskID)
+}
+
+// editTagNames adds and removes tag names from names, matching them by
+// tagKey and keeping the order
+func editTagNames(names, add, remove []string) []string {
+	removed := make(map[string]bool)
+	for _, name := range remove {
+		removed[tagKey(name)] = true
+	}
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, name := range append(append([]string{}, names...), add...) {
+		name = strings.TrimSpace(name)
+		key := tagKey(name)
+		if name == "" || removed[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, name)
+	}
+	return result
 }
 
 // parsePriority converts priority string to int
-func parsePriority(priority string) int {
-	priority = strings.ToLower(strings.TrimSpace(priority))
-	if priority == "" {
-		return 0 // 0 means no priority set
-	}
-	switch priority {
-	case "low", "1":
-		return 1
-	case "medium", "2":
-		return 2
-	case "high", "3":
-		return 3
-	default:
+func parsePriority(input string) int {
+	value, err := priority.Parse(input)
+	if err != nil {
 		return 0 // invalid priority defaults to no priority
 	}
+	return value
 }
 
-// findOrCreateTags finds existing tags or creates new ones
+// findOrCreateTags finds existing tags or creates new ones. Names follow the
+// [tags] naming policy and match existing tags whatever their case, so
+// "Backend" finds "backend" instead of becoming a second tag
 func findOrCreateTags(tagNames []string) ([]models.Tag, error) {
+	return findOrCreateTagsTx(DB, tagNames)
+}
+
+// findOrCreateTagsTx is findOrCreateTags within a transaction
+func findOrCreateTagsTx(tx *gorm.DB, tagNames []string) ([]models.Tag, error) {
 	var tags []models.Tag
+	seen := make(map[string]bool)
 	
 	for _, name := range tagNames {
-		name = strings.TrimSpace(name)
-		if name == "" {
+		name = NormalizeTagName(name)
+		key := tagKey(name)
+		if name == "" || seen[key] {
 			continue
 		}
+		seen[key] = true
 		
 		var tag models.Tag
 		
-		// Try to find existing tag
-		err := DB.Where("name = ?", name).First(&tag).Error
+		// Try to find existing tag, the oldest one should duplicates remain
+		err := tx.Where(tagKeySQL()+" = ?", key).Order("id ASC").First(&tag).Error
 		if err != nil {
 			// Tag doesn't exist, create it
 			tag = models.Tag{Name: name}
-			if err := DB.Create(&tag).Error; err != nil {
+			if err := tx.Create(&tag).Error; err != nil {
 				return nil, err
 			}
 		}
@@ -180,16 +364,71 @@ func findOrCreateTags(tagNames []string) ([]models.Tag, error) {
 	return tags, nil
 }
 
+// GetTagNames returns the names of all tags, most used first (deleted tasks don't count)
+func GetTagNames() ([]string, error) {
+	var names []string
+	err := DB.Table("tags").
+		Select("tags.name").
+		Joins("LEFT JOIN task_tags ON task_tags.tag_id = tags.id").
+		Joins("LEFT JOIN tasks ON tasks.id = task_tags.task_id AND tasks.deleted_at IS NULL").
+		Group("tags.id").
+		Order("COUNT(tasks.id) DESC, tags.name ASC").
+		Pluck("tags.name", &names).Error
+	return names, err
+}
+
+// GetProjectNames returns the distinct project names in use, most used first.
+// Archived projects, whose tasks are all archived, are left out
+func GetProjectNames() ([]string, error) {
+	var names []string
+	err := DB.Model(&models.Task{}).
+		Where("project != ''").
+		Group("project").
+		Having("SUM(CASE WHEN status != 'archived' THEN 1 ELSE 0 END) > 0").
+		Order("COUNT(*) DESC, project ASC").
+		Pluck("project", &names).Error
+	return names, err
+}
+
+// GetJiraPrefixes returns the JIRA project prefixes in use ("APP" for "APP-123"),
+// most recently used first
+func GetJiraPrefixes() ([]string, error) {
+	var ids []string
+	err := DB.Model(&models.Task{}).
+		Where("jira_id != ''").
+		Order("created_at DESC").
+		Pluck("jira_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var prefixes []string
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		prefix := parser.JiraPrefix(id)
+		if prefix != "" && !seen[prefix] {
+			seen[prefix] = true
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes, nil
+}
+
 // TaskQueryOptions holds options for querying tasks
 type TaskQueryOptions struct {
-	Status    string   // Filter by status
-	Project   string   // Filter by project
-	Tags      []string // Filter by tags (AND logic)
-	JiraID    string   // Filter by JIRA ID
-	Priority  string   // Filter by priority (low/medium/high)
-	OrderBy   string   // Order by clause (e.g., "id DESC", "created_at ASC")
-	Limit     int      // Limit results
-	Offset    int      // Offset for pagination
+	Status         string   // Filter by status; "open" is todo and the configured open statuses
+	HideArchived   bool     // Leave out archived tasks when no Status is given
+	Project        string   // Filter by project
+	Tags           []string // Filter by tags (AND logic)
+	JiraID         string   // Filter by JIRA ID
+	Priority       string   // Filter by priority (a level name or number)
+	Priorities     []int    // Filter to any of these priority levels
+	Overdue        bool     // Only todo tasks past their due date
+	HideSnoozed    bool     // Leave out tasks hidden by 'wrok snooze --hide' until it expires
+	IncludeDeleted bool     // Also match soft-deleted tasks that aren't purged yet
+	OrderBy        string   // Order by clause (e.g., "id DESC", "created_at ASC")
+	Limit          int      // Limit results
+	Offset         int      // Offset for pagination
 }
 
 // GetTasks retrieves all tasks (legacy function for backward compatibility)
@@ -204,12 +443,58 @@ func GetTasks() ([]models.Task, error) {
 func GetTasksWithOptions(opts TaskQueryOptions) ([]models.Task, error) {
 	var tasks []models.Task
 	
-	// Start with base query, preload tags
-	query := DB.Preload("Tags")
+	query := taskFilters(opts)
+	
+	if opts.OrderBy == OrderUrgency {
+		ids, err := urgencyOrder(query, time.Now(), opts.Offset, opts.Limit)
+		if err != nil {
+			return nil, err
+		}
+		return GetTasksByIDs(ids)
+	}
+	
+	// Apply ordering - pinned tasks always come first
+	query = query.Order("pinned DESC")
+	if opts.OrderBy != "" {
+		query = query.Order(opts.OrderBy)
+	}
+	
+	// Apply pagination
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		query = query.Offset(opts.Offset)
+	}
+	
+	// Execute query
+	if err := query.Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	
+	if err := loadTagsForTasks(tasks); err != nil {
+		return nil, err
+	}
+	
+	return tasks, nil
+}
+
+// taskFilters starts a query on the tasks matching the filters of opts. Tags are
+// loaded afterwards in one batched query so that ordering and limits are
+// applied to the tasks table alone
+func taskFilters(opts TaskQueryOptions) *gorm.DB {
+	query := DB.Model(&models.Task{})
+	if opts.IncludeDeleted {
+		query = query.Unscoped()
+	}
 	
 	// Apply filters
-	if opts.Status != "" {
+	if opts.Status == "open" {
+		query = query.Where("status NOT IN ?", []string{workflow.Done, workflow.Archived})
+	} else if opts.Status != "" {
 		query = query.Where("status = ?", opts.Status)
+	} else if opts.HideArchived {
+		query = query.Where("status <> ?", "archived")
 	}
 	
 	if opts.Project != "" {
@@ -221,52 +506,83 @@ func GetTasksWithOptions(opts TaskQueryOptions) ([]models.Task, error) {
 	}
 	
 	if opts.Priority != "" {
-		// Convert priority string to int
-		var priorityInt int
-		switch strings.ToLower(opts.Priority) {
-		case "low", "1":
-			priorityInt = 1
-		case "medium", "med", "2":
-			priorityInt = 2
-		case "high", "3":
-			priorityInt = 3
-		default:
-			priorityInt = 0 // No priority
-		}
-		query = query.Where("priority = ?", priorityInt)
+		// Unknown names match tasks without a priority
+		query = query.Where("priority = ?", parsePriority(opts.Priority))
+	}
+	
+	if len(opts.Priorities) > 0 {
+		query = query.Where("priority IN ?", opts.Priorities)
+	}
+	
+	if opts.Overdue {
+		query = query.Where("status NOT IN ? AND due < ?", []string{workflow.Done, workflow.Archived}, time.Now())
+	}
+	
+	if opts.HideSnoozed {
+		query = query.Where("hidden_until IS NULL OR hidden_until <= ?", time.Now())
 	}
 	
 	// Filter by tags (AND logic - task must have all specified tags)
 	if len(opts.Tags) > 0 {
-		// Use subquery to find tasks that have all specified tags
+		// A correlated EXISTS per tag walks task_tags by task, which is far
+		// cheaper on a big table than building the whole list of tagged IDs
 		for _, tag := range opts.Tags {
-			query = query.Where("id IN (?)", 
+			query = query.Where("EXISTS (?)",
 				DB.Table("task_tags").
-					Select("task_id").
+					Select("1").
 					Joins("JOIN tags ON task_tags.tag_id = tags.id").
-					Where("tags.name LIKE ?", "%"+tag+"%"))
+					Where("task_tags.task_id = tasks.id AND tags.name LIKE ?", "%"+tag+"%"))
 		}
 	}
 	
-	// Apply ordering
-	if opts.OrderBy != "" {
-		query = query.Order(opts.OrderBy)
+	return query
+}
+
+// tagBatchSize keeps IN (...) lists well below SQLite's bound variable limit
+const tagBatchSize = 500
+
+// loadTagsForTasks fills the Tags of every task using one joined query per batch
+// instead of a separate preload round trip for the join table and tags table
+func loadTagsForTasks(tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
 	}
 	
-	// Apply pagination
-	if opts.Limit > 0 {
-		query = query.Limit(opts.Limit)
+	indexByID := make(map[uint]int, len(tasks))
+	ids := make([]uint, 0, len(tasks))
+	for i := range tasks {
+		indexByID[tasks[i].ID] = i
+		ids = append(ids, tasks[i].ID)
+		tasks[i].Tags = []models.Tag{}
 	}
-	if opts.Offset > 0 {
-		query = query.Offset(opts.Offset)
+	
+	type taskTagRow struct {
+		TaskID uint
+		TagID  uint
+		Name   string
 	}
 	
-	// Execute query
-	if err := query.Find(&tasks).Error; err != nil {
-		return nil, err
+	for start := 0; start < len(ids); start += tagBatchSize {
+		end := min(start+tagBatchSize, len(ids))
+		
+		var rows []taskTagRow
+		err := DB.Table("task_tags").
+			Select("task_tags.task_id AS task_id, tags.id AS tag_id, tags.name AS name").
+			Joins("JOIN tags ON tags.id = task_tags.tag_id").
+			Where("task_tags.task_id IN ?", ids[start:end]).
+			Order("tags.id ASC").
+			Scan(&rows).Error
+		if err != nil {
+			return err
+		}
+		
+		for _, row := range rows {
+			i := indexByID[row.TaskID]
+			tasks[i].Tags = append(tasks[i].Tags, models.Tag{ID: row.TagID, Name: row.Name})
+		}
 	}
 	
-	return tasks, nil
+	return nil
 }
 
 // GetActiveTask returns the currently active (tracking time) task
@@ -295,6 +611,9 @@ func MarkTaskDone(taskID uint) (*models.Task, error) {
 	if task.Status == "done" {
 		return nil, fmt.Errorf("task #%d is already completed", taskID)
 	}
+	if err := workflow.CheckMove(task.Status, workflow.Done); err != nil {
+		return nil, fmt.Errorf("task #%d: %w", taskID, err)
+	}
 	
 	// Check if there's an active session for this task and stop it
 	activeSession, err := GetActiveSession()
@@ -305,15 +624,16 @@ func MarkTaskDone(taskID uint) (*models.Task, error) {
 		}
 	}
 	
-	// Update task status
-	now := time.Now()
-	task.Status = "done"
-	task.DoneAt = &now
-	
-	if err := DB.Save(task).Error; err != nil {
+	// Update only the status columns, keeping edits made meanwhile elsewhere
+	previous := task.Status
+	task, err = updateTaskColumns(taskID, map[string]interface{}{"status": workflow.Done, "done_at": time.Now()})
+	if err != nil {
 		return nil, err
 	}
 	
+	recordStatusChange(task.ID, previous, task.Status)
+	emit(Event{Name: EventTaskDone, Task: task})
+	
 	return task, nil
 }
 
@@ -328,6 +648,9 @@ func ArchiveTask(taskID uint) (*models.Task, error) {
 	if task.Status == "archived" {
 		return nil, fmt.Errorf("task #%d is already archived", taskID)
 	}
+	if err := workflow.CheckMove(task.Status, workflow.Archived); err != nil {
+		return nil, fmt.Errorf("task #%d: %w", taskID, err)
+	}
 	
 	// Check if there's an active session for this task and stop it
 	activeSession, err := GetActiveSession()
@@ -338,15 +661,16 @@ func ArchiveTask(taskID uint) (*models.Task, error) {
 		}
 	}
 	
-	// Update task status
-	now := time.Now()
-	task.Status = "archived"
-	task.ArchivedAt = &now
-	
-	if err := DB.Save(task).Error; err != nil {
+	// Update only the status columns, keeping edits made meanwhile elsewhere
+	previous := task.Status
+	task, err = updateTaskColumns(taskID, map[string]interface{}{"status": workflow.Archived, "archived_at": time.Now()})
+	if err != nil {
 		return nil, err
 	}
 	
+	recordStatusChange(task.ID, previous, task.Status)
+	emit(Event{Name: EventTaskArchived, Task: task})
+	
 	return task, nil
 }
 
@@ -361,18 +685,51 @@ func UnarchiveTask(taskID uint) (*models.Task, error) {
 	if task.Status != "archived" {
 		return nil, fmt.Errorf("task #%d is not archived", taskID)
 	}
+	if err := workflow.CheckMove(task.Status, workflow.Todo); err != nil {
+		return nil, fmt.Errorf("task #%d: %w", taskID, err)
+	}
 	
-	// Update task status back to todo
-	task.Status = "todo"
-	task.ArchivedAt = nil // Clear archived timestamp
-	
-	if err := DB.Save(task).Error; err != nil {
+	// Update task status back to todo and clear the archived timestamp
+	task, err = updateTaskColumns(taskID, map[string]interface{}{"status": workflow.Todo, "archived_at": nil})
+	if err != nil {
 		return nil, err
 	}
 	
+	recordStatusChange(task.ID, "archived", task.Status)
+	
 	return task, nil
 }
 
+// PinTask pins a task so it is listed before unpinned tasks
+func PinTask(taskID uint) (*models.Task, error) {
+	// Get the task
+	task, err := GetTaskByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if task.Pinned {
+		return nil, fmt.Errorf("task #%d is already pinned", taskID)
+	}
+	
+	return updateTaskColumns(taskID, map[string]interface{}{"pinned": true})
+}
+
+// UnpinTask removes the pin from a task
+func UnpinTask(taskID uint) (*models.Task, error) {
+	// Get the task
+	task, err := GetTaskByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if !task.Pinned {
+		return nil, fmt.Errorf("task #%d is not pinned", taskID)
+	}
+	
+	return updateTaskColumns(taskID, map[string]interface{}{"pinned": false})
+}
+
 // MarkTaskUndone moves a done task back to todo status
 func MarkTaskUndone(taskID uint) (*models.Task, error) {
 	// Get the task
@@ -384,123 +741,248 @@ func MarkTaskUndone(taskID uint) (*models.Task, error) {
 	if task.Status != "done" {
 		return nil, fmt.Errorf("task #%d is not completed", taskID)
 	}
+	if err := workflow.CheckMove(task.Status, workflow.Todo); err != nil {
+		return nil, fmt.Errorf("task #%d: %w", taskID, err)
+	}
 	
-	// Update task status back to todo
-	task.Status = "todo"
-	task.DoneAt = nil // Clear done timestamp
-	
-	if err := DB.Save(task).Error; err != nil {
+	// Update task status back to todo and clear the done timestamp
+	task, err = updateTaskColumns(taskID, map[string]interface{}{"status": workflow.Todo, "done_at": nil})
+	if err != nil {
 		return nil, err
 	}
 	
+	recordStatusChange(task.ID, "done", task.Status)
+	
 	return task, nil
 }
 
-// SearchTasks performs comprehensive search across all task fields
-// Search priority: exact match > prefix > suffix > fuzzy (case insensitive)
-func SearchTasks(query string, opts TaskQueryOptions) ([]models.Task, error) {
-	if query == "" {
-		return GetTasksWithOptions(opts)
+// SetTaskStatus moves a task to any status of the workflow, if the [status]
+// transitions allow it. Moving to done or archived is MarkTaskDone or
+// ArchiveTask; moving out of them clears the time they were set
+func SetTaskStatus(taskID uint, status string) (*models.Task, error) {
+	status, err := workflow.Parse(status)
+	if err != nil {
+		return nil, err
 	}
-	
+	switch status {
+	case workflow.Done:
+		return MarkTaskDone(taskID)
+	case workflow.Archived:
+		return ArchiveTask(taskID)
+	}
+
+	// Read, check and write in one transaction, touching only the status columns
+	err = withRetry(func() error {
+		return DB.Transaction(func(tx *gorm.DB) error {
+			var task models.Task
+			if err := tx.First(&task, taskID).Error; err != nil {
+				return fmt.Errorf("task #%d not found", taskID)
+			}
+			if task.Status == status {
+				return fmt.Errorf("task #%d is already %s", taskID, status)
+			}
+			if err := workflow.CheckMove(task.Status, status); err != nil {
+				return fmt.Errorf("task #%d: %w", taskID, err)
+			}
+
+			comment := statusChangeComment(taskID, task.Status, status)
+			updates := map[string]interface{}{"status": status, "done_at": nil, "archived_at": nil}
+			if err := tx.Model(&task).Updates(updates).Error; err != nil {
+				return err
+			}
+			return tx.Create(&comment).Error
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetTaskByID(taskID)
+}
+
+// FindTasksByTitle returns the tasks whose titles best match query, limited to the given
+// statuses (all if none). Matches are tiered: exact title, then prefix, then substring,
+// then titles containing every word of the query; only the best tier found is returned
+func FindTasksByTitle(query string, statuses ...string) ([]models.Task, error) {
 	query = strings.ToLower(strings.TrimSpace(query))
 	if query == "" {
-		return GetTasksWithOptions(opts)
+		return nil, nil
 	}
 	
-	// Get all tasks first (with existing filters applied)
-	allTasks, err := GetTasksWithOptions(opts)
-	if err != nil {
-		return nil, err
+	dbQuery := DB.Order("pinned DESC, id DESC")
+	if len(statuses) > 0 {
+		dbQuery = dbQuery.Where("status IN ?", statuses)
 	}
 	
-	var exactMatches []models.Task
-	var prefixMatches []models.Task
-	var suffixMatches []models.Task
-	var fuzzyMatches []models.Task
+	var tasks []models.Task
+	if err := dbQuery.Find(&tasks).Error; err != nil {
+		return nil, err
+	}
 	
-	for _, task := range allTasks {
-		// Build searchable text from all fields
-		searchableFields := []string{
-			fmt.Sprintf("%d", task.ID), // Include task ID
-			task.Title,
-			task.Project,
-			task.JiraID,
-			task.Note,
+	words := strings.Fields(query)
+	tiers := make([][]models.Task, 4)
+	for _, task := range tasks {
+		title := strings.ToLower(task.Title)
+		switch {
+		case title == query:
+			tiers[0] = append(tiers[0], task)
+		case strings.HasPrefix(title, query):
+			tiers[1] = append(tiers[1], task)
+		case strings.Contains(title, query):
+			tiers[2] = append(tiers[2], task)
+		case containsAllWords(title, words):
+			tiers[3] = append(tiers[3], task)
 		}
-		
-		// Add tag names
-		for _, tag := range task.Tags {
-			searchableFields = append(searchableFields, tag.Name)
+	}
+	
+	for _, tier := range tiers {
+		if len(tier) > 0 {
+			if err := loadTagsForTasks(tier); err != nil {
+				return nil, err
+			}
+			return tier, nil
 		}
-		
-		// Add priority as text
-		switch task.Priority {
-		case 1:
-			searchableFields = append(searchableFields, "low", "1")
-		case 2:
-			searchableFields = append(searchableFields, "medium", "med", "2")
-		case 3:
-			searchableFields = append(searchableFields, "high", "3")
+	}
+	return nil, nil
+}
+
+// containsAllWords reports whether text contains every word, in any order
+func containsAllWords(text string, words []string) bool {
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
 		}
-		
-		// Add status
-		searchableFields = append(searchableFields, task.Status)
-		
-		// Check each field for matches
-		found := false
-		matchType := ""
-		
-		for _, field := range searchableFields {
-			if field == "" {
-				continue
-			}
-			
-			fieldLower := strings.ToLower(field)
-			
-			// 1. Exact match (highest priority)
-			if fieldLower == query {
-				exactMatches = append(exactMatches, task)
-				found = true
-				matchType = "exact"
-				break
-			}
-			
-			// 2. Prefix match
-			if matchType == "" && strings.HasPrefix(fieldLower, query) {
-				matchType = "prefix"
-			}
-			
-			// 3. Suffix match
-			if matchType == "" && strings.HasSuffix(fieldLower, query) {
-				matchType = "suffix"
-			}
-			
-			// 4. Fuzzy match (contains)
-			if matchType == "" && strings.Contains(fieldLower, query) {
-				matchType = "fuzzy"
-			}
+	}
+	return len(words) > 0
+}
+
+// GetOpenTasksDueBy returns open tasks with a due date at or before the given time
+func GetOpenTasksDueBy(t time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+
+	err := DB.Preload("Tags").
+		Where("status NOT IN ? AND due IS NOT NULL AND due <= ?", []string{workflow.Done, workflow.Archived}, t).
+		Order("due ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// DueSoon is what the due-date banner reports: todo tasks past their due date
+// and those due later today, soonest first
+type DueSoon struct {
+	Overdue  []models.Task
+	DueToday []models.Task
+}
+
+// GetDueSoon returns the todo tasks that are overdue or due later today
+func GetDueSoon(now time.Time) (DueSoon, error) {
+	endOfDay := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, now.Location())
+	tasks, err := GetOpenTasksDueBy(endOfDay)
+	if err != nil {
+		return DueSoon{}, err
+	}
+
+	var due DueSoon
+	for _, task := range tasks {
+		if task.Due.Before(now) {
+			due.Overdue = append(due.Overdue, task)
+		} else {
+			due.DueToday = append(due.DueToday, task)
 		}
-		
-		// Add to appropriate list if found and not exact
-		if !found && matchType != "" {
-			switch matchType {
-			case "prefix":
-				prefixMatches = append(prefixMatches, task)
-			case "suffix":
-				suffixMatches = append(suffixMatches, task)
-			case "fuzzy":
-				fuzzyMatches = append(fuzzyMatches, task)
-			}
+	}
+	return due, nil
+}
+
+// GetTasksCompletedInRange returns tasks marked done within the specified range
+func GetTasksCompletedInRange(startTime, endTime time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+
+	err := DB.Preload("Tags").
+		Where("status = ? AND done_at >= ? AND done_at <= ?", "done", startTime, endTime).
+		Order("done_at ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// RescheduleTask sets a new due date on a task
+func RescheduleTask(taskID uint, due *time.Time) (*models.Task, error) {
+	return updateTaskColumns(taskID, map[string]interface{}{"due": due})
+}
+
+// SnoozeTask sets a new due date on a task and hides it from listings until
+// hiddenUntil; a nil hiddenUntil shows it again
+func SnoozeTask(taskID uint, due, hiddenUntil *time.Time) (*models.Task, error) {
+	return updateTaskColumns(taskID, map[string]interface{}{"due": due, "hidden_until": hiddenUntil})
+}
+
+// updateTaskColumns writes only the given columns of a task and returns it as
+// stored, so a change another process made to the rest of the row is kept
+func updateTaskColumns(taskID uint, updates map[string]interface{}) (*models.Task, error) {
+	err := withRetry(func() error {
+		result := DB.Model(&models.Task{}).Where("id = ?", taskID).Updates(updates)
+		if result.Error == nil && result.RowsAffected == 0 {
+			return fmt.Errorf("task #%d not found", taskID)
 		}
+		return result.Error
+	})
+	if err != nil {
+		return nil, err
 	}
-	
-	// Combine results in priority order
-	var results []models.Task
-	results = append(results, exactMatches...)
-	results = append(results, prefixMatches...)
-	results = append(results, suffixMatches...)
-	results = append(results, fuzzyMatches...)
-	
-	return results, nil
-}
\ No newline at end of file
+	return GetTaskByID(taskID)
+}
+
+// SetTaskNote replaces a task's notes
+func SetTaskNote(taskID uint, note string) (*models.Task, error) {
+	return updateTaskColumns(taskID, map[string]interface{}{"note": note})
+}
+
+// GetTasksByJiraID returns the tasks linked to a JIRA ID, ignoring case, newest first
+func GetTasksByJiraID(jiraID string) ([]models.Task, error) {
+	var tasks []models.Task
+	err := DB.Where("UPPER(jira_id) = ?", strings.ToUpper(jiraID)).
+		Order("id DESC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetJiraLinkedTasks returns every task with a JIRA ID, oldest first
+func GetJiraLinkedTasks() ([]models.Task, error) {
+	var tasks []models.Task
+	if err := DB.Where("jira_id <> ''").Order("id ASC").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// JiraIssueInfo is what 'wrok jira fetch' copies from a Jira issue onto a task
+type JiraIssueInfo struct {
+	Status   string
+	Assignee string
+	Title    string // replaces the task's title; empty keeps it
+}
+
+// ApplyJiraIssue stores a Jira issue's status and assignee on a task and
+// optionally takes over its summary as the title
+func ApplyJiraIssue(taskID uint, info JiraIssueInfo, now time.Time) (*models.Task, error) {
+	// Only the Jira columns are written, so local edits made meanwhile survive
+	updates := map[string]interface{}{
+		"jira_status":    info.Status,
+		"jira_assignee":  info.Assignee,
+		"jira_synced_at": now,
+	}
+	if info.Title != "" {
+		updates["title"] = info.Title
+	}
+	return updateTaskColumns(taskID, updates)
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/workflow"
+)
+
+// TestStatusChangesKeepConcurrentEdits renames a task from a second connection,
+// as another wrok process would, just before each status change writes, and
+// checks the rename survives it
+func TestStatusChangesKeepConcurrentEdits(t *testing.T) {
+	task, err := CreateTask(CreateTaskRequest{Title: "status race"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := getDatabasePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := gorm.Open(sqlite.Open(dataSource(path)), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sqlDB, err := other.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	// The rename for the next write; it runs before that write's transaction
+	rename := ""
+	err = DB.Callback().Update().Before("gorm:begin_transaction").Register("test:concurrent_edit", func(tx *gorm.DB) {
+		if rename == "" {
+			return
+		}
+		if err := other.Exec("UPDATE tasks SET title = ? WHERE id = ?", rename, task.ID).Error; err != nil {
+			t.Error(err)
+		}
+		rename = ""
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer DB.Callback().Update().Remove("test:concurrent_edit")
+
+	changes := []struct {
+		title  string
+		status string
+		change func(uint) (*models.Task, error)
+	}{
+		{"renamed while marked done", workflow.Done, MarkTaskDone},
+		{"renamed while reopened", workflow.Todo, MarkTaskUndone},
+		{"renamed while archived", workflow.Archived, ArchiveTask},
+		{"renamed while unarchived", workflow.Todo, UnarchiveTask},
+	}
+	for _, change := range changes {
+		rename = change.title
+		if _, err := change.change(task.ID); err != nil {
+			t.Fatalf("%s: %v", change.title, err)
+		}
+		stored, err := GetTaskByID(task.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Title != change.title || stored.Status != change.status {
+			t.Errorf("got %q %s, want %q %s", stored.Title, stored.Status, change.title, change.status)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// TimesheetRow is a session as edited on a day's timesheet
+type TimesheetRow struct {
+	ID     uint // 0 for a session to add
+	TaskID uint
+	Start  time.Time
+	End    *time.Time // nil only for the running session
+	Note   string
+}
+
+// TimesheetChanges counts what SaveTimesheet did
+type TimesheetChanges struct {
+	Added   int
+	Updated int
+	Deleted int
+}
+
+// GetTimesheet returns the sessions started on day, oldest first, with their tasks
+func GetTimesheet(day time.Time) ([]models.Session, error) {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	var sessions []models.Session
+	err := DB.Preload("Task").
+		Where("started_at >= ? AND started_at < ?", start, start.AddDate(0, 0, 1)).
+		Order("started_at ASC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// SaveTimesheet replaces the sessions started on day with rows in one
+// transaction: rows with an ID update that session, rows without one are
+// added, and the day's sessions missing from rows are deleted. Every row must
+// start on day and end after it starts, only the running session may stay
+// open, and no two sessions may overlap, including sessions of other days.
+// Locked sessions are only changed or deleted when forced
+func SaveTimesheet(day time.Time, rows []TimesheetRow, force bool) (*TimesheetChanges, error) {
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	now := time.Now()
+
+	// Hold the session lock so no timer starts or stops while the day is rewritten
+	unlock, err := lockSessions()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	existing, err := GetTimesheet(dayStart)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Session, len(existing))
+	for _, session := range existing {
+		byID[session.ID] = session
+	}
+
+	// Validate every row on its own, then the rows against each other
+	sorted := append([]TimesheetRow(nil), rows...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+	kept := make(map[uint]bool, len(rows))
+	for i, row := range sorted {
+		label := "new session"
+		if row.ID != 0 {
+			label = fmt.Sprintf("session #%d", row.ID)
+			session, ok := byID[row.ID]
+			if !ok {
+				return nil, fmt.Errorf("%s doesn't belong to %s", label, dayStart.Format("02/01/2006"))
+			}
+			if kept[row.ID] {
+				return nil, fmt.Errorf("%s is on the timesheet twice", label)
+			}
+			kept[row.ID] = true
+			if row.End == nil && session.FinishedAt != nil {
+				return nil, fmt.Errorf("%s is finished and needs an end time", label)
+			}
+			if timesheetRowChanged(session, row) {
+				if err := checkUnlocked(&session, force); err != nil {
+					return nil, err
+				}
+			}
+		} else if row.End == nil {
+			return nil, fmt.Errorf("a new session needs an end time; use 'wrok start' to run a timer")
+		}
+
+		if _, err := GetTaskByID(row.TaskID); err != nil {
+			return nil, fmt.Errorf("%s: %w", label, err)
+		}
+		if row.Start.Before(dayStart) || !row.Start.Before(dayEnd) {
+			return nil, fmt.Errorf("%s starts at %s, not on %s", label, row.Start.Format("02/01/2006 15:04"), dayStart.Format("02/01/2006"))
+		}
+		if row.Start.After(now) {
+			return nil, fmt.Errorf("%s starts in the future", label)
+		}
+		if row.End != nil && !row.End.After(row.Start) {
+			return nil, fmt.Errorf("%s must end after it starts (%s)", label, row.Start.Format("15:04"))
+		}
+		if row.End != nil && row.End.After(now) {
+			return nil, fmt.Errorf("%s ends in the future", label)
+		}
+
+		if i > 0 {
+			previous := sorted[i-1]
+			if previous.End == nil || previous.End.After(row.Start) {
+				return nil, fmt.Errorf("the sessions at %s and %s overlap", previous.Start.Format("15:04"), row.Start.Format("15:04"))
+			}
+		}
+	}
+	for _, session := range existing {
+		if !kept[session.ID] {
+			if err := checkUnlocked(&session, force); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Sessions of other days that reach into the time the rows cover
+	if len(sorted) > 0 {
+		from, to := sorted[0].Start, now
+		if last := sorted[len(sorted)-1]; last.End != nil {
+			to = *last.End
+		}
+		var others []models.Session
+		err := DB.Where("(started_at < ? OR started_at >= ?) AND started_at < ? AND (finished_at IS NULL OR finished_at > ?)",
+			dayStart, dayEnd, to, from).Find(&others).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range others {
+			for _, row := range sorted {
+				end := now
+				if row.End != nil {
+					end = *row.End
+				}
+				otherEnd := now
+				if other.FinishedAt != nil {
+					otherEnd = *other.FinishedAt
+				}
+				if row.Start.Before(otherEnd) && other.StartedAt.Before(end) {
+					return nil, fmt.Errorf("the session at %s overlaps session #%d (%s–%s)",
+						row.Start.Format("15:04"), other.ID, other.StartedAt.Format("02/01 15:04"), otherEnd.Format("02/01 15:04"))
+				}
+			}
+		}
+	}
+
+	changes := &TimesheetChanges{}
+	err = withRetry(func() error {
+		*changes = TimesheetChanges{}
+		return DB.Transaction(func(tx *gorm.DB) error {
+			for _, session := range existing {
+				if kept[session.ID] {
+					continue
+				}
+				if err := tx.Delete(&models.Session{}, session.ID).Error; err != nil {
+					return err
+				}
+				changes.Deleted++
+			}
+
+			for _, row := range rows {
+				if row.ID == 0 {
+					session := models.Session{
+						TaskID:          row.TaskID,
+						StartedAt:       row.Start,
+						FinishedAt:      row.End,
+						DurationSeconds: int(row.End.Sub(row.Start).Seconds()),
+						Note:            row.Note,
+					}
+					if err := tx.Create(&session).Error; err != nil {
+						return err
+					}
+					changes.Added++
+					continue
+				}
+
+				session := byID[row.ID]
+				if !timesheetRowChanged(session, row) {
+					continue
+				}
+				session.TaskID = row.TaskID
+				session.StartedAt = row.Start
+				session.FinishedAt = row.End
+				session.Note = row.Note
+				if row.End != nil {
+					// Breaks outside the new times no longer count against it
+					var pauses []models.SessionPause
+					if err := tx.Where("session_id = ?", session.ID).Find(&pauses).Error; err != nil {
+						return err
+					}
+					if session.PausedAt != nil {
+						// Ending a session left on a break ends the break too
+						err := tx.Model(&models.SessionPause{}).
+							Where("session_id = ? AND ended_at IS NULL", session.ID).
+							Update("ended_at", *row.End).Error
+						if err != nil {
+							return err
+						}
+						session.PausedAt = nil
+					}
+					session.PausedSeconds = int(pausedBetween(pauses, row.Start, *row.End).Seconds())
+					session.DurationSeconds = int(row.End.Sub(row.Start).Seconds()) - session.PausedSeconds
+				}
+				if err := tx.Omit("Task").Save(&session).Error; err != nil {
+					return err
+				}
+				changes.Updated++
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// timesheetRowChanged reports whether a row differs from the session it edits
+func timesheetRowChanged(session models.Session, row TimesheetRow) bool {
+	if session.TaskID != row.TaskID || !session.StartedAt.Equal(row.Start) || session.Note != row.Note {
+		return true
+	}
+	if session.FinishedAt == nil || row.End == nil {
+		return (session.FinishedAt == nil) != (row.End == nil)
+	}
+	return !session.FinishedAt.Equal(*row.End)
+}
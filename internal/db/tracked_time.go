@@ -0,0 +1,134 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// TrackedTimeQuery selects the sessions to add up; empty fields don't filter
+type TrackedTimeQuery struct {
+	TaskID  uint
+	Project string
+	Tag     string
+	From    time.Time // sessions started at or after
+	To      time.Time // sessions started before
+	Locked  *bool     // only locked (true) or unlocked (false) sessions
+}
+
+// TaskTime is the time tracked on one task
+type TaskTime struct {
+	Task     models.Task
+	Duration time.Duration
+}
+
+// TrackedTime is the time tracked in the sessions a TrackedTimeQuery selects
+type TrackedTime struct {
+	Total    time.Duration
+	Sessions int
+	Tasks    []TaskTime // most time first
+}
+
+// GetTrackedSessions returns the sessions matching query with their task and its tags, oldest first
+func GetTrackedSessions(query TrackedTimeQuery) ([]models.Session, error) {
+	var sessions []models.Session
+	err := trackedSessions(query).Preload("Task.Tags").Order("sessions.started_at ASC").Find(&sessions).Error
+	return sessions, err
+}
+
+// trackedSessions selects the sessions matching query
+func trackedSessions(query TrackedTimeQuery) *gorm.DB {
+	dbQuery := DB.Model(&models.Session{}).Preload("Task")
+	if query.TaskID != 0 {
+		dbQuery = dbQuery.Where("sessions.task_id = ?", query.TaskID)
+	}
+	if query.Project != "" {
+		dbQuery = dbQuery.Joins("JOIN tasks ON tasks.id = sessions.task_id").
+			Where("tasks.project = ?", query.Project)
+	}
+	if query.Tag != "" {
+		dbQuery = dbQuery.Joins("JOIN task_tags ON task_tags.task_id = sessions.task_id").
+			Joins("JOIN tags ON tags.id = task_tags.tag_id").
+			Where("LOWER(tags.name) = LOWER(?)", query.Tag)
+	}
+	if !query.From.IsZero() {
+		dbQuery = dbQuery.Where("sessions.started_at >= ?", query.From)
+	}
+	if !query.To.IsZero() {
+		dbQuery = dbQuery.Where("sessions.started_at < ?", query.To)
+	}
+	if query.Locked != nil {
+		dbQuery = whereLocked(dbQuery, "sessions", *query.Locked)
+	}
+	return dbQuery
+}
+
+// SumTrackedTime adds up the sessions matching query; a running session counts up to now.
+// The finished sessions' sum is cached (see aggregates), keyed by query
+func SumTrackedTime(query TrackedTimeQuery, now time.Time) (TrackedTime, error) {
+	finished, err := cachedAggregate("tracked "+query.cacheKey(), func() (TrackedTime, error) {
+		var sessions []models.Session
+		if err := trackedSessions(query).Where("sessions.finished_at IS NOT NULL").Find(&sessions).Error; err != nil {
+			return TrackedTime{}, err
+		}
+		return addSessions(TrackedTime{}, sessions, now), nil
+	})
+	if err != nil {
+		return TrackedTime{}, err
+	}
+
+	var running []models.Session
+	if err := trackedSessions(query).Where("sessions.finished_at IS NULL").Find(&running).Error; err != nil {
+		return TrackedTime{}, err
+	}
+	return addSessions(finished, running, now), nil
+}
+
+// cacheKey identifies the sessions a query selects
+func (q TrackedTimeQuery) cacheKey() string {
+	locked := ""
+	if q.Locked != nil {
+		locked = fmt.Sprint(*q.Locked)
+	}
+	return fmt.Sprintf("%d|%s|%s|%s|%s|%s", q.TaskID, q.Project, q.Tag, q.From.Format(time.RFC3339Nano), q.To.Format(time.RFC3339Nano), locked)
+}
+
+// addSessions returns tracked with sessions added to its totals; tracked itself,
+// which may be cached, is left alone
+func addSessions(tracked TrackedTime, sessions []models.Session, now time.Time) TrackedTime {
+	var tasks []TaskTime
+	tasks = append(tasks, tracked.Tasks...)
+	index := make(map[uint]int, len(tasks))
+	for i, taskTime := range tasks {
+		if taskTime.Task.ID != 0 {
+			index[taskTime.Task.ID] = i
+		}
+	}
+
+	for _, session := range sessions {
+		duration := session.Elapsed(now)
+		tracked.Total += duration
+		tracked.Sessions++
+
+		i, ok := index[session.TaskID]
+		if !ok {
+			i = len(tasks)
+			index[session.TaskID] = i
+			tasks = append(tasks, TaskTime{Task: session.Task})
+		}
+		tasks[i].Duration += duration
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].Duration != tasks[j].Duration {
+			return tasks[i].Duration > tasks[j].Duration
+		}
+		return tasks[i].Task.ID < tasks[j].Task.ID
+	})
+	tracked.Tasks = tasks
+	return tracked
+}
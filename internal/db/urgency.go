@@ -0,0 +1,195 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/priority"
+	"github.com/balkashynov/wrok/internal/workflow"
+)
+
+// OrderUrgency is the TaskQueryOptions.OrderBy value that sorts by urgency score
+const OrderUrgency = "urgency"
+
+// Urgency coefficients, loosely following Taskwarrior
+const (
+	urgencyPinned     = 5.0
+	urgencyHigh       = 6.0 // highest priority; levels in between are interpolated
+	urgencyLow        = 1.8 // lowest priority
+	urgencyDue        = 12.0
+	urgencyStale      = 2.0
+	urgencyStaleDays  = 30.0 // days untouched before staleness maxes out
+	urgencyDueHorizon = 14.0 // due dates further away than this count the minimum
+	urgencyOverdueMax = 7.0  // days overdue at which the due term maxes out
+	urgencyDueMin     = 0.2  // share of urgencyDue a due date beyond the horizon adds
+)
+
+// urgencyPinnedRank is added to the SQL sort key of pinned tasks so they sort
+// before every unpinned one, as SortByUrgency does; scores stay far below it
+const urgencyPinnedRank = 100.0
+
+// UrgencyScore combines priority, due date proximity, pinned state and staleness
+// into a single number; higher is more urgent. Closed tasks score 0
+func UrgencyScore(task models.Task, now time.Time) float64 {
+	if task.Status == workflow.Done || task.Status == workflow.Archived {
+		return 0
+	}
+
+	score := 0.0
+
+	if task.Pinned {
+		score += urgencyPinned
+	}
+
+	score += priorityUrgency(task.Priority)
+
+	if task.Due != nil {
+		score += dueUrgency(task.Due.Sub(now).Hours() / 24)
+	}
+
+	// Staleness: tasks nobody touched for a while slowly float up
+	staleDays := now.Sub(task.UpdatedAt).Hours() / 24
+	if staleDays > urgencyStaleDays {
+		staleDays = urgencyStaleDays
+	}
+	if staleDays > 0 {
+		score += urgencyStale * staleDays / urgencyStaleDays
+	}
+
+	return score
+}
+
+// priorityUrgencyScale returns what the lowest priority adds to the score and
+// the step per level above it
+func priorityUrgencyScale() (lowest, step float64) {
+	top := priority.Max()
+	if top <= 1 {
+		return urgencyHigh, 0
+	}
+	return urgencyLow, (urgencyHigh - urgencyLow) / float64(top-1)
+}
+
+// priorityUrgency is what a stored priority adds to the score
+func priorityUrgency(value int) float64 {
+	if value <= 0 {
+		return 0
+	}
+	lowest, step := priorityUrgencyScale()
+	return lowest + step*float64(min(value, priority.Max())-1)
+}
+
+// dueUrgency is what a due date daysLeft days away adds to the score: 20% of
+// the coefficient two weeks out, rising linearly to 100% a week overdue
+func dueUrgency(daysLeft float64) float64 {
+	factor := 1.0
+	switch {
+	case daysLeft >= urgencyDueHorizon:
+		factor = urgencyDueMin
+	case daysLeft > -urgencyOverdueMax:
+		factor = urgencyDueMin + (1-urgencyDueMin)*(urgencyDueHorizon-daysLeft)/(urgencyDueHorizon+urgencyOverdueMax)
+	}
+	return urgencyDue * factor
+}
+
+// SortByUrgency orders tasks by descending urgency score, pinned tasks first
+func SortByUrgency(tasks []models.Task, now time.Time) {
+	scores := make(map[uint]float64, len(tasks))
+	for _, task := range tasks {
+		scores[task.ID] = UrgencyScore(task, now)
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Pinned != tasks[j].Pinned {
+			return tasks[i].Pinned
+		}
+		if scores[tasks[i].ID] != scores[tasks[j].ID] {
+			return scores[tasks[i].ID] > scores[tasks[j].ID]
+		}
+		return tasks[i].ID > tasks[j].ID
+	})
+}
+
+// julianDay returns t as SQLite's julianday() would
+func julianDay(t time.Time) float64 {
+	return float64(t.UnixNano())/float64(24*time.Hour) + 2440587.5
+}
+
+// urgencySQL returns the urgency sort key of a tasks row as a SQL expression:
+// UrgencyScore at now, plus urgencyPinnedRank for pinned tasks. Sorting on it
+// descending, then by ID, gives SortByUrgency's order
+func urgencySQL(now time.Time) (string, []interface{}) {
+	lowest, step := priorityUrgencyScale()
+	nowDay := julianDay(now)
+	expr := fmt.Sprintf(`CASE WHEN status IN (?, ?) THEN pinned * %[1]g ELSE pinned * %[2]g
+		+ CASE WHEN priority > 0 THEN %[3]g + %[4]g * (MIN(priority, %[5]d) - 1) ELSE 0 END
+		+ COALESCE(%[6]g * MIN(MAX(%[7]g + %[8]g * (%[9]g - (julianday(due) - %.10[10]f)) / %[11]g, %[7]g), 1), 0)
+		+ %[12]g * MIN(MAX(%.10[10]f - julianday(updated_at), 0), %[13]g) / %[13]g END`,
+		urgencyPinnedRank, urgencyPinnedRank+urgencyPinned,
+		lowest, step, priority.Max(),
+		urgencyDue, urgencyDueMin, 1-urgencyDueMin, urgencyDueHorizon, nowDay, urgencyDueHorizon+urgencyOverdueMax,
+		urgencyStale, urgencyStaleDays)
+	return expr, []interface{}{workflow.Done, workflow.Archived}
+}
+
+// urgencyCandidatesSQL returns a SQL condition that holds for every tasks row
+// whose urgency sort key (see urgencySQL) can be atLeast or more. It bounds the
+// key by the priority and due date alone, counting staleness at its maximum.
+// Each bound is a subquery on pinned and a due range, which idx_tasks_urgency
+// answers without a scan, so a page of the most urgent tasks is sorted out of a
+// few rows instead of the whole table
+func urgencyCandidatesSQL(atLeast float64, now time.Time) (string, []interface{}) {
+	var terms []string
+	var args []interface{}
+	for _, pinned := range []bool{true, false} {
+		rank := 0.0
+		if pinned {
+			rank = urgencyPinnedRank
+		}
+		// Closed tasks score 0, so they are in if anything with this pin is
+		if rank >= atLeast {
+			terms = append(terms, "pinned = ?")
+			args = append(args, pinned)
+			continue
+		}
+		if pinned {
+			rank += urgencyPinned
+		}
+
+		// Priorities below 0 and above the highest level score as 0 and the
+		// highest; -1 stands for the former
+		for value := -1; value <= priority.Max(); value++ {
+			term := "pinned = ? AND status NOT IN (?, ?) AND priority = ?"
+			termArgs := []interface{}{pinned, workflow.Done, workflow.Archived, value}
+			switch {
+			case value < 0:
+				term = "pinned = ? AND status NOT IN (?, ?) AND priority < ?"
+				termArgs[3] = 0
+			case value == priority.Max():
+				term = "pinned = ? AND status NOT IN (?, ?) AND priority >= ?"
+			}
+
+			// What the due date has to add for the task to be in
+			needed := atLeast - rank - priorityUrgency(value) - urgencyStale
+			switch {
+			case needed <= 0:
+			case needed <= urgencyDue*urgencyDueMin:
+				term += " AND due IS NOT NULL"
+			case needed <= urgencyDue:
+				daysLeft := urgencyDueHorizon - (needed/urgencyDue-urgencyDueMin)*(urgencyDueHorizon+urgencyOverdueMax)/(1-urgencyDueMin)
+				term += " AND due <= ?"
+				termArgs = append(termArgs, now.Add(time.Duration(daysLeft*float64(24*time.Hour))))
+			default:
+				continue
+			}
+			terms = append(terms, term)
+			args = append(args, termArgs...)
+		}
+	}
+	if len(terms) == 0 {
+		return "0", nil
+	}
+	return "id IN (SELECT id FROM tasks WHERE " + strings.Join(terms, " UNION ALL SELECT id FROM tasks WHERE ") + ")", args
+}
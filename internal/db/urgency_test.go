@@ -0,0 +1,71 @@
+package db
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// TestUrgencyOrderMatchesSortByUrgency checks that pages of tasks sorted by
+// urgency in SQL are those of SortByUrgency on all of them
+func TestUrgencyOrderMatchesSortByUrgency(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	random := rand.New(rand.NewSource(1))
+	// Other tests fill the same database; only these tasks are compared
+	title := fmt.Sprintf("urgency %d", now.UnixNano())
+	ours := func() *gorm.DB { return DB.Model(&models.Task{}).Where("title = ?", title) }
+	statuses := []string{"todo", "todo", "doing", "done", "archived"}
+	for i := 0; i < 400; i++ {
+		task := models.Task{
+			Title:    title,
+			Status:   statuses[random.Intn(len(statuses))],
+			Priority: random.Intn(5), // 4 is above the highest level
+			Pinned:   random.Intn(20) == 0,
+		}
+		if random.Intn(3) > 0 {
+			due := now.Add(time.Duration(random.Intn(60*86400)-20*86400) * time.Second)
+			task.Due = &due
+		}
+		if err := DB.Create(&task).Error; err != nil {
+			t.Fatal(err)
+		}
+		updated := now.Add(-time.Duration(random.Intn(60*86400)) * time.Second)
+		if err := DB.Model(&task).UpdateColumn("updated_at", updated).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var tasks []models.Task
+	if err := ours().Find(&tasks).Error; err != nil {
+		t.Fatal(err)
+	}
+	SortByUrgency(tasks, now)
+	want := make([]uint, len(tasks))
+	for i, task := range tasks {
+		want[i] = task.ID
+	}
+
+	all, err := urgencyOrder(ours(), now, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(all, want) {
+		t.Errorf("all tasks: got %v…, want %v…", all[:10], want[:10])
+	}
+	for _, page := range [][2]int{{0, 1}, {0, 10}, {0, 40}, {10, 10}, {40, 40}, {200, 50}, {len(want) - 5, 40}} {
+		offset, limit := page[0], page[1]
+		got, err := urgencyOrder(ours(), now, offset, limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := want[offset:min(offset+limit, len(want))]; !slices.Equal(got, expected) {
+			t.Errorf("page %d+%d: got %v, want %v", offset, limit, got, expected)
+		}
+	}
+}
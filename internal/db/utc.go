@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Timestamps are stored in UTC, so rows written in different timezones compare
+// and sort correctly as text. utcPool converts every time argument on its way
+// to SQLite; localizeTimes converts loaded rows back to the local timezone,
+// which the display timezone from the config replaces (see config.Timezone)
+
+// utcPool is the connection pool gorm runs statements on
+type utcPool struct {
+	db *sql.DB
+}
+
+func (p *utcPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.db.PrepareContext(ctx, query)
+}
+
+func (p *utcPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return p.db.ExecContext(ctx, query, utcArgs(args)...)
+}
+
+func (p *utcPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.db.QueryContext(ctx, query, utcArgs(args)...)
+}
+
+func (p *utcPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return p.db.QueryRowContext(ctx, query, utcArgs(args)...)
+}
+
+// BeginTx starts a transaction that converts its arguments too
+func (p *utcPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	tx, err := p.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &utcTx{tx: tx}, nil
+}
+
+// GetDBConn returns the underlying database, for DB.DB()
+func (p *utcPool) GetDBConn() (*sql.DB, error) {
+	return p.db, nil
+}
+
+// utcTx is a transaction on a utcPool
+type utcTx struct {
+	tx *sql.Tx
+}
+
+func (t *utcTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return t.tx.PrepareContext(ctx, query)
+}
+
+func (t *utcTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return t.tx.ExecContext(ctx, query, utcArgs(args)...)
+}
+
+func (t *utcTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return t.tx.QueryContext(ctx, query, utcArgs(args)...)
+}
+
+func (t *utcTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return t.tx.QueryRowContext(ctx, query, utcArgs(args)...)
+}
+
+func (t *utcTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *utcTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// utcArgs returns args with every time converted to UTC
+func utcArgs(args []interface{}) []interface{} {
+	var converted []interface{}
+	for i, arg := range args {
+		utc, ok := utcValue(arg)
+		if !ok {
+			continue
+		}
+		if converted == nil {
+			converted = append([]interface{}(nil), args...)
+		}
+		converted[i] = utc
+	}
+	if converted == nil {
+		return args
+	}
+	return converted
+}
+
+// utcValue converts a time argument to UTC; ok is false for other arguments
+func utcValue(arg interface{}) (interface{}, bool) {
+	switch value := arg.(type) {
+	case time.Time:
+		return value.UTC(), true
+	case *time.Time:
+		if value == nil {
+			return nil, false
+		}
+		return value.UTC(), true
+	case driver.Valuer: // gorm.DeletedAt, sql.NullTime
+		if reflect.ValueOf(arg).Kind() == reflect.Ptr && reflect.ValueOf(arg).IsNil() {
+			return nil, false
+		}
+		if v, err := value.Value(); err == nil {
+			if t, ok := v.(time.Time); ok {
+				return t.UTC(), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// localizeTimes converts the timestamps of the rows a query loaded to the local timezone
+func localizeTimes(tx *gorm.DB) {
+	stmt := tx.Statement
+	if tx.Error != nil || stmt.Schema == nil || !stmt.ReflectValue.IsValid() {
+		return
+	}
+
+	rv := reflect.Indirect(stmt.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			localizeRow(stmt.Context, stmt.Schema, reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		localizeRow(stmt.Context, stmt.Schema, rv)
+	}
+}
+
+// localizeRow converts the time fields of one loaded row
+func localizeRow(ctx context.Context, s *schema.Schema, row reflect.Value) {
+	if row.Kind() != reflect.Struct || row.Type() != s.ModelType {
+		return
+	}
+	for _, field := range s.Fields {
+		if field.FieldType == nil {
+			continue
+		}
+		value, zero := field.ValueOf(ctx, row)
+		if zero {
+			continue
+		}
+		switch t := value.(type) {
+		case time.Time:
+			field.Set(ctx, row, t.Local())
+		case *time.Time:
+			local := t.Local()
+			field.Set(ctx, row, &local)
+		case gorm.DeletedAt:
+			if t.Valid {
+				field.Set(ctx, row, gorm.DeletedAt{Time: t.Time.Local(), Valid: true})
+			}
+		}
+	}
+}
+
+// openUTC wraps the database gorm opened so timestamps are stored in UTC and
+// loaded in the local timezone
+func openUTC(conn *gorm.DB) error {
+	sqlDB, err := conn.DB()
+	if err != nil {
+		return err
+	}
+	pool := &utcPool{db: sqlDB}
+	conn.ConnPool = pool
+	conn.Statement.ConnPool = pool
+
+	return conn.Callback().Query().After("gorm:after_query").Register("wrok:localize_times", localizeTimes)
+}
@@ -0,0 +1,146 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// Wrapped is a week or month of work summed up, for 'wrok wrapped'
+type Wrapped struct {
+	Period     string // parser.BudgetWeek or parser.BudgetMonth
+	Start      time.Time
+	End        time.Time
+	Total      time.Duration
+	Sessions   int
+	TopProject string // "" when no time went to a project
+	TopTime    time.Duration
+	Longest    *models.Session // with its task; nil without sessions
+	LongestFor time.Duration
+	BusiestDay time.Time // midnight of the day with the most time tracked; BusiestFor is 0 without one
+	BusiestFor time.Duration
+	Completed  int
+	Streak     int // most days in a row with time tracked
+}
+
+// GetWrapped sums up the week or month containing at: tracked time, top
+// project, longest session, busiest day, completed tasks and the longest
+// streak of days worked. A running session counts up to now
+func GetWrapped(period string, at, now time.Time) (*Wrapped, error) {
+	firstDay := time.Monday
+	if cfg, err := config.Load(); err == nil {
+		firstDay = cfg.FirstWeekday()
+	}
+	start := budgetPeriodStart(period, at, firstDay)
+	w := &Wrapped{Period: period, Start: start, End: goalPeriodEnd(period, start)}
+
+	sessions, err := GetTrackedSessions(TrackedTimeQuery{From: w.Start, To: w.End})
+	if err != nil {
+		return nil, err
+	}
+
+	byProject := make(map[string]time.Duration)
+	byDay := make(map[string]time.Duration) // by date, 2006-01-02
+	for i, session := range sessions {
+		duration := session.Elapsed(now)
+		w.Total += duration
+		w.Sessions++
+		if project := session.Task.Project; project != "" {
+			byProject[project] += duration
+		}
+		byDay[session.StartedAt.In(start.Location()).Format("2006-01-02")] += duration
+		if w.Longest == nil || duration > w.LongestFor {
+			w.Longest, w.LongestFor = &sessions[i], duration
+		}
+	}
+
+	projects := make([]string, 0, len(byProject))
+	for project := range byProject {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+	for _, project := range projects {
+		if byProject[project] > w.TopTime {
+			w.TopProject, w.TopTime = project, byProject[project]
+		}
+	}
+
+	// Days in order, so the earliest of equally busy days wins
+	run := 0
+	for day := w.Start; day.Before(w.End); day = day.AddDate(0, 0, 1) {
+		tracked := byDay[day.Format("2006-01-02")]
+		if tracked > w.BusiestFor {
+			w.BusiestDay, w.BusiestFor = day, tracked
+		}
+		if tracked > 0 {
+			run++
+			w.Streak = max(w.Streak, run)
+		} else {
+			run = 0
+		}
+	}
+
+	completed, err := GetTasksCompletedInRange(w.Start, w.End.Add(-time.Second))
+	if err != nil {
+		return nil, err
+	}
+	w.Completed = len(completed)
+	return w, nil
+}
+
+// Title names the period: "week of 12 Oct 2026" or "October 2026"
+func (w Wrapped) Title() string {
+	if w.Period == parser.BudgetMonth {
+		return w.Start.Format("January 2006")
+	}
+	return "week of " + w.Start.Format("2 Jan 2006")
+}
+
+// Lines returns the summary as label and value pairs, in order
+func (w Wrapped) Lines() [][2]string {
+	if w.Sessions == 0 {
+		return [][2]string{
+			{"Tracked", "no time tracked yet"},
+			{"Completed", plural(w.Completed, "task")},
+		}
+	}
+
+	lines := [][2]string{{"Tracked", fmt.Sprintf("%s in %s", formatHours(w.Total), plural(w.Sessions, "session"))}}
+	if w.TopProject != "" {
+		lines = append(lines, [2]string{"Top project", fmt.Sprintf("@%s · %s (%.0f%%)", w.TopProject, formatHours(w.TopTime), 100*w.TopTime.Seconds()/w.Total.Seconds())})
+	}
+	longest := w.Longest
+	lines = append(lines, [2]string{"Longest", fmt.Sprintf("%s on #%d %s (%s)", formatHours(w.LongestFor), longest.TaskID, longest.Task.Title, longest.StartedAt.Format("Mon 2 Jan"))})
+	if w.BusiestFor > 0 {
+		// None when the time is all in zero-length sessions or ones started before the period
+		lines = append(lines, [2]string{"Busiest day", fmt.Sprintf("%s · %s", w.BusiestDay.Format("Monday 2 Jan"), formatHours(w.BusiestFor))})
+	}
+	lines = append(lines,
+		[2]string{"Completed", plural(w.Completed, "task")},
+		[2]string{"Streak", plural(w.Streak, "day") + " in a row"},
+	)
+	return lines
+}
+
+// String renders the summary as plain text, fit for pasting into a chat
+func (w Wrapped) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "wrok wrapped: %s\n", w.Title())
+	for _, line := range w.Lines() {
+		fmt.Fprintf(&b, "  %-12s %s\n", line[0], line[1])
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// plural renders a count with its noun, adding an s unless there is one
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
@@ -0,0 +1,78 @@
+package digest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/templates"
+)
+
+// TemplateFile is the template override in the wrok data directory; when it
+// exists it replaces DefaultTemplate
+const TemplateFile = "digest.tmpl"
+
+// DefaultTemplate renders the digest as Markdown. Templates get a db.Digest
+// and the functions in templates.Funcs
+const DefaultTemplate = `# Weekly digest · week of {{date .Start}}
+
+**{{hours .Total}}** tracked in {{.Sessions}} session(s)
+
+## Hours per project
+{{range .Projects}}- {{if .Name}}@{{.Name}}{{else}}(no project){{end}}: {{hours .Duration}}
+{{else}}- No time tracked
+{{end}}
+## Completed ({{len .Completed}})
+{{range .Completed}}- #{{.ID}} {{.Title}}{{if .Project}} @{{.Project}}{{end}}
+{{else}}- Nothing completed
+{{end}}
+## Upcoming deadlines
+{{range .Upcoming}}- {{date .Due}}: #{{.ID}} {{.Title}}
+{{else}}- Nothing due in the next 7 days
+{{end}}{{if .Overdue}}
+## Overdue
+{{range .Overdue}}- {{date .Due}}: #{{.ID}} {{.Title}}
+{{end}}{{end}}`
+
+// TemplatePath returns where the template override lives (~/.wrok/digest.tmpl)
+func TemplatePath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, TemplateFile), nil
+}
+
+// Render fills the template override, or DefaultTemplate when there is none, with d
+func Render(d *db.Digest) (string, error) {
+	text := DefaultTemplate
+	name := "digest"
+	if path, err := TemplatePath(); err == nil {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			text, name = string(data), path
+		case !errors.Is(err, os.ErrNotExist):
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	}
+
+	tmpl, err := templates.Parse(name, text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, d); err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+	return b.String(), nil
+}
+
+// Subject is the subject line of a mailed digest
+func Subject(d *db.Digest) string {
+	return "wrok digest: week of " + d.Start.Format("2 Jan 2006")
+}
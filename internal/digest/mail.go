@@ -0,0 +1,105 @@
+package digest
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+)
+
+// defaultSMTPPort is the submission port, which upgrades to TLS with STARTTLS
+const defaultSMTPPort = 587
+
+// implicitTLSPort is the port that speaks TLS from the start
+const implicitTLSPort = 465
+
+// Send mails body to the given addresses through the [smtp] server of the
+// config. The password may also come from WROK_SMTP_PASSWORD, which keeps it
+// out of the config file
+func Send(cfg config.SMTPConfig, to []string, subject, body string) error {
+	if cfg.Host == "" {
+		return errors.New("set [smtp] host in the config to send email")
+	}
+	from := cfg.From
+	if from == "" {
+		from = cfg.Username
+	}
+	if from == "" {
+		return errors.New("set [smtp] from (or username) in the config")
+	}
+	password := strings.TrimSpace(os.Getenv("WROK_SMTP_PASSWORD"))
+	if password == "" {
+		password = cfg.Password
+	}
+	port := cfg.Port
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, password, cfg.Host)
+	}
+	msg := message(from, to, subject, body)
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(port))
+
+	if port != implicitTLSPort {
+		// SendMail upgrades with STARTTLS when the server offers it
+		return smtp.SendMail(addr, auth, from, to, msg)
+	}
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: cfg.Host})
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	client, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// message builds a plain text mail with CRLF line endings
+func message(from string, to []string, subject, body string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(b.String())
+}
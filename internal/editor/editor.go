@@ -0,0 +1,83 @@
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/balkashynov/wrok/internal/plain"
+)
+
+// Command returns the command that opens path in the user's editor: $VISUAL,
+// then $EDITOR, then vi (notepad on Windows). The variables may carry
+// arguments, e.g. "code --wait"
+func Command(path string) *exec.Cmd {
+	editor := strings.TrimSpace(os.Getenv("VISUAL"))
+	if editor == "" {
+		editor = strings.TrimSpace(os.Getenv("EDITOR"))
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		fields := strings.Fields(editor)
+		return exec.Command(fields[0], append(fields[1:], path)...)
+	}
+	// Like git, let the shell split the command so quoted arguments work
+	return exec.Command("sh", "-c", editor+` "$1"`, "sh", path)
+}
+
+// TempFile writes text to a new temporary file for editing and returns its path.
+// The caller removes the file
+func TempFile(text string) (string, error) {
+	file, err := os.CreateTemp("", "wrok-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer file.Close()
+
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	if _, err := file.WriteString(text); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// ReadFile returns the edited text of path, without the trailing newline
+// editors add, and removes the file
+func ReadFile(path string) (string, error) {
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return strings.TrimRight(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"), nil
+}
+
+// Edit opens text in the user's editor on the current terminal, waits for it
+// to exit and returns the edited text
+func Edit(text string) (string, error) {
+	path, err := TempFile(text)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := Command(path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = plain.Stdout() // the terminal itself, even when plain mode filters stdout
+	cmd.Stderr = plain.Stderr()
+	if err := cmd.Run(); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("editor failed: %w", err)
+	}
+	return ReadFile(path)
+}
@@ -0,0 +1,212 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// hookTimeout bounds how long a single command or webhook may run
+const hookTimeout = 10 * time.Second
+
+var (
+	wg         sync.WaitGroup
+	mu         sync.Mutex
+	hookErrors []error
+)
+
+// Payload is the JSON document sent to hooks
+type Payload struct {
+	Event     string          `json:"event"`
+	Timestamp time.Time       `json:"timestamp"`
+	Task      *TaskPayload    `json:"task,omitempty"`
+	Session   *SessionPayload `json:"session,omitempty"`
+}
+
+// TaskPayload is the task part of a hook payload
+type TaskPayload struct {
+	ID       uint       `json:"id"`
+	Title    string     `json:"title"`
+	Project  string     `json:"project"`
+	Status   string     `json:"status"`
+	Priority int        `json:"priority"`
+	Tags     []string   `json:"tags"`
+	JiraID   string     `json:"jira_id,omitempty"`
+	URL      string     `json:"url,omitempty"`
+	Due      *time.Time `json:"due,omitempty"`
+}
+
+// SessionPayload is the session part of a hook payload
+type SessionPayload struct {
+	ID              uint       `json:"id"`
+	TaskID          uint       `json:"task_id"`
+	StartedAt       time.Time  `json:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	DurationSeconds int        `json:"duration_seconds"`
+}
+
+// Register subscribes the configured hooks to database events
+func Register(cfg config.HooksConfig) {
+	commands := map[string]string{
+		db.EventTaskCreated:  cfg.OnCreate,
+		db.EventTaskDone:     cfg.OnDone,
+		db.EventTaskArchived: cfg.OnArchive,
+		db.EventSessionStart: cfg.OnStart,
+		db.EventSessionStop:  cfg.OnStop,
+	}
+
+	db.Subscribe(func(event db.Event) {
+		command := commands[event.Name]
+		if command == "" && len(cfg.Webhooks) == 0 {
+			return
+		}
+
+		body, err := json.Marshal(newPayload(event))
+		if err != nil {
+			recordError(fmt.Errorf("%s: failed to encode payload: %w", event.Name, err))
+			return
+		}
+
+		// Hooks run in the background so the TUI never blocks on them;
+		// Wait is called before the process exits
+		if command != "" {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := runCommand(command, event, body); err != nil {
+					recordError(fmt.Errorf("%s hook: %w", event.Name, err))
+				}
+			}()
+		}
+		for _, url := range cfg.Webhooks {
+			wg.Add(1)
+			go func(url string) {
+				defer wg.Done()
+				if err := postWebhook(url, body); err != nil {
+					recordError(fmt.Errorf("%s webhook %s: %w", event.Name, url, err))
+				}
+			}(url)
+		}
+	})
+}
+
+// Wait blocks until all running hooks have finished and returns their errors
+func Wait() []error {
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	result := hookErrors
+	hookErrors = nil
+	return result
+}
+
+// recordError stores a hook failure to be reported by Wait
+func recordError(err error) {
+	mu.Lock()
+	defer mu.Unlock()
+	hookErrors = append(hookErrors, err)
+}
+
+// newPayload builds the JSON payload for an event
+func newPayload(event db.Event) Payload {
+	payload := Payload{
+		Event:     event.Name,
+		Timestamp: time.Now(),
+	}
+
+	if event.Task != nil {
+		payload.Task = newTaskPayload(event.Task)
+	}
+	if event.Session != nil {
+		payload.Session = &SessionPayload{
+			ID:              event.Session.ID,
+			TaskID:          event.Session.TaskID,
+			StartedAt:       event.Session.StartedAt,
+			FinishedAt:      event.Session.FinishedAt,
+			DurationSeconds: event.Session.DurationSeconds,
+		}
+	}
+
+	return payload
+}
+
+// newTaskPayload converts a task into its payload form
+func newTaskPayload(task *models.Task) *TaskPayload {
+	tags := []string{}
+	for _, tag := range task.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	return &TaskPayload{
+		ID:       task.ID,
+		Title:    task.Title,
+		Project:  task.Project,
+		Status:   task.Status,
+		Priority: task.Priority,
+		Tags:     tags,
+		JiraID:   task.JiraID,
+		URL:      task.URL,
+		Due:      task.Due,
+	}
+}
+
+// runCommand runs a shell hook with the payload on stdin and WROK_* variables set
+func runCommand(command string, event db.Event, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(), "WROK_EVENT="+event.Name)
+	if event.Task != nil {
+		cmd.Env = append(cmd.Env,
+			fmt.Sprintf("WROK_TASK_ID=%d", event.Task.ID),
+			"WROK_TASK_TITLE="+event.Task.Title,
+			"WROK_TASK_PROJECT="+event.Task.Project,
+		)
+	}
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// postWebhook sends the payload to a webhook URL
+func postWebhook(url string, body []byte) error {
+	client := &http.Client{Timeout: hookTimeout}
+
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
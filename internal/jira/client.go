@@ -0,0 +1,117 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+)
+
+// requestTimeout bounds each call to the Jira API
+const requestTimeout = 15 * time.Second
+
+// Client reads issues from the Jira REST API
+type Client struct {
+	baseURL string
+	email   string
+	token   string
+	http    *http.Client
+}
+
+// Issue holds the fields wrok copies from a Jira issue
+type Issue struct {
+	Key      string
+	Summary  string
+	Status   string // e.g. "In Progress"
+	Assignee string // display name; empty when unassigned
+}
+
+// NewClient creates a client from the [jira] config section. The token may
+// also come from WROK_JIRA_TOKEN, which keeps it out of the config file
+func NewClient(cfg config.JiraConfig) (*Client, error) {
+	token := strings.TrimSpace(os.Getenv("WROK_JIRA_TOKEN"))
+	if token == "" {
+		token = cfg.Token
+	}
+	if cfg.BaseURL == "" {
+		return nil, errors.New("set [jira] base_url in the config to talk to Jira")
+	}
+	if token == "" {
+		return nil, errors.New("set WROK_JIRA_TOKEN (or [jira] token in the config) to an API token")
+	}
+
+	return &Client{
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		email:   cfg.Email,
+		token:   token,
+		http:    &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// issueResponse is the part of GET /rest/api/2/issue/{key} wrok reads
+type issueResponse struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+		Status  struct {
+			Name string `json:"name"`
+		} `json:"status"`
+		Assignee *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"assignee"`
+	} `json:"fields"`
+}
+
+// GetIssue fetches one issue by key, e.g. "APP-42"
+func (c *Client) GetIssue(key string) (*Issue, error) {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=summary,status,assignee", c.baseURL, url.PathEscape(key))
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.email != "" {
+		req.SetBasicAuth(c.email, c.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Jira: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("jira refused the credentials (HTTP %d); check [jira] email and the token", resp.StatusCode)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("jira issue %s not found", key)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return nil, fmt.Errorf("jira returned HTTP %d for %s: %s", resp.StatusCode, key, strings.TrimSpace(string(body)))
+	}
+
+	var data issueResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to read Jira issue %s: %w", key, err)
+	}
+
+	issue := &Issue{
+		Key:     data.Key,
+		Summary: data.Fields.Summary,
+		Status:  data.Fields.Status.Name,
+	}
+	if data.Fields.Assignee != nil {
+		issue.Assignee = data.Fields.Assignee.DisplayName
+	}
+	return issue, nil
+}
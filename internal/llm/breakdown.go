@@ -0,0 +1,164 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// requestTimeout bounds one answer of the model
+const requestTimeout = 90 * time.Second
+
+// maxSubtasks caps the proposals; more than this is a project plan, not a breakdown
+const maxSubtasks = 12
+
+// breakdownPrompt tells the model what to answer and in which shape
+const breakdownPrompt = `You split a task from a developer's todo list into concrete steps.
+Answer with JSON only, in this shape:
+{"subtasks": [{"title": "short imperative title", "estimate": "1h30m"}]}
+Give 2 to 8 steps in the order they should be done. Each estimate is how long
+the step takes, written like 30m, 2h or 1h30m. Titles are at most 80 characters
+and don't repeat the parent task's title.`
+
+// Subtask is a step the model proposes
+type Subtask struct {
+	Title    string
+	Estimate time.Duration // 0 when the model gave none that parses
+}
+
+// Enabled reports whether an endpoint is configured; breakdown is off otherwise
+func Enabled(cfg config.LLMConfig) bool {
+	return cfg.URL != ""
+}
+
+// Breakdown asks the OpenAI-compatible chat endpoint of the [llm] config to
+// split task into steps with estimates. The key may also come from
+// WROK_LLM_KEY, which keeps it out of the config file
+func Breakdown(cfg config.LLMConfig, task models.Task) ([]Subtask, error) {
+	if !Enabled(cfg) {
+		return nil, errors.New("set [llm] url and model in the config to an OpenAI-compatible chat completions endpoint")
+	}
+	if cfg.Model == "" {
+		return nil, errors.New("set [llm] model in the config, e.g. gpt-4o-mini")
+	}
+	key := strings.TrimSpace(os.Getenv("WROK_LLM_KEY"))
+	if key == "" {
+		key = cfg.APIKey
+	}
+
+	described := "Task: " + task.Title
+	if task.Project != "" {
+		described += "\nProject: " + task.Project
+	}
+	if task.EstimateSeconds > 0 {
+		described += "\nEstimate for the whole task: " + parser.FormatEstimate(time.Duration(task.EstimateSeconds)*time.Second)
+	}
+	if task.Note != "" {
+		described += "\nNotes: " + task.Note
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"model": cfg.Model,
+		"messages": []map[string]string{
+			{"role": "system", "content": breakdownPrompt},
+			{"role": "user", "content": described},
+		},
+		"response_format": map[string]string{"type": "json_object"},
+		"temperature":     0.2,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, cfg.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	resp, err := (&http.Client{Timeout: requestTimeout}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the LLM endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("the LLM endpoint refused the key (HTTP %d); check WROK_LLM_KEY or [llm] api_key", resp.StatusCode)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return nil, fmt.Errorf("the LLM endpoint returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var completion struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return nil, fmt.Errorf("failed to read the LLM answer: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return nil, errors.New("the LLM endpoint answered without a message")
+	}
+	return parseSubtasks(completion.Choices[0].Message.Content)
+}
+
+// parseSubtasks reads the model's JSON answer, tolerating text or code fences around it
+func parseSubtasks(content string) ([]Subtask, error) {
+	start, end := strings.Index(content, "{"), strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("the model didn't answer with JSON: %s", truncate(content, 120))
+	}
+	var answer struct {
+		Subtasks []struct {
+			Title    string `json:"title"`
+			Estimate string `json:"estimate"`
+		} `json:"subtasks"`
+	}
+	if err := json.Unmarshal([]byte(content[start:end+1]), &answer); err != nil {
+		return nil, fmt.Errorf("the model's answer isn't the JSON asked for: %w", err)
+	}
+
+	var subtasks []Subtask
+	for _, proposal := range answer.Subtasks {
+		title := strings.TrimSpace(proposal.Title)
+		if title == "" {
+			continue
+		}
+		estimate, _ := parser.ParseEstimate(strings.ReplaceAll(proposal.Estimate, " ", ""))
+		subtasks = append(subtasks, Subtask{Title: title, Estimate: estimate})
+		if len(subtasks) == maxSubtasks {
+			break
+		}
+	}
+	if len(subtasks) == 0 {
+		return nil, errors.New("the model proposed no steps")
+	}
+	return subtasks, nil
+}
+
+// truncate cuts s to n characters for error messages
+func truncate(s string, n int) string {
+	runes := []rune(strings.TrimSpace(s))
+	if len(runes) <= n {
+		return string(runes)
+	}
+	return string(runes[:n-3]) + "..."
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Audit log actions
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+)
+
+// AuditEntry is a row of the append-only audit log: the creation or deletion
+// of a task, session, break, task tag, comment, attachment, goal or setting, the
+// change of one of its fields, or a tag rename. Rows are written by triggers
+// (migrations 0004 and 0006), never by Go
+type AuditEntry struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	Actor  string `json:"actor"`  // OS user of the wrok process; empty for writes made outside wrok
+	Source string `json:"source"` // the wrok command that made the change, e.g. "wrok done"
+
+	Entity   string  `gorm:"not null" json:"entity"` // task, session, pause, tag, comment, file, goal or setting
+	EntityID uint    `json:"entity_id"`              // a break's session; 0 for settings
+	TaskID   *uint   `gorm:"index" json:"task_id"`   // the task the change belongs to; nil for goals, settings and tag renames
+	Action   string  `gorm:"not null" json:"action"` // AuditCreate, AuditUpdate or AuditDelete
+	Field    string  `json:"field"`
+	OldValue *string `json:"old_value"`
+	NewValue *string `json:"new_value"`
+}
+
+// TableName keeps the log's table name singular
+func (AuditEntry) TableName() string {
+	return "audit_log"
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Comment kinds
+const (
+	CommentNote   = "comment" // written by the user
+	CommentStatus = "status"  // recorded when a task changes status
+)
+
+// Comment is an entry in a task's history: a user comment or a status change
+type Comment struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	TaskID uint   `gorm:"not null;index" json:"task_id"`
+	Kind   string `gorm:"default:comment" json:"kind"` // CommentNote or CommentStatus
+	Body   string `gorm:"not null" json:"body"`
+}
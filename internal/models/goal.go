@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Goal scopes: what a goal's tracked time is counted over
+const (
+	GoalOverall = ""        // every session
+	GoalProject = "project" // sessions on tasks of one project
+	GoalTag     = "tag"     // sessions on tasks with one tag
+)
+
+// Goal is a target amount of tracked time per week or month
+type Goal struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Scope         string `gorm:"uniqueIndex:idx_goal_target" json:"scope"`  // GoalOverall, GoalProject or GoalTag
+	Target        string `gorm:"uniqueIndex:idx_goal_target" json:"target"` // project or tag name; empty for GoalOverall
+	TargetSeconds int    `gorm:"not null" json:"target_seconds"`
+	Period        string `gorm:"not null" json:"period"` // "week" or "month"
+}
+
+// GoalPeriod records how a goal went in one finished week or month
+type GoalPeriod struct {
+	ID uint `gorm:"primarykey" json:"id"`
+
+	GoalID         uint      `gorm:"not null;uniqueIndex:idx_goal_period" json:"goal_id"`
+	PeriodStart    time.Time `gorm:"not null;uniqueIndex:idx_goal_period" json:"period_start"`
+	PeriodEnd      time.Time `gorm:"not null" json:"period_end"`
+	TargetSeconds  int       `json:"target_seconds"` // the goal's target at the time
+	TrackedSeconds int       `json:"tracked_seconds"`
+}
+
+// Met reports whether the tracked time reached the target
+func (p GoalPeriod) Met() bool {
+	return p.TrackedSeconds >= p.TargetSeconds
+}
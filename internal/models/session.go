@@ -12,12 +12,56 @@ type Session struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 	
-	TaskID         uint       `gorm:"not null" json:"task_id"`
-	StartedAt      time.Time  `gorm:"not null" json:"started_at"`
-	FinishedAt     *time.Time `json:"finished_at"`
-	DurationSeconds int       `json:"duration_seconds"` // calculated field
+	TaskID         uint       `gorm:"not null;index" json:"task_id"`
+	StartedAt      time.Time  `gorm:"not null;index" json:"started_at"`
+	FinishedAt     *time.Time `gorm:"index" json:"finished_at"`
+	DurationSeconds int       `json:"duration_seconds"` // calculated field, without breaks
 	Note           string     `json:"note"`
+
+	// Breaks: PausedAt is set while the session is paused, PausedSeconds
+	// adds up the breaks that ended. Each break is kept as a SessionPause
+	PausedAt      *time.Time `json:"paused_at,omitempty"`
+	PausedSeconds int        `gorm:"default:0" json:"paused_seconds"`
+
+	// LockedAt is set once the session's time has been reported, e.g. to a
+	// client; a locked session is only changed when forced
+	LockedAt *time.Time `gorm:"index" json:"locked_at,omitempty"`
 	
 	// Relationships
 	Task Task `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"task"`
+}
+
+// SessionPause is a break taken during a session; EndedAt is nil while it lasts
+type SessionPause struct {
+	ID        uint       `gorm:"primarykey" json:"id"`
+	SessionID uint       `gorm:"not null;index" json:"session_id"`
+	StartedAt time.Time  `gorm:"not null" json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+// Paused reports whether the session is running but on a break
+func (s Session) Paused() bool {
+	return s.FinishedAt == nil && s.PausedAt != nil
+}
+
+// Locked reports whether the session has been locked against changes
+func (s Session) Locked() bool {
+	return s.LockedAt != nil
+}
+
+// Elapsed returns the time worked in the session up to now, leaving out
+// breaks. For a finished session it is its duration
+func (s Session) Elapsed(now time.Time) time.Duration {
+	if s.FinishedAt != nil {
+		return time.Duration(s.DurationSeconds) * time.Second
+	}
+	end := now
+	if s.PausedAt != nil {
+		end = *s.PausedAt
+	}
+	elapsed := end.Sub(s.StartedAt) - time.Duration(s.PausedSeconds)*time.Second
+	if elapsed < 0 {
+		return 0
+	}
+	return elapsed
 }
\ No newline at end of file
@@ -0,0 +1,7 @@
+package models
+
+// Setting is a persistent key/value pair for user state (e.g. focus context)
+type Setting struct {
+	Key   string `gorm:"primaryKey" json:"key"`
+	Value string `json:"value"`
+}
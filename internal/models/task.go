@@ -13,19 +13,34 @@ type Task struct {
 	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
 	
 	Title      string     `gorm:"not null" json:"title"`
-	Project    string     `json:"project"`
-	Status     string     `gorm:"default:todo" json:"status"` // todo, done, archived
+	Project    string     `gorm:"index" json:"project"`
+	Status     string     `gorm:"default:todo;index" json:"status"` // todo, done, archived, or one configured in [status]
 	Priority   int        `gorm:"default:0" json:"priority"`   // 0=no priority, 1=low, 2=medium, 3=high
 	Pinned     bool       `gorm:"default:false" json:"pinned"`
-	Due        *time.Time `json:"due"`
+	Due        *time.Time `gorm:"index" json:"due"`
 	DoneAt     *time.Time `json:"done_at"`
 	ArchivedAt *time.Time `json:"archived_at"`
 	
+	// Set by 'wrok snooze --hide'; ls and the TUI leave the task out until then
+	HiddenUntil *time.Time `gorm:"index" json:"hidden_until"`
+	
+	// How long the task should take; 0 when not estimated
+	EstimateSeconds int `gorm:"default:0" json:"estimate_seconds"`
+	
+	// The task this one is a step of, e.g. set by 'wrok breakdown'; nil for top-level tasks
+	ParentID *uint `gorm:"index" json:"parent_id,omitempty"`
+	
 	// Optional metadata
+	Alias  string `json:"alias"` // short name usable in place of the ID, e.g. "login-bug"
 	JiraID string `json:"jira_id"`
 	URL    string `json:"url"`
 	Note   string `json:"note"`
 	
+	// Copied from the Jira issue by 'wrok jira fetch'
+	JiraStatus   string     `json:"jira_status"`
+	JiraAssignee string     `json:"jira_assignee"`
+	JiraSyncedAt *time.Time `json:"jira_synced_at"`
+	
 	// Relationships
 	Tags     []Tag     `gorm:"many2many:task_tags;" json:"tags"`
 	Sessions []Session `gorm:"foreignKey:TaskID" json:"sessions"`
@@ -43,5 +58,5 @@ type Tag struct {
 // TaskTag is the join table for the many-to-many relationship
 type TaskTag struct {
 	TaskID uint `gorm:"primaryKey"`
-	TagID  uint `gorm:"primaryKey"`
+	TagID  uint `gorm:"primaryKey;index"` // lookups by tag (tag filters)
 }
\ No newline at end of file
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// TaskFile is a file attached to a task, referenced in place or copied into ~/.wrok/attachments
+type TaskFile struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	TaskID uint   `gorm:"not null;index" json:"task_id"`
+	Path   string `gorm:"not null" json:"path"`        // absolute path of the file
+	Copied bool   `gorm:"default:false" json:"copied"` // Path is a copy under ~/.wrok/attachments
+}
@@ -0,0 +1,29 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// branchTaskRegex matches a wrok task reference in a branch name, e.g. "feature/wrok-42-login"
+var branchTaskRegex = regexp.MustCompile(`(?i)(?:^|[^a-z])wrok-(\d+)`)
+
+// branchJiraRegex matches JIRA-like keys in a branch name, e.g. "fix/APP-123-crash"
+var branchJiraRegex = regexp.MustCompile(`(?i)(?:^|[^a-z])([a-z]+-\d+)`)
+
+// ParseBranchRef returns the task a git branch name refers to: a task ID from
+// "wrok-42", or else the JIRA-like keys it contains (uppercased, in order).
+// Keys are only candidates; a "fix-2" in "fix-2-crash" looks like one too
+func ParseBranchRef(branch string) (taskID uint, jiraIDs []string) {
+	if matches := branchTaskRegex.FindStringSubmatch(branch); matches != nil {
+		if id, err := strconv.ParseUint(matches[1], 10, 0); err == nil && id > 0 {
+			return uint(id), nil
+		}
+	}
+
+	for _, matches := range branchJiraRegex.FindAllStringSubmatch(branch, -1) {
+		jiraIDs = append(jiraIDs, strings.ToUpper(matches[1]))
+	}
+	return 0, jiraIDs
+}
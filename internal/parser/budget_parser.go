@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Budget periods
+const (
+	BudgetWeek  = "week"
+	BudgetMonth = "month"
+)
+
+// ParseBudget parses an amount of time per period like "10h/week", "40h/month"
+// or "7h30m/w", as used by budgets and goals, and returns the amount and its
+// period (BudgetWeek or BudgetMonth)
+func ParseBudget(input string) (time.Duration, string, error) {
+	input = strings.ToLower(strings.ReplaceAll(input, " ", ""))
+
+	amountText, periodText, ok := strings.Cut(input, "/")
+	if !ok {
+		return 0, "", fmt.Errorf("invalid time per period '%s', use e.g. 10h/week or 40h/month", input)
+	}
+
+	amount, err := time.ParseDuration(amountText)
+	if err != nil || amount <= 0 {
+		return 0, "", fmt.Errorf("invalid amount '%s', use e.g. 10h or 7h30m", amountText)
+	}
+
+	switch periodText {
+	case "w", "week", "weekly":
+		return amount, BudgetWeek, nil
+	case "m", "month", "monthly":
+		return amount, BudgetMonth, nil
+	default:
+		return 0, "", fmt.Errorf("invalid period '%s', use week or month", periodText)
+	}
+}
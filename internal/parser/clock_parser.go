@@ -0,0 +1,23 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseClockTime parses "HH:MM" on the given day, or a full "dd/mm/yyyy HH:MM"
+func ParseClockTime(input string, day time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+
+	if t, err := time.ParseInLocation("02/01/2006 15:04", input, day.Location()); err == nil {
+		return t, nil
+	}
+
+	clock, err := time.ParseInLocation("15:04", input, day.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time '%s'. Use: HH:MM or dd/mm/yyyy HH:MM", input)
+	}
+
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, day.Location()), nil
+}
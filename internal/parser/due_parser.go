@@ -11,9 +11,12 @@ import (
 // ParseDueDate parses various due date formats
 // Supported formats:
 // - dd/mm/yyyy (e.g., "15/12/2024")
-// - X days (e.g., "3 days", "1 day")
-// - X hours (e.g., "24 hours", "1 hour")
-// - X weeks (e.g., "2 weeks", "1 week")
+// - X days (e.g., "3 days", "1 day", "3d")
+// - X hours (e.g., "24 hours", "1 hour", "24h")
+// - X weeks (e.g., "2 weeks", "1 week", "2w")
+// - X business days, next business day (skipping weekends and holidays)
+// - a weekday (e.g., "friday", "fri"): the next one after today
+// Words may be joined by hyphens, e.g. "next-business-day", to fit due:
 func ParseDueDate(input string) (*time.Time, error) {
 	if input == "" {
 		return nil, nil
@@ -30,8 +33,48 @@ func ParseDueDate(input string) (*time.Time, error) {
 	if dueDate, err := parseRelativeTime(input); err == nil {
 		return dueDate, nil
 	}
+
+	// Try business days and weekday names
+	if dueDate, ok, err := parseWorkday(input); ok {
+		return dueDate, err
+	}
 	
-	return nil, fmt.Errorf("invalid date format. Use: dd/mm/yyyy, X days, X hours, or X weeks")
+	return nil, fmt.Errorf("invalid date format. Use: dd/mm/yyyy, X days, X hours, X weeks, X business days, next business day or a weekday")
+}
+
+// businessDaysRegex matches "next business day" and "X business days" (or workdays)
+var businessDaysRegex = regexp.MustCompile(`^(?:next|(\d+))\s*(?:business|work)\s*days?$`)
+
+// parseWorkday parses business days and weekday names; ok is false when
+// input is neither
+func parseWorkday(input string) (dueDate *time.Time, ok bool, err error) {
+	input = strings.Join(strings.Fields(strings.ReplaceAll(strings.ToLower(input), "-", " ")), " ")
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	endOfDay := func(day time.Time) *time.Time {
+		due := day.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+		return &due
+	}
+
+	if matches := businessDaysRegex.FindStringSubmatch(input); matches != nil {
+		amount := 1
+		if matches[1] != "" {
+			amount, _ = strconv.Atoi(matches[1])
+			if amount < 1 || amount > 260 { // Max 1 year of business days
+				return nil, true, fmt.Errorf("business days must be between 1 and 260")
+			}
+		}
+		day, err := addBusinessDays(today, amount)
+		if err != nil {
+			return nil, true, err
+		}
+		return endOfDay(day), true, nil
+	}
+
+	if weekday, found := weekdayNames[strings.TrimPrefix(input, "next ")]; found {
+		return endOfDay(nextWeekday(today, weekday)), true, nil
+	}
+	return nil, false, nil
 }
 
 // parseDateFormat parses dd/mm/yyyy format
@@ -83,8 +126,8 @@ func parseDateFormat(input string) (*time.Time, error) {
 func parseRelativeTime(input string) (*time.Time, error) {
 	input = strings.ToLower(input)
 	
-	// Regex for "X unit" or "X units"
-	relativeRegex := regexp.MustCompile(`^(\d+)\s+(hour|hours|day|days|week|weeks)$`)
+	// Regex for "X unit" or "X units", or the short "Xh", "Xd" and "Xw"
+	relativeRegex := regexp.MustCompile(`^(\d+)\s*(h|hour|hours|d|day|days|w|week|weeks)$`)
 	matches := relativeRegex.FindStringSubmatch(input)
 	
 	if len(matches) != 3 {
@@ -100,14 +143,14 @@ func parseRelativeTime(input string) (*time.Time, error) {
 	now := time.Now()
 	
 	switch unit {
-	case "hour", "hours":
+	case "h", "hour", "hours":
 		if amount < 1 || amount > 8760 { // Max 1 year in hours
 			return nil, fmt.Errorf("hours must be between 1 and 8760")
 		}
 		dueDate := now.Add(time.Duration(amount) * time.Hour)
 		return &dueDate, nil
 		
-	case "day", "days":
+	case "d", "day", "days":
 		if amount < 1 || amount > 365 { // Max 1 year in days
 			return nil, fmt.Errorf("days must be between 1 and 365")
 		}
@@ -116,7 +159,7 @@ func parseRelativeTime(input string) (*time.Time, error) {
 		dueDate := today.AddDate(0, 0, amount).Add(23*time.Hour + 59*time.Minute + 59*time.Second)
 		return &dueDate, nil
 		
-	case "week", "weeks":
+	case "w", "week", "weeks":
 		if amount < 1 || amount > 52 { // Max 1 year in weeks
 			return nil, fmt.Errorf("weeks must be between 1 and 52")
 		}
@@ -136,15 +179,10 @@ func FormatDueDate(dueDate *time.Time) string {
 		return ""
 	}
 	
-	now := time.Now()
-	
-	// Calculate calendar days difference
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	dueDay := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, dueDate.Location())
-	daysDiff := int(dueDay.Sub(today).Hours() / 24)
+	daysDiff := DaysUntil(*dueDate, time.Now())
 	
 	// Always show the actual date to avoid confusion
-	dateStr := dueDate.Format("02/01/2006")
+	dateStr := dueDate.Local().Format("02/01/2006")
 	
 	if daysDiff < 0 {
 		// Overdue
@@ -162,4 +200,34 @@ func FormatDueDate(dueDate *time.Time) string {
 		// Due later
 		return fmt.Sprintf("📅 Due %s", dateStr)
 	}
-}
\ No newline at end of file
+}
+// DaysUntil counts calendar days from now to t in the local timezone: 0 for
+// later today, 1 for tomorrow. A time already past is at least a day overdue (-1)
+func DaysUntil(t, now time.Time) int {
+	ty, tm, td := t.Local().Date()
+	ny, nm, nd := now.Local().Date()
+	// Whole days between the two dates, counted in UTC so DST changes don't matter
+	days := int(time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC).Sub(time.Date(ny, nm, nd, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+	if t.Before(now) {
+		return min(days, -1)
+	}
+	return days
+}
+
+// FormatAge formats how long ago something happened in at most 4 characters,
+// e.g. "<1d", "3d", "2w", "5mo", "1y"
+func FormatAge(age time.Duration) string {
+	days := int(age.Hours() / 24)
+	switch {
+	case days < 1:
+		return "<1d"
+	case days < 14:
+		return fmt.Sprintf("%dd", days)
+	case days < 60:
+		return fmt.Sprintf("%dw", days/7)
+	case days < 365:
+		return fmt.Sprintf("%dmo", days/30)
+	default:
+		return fmt.Sprintf("%dy", days/365)
+	}
+}
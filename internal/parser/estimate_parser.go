@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxEstimate caps estimates; anything longer is a project, not a task
+const maxEstimate = 1000 * time.Hour
+
+// ParseEstimate parses how long a task should take, like "4h", "30m", "1h30m"
+// or "1.5h", rounded to the minute
+func ParseEstimate(input string) (time.Duration, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+
+	estimate, err := time.ParseDuration(input)
+	if err != nil || estimate.Round(time.Minute) <= 0 {
+		return 0, fmt.Errorf("use e.g. 4h, 30m or 1h30m")
+	}
+	if estimate > maxEstimate {
+		return 0, fmt.Errorf("at most %.0fh", maxEstimate.Hours())
+	}
+	return estimate.Round(time.Minute), nil
+}
+
+// FormatEstimate formats an estimate the way ParseEstimate reads it, e.g. "1h30m"
+func FormatEstimate(estimate time.Duration) string {
+	hours := int(estimate.Hours())
+	minutes := int(estimate.Minutes()) % 60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dh%dm", hours, minutes)
+}
@@ -39,4 +39,27 @@ func IsValidJiraFormat(jiraID string) bool {
 	
 	jiraRegex := regexp.MustCompile(`^([A-Z]+)-(\d+)$`)
 	return jiraRegex.MatchString(jiraID)
-}
\ No newline at end of file
+}
+// looseJiraRegex matches IDs that are close to XXX-111: a missing dash, or a space
+// or underscore in its place
+var looseJiraRegex = regexp.MustCompile(`^([A-Z]+)[\s_-]*(\d+)$`)
+
+// SuggestJiraID returns the valid JIRA ID that input was most likely meant to be,
+// e.g. "app123" or "app_123" -> "APP-123". ok is false if no correction is found
+func SuggestJiraID(input string) (suggestion string, ok bool) {
+	input = strings.ToUpper(strings.TrimSpace(input))
+	matches := looseJiraRegex.FindStringSubmatch(input)
+	if matches == nil {
+		return "", false
+	}
+	return matches[1] + "-" + matches[2], true
+}
+
+// JiraPrefix returns the project part of a JIRA ID ("APP" for "APP-123")
+func JiraPrefix(jiraID string) string {
+	prefix, _, found := strings.Cut(strings.ToUpper(strings.TrimSpace(jiraID)), "-")
+	if !found {
+		return ""
+	}
+	return prefix
+}
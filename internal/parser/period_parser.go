@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// namedPeriods are the periods ParsePeriod knows by name
+var namedPeriods = []string{"today", "yesterday", "this week", "last week", "this month", "last month", "this year"}
+
+// ParsePeriod parses a named period ("today", "yesterday", "this week", "last
+// week", "this month", "last month", "this year"; hyphens work in place of
+// spaces) or a look-back like "30d" (see ParseSince) and returns when it starts
+// and ends. to is the zero time for periods that run up to now
+func ParsePeriod(input string, now time.Time, firstDay time.Weekday) (from, to time.Time, err error) {
+	name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(input)), "-", " ")
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	month := today.AddDate(0, 0, 1-today.Day())
+
+	switch name {
+	case "today":
+		return today, time.Time{}, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), today, nil
+	case "this week":
+		return StartOfWeek(now, firstDay), time.Time{}, nil
+	case "last week":
+		week := StartOfWeek(now, firstDay)
+		return week.AddDate(0, 0, -7), week, nil
+	case "this month":
+		return month, time.Time{}, nil
+	case "last month":
+		return month.AddDate(0, -1, 0), month, nil
+	case "this year":
+		return time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location()), time.Time{}, nil
+	}
+
+	if since, err := ParseSince(name); err == nil {
+		return since, time.Time{}, nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid period '%s', use e.g. today, this week, last month or 30d", input)
+}
+
+// SplitPeriod splits a trailing named period off a query, so "login bug this
+// week" gives "login bug" and "this week". period is "" if there is none
+func SplitPeriod(query string) (rest, period string) {
+	words := strings.Fields(query)
+	lower := strings.ToLower(strings.Join(words, " "))
+	for _, name := range namedPeriods {
+		if lower == name {
+			return "", name
+		}
+		if strings.HasSuffix(lower, " "+name) {
+			kept := len(words) - len(strings.Fields(name))
+			return strings.Join(words[:kept], " "), name
+		}
+	}
+	return strings.Join(words, " "), ""
+}
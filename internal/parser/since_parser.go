@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseSince parses a look-back period like "4w", "30d", "3m", "1y" or "2 weeks"
+// and returns the start of the day that far in the past. A period of 0, e.g.
+// "0d", is the start of today
+func ParseSince(input string) (time.Time, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+
+	sinceRegex := regexp.MustCompile(`^(\d+)\s*(d|day|days|w|week|weeks|m|month|months|y|year|years)$`)
+	matches := sinceRegex.FindStringSubmatch(input)
+	if len(matches) != 3 {
+		return time.Time{}, fmt.Errorf("invalid period '%s', use e.g. 30d, 4w, 3m or 1y", input)
+	}
+
+	amount, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid number in period '%s'", input)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch matches[2] {
+	case "d", "day", "days":
+		return today.AddDate(0, 0, -amount), nil
+	case "w", "week", "weeks":
+		return today.AddDate(0, 0, -amount*7), nil
+	case "m", "month", "months":
+		return today.AddDate(0, -amount, 0), nil
+	default:
+		return today.AddDate(-amount, 0, 0), nil
+	}
+}
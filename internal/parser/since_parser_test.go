@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"0d", today},
+		{"0 weeks", today},
+		{"30d", today.AddDate(0, 0, -30)},
+		{"4w", today.AddDate(0, 0, -28)},
+		{"3m", today.AddDate(0, -3, 0)},
+		{" 1 Year ", today.AddDate(-1, 0, 0)},
+	}
+	for _, tt := range tests {
+		got, err := ParseSince(tt.input)
+		if err != nil {
+			t.Errorf("ParseSince(%q): %v", tt.input, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseSince(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "d", "-1d", "2 fortnights", "1.5w"} {
+		if _, err := ParseSince(input); err == nil {
+			t.Errorf("ParseSince(%q) accepted an invalid period", input)
+		}
+	}
+}
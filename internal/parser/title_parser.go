@@ -4,6 +4,8 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/balkashynov/wrok/internal/priority"
 )
 
 // ParsedTask represents a task parsed from natural language
@@ -14,11 +16,24 @@ type ParsedTask struct {
 	Priority string
 	JiraID   string
 	DueDate  *time.Time
+	URL      string
+	Note     string
+	Estimate time.Duration
 	Errors   []string
 }
 
+var (
+	// noteRegex matches note:"some text" or a one-word note:text
+	noteRegex = regexp.MustCompile(`note:(?:"([^"]*)"|(\S+))`)
+	// commentRegex matches a trailing "// comment"; the // of a URL isn't preceded by a space
+	commentRegex = regexp.MustCompile(`(?:^|\s)//(.*)$`)
+	// urlRegex matches a bare web link
+	urlRegex = regexp.MustCompile(`https?://\S+`)
+)
+
 // ParseTitle extracts metadata from a task title using natural syntax
-// Syntax: "Task title #tag1,tag2 @project +priority JIRA-123 due:3days"
+// Syntax: "Task title #tag1,tag2 @project +priority JIRA-123 due:3days est:4h
+// https://link note:"text" // more notes"
 func ParseTitle(input string) ParsedTask {
 	result := ParsedTask{
 		Title:  input,
@@ -26,6 +41,36 @@ func ParseTitle(input string) ParsedTask {
 		Errors: []string{},
 	}
 
+	// Notes and links go first, so the tags, projects and JIRA IDs in them stay put
+	var notes []string
+	if matches := noteRegex.FindAllStringSubmatch(input, -1); len(matches) > 0 {
+		for _, match := range matches {
+			notes = append(notes, strings.TrimSpace(match[1]+match[2]))
+		}
+		input = noteRegex.ReplaceAllString(input, "")
+	}
+	if match := commentRegex.FindStringSubmatch(input); match != nil {
+		notes = append(notes, strings.TrimSpace(match[1]))
+		input = commentRegex.ReplaceAllString(input, "")
+	}
+	input = urlRegex.ReplaceAllStringFunc(input, func(match string) string {
+		// Punctuation after a link usually ends the sentence, not the link
+		url := strings.TrimRight(match, ".,;:!?)")
+		if result.URL == "" {
+			result.URL = url
+		} else {
+			notes = append(notes, url)
+		}
+		return match[len(url):]
+	})
+	var kept []string
+	for _, note := range notes {
+		if note != "" {
+			kept = append(kept, note)
+		}
+	}
+	result.Note = strings.Join(kept, "\n")
+
 	// Extract JIRA tickets (pattern: XXX-123)
 	jiraRegex := regexp.MustCompile(`\b([A-Za-z]+)-(\d+)\b`)
 	jiraMatches := jiraRegex.FindAllString(input, -1)
@@ -72,23 +117,23 @@ func ParseTitle(input string) ParsedTask {
 	priorityRegex := regexp.MustCompile(`\+([a-zA-Z0-9]+)`)
 	priorityMatches := priorityRegex.FindStringSubmatch(input)
 	if len(priorityMatches) > 1 {
-		priority := strings.ToLower(priorityMatches[1])
-		if isValidPriority(priority) {
-			result.Priority = priority
+		if priority.Valid(priorityMatches[1]) {
+			result.Priority = strings.ToLower(priorityMatches[1])
 		} else {
-			result.Errors = append(result.Errors, "Invalid priority '"+priorityMatches[1]+"'. Use: low, medium, high, 1, 2, or 3")
+			result.Errors = append(result.Errors, "Invalid priority '"+priorityMatches[1]+"'. Use: "+priority.Hint())
 		}
 		// Remove from title
 		input = priorityRegex.ReplaceAllString(input, "")
 	}
 
-	// Extract due date (due:3days, due:15/12/2024, etc.)
-	dueRegex := regexp.MustCompile(`due:([^\s]+)`)
+	// Extract due date (due:3days, due:15/12/2024, due:"next business day", etc.)
+	dueRegex := regexp.MustCompile(`due:(?:"([^"]*)"|([^\s]+))`)
 	dueMatches := dueRegex.FindStringSubmatch(input)
-	if len(dueMatches) > 1 {
-		dueDate, err := ParseDueDate(dueMatches[1])
+	if len(dueMatches) > 2 {
+		value := dueMatches[1] + dueMatches[2]
+		dueDate, err := ParseDueDate(value)
 		if err != nil {
-			result.Errors = append(result.Errors, "Invalid due date '"+dueMatches[1]+"': "+err.Error())
+			result.Errors = append(result.Errors, "Invalid due date '"+value+"': "+err.Error())
 		} else {
 			result.DueDate = dueDate
 		}
@@ -96,52 +141,23 @@ func ParseTitle(input string) ParsedTask {
 		input = dueRegex.ReplaceAllString(input, "")
 	}
 
+	// Extract estimate (est:4h or ~4h)
+	estimateRegex := regexp.MustCompile(`(?:\best:|(?:^|\s)~)(\S+)`)
+	estimateMatches := estimateRegex.FindStringSubmatch(input)
+	if len(estimateMatches) > 1 {
+		estimate, err := ParseEstimate(estimateMatches[1])
+		if err != nil {
+			result.Errors = append(result.Errors, "Invalid estimate '"+estimateMatches[1]+"': "+err.Error())
+		} else {
+			result.Estimate = estimate
+		}
+		// Remove from title
+		input = estimateRegex.ReplaceAllString(input, " ")
+	}
+
 	// Clean up the title (remove extra spaces)
 	result.Title = strings.Join(strings.Fields(input), " ")
 	result.Title = strings.TrimSpace(result.Title)
 
 	return result
 }
-
-// isValidPriority checks if a priority value is valid
-func isValidPriority(priority string) bool {
-	validPriorities := map[string]bool{
-		"low":    true,
-		"medium": true,
-		"med":    true,
-		"high":   true,
-		"1":      true,
-		"2":      true,
-		"3":      true,
-	}
-	return validPriorities[priority]
-}
-
-// NormalizePriority converts priority to standard form
-func NormalizePriority(priority string) string {
-	priority = strings.ToLower(strings.TrimSpace(priority))
-	switch priority {
-	case "1", "low":
-		return "low"
-	case "2", "medium", "med":
-		return "medium"
-	case "3", "high":
-		return "high"
-	default:
-		return "low"
-	}
-}
-
-// PriorityToInt converts priority string to integer
-func PriorityToInt(priority string) int {
-	switch NormalizePriority(priority) {
-	case "low":
-		return 1
-	case "medium":
-		return 2
-	case "high":
-		return 3
-	default:
-		return 1
-	}
-}
\ No newline at end of file
@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// StartOfWeek returns midnight on the most recent first day of the week on or before t
+func StartOfWeek(t time.Time, first time.Weekday) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) - int(first) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// WeekDays returns the seven days of the week in order, starting at first
+func WeekDays(first time.Weekday) []time.Weekday {
+	days := make([]time.Weekday, 7)
+	for i := range days {
+		days[i] = time.Weekday((int(first) + i) % 7)
+	}
+	return days
+}
+
+// dayLabels holds short weekday names per language, indexed by time.Weekday (Sunday first)
+var dayLabels = map[string][7]string{
+	"en": {"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	"de": {"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	"fr": {"dim", "lun", "mar", "mer", "jeu", "ven", "sam"},
+	"es": {"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	"it": {"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+	"nl": {"zo", "ma", "di", "wo", "do", "vr", "za"},
+	"pt": {"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+}
+
+// DayLabel returns the short name of a weekday in the user's locale
+// (LC_ALL, LC_TIME or LANG), falling back to English
+func DayLabel(day time.Weekday) string {
+	labels, ok := dayLabels[localeLanguage()]
+	if !ok {
+		labels = dayLabels["en"]
+	}
+	return labels[day]
+}
+
+// localeLanguage returns the language part of the locale, e.g. "de" for "de_DE.UTF-8"
+func localeLanguage() string {
+	for _, name := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		language, _, _ := strings.Cut(strings.ToLower(value), "_")
+		language, _, _ = strings.Cut(language, ".")
+		return language
+	}
+	return "en"
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+)
+
+// weekdayNames maps the names due dates accept to weekdays
+var weekdayNames = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// dayLayouts are the ways a calendar day can be written: dd/mm/yyyy or yyyy-mm-dd
+var dayLayouts = []string{"02/01/2006", "2/1/2006", "2006-01-02"}
+
+// ParseDay parses a calendar day written as dd/mm/yyyy or yyyy-mm-dd and
+// returns its start in the local timezone
+func ParseDay(input string) (time.Time, error) {
+	for _, layout := range dayLayouts {
+		if day, err := time.ParseInLocation(layout, strings.TrimSpace(input), time.Local); err == nil {
+			return day, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date '%s', use dd/mm/yyyy or yyyy-mm-dd", input)
+}
+
+// holidays returns the days off listed as holidays in the config, keyed by
+// date (2006-01-02). Entries are dd/mm/yyyy or yyyy-mm-dd
+func holidays() (map[string]bool, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	days := make(map[string]bool)
+	for _, entry := range cfg.Holidays {
+		var day time.Time
+		var parseErr error
+		for _, layout := range dayLayouts {
+			if day, parseErr = time.Parse(layout, strings.TrimSpace(entry)); parseErr == nil {
+				break
+			}
+		}
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid holiday '%s' in the config, use dd/mm/yyyy or yyyy-mm-dd", entry)
+		}
+		days[day.Format("2006-01-02")] = true
+	}
+	return days, nil
+}
+
+// NonWorkingDay reports whether t falls on a weekend or a holiday from the
+// config, and which of them, e.g. "a Saturday" or "a holiday"
+func NonWorkingDay(t time.Time) (string, bool) {
+	switch t.Weekday() {
+	case time.Saturday, time.Sunday:
+		return "a " + t.Weekday().String(), true
+	}
+	days, err := holidays()
+	if err == nil && days[t.Format("2006-01-02")] {
+		return "a holiday", true
+	}
+	return "", false
+}
+
+// addBusinessDays returns the day n working days after day, skipping weekends
+// and holidays
+func addBusinessDays(day time.Time, n int) (time.Time, error) {
+	days, err := holidays()
+	if err != nil {
+		return time.Time{}, err
+	}
+	for n > 0 {
+		day = day.AddDate(0, 0, 1)
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday && !days[day.Format("2006-01-02")] {
+			n--
+		}
+	}
+	return day, nil
+}
+
+// nextWeekday returns the first day after day that is weekday, a week later
+// when day already is one
+func nextWeekday(day time.Time, weekday time.Weekday) time.Time {
+	offset := (int(weekday) - int(day.Weekday()) + 7) % 7
+	if offset == 0 {
+		offset = 7
+	}
+	return day.AddDate(0, 0, offset)
+}
@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestMain points HOME at a config whose holidays are Christmas 2026 (a
+// Friday) and the Monday after, so the business-day tests don't depend on
+// the machine's config
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "wrok-parser-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv("HOME", home)
+	config := "holidays = [\"2026-12-25\", \"28/12/2026\"]\n"
+	if err := os.MkdirAll(filepath.Join(home, ".wrok"), 0755); err == nil {
+		err = os.WriteFile(filepath.Join(home, ".wrok", "config.toml"), []byte(config), 0644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, 12, d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		from time.Time
+		n    int
+		want time.Time
+	}{
+		{day(16), 1, day(17)}, // Wednesday to Thursday
+		{day(16), 2, day(18)},
+		{day(18), 1, day(21)}, // Friday to Monday
+		{day(19), 1, day(21)}, // Saturday to Monday
+		{day(23), 1, day(24)},
+		{day(24), 1, day(29)}, // over Christmas, the weekend and the Monday off
+		{day(21), 5, day(30)},
+	}
+	for _, tt := range tests {
+		got, err := addBusinessDays(tt.from, tt.n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("addBusinessDays(%s, %d) = %s, want %s", tt.from.Format("Mon 02/01"), tt.n, got.Format("Mon 02/01"), tt.want.Format("Mon 02/01"))
+		}
+	}
+}
+
+func TestNextWeekday(t *testing.T) {
+	// Wednesday 16 December 2026
+	wednesday := time.Date(2026, 12, 16, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		weekday time.Weekday
+		want    int
+	}{
+		{time.Thursday, 17},
+		{time.Friday, 18},
+		{time.Sunday, 20},
+		{time.Monday, 21},
+		{time.Wednesday, 23}, // a week later, not today
+	}
+	for _, tt := range tests {
+		if got := nextWeekday(wednesday, tt.weekday); got.Day() != tt.want {
+			t.Errorf("nextWeekday(Wednesday 16th, %s) = %s, want the %dth", tt.weekday, got.Format("Mon 02/01"), tt.want)
+		}
+	}
+}
+
+func TestNonWorkingDay(t *testing.T) {
+	tests := []struct {
+		day  int
+		want string
+	}{
+		{18, ""},
+		{19, "a Saturday"},
+		{20, "a Sunday"},
+		{25, "a holiday"},
+		{28, "a holiday"},
+	}
+	for _, tt := range tests {
+		got, ok := NonWorkingDay(time.Date(2026, 12, tt.day, 15, 0, 0, 0, time.Local))
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("NonWorkingDay(%d/12/2026) = %q, %v, want %q", tt.day, got, ok, tt.want)
+		}
+	}
+}
+
+func TestParseDueDateWorkdays(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	endOfDay := func(day time.Time) time.Time {
+		return day.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+	}
+	businessDays := func(n int) time.Time {
+		day, err := addBusinessDays(today, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return endOfDay(day)
+	}
+	friday := endOfDay(nextWeekday(today, time.Friday))
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"friday", friday},
+		{"Fri", friday},
+		{"next friday", friday},
+		{"next-Friday", friday},
+		{"tues", endOfDay(nextWeekday(today, time.Tuesday))},
+		{"next business day", businessDays(1)},
+		{"next-business-day", businessDays(1)},
+		{"1 business day", businessDays(1)},
+		{"3 business days", businessDays(3)},
+		{"3businessdays", businessDays(3)},
+		{" 10 workdays ", businessDays(10)},
+		{"260 work days", businessDays(260)},
+	}
+	for _, tt := range tests {
+		got, err := ParseDueDate(tt.input)
+		if err != nil {
+			t.Errorf("ParseDueDate(%q): %v", tt.input, err)
+			continue
+		}
+		if got == nil || !got.Equal(tt.want) {
+			t.Errorf("ParseDueDate(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{
+		"0 business days",
+		"261 business days",
+		"business day",
+		"next holiday",
+		"fridays",
+		"next next friday",
+		"friday 3pm",
+	} {
+		if _, err := ParseDueDate(input); err == nil {
+			t.Errorf("ParseDueDate(%q) accepted an invalid due date", input)
+		}
+	}
+}
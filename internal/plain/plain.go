@@ -0,0 +1,270 @@
+package plain
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// enabled is set once at startup by Enable
+var enabled bool
+
+// The process's own stdout and stderr, kept when CaptureOutput puts filters in their place
+var (
+	stdout = os.Stdout
+	stderr = os.Stderr
+)
+
+// captures are the filters CaptureOutput started; Flush waits for them
+var captures []*capture
+
+// labels are the symbols that carry meaning, replaced by words or ASCII
+var labels = map[rune]string{
+	'⚠': "Warning:",
+	'❌': "Error:",
+	'🚨': "Alert:",
+	'✅': "[x]",
+	'✓': "[x]",
+	'○': "[ ]",
+	'▪': "[-]",
+	'⏸': "[paused]",
+	'📌': "[pinned]",
+	'🔒': "[locked]",
+	'●': "*",
+	'◈': "*",
+	'•': "*",
+	'▶': ">",
+	'▸': ">",
+	'›': ">",
+	'➡': "->",
+	'█': "#",
+	'▓': "#",
+	'▒': "-",
+	'░': "-",
+	'▁': "_",
+	'▂': ".",
+	'▃': ":",
+	'▄': "-",
+	'▅': "=",
+	'▆': "+",
+	'▇': "*",
+}
+
+// Enable turns plain mode on for the rest of the run
+func Enable() {
+	enabled = true
+}
+
+// Enabled reports whether output is plain: no emoji, box-drawing art, ASCII
+// logos or animation, for screen readers and terminals without those glyphs
+func Enabled() bool {
+	return enabled
+}
+
+// Text returns s with emoji and symbols replaced by textual labels (⚠️ becomes
+// "Warning:", ✅ "[x]", 📌 "[pinned]"), box-drawing lines by - | and + and
+// decorative emoji removed along with the spaces after them
+func Text(s string) string {
+	if !hasSymbols(s) {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+
+		if r < utf8.RuneSelf || isText(r) {
+			b.WriteRune(r)
+			continue
+		}
+		if isSelector(r) {
+			continue
+		}
+		if line, ok := boxDrawing(r); ok {
+			b.WriteString(line)
+			continue
+		}
+
+		label, labeled := labels[r]
+		if !labeled && !isEmoji(r) {
+			b.WriteRune(r) // Arrows, dashes and other text symbols read fine
+			continue
+		}
+
+		// The variation selectors and spaces after a symbol go with it
+		rest := strings.TrimLeft(strings.TrimLeftFunc(s[i:], isSelector), " ")
+		spaced := len(rest) < len(strings.TrimLeftFunc(s[i:], isSelector))
+		i = len(s) - len(rest)
+
+		// "❌ Error: ..." needs no second "Error:"
+		if strings.HasSuffix(label, ":") && hasPrefixFold(rest, strings.TrimSuffix(label, ":")) {
+			label = ""
+		}
+		if label == "" {
+			continue
+		}
+		b.WriteString(label)
+		if spaced {
+			b.WriteByte(' ')
+		}
+	}
+	return b.String()
+}
+
+// hasSymbols reports whether s has anything Text would change
+func hasSymbols(s string) bool {
+	for _, r := range s {
+		if r >= utf8.RuneSelf && !isText(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// isText reports whether r is a letter, digit or Latin punctuation that any
+// terminal font and screen reader handles
+func isText(r rune) bool {
+	return r < 0x2000 && !isSelector(r)
+}
+
+// isSelector reports whether r only modifies the symbol before it
+func isSelector(r rune) bool {
+	return (r >= 0xFE00 && r <= 0xFE0F) || r == 0x200D || r == 0x20E3
+}
+
+// isEmoji reports whether r is a pictograph or dingbat
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x2300 && r <= 0x23FF: // ⏱ ⏲ ⏹
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // ⚡ ✨ ♻
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF:
+		return true
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return true
+	}
+	return false
+}
+
+// boxDrawing returns the ASCII for a box-drawing character: - or = for
+// horizontal lines, | for vertical ones and + for corners and joints
+func boxDrawing(r rune) (string, bool) {
+	if r < 0x2500 || r > 0x257F {
+		return "", false
+	}
+	switch r {
+	case '─', '━', '┄', '┅', '┈', '┉', '╌', '╍', '╴', '╶', '╸', '╺', '╼', '╾':
+		return "-", true
+	case '═':
+		return "=", true
+	case '│', '┃', '║', '┆', '┇', '┊', '┋', '╎', '╏', '╵', '╷', '╹', '╻', '╽', '╿':
+		return "|", true
+	}
+	return "+", true
+}
+
+// hasPrefixFold reports whether s starts with prefix, ignoring case
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// Stdout returns the process's stdout, unfiltered even after CaptureOutput
+func Stdout() *os.File {
+	return stdout
+}
+
+// Stderr returns the process's stderr, unfiltered even after CaptureOutput
+func Stderr() *os.File {
+	return stderr
+}
+
+// capture pipes what is written to a std stream through Text to the real one
+type capture struct {
+	pipe *os.File
+	done chan struct{}
+}
+
+// CaptureOutput makes everything printed to stdout and stderr plain, when they
+// are terminals. Output to files and pipes is data for other programs and is
+// left alone. Call Flush before exiting so nothing is lost
+func CaptureOutput() {
+	if isTerminal(stdout) {
+		if c := startCapture(stdout); c != nil {
+			os.Stdout = c.pipe
+		}
+	}
+	if isTerminal(stderr) {
+		if c := startCapture(stderr); c != nil {
+			os.Stderr = c.pipe
+		}
+	}
+}
+
+// startCapture starts copying a new pipe's output to out through Text
+func startCapture(out *os.File) *capture {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil
+	}
+
+	c := &capture{pipe: writer, done: make(chan struct{})}
+	captures = append(captures, c)
+	go func() {
+		defer close(c.done)
+		buf := make([]byte, 4096)
+		var pending []byte
+		for {
+			n, err := reader.Read(buf)
+			if n > 0 {
+				pending = append(pending, buf[:n]...)
+				// A multi-byte character split across reads waits for its rest
+				complete := len(pending)
+				if start := lastRuneStart(pending); !utf8.FullRune(pending[start:]) {
+					complete = start
+				}
+				io.WriteString(out, Text(string(pending[:complete])))
+				pending = append(pending[:0], pending[complete:]...)
+			}
+			if err != nil {
+				io.WriteString(out, Text(string(pending)))
+				return
+			}
+		}
+	}()
+	return c
+}
+
+// lastRuneStart returns where the last character of b starts
+func lastRuneStart(b []byte) int {
+	i := len(b) - 1
+	for i > 0 && !utf8.RuneStart(b[i]) {
+		i--
+	}
+	return i
+}
+
+// flushOnce makes Flush safe to call more than once
+var flushOnce sync.Once
+
+// Flush writes out what is still in the capture pipes and restores stdout and
+// stderr
+func Flush() {
+	flushOnce.Do(func() {
+		os.Stdout, os.Stderr = stdout, stderr
+		for _, c := range captures {
+			c.pipe.Close()
+			<-c.done
+		}
+	})
+}
+
+// isTerminal reports whether f is a terminal rather than a file or pipe
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
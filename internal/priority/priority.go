@@ -0,0 +1,152 @@
+package priority
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/balkashynov/wrok/internal/config"
+)
+
+// Level is one step of the priority scheme. Tasks store the level's position
+// in the scheme, 1 for the lowest; 0 means no priority
+type Level struct {
+	Name  string // full name, e.g. "medium"
+	Short string // name in table columns, at most 8 cells, e.g. "med"
+	Color string // display color (#RRGGBB or an ANSI 256 code); empty picks one by rank
+}
+
+// defaultLevels is the scheme used when the config doesn't define one
+var defaultLevels = []Level{
+	{Name: "low", Short: "low"},
+	{Name: "medium", Short: "med"},
+	{Name: "high", Short: "high"},
+}
+
+// levels caches the scheme for the lifetime of the process
+var levels []Level
+
+// Levels returns the priority scheme from the [priority] config section, lowest first
+func Levels() []Level {
+	if levels != nil {
+		return levels
+	}
+
+	levels = defaultLevels
+	cfg, err := config.Load()
+	if err != nil || len(cfg.Priority.Levels) == 0 {
+		return levels
+	}
+
+	var configured []Level
+	for _, level := range cfg.Priority.Levels {
+		name := strings.TrimSpace(level.Name)
+		if name == "" {
+			continue
+		}
+		short := strings.TrimSpace(level.Short)
+		if short == "" {
+			short = name
+		}
+		configured = append(configured, Level{Name: name, Short: short, Color: level.Color})
+	}
+	if len(configured) > 0 {
+		levels = configured
+	}
+	return levels
+}
+
+// Max returns the stored value of the highest priority
+func Max() int {
+	return len(Levels())
+}
+
+// level returns the level for a stored value
+func level(value int) (Level, bool) {
+	if value < 1 || value > Max() {
+		return Level{}, false
+	}
+	return Levels()[value-1], true
+}
+
+// Parse converts a level name, short name or number (1 = lowest) to its stored
+// value, ignoring case. Empty input means no priority (0)
+func Parse(input string) (int, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0, nil
+	}
+	for i, level := range Levels() {
+		if strings.EqualFold(input, level.Name) || strings.EqualFold(input, level.Short) {
+			return i + 1, nil
+		}
+	}
+	if n, err := strconv.Atoi(input); err == nil && n >= 1 && n <= Max() {
+		return n, nil
+	}
+	return 0, fmt.Errorf("invalid priority '%s'. Use: %s", input, Hint())
+}
+
+// Valid reports whether input names a priority
+func Valid(input string) bool {
+	_, err := Parse(input)
+	return err == nil
+}
+
+// Hint lists the accepted values, e.g. "low, medium, high, or 1-3"
+func Hint() string {
+	var names []string
+	for _, level := range Levels() {
+		names = append(names, level.Name)
+	}
+	numbers := "1"
+	if Max() > 1 {
+		numbers = fmt.Sprintf("1-%d", Max())
+	}
+	return strings.Join(names, ", ") + ", or " + numbers
+}
+
+// Name returns the full name of a stored value, "" for no priority
+func Name(value int) string {
+	if value == 0 {
+		return ""
+	}
+	if level, ok := level(value); ok {
+		return level.Name
+	}
+	return strconv.Itoa(value) // stored under a scheme with more levels
+}
+
+// Short returns the column name of a stored value, "" for no priority
+func Short(value int) string {
+	if value == 0 {
+		return ""
+	}
+	if level, ok := level(value); ok {
+		return level.Short
+	}
+	return strconv.Itoa(value)
+}
+
+// Normalize returns the full name for input, "" if it isn't a priority
+func Normalize(input string) string {
+	value, err := Parse(input)
+	if err != nil {
+		return ""
+	}
+	return Name(value)
+}
+
+// Rank returns how far a stored value is from the top: 0 for the highest
+// priority, 1 for the next one, and so on. Values above the scheme rank 0
+func Rank(value int) int {
+	return max(Max()-value, 0)
+}
+
+// Color returns the configured display color of a stored value, "" if none is set
+func Color(value int) string {
+	if level, ok := level(value); ok {
+		return level.Color
+	}
+	return ""
+}
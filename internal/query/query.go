@@ -0,0 +1,196 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/workflow"
+)
+
+// Fields operators can filter on
+const (
+	FieldProject  = "project"
+	FieldTag      = "tag"
+	FieldStatus   = "status"
+	FieldPriority = "priority"
+	FieldDue      = "due"
+	FieldJira     = "jira"
+)
+
+// Operators between a field and its value
+const (
+	OpIs   = ":"
+	OpLess = "<"
+	OpMore = ">"
+)
+
+// comparable are the fields that take < and > besides :
+var comparable = map[string]bool{FieldPriority: true, FieldDue: true}
+
+// dueKeywords are the due values that aren't a day or a moment
+var dueKeywords = map[string]bool{"none": true, "any": true, "overdue": true}
+
+// dueOffsetRegex matches due values relative to now, e.g. "7d", "2w" or "5h"
+var dueOffsetRegex = regexp.MustCompile(`^(\d+)(h|d|w)$`)
+
+// termRegex matches a field term: an optional "-", a field, an operator and a value
+var termRegex = regexp.MustCompile(`^(-?)([a-zA-Z]+)(:|<|>)(.*)$`)
+
+// Term is one field filter of a query, e.g. "tag:backend" or "due<7d"
+type Term struct {
+	Field  string
+	Op     string
+	Value  string
+	Negate bool // written with a leading "-", e.g. "-tag:wip"
+}
+
+// Query is a parsed search query: field filters and the free text around them
+type Query struct {
+	Terms []Term
+	Text  string
+}
+
+// Parse splits a search query like `project:web tag:backend due<7d "login bug"`
+// into field terms and free text. Values with spaces are quoted, e.g.
+// project:"client site". Words that only look like terms, such as "note:" or
+// URLs, stay free text
+func Parse(input string) (Query, error) {
+	words, err := splitWords(input)
+	if err != nil {
+		return Query{}, err
+	}
+
+	var q Query
+	var text []string
+	for _, word := range words {
+		term, ok := parseTerm(word)
+		if !ok {
+			text = append(text, word)
+			continue
+		}
+		if err := validate(term); err != nil {
+			return Query{}, err
+		}
+		q.Terms = append(q.Terms, term)
+	}
+	q.Text = strings.Join(text, " ")
+	return q, nil
+}
+
+// splitWords splits input at whitespace, keeping quoted parts (which may follow
+// an operator, as in project:"client site") together without their quotes
+func splitWords(input string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord, quoted := false, false
+
+	for _, r := range input {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inWord = true
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in query")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// parseTerm reads a word as a field term; ok is false for free text
+func parseTerm(word string) (Term, bool) {
+	matches := termRegex.FindStringSubmatch(word)
+	if matches == nil || matches[4] == "" {
+		return Term{}, false
+	}
+	field := strings.ToLower(matches[2])
+	switch field {
+	case FieldProject, FieldTag, FieldStatus, FieldPriority, FieldDue, FieldJira:
+	default:
+		return Term{}, false
+	}
+	return Term{Field: field, Op: matches[3], Value: matches[4], Negate: matches[1] == "-"}, true
+}
+
+// validate checks that a term's operator and value make sense for its field
+func validate(t Term) error {
+	if t.Op != OpIs && !comparable[t.Field] {
+		return fmt.Errorf("'%s' only works with ':' (e.g. %s:value)", t.Field, t.Field)
+	}
+
+	switch t.Field {
+	case FieldStatus:
+		if !workflow.Known(strings.ToLower(t.Value)) {
+			return fmt.Errorf("invalid status '%s', use %s", t.Value, workflow.Hint())
+		}
+	case FieldDue:
+		value := strings.ToLower(t.Value)
+		if dueKeywords[value] {
+			if t.Op != OpIs {
+				return fmt.Errorf("'due%s%s' makes no sense, use due:%s", t.Op, t.Value, t.Value)
+			}
+			return nil
+		}
+		start, end, err := DueSpan(value, time.Now())
+		if err != nil {
+			return err
+		}
+		if t.Op == OpIs && start.Equal(end) {
+			return fmt.Errorf("'due:%s' is a moment, not a day; use due<%s or due>%s", t.Value, t.Value, t.Value)
+		}
+	}
+	return nil
+}
+
+// DueSpan returns the time a due value stands for, from start up to (not
+// including) end: a whole day for "today", "tomorrow", "3d", "2w" and dates
+// (31/12/2026 or 2026-12-31), a single moment for hours like "5h"
+func DueSpan(value string, now time.Time) (start, end time.Time, err error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	day := func(d time.Time) (time.Time, time.Time, error) {
+		return d, d.AddDate(0, 0, 1), nil
+	}
+
+	switch value {
+	case "today":
+		return day(today)
+	case "tomorrow":
+		return day(today.AddDate(0, 0, 1))
+	}
+
+	if matches := dueOffsetRegex.FindStringSubmatch(value); matches != nil {
+		amount, _ := strconv.Atoi(matches[1])
+		switch matches[2] {
+		case "h":
+			moment := now.Add(time.Duration(amount) * time.Hour)
+			return moment, moment, nil
+		case "d":
+			return day(today.AddDate(0, 0, amount))
+		default:
+			return day(today.AddDate(0, 0, amount*7))
+		}
+	}
+
+	for _, layout := range []string{"02/01/2006", "2/1/2006", "2006-01-02"} {
+		if date, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return day(date)
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid due '%s', use e.g. today, 7d, 2w, 5h, 31/12/2026, none, any or overdue", value)
+}
@@ -0,0 +1,111 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Query
+	}{
+		{"", Query{}},
+		{"login bug", Query{Text: "login bug"}},
+		{"project:web tag:backend status:todo priority:high due<7d fix login", Query{
+			Terms: []Term{
+				{Field: FieldProject, Op: OpIs, Value: "web"},
+				{Field: FieldTag, Op: OpIs, Value: "backend"},
+				{Field: FieldStatus, Op: OpIs, Value: "todo"},
+				{Field: FieldPriority, Op: OpIs, Value: "high"},
+				{Field: FieldDue, Op: OpLess, Value: "7d"},
+			},
+			Text: "fix login",
+		}},
+		{`project:"client site"  "login bug"`, Query{
+			Terms: []Term{{Field: FieldProject, Op: OpIs, Value: "client site"}},
+			Text:  "login bug",
+		}},
+		{"-tag:wip Tag:Backend priority>1 due>tomorrow jira:ABC-123", Query{
+			Terms: []Term{
+				{Field: FieldTag, Op: OpIs, Value: "wip", Negate: true},
+				{Field: FieldTag, Op: OpIs, Value: "Backend"},
+				{Field: FieldPriority, Op: OpMore, Value: "1"},
+				{Field: FieldDue, Op: OpMore, Value: "tomorrow"},
+				{Field: FieldJira, Op: OpIs, Value: "ABC-123"},
+			},
+		}},
+		{"due:none due:Overdue due:2026-12-31", Query{
+			Terms: []Term{
+				{Field: FieldDue, Op: OpIs, Value: "none"},
+				{Field: FieldDue, Op: OpIs, Value: "Overdue"},
+				{Field: FieldDue, Op: OpIs, Value: "2026-12-31"},
+			},
+		}},
+		// Words that only look like terms stay free text
+		{"note: https://example.com/a:b owner:me tag:", Query{Text: "note: https://example.com/a:b owner:me tag:"}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{
+		`project:"client site`,
+		"status:someday",
+		"project<web",
+		"tag>backend",
+		"due>none",
+		"due:5h",
+		"due<next-week",
+	} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) accepted an invalid query", input)
+		}
+	}
+}
+
+func TestDueSpan(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 30, 0, 0, time.Local)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2026, month, d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		value      string
+		start, end time.Time
+	}{
+		{"today", day(3, 10), day(3, 11)},
+		{" Tomorrow ", day(3, 11), day(3, 12)},
+		{"0d", day(3, 10), day(3, 11)},
+		{"7d", day(3, 17), day(3, 18)},
+		{"2w", day(3, 24), day(3, 25)},
+		{"5h", now.Add(5 * time.Hour), now.Add(5 * time.Hour)},
+		{"31/12/2026", day(12, 31), time.Date(2027, 1, 1, 0, 0, 0, 0, time.Local)},
+		{"1/4/2026", day(4, 1), day(4, 2)},
+		{"2026-04-01", day(4, 1), day(4, 2)},
+	}
+	for _, tt := range tests {
+		start, end, err := DueSpan(tt.value, now)
+		if err != nil {
+			t.Errorf("DueSpan(%q): %v", tt.value, err)
+			continue
+		}
+		if !start.Equal(tt.start) || !end.Equal(tt.end) {
+			t.Errorf("DueSpan(%q) = %v to %v, want %v to %v", tt.value, start, end, tt.start, tt.end)
+		}
+	}
+
+	for _, value := range []string{"", "yesterday", "-1d", "3m", "1.5d", "31/02/2026", "2026-13-01", "none"} {
+		if _, _, err := DueSpan(value, now); err == nil {
+			t.Errorf("DueSpan(%q) accepted an invalid due", value)
+		}
+	}
+}
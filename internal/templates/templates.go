@@ -0,0 +1,106 @@
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/priority"
+)
+
+// Funcs are the helpers available in user templates (--template and the digest)
+var Funcs = template.FuncMap{
+	// hours renders a duration as "7.5h", or minutes below an hour
+	"hours": func(d time.Duration) string {
+		if d < time.Hour {
+			return fmt.Sprintf("%.0fm", d.Minutes())
+		}
+		return fmt.Sprintf("%.1fh", d.Hours())
+	},
+	// seconds turns a duration, or a session's duration_seconds, into a time.Duration
+	"seconds": func(s int) time.Duration {
+		return time.Duration(s) * time.Second
+	},
+	// date renders a day as "Mon 12 Oct 2026"
+	"date": func(t any) string {
+		return formatTime(t, "Mon 2 Jan 2006")
+	},
+	// format renders a time with a Go layout, e.g. {{format "2006-01-02" .Due}}
+	"format": func(layout string, t any) string {
+		return formatTime(t, layout)
+	},
+	// priority names a priority level from the configured scheme
+	"priority": priority.Name,
+	// tags lists a task's tag names
+	"tags": func(task models.Task) []string {
+		names := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			names[i] = tag.Name
+		}
+		return names
+	},
+	"join":  func(sep string, items []string) string { return strings.Join(items, sep) },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	// pad pads s with spaces to width characters
+	"pad": func(width int, s string) string {
+		return fmt.Sprintf("%-*s", width, s)
+	},
+	// truncate cuts s to width characters, ending in "..."
+	"truncate": func(width int, s string) string {
+		runes := []rune(s)
+		if len(runes) <= width || width < 4 {
+			return s
+		}
+		return string(runes[:width-3]) + "..."
+	},
+	// json renders a value as JSON, e.g. to quote a title
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// formatTime formats a time.Time or *time.Time; a nil time gives ""
+func formatTime(t any, layout string) string {
+	switch t := t.(type) {
+	case time.Time:
+		return t.Format(layout)
+	case *time.Time:
+		if t != nil {
+			return t.Format(layout)
+		}
+	}
+	return ""
+}
+
+// Parse parses template text with Funcs; name shows up in error messages
+func Parse(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(Funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Execute renders the template file at path with data to w
+func Execute(w io.Writer, path string, data any) error {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+	tmpl, err := Parse(filepath.Base(path), string(text))
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package transcribe
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/balkashynov/wrok/internal/config"
+)
+
+// requestTimeout bounds the upload and transcription of one recording
+const requestTimeout = 2 * time.Minute
+
+// defaultModel is the model asked for when [transcription] model is unset
+const defaultModel = "whisper-1"
+
+// maxFileSize is the largest recording OpenAI's endpoint accepts
+const maxFileSize = 25 << 20
+
+// File sends an audio recording to the OpenAI-compatible transcription
+// endpoint of the [transcription] config and returns what was said. The key
+// may also come from WROK_TRANSCRIPTION_KEY, which keeps it out of the config file
+func File(cfg config.TranscriptionConfig, path string) (string, error) {
+	if cfg.URL == "" {
+		return "", errors.New("set [transcription] url in the config to an OpenAI-compatible endpoint, e.g. https://api.openai.com/v1/audio/transcriptions")
+	}
+	key := strings.TrimSpace(os.Getenv("WROK_TRANSCRIPTION_KEY"))
+	if key == "" {
+		key = cfg.APIKey
+	}
+	model := cfg.Model
+	if model == "" {
+		model = defaultModel
+	}
+
+	audio, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(audio) > maxFileSize {
+		return "", fmt.Errorf("%s is over 25MB, too long for a voice note", path)
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+	part.Write(audio)
+	form.WriteField("model", model)
+	form.WriteField("response_format", "json")
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, cfg.URL, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	resp, err := (&http.Client{Timeout: requestTimeout}).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach the transcription endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", fmt.Errorf("the transcription endpoint refused the key (HTTP %d); check WROK_TRANSCRIPTION_KEY or [transcription] api_key", resp.StatusCode)
+	default:
+		text, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return "", fmt.Errorf("the transcription endpoint returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(text)))
+	}
+
+	var data struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return "", fmt.Errorf("failed to read the transcript: %w", err)
+	}
+	text := strings.TrimSpace(data.Text)
+	if text == "" {
+		return "", errors.New("nothing was heard in the recording")
+	}
+	return text, nil
+}
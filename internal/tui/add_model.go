@@ -2,16 +2,18 @@ package tui
 
 import (
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/balkashynov/wrok/internal/config"
 	"github.com/balkashynov/wrok/internal/db"
 	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/priority"
 )
 
 
@@ -31,10 +33,23 @@ const (
 	StepComplete
 )
 
+// AfterSave is what the add wizard does once the new task is saved
+type AfterSave int
+
+const (
+	AfterSaveNothing AfterSave = iota
+	AfterSaveStart             // start tracking time on the task
+	AfterSaveTimer             // start tracking and open the timer
+)
+
+// afterSaveLabels describe each AfterSave choice on the Save step
+var afterSaveLabels = []string{"don't start the timer", "start the timer", "start the timer and open it"}
+
 // AddTaskModel represents the TUI model for adding tasks
 type AddTaskModel struct {
 	currentStep Step
-	inputs      []textinput.Model
+	inputs      []textinput.Model // one per step from title to due date
+	notesInput  textarea.Model    // notes can span several lines
 	width       int
 	height      int
 	
@@ -46,6 +61,8 @@ type AddTaskModel struct {
 	jiraID    string
 	dueDate   string
 	notes     string
+	url       string // no wizard step; kept from the flags, parsing or the edited task
+	estimate  time.Duration // same
 	
 	// Pre-filled data from flags or parsing
 	prefilled map[string]string
@@ -61,9 +78,22 @@ type AddTaskModel struct {
 	validationErr string
 	createdTaskID uint
 	createdTaskTitle string
+	toast         Toast // Save errors, shown until they time out
 	
 	// Tag input state
 	isAddingTags bool
+	tagSuggest   suggester
+	
+	// Project input state
+	projectSuggest suggester
+	projectWarned  string // new project name the user was already warned about
+	
+	// JIRA input state
+	jiraSuggest   suggester // recently used prefixes, completed as "APP-"
+	jiraPrefixes  []string
+	jiraCorrected string // what the user typed before the input was auto-corrected
+	jiraWarned    string // invalid ID the user was already warned about
+	jiraStrict    bool   // [jira] strict: refuse invalid IDs instead of storing them
 	
 	// Shimmer effect for field labels
 	shimmer *ShimmerState
@@ -71,14 +101,20 @@ type AddTaskModel struct {
 	// Save confirmation modal
 	showSaveModal bool
 	saveModalChoice bool // true for Yes, false for No
+	
+	// What to do once a new task is saved, toggled on the Save step
+	afterSave AfterSave
 }
 
+// notesCharLimit is the longest note the wizard accepts
+const notesCharLimit = 2000
+
 // NewAddTaskModel creates a new add task TUI model
 func NewAddTaskModel(prefilled map[string]string) AddTaskModel {
-	inputs := make([]textinput.Model, 7)
+	inputs := make([]textinput.Model, StepNotes)
 	
 	// Apply color theme to all inputs
-	for i := 0; i < 7; i++ {
+	for i := range inputs {
 		inputs[i] = textinput.New()
 		inputs[i].Width = 60
 		
@@ -102,8 +138,8 @@ func NewAddTaskModel(prefilled map[string]string) AddTaskModel {
 	inputs[2].CharLimit = 50
 	
 	// Priority input
-	inputs[3].Placeholder = "low/medium/high or 1/2/3 (Enter to skip - no priority)"
-	inputs[3].CharLimit = 10
+	inputs[3].Placeholder = priority.Hint() + " (Enter to skip - no priority)"
+	inputs[3].CharLimit = 20
 	
 	// JIRA input
 	inputs[4].Placeholder = "JIRA ticket like APP-42 (Enter to skip)"
@@ -114,8 +150,18 @@ func NewAddTaskModel(prefilled map[string]string) AddTaskModel {
 	inputs[5].CharLimit = 50
 	
 	// Notes input
-	inputs[6].Placeholder = "Additional notes (Enter to skip)"
-	inputs[6].CharLimit = 500
+	notesInput := textarea.New()
+	notesInput.Placeholder = "Additional notes (Enter to skip; once typing, Enter adds a line and Tab moves on)"
+	notesInput.CharLimit = notesCharLimit
+	notesInput.ShowLineNumbers = false
+	notesInput.SetWidth(60)
+	notesInput.SetHeight(5)
+	notesInput.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	notesInput.FocusedStyle.Text = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimaryText))
+	notesInput.FocusedStyle.Placeholder = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPlaceholder))
+	notesInput.FocusedStyle.Prompt = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccentMain))
+	notesInput.BlurredStyle = notesInput.FocusedStyle
+	notesInput.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccentBright))
 
 	// Initialize shimmer effect
 	shimmerConfig := DefaultShimmerConfig()
@@ -124,11 +170,27 @@ func NewAddTaskModel(prefilled map[string]string) AddTaskModel {
 	m := AddTaskModel{
 		currentStep: StepTitle,
 		inputs:      inputs,
+		notesInput:  notesInput,
 		prefilled:   prefilled,
 		tags:        []string{},
 		shimmer:     shimmer,
 	}
 	
+	// Existing tags for autocompletion; suggestions are just skipped if this fails
+	tagNames, _ := db.GetTagNames()
+	m.tagSuggest = newSuggester(tagNames)
+	projectNames, _ := db.GetProjectNames()
+	m.projectSuggest = newSuggester(projectNames)
+	m.jiraPrefixes, _ = db.GetJiraPrefixes()
+	var prefixItems []string
+	for _, prefix := range m.jiraPrefixes {
+		prefixItems = append(prefixItems, prefix+"-")
+	}
+	m.jiraSuggest = newSuggester(prefixItems)
+	if cfg, err := config.Load(); err == nil {
+		m.jiraStrict = cfg.Jira.Strict
+	}
+	
 	// Set pre-filled values
 	if title, ok := prefilled["title"]; ok {
 		m.inputs[0].SetValue(title)
@@ -139,7 +201,7 @@ func NewAddTaskModel(prefilled map[string]string) AddTaskModel {
 		m.project = project
 	}
 	if tags, ok := prefilled["tags"]; ok {
-		m.inputs[2].SetValue(tags)
+		m.tags = splitTagList(tags)
 	}
 	if priority, ok := prefilled["priority"]; ok {
 		m.inputs[3].SetValue(priority)
@@ -154,9 +216,19 @@ func NewAddTaskModel(prefilled map[string]string) AddTaskModel {
 		m.dueDate = dueDate
 	}
 	if notes, ok := prefilled["notes"]; ok {
-		m.inputs[6].SetValue(notes)
+		m.notesInput.SetValue(notes)
 		m.notes = notes
 	}
+	m.url = prefilled["url"]
+	if estimate, err := parser.ParseEstimate(prefilled["estimate"]); err == nil {
+		m.estimate = estimate
+	}
+	switch prefilled["after_save"] {
+	case "start":
+		m.afterSave = AfterSaveStart
+	case "timer":
+		m.afterSave = AfterSaveTimer
+	}
 
 	return m
 }
@@ -218,6 +290,7 @@ func (m AddTaskModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		for i := range m.inputs {
 			m.inputs[i].Width = maxInputWidth
 		}
+		m.notesInput.SetWidth(maxInputWidth + 2) // textinput's width leaves out its prompt
 		
 		return m, nil
 		
@@ -247,6 +320,100 @@ func (m AddTaskModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		
+		// Tab and arrows pick project suggestions while any are shown
+		if m.currentStep == StepProject && len(m.projectSuggest.matches) > 0 {
+			switch msg.String() {
+			case "tab":
+				if project, ok := m.projectSuggest.current(); ok {
+					m.inputs[1].SetValue(project)
+					m.inputs[1].CursorEnd()
+					m.project = project
+					m.projectSuggest.filter(project, nil)
+				}
+				return m, nil
+			case "down":
+				m.projectSuggest.move(1)
+				return m, nil
+			case "up":
+				m.projectSuggest.move(-1)
+				return m, nil
+			}
+		}
+		
+		// Tab and arrows pick JIRA prefix suggestions while any are shown
+		if m.currentStep == StepJira && len(m.jiraSuggest.matches) > 0 {
+			switch msg.String() {
+			case "tab":
+				m.completeJiraPrefix()
+				return m, nil
+			case "down":
+				m.jiraSuggest.move(1)
+				return m, nil
+			case "up":
+				m.jiraSuggest.move(-1)
+				return m, nil
+			}
+		}
+		
+		// Backspace in the empty tag input removes the last tag
+		if m.currentStep == StepTags && msg.String() == "backspace" && m.inputs[2].Value() == "" && len(m.tags) > 0 {
+			m.tags = m.tags[:len(m.tags)-1]
+			m.tagSuggest.filter("", m.tags)
+			return m, nil
+		}
+
+		// Tab and arrows pick tag suggestions while any are shown
+		if m.currentStep == StepTags && len(m.tagSuggest.matches) > 0 {
+			switch msg.String() {
+			case "tab":
+				if tag, ok := m.tagSuggest.current(); ok {
+					m.inputs[2].SetValue(tag)
+					m.inputs[2].CursorEnd()
+					m.tagSuggest.filter(tag, m.tags)
+				}
+				return m, nil
+			case "down":
+				m.tagSuggest.move(1)
+				return m, nil
+			case "up":
+				m.tagSuggest.move(-1)
+				return m, nil
+			}
+		}
+		
+		// Notes span lines: Enter adds one once something is typed, and the
+		// arrows move the cursor, leaving the field only past the first or last line
+		if m.currentStep == StepNotes {
+			switch msg.String() {
+			case "enter":
+				if strings.TrimSpace(m.notesInput.Value()) != "" {
+					var cmd tea.Cmd
+					m.notesInput, cmd = m.notesInput.Update(msg)
+					m.notes = m.notesInput.Value()
+					return m, cmd
+				}
+			case "up":
+				if m.notesInput.Line() > 0 {
+					m.notesInput.CursorUp()
+					return m, nil
+				}
+			case "down":
+				if m.notesInput.Line() < m.notesInput.LineCount()-1 {
+					m.notesInput.CursorDown()
+					return m, nil
+				}
+			}
+		}
+		
+		// Space or s on the Save step of a new task picks what happens after saving
+		if m.currentStep == StepSave && !m.isEditMode {
+			switch msg.String() {
+			case " ", "s":
+				m.afterSave = (m.afterSave + 1) % AfterSave(len(afterSaveLabels))
+				return m, nil
+			}
+		}
+		
 		switch msg.String() {
 		case "ctrl+c":
 			m.cancelled = true
@@ -288,11 +455,23 @@ func (m AddTaskModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	
 	// Update the current input (only for input steps, not Save step)
 	var cmd tea.Cmd
-	if m.currentStep < StepSave {
+	if m.currentStep == StepNotes {
+		m.notesInput, cmd = m.notesInput.Update(msg)
+		m.updateCurrentField()
+	} else if m.currentStep < StepSave {
 		m.inputs[m.currentStep], cmd = m.inputs[m.currentStep].Update(msg)
 		
 		// Update the corresponding field
 		m.updateCurrentField()
+		
+		switch m.currentStep {
+		case StepProject:
+			m.projectSuggest.filter(m.inputs[1].Value(), nil)
+		case StepTags:
+			m.tagSuggest.filter(m.inputs[2].Value(), m.tags)
+		case StepJira:
+			m.jiraSuggest.filter(m.inputs[4].Value(), nil)
+		}
 	}
 	
 	return m, cmd
@@ -394,97 +573,43 @@ func (m AddTaskModel) renderWizard() string {
 	// Current step indicator with dynamic coloring (with fallback)
 	stepLabels := []string{"Title", "Project", "Tags", "Priority", "JIRA", "Due Date", "Notes", "Save"}
 	
-	// Check if terminal supports colors
-	supportsColor := m.terminalSupportsColor()
+	// Step colors through lipgloss, so they degrade with the terminal and NO_COLOR
+	currentColor := lipgloss.Color(ColorAccentBright)  // current step (theme accent)
+	doneColor := lipgloss.Color(ColorSuccess)          // completed steps (green)
+	skippedColor := lipgloss.Color(ColorDisabledText)  // skipped steps (darker grey-purple)
+	futureColor := lipgloss.Color(ColorSecondaryText)  // future steps (lighter default grey)
 	
-	if supportsColor {
-		// Define color codes for direct terminal output
-		purpleColor := "\033[38;2;167;139;250m"   // ColorAccentBright - current step (purple)
-		greenColor := "\033[38;2;34;197;94m"      // ColorSuccess - completed steps (green)
-		darkGreyPurpleColor := "\033[38;2;109;115;131m" // ColorDisabledText - skipped steps (darker grey-purple)
-		lightGreyColor := "\033[38;2;177;184;199m"      // ColorSecondaryText - future steps (lighter default grey)
-		resetColor := "\033[0m"
+	for i, label := range stepLabels {
+		hasValue := m.stepHasValue(Step(i))
 		
-		for i, label := range stepLabels {
-			hasValue := m.stepHasValue(Step(i))
-			
-			// Add extra spacing before Save step to distinguish it
-			if Step(i) == StepSave {
-				b.WriteString("\n") // Extra line before Save step
-			}
-			
-			if Step(i) == m.currentStep {
-				// Current step - purple arrow
-				if Step(i) == StepSave {
-					// Special styling for Save step when current
-					b.WriteString(fmt.Sprintf("%s▶ 💾 %s%s\n", purpleColor, label, resetColor))
-				} else {
-					b.WriteString(fmt.Sprintf("%s▶ %s%s\n", purpleColor, label, resetColor))
-				}
-			} else if m.isEditMode && hasValue {
-				// Edit mode: all populated steps show as completed (green)
-				b.WriteString(fmt.Sprintf("%s✓ %s%s\n", greenColor, label, resetColor))
-			} else if Step(i) < m.currentStep {
-				// Check if step was actually completed or skipped
-				if hasValue {
-					// Completed with value - green checkmark and text
-					b.WriteString(fmt.Sprintf("%s✓ %s%s\n", greenColor, label, resetColor))
-				} else {
-					// Skipped - no icon, darker grey-purple text
-					b.WriteString(fmt.Sprintf("%s  %s%s\n", darkGreyPurpleColor, label, resetColor))
-				}
-			} else {
-				// Future step - lighter default grey (or grey in edit mode if no value)
-				color := lightGreyColor
-				if m.isEditMode && !hasValue {
-					color = darkGreyPurpleColor // Darker grey for empty fields in edit mode
-				}
-				
-				if Step(i) == StepSave {
-					// Special styling for Save step when not current
-					b.WriteString(fmt.Sprintf("%s  💾 %s%s\n", color, label, resetColor))
-				} else {
-					b.WriteString(fmt.Sprintf("%s  %s%s\n", color, label, resetColor))
-				}
-			}
+		// Add extra spacing before Save step to distinguish it
+		if Step(i) == StepSave {
+			b.WriteString("\n") // Extra line before Save step
+			label = "💾 " + label
 		}
-	} else {
-		// Fallback for terminals that don't support colors - plain text
-		for i, label := range stepLabels {
-			hasValue := m.stepHasValue(Step(i))
-			
-			// Add extra spacing before Save step to distinguish it
-			if Step(i) == StepSave {
-				b.WriteString("\n") // Extra line before Save step
-			}
-			
-			if Step(i) == m.currentStep {
-				// Current step - arrow
-				if Step(i) == StepSave {
-					b.WriteString(fmt.Sprintf("▶ 💾 %s\n", label))
-				} else {
-					b.WriteString(fmt.Sprintf("▶ %s\n", label))
-				}
-			} else if m.isEditMode && hasValue {
-				// Edit mode: all populated steps show as completed (checkmark)
-				b.WriteString(fmt.Sprintf("✓ %s\n", label))
-			} else if Step(i) < m.currentStep {
-				// Check if step was actually completed or skipped
-				if hasValue {
-					// Completed with value - checkmark
-					b.WriteString(fmt.Sprintf("✓ %s\n", label))
-				} else {
-					// Skipped - no icon
-					b.WriteString(fmt.Sprintf("  %s\n", label))
-				}
+		
+		if Step(i) == m.currentStep {
+			// Current step - purple arrow
+			b.WriteString(foreground(currentColor, "▶ "+label) + "\n")
+		} else if m.isEditMode && hasValue {
+			// Edit mode: all populated steps show as completed (green)
+			b.WriteString(foreground(doneColor, "✓ "+label) + "\n")
+		} else if Step(i) < m.currentStep {
+			// Check if step was actually completed or skipped
+			if hasValue {
+				// Completed with value - green checkmark and text
+				b.WriteString(foreground(doneColor, "✓ "+label) + "\n")
 			} else {
-				// Future step
-				if Step(i) == StepSave {
-					b.WriteString(fmt.Sprintf("  💾 %s\n", label))
-				} else {
-					b.WriteString(fmt.Sprintf("  %s\n", label))
-				}
+				// Skipped - no icon, darker grey-purple text
+				b.WriteString(foreground(skippedColor, "  "+label) + "\n")
+			}
+		} else {
+			// Future step - lighter default grey (or grey in edit mode if no value)
+			color := futureColor
+			if m.isEditMode && !hasValue {
+				color = skippedColor // Darker grey for empty fields in edit mode
 			}
+			b.WriteString(foreground(color, "  "+label) + "\n")
 		}
 	}
 	b.WriteString("\n")
@@ -498,13 +623,28 @@ func (m AddTaskModel) renderWizard() string {
 	case StepProject:
 		b.WriteString("📁 Project\n") 
 		b.WriteString(m.inputs[1].View())
+		if m.projectWarned != "" && m.projectWarned == strings.TrimSpace(m.inputs[1].Value()) {
+			existing, _ := m.projectSuggest.similar(m.projectWarned)
+			warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorWarning))
+			b.WriteString("\n" + warnStyle.Render(fmt.Sprintf("⚠️  '%s' is a new project, did you mean '%s'? Enter again to create it", m.projectWarned, existing)))
+		}
+		if suggestions := m.projectSuggest.view("@"); suggestions != "" {
+			b.WriteString("\n" + suggestions)
+		}
 		
 	case StepTags:
 		b.WriteString("🔖 Tags\n")
 		if len(m.tags) > 0 {
-			b.WriteString(fmt.Sprintf("Added: %s\n", strings.Join(m.tags, ", ")))
+			b.WriteString(fmt.Sprintf("Added: %s (Backspace removes the last)\n", strings.Join(m.tags, ", ")))
 		}
 		b.WriteString(m.inputs[2].View())
+		if existing, ok := m.tagSuggest.nearDuplicate(strings.TrimPrefix(strings.TrimSpace(m.inputs[2].Value()), "#")); ok && m.tagSuggest.selected < 0 {
+			hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText))
+			b.WriteString("\n" + hintStyle.Render(fmt.Sprintf("↳ will use existing tag #%s", existing)))
+		}
+		if suggestions := m.tagSuggest.view("#"); suggestions != "" {
+			b.WriteString("\n" + suggestions)
+		}
 		
 	case StepPriority:
 		b.WriteString("⚡ Priority\n")
@@ -513,18 +653,39 @@ func (m AddTaskModel) renderWizard() string {
 	case StepJira:
 		b.WriteString("🎫 JIRA Ticket\n")
 		b.WriteString(m.inputs[4].View())
+		if hint := m.jiraHint(); hint != "" {
+			b.WriteString("\n" + hint)
+		}
+		if suggestions := m.jiraSuggest.view(""); suggestions != "" {
+			b.WriteString("\n" + suggestions)
+		}
 		
 	case StepDueDate:
 		b.WriteString("📅 Due Date\n")
 		b.WriteString(m.inputs[5].View())
+		if hint := m.dueHint(); hint != "" {
+			b.WriteString("\n" + hint)
+		}
 		
 	case StepNotes:
 		b.WriteString("📝 Notes\n")
-		b.WriteString(m.inputs[6].View())
+		b.WriteString(m.notesInput.View())
+		countStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorHelpText))
+		if m.notesInput.Length() >= notesCharLimit {
+			countStyle = countStyle.Foreground(lipgloss.Color(ColorWarning))
+		}
+		b.WriteString("\n" + countStyle.Render(fmt.Sprintf("%d/%d characters", m.notesInput.Length(), notesCharLimit)))
 		
 	case StepSave:
 		b.WriteString("💾 Save Task\n")
 		b.WriteString("Press Enter to save task")
+		if !m.isEditMode {
+			toggleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText))
+			if m.afterSave != AfterSaveNothing {
+				toggleStyle = toggleStyle.Foreground(lipgloss.Color(ColorAccentBright))
+			}
+			b.WriteString("\n\n" + toggleStyle.Render("⏱️  After saving: "+afterSaveLabels[m.afterSave]+" (Space to change)"))
+		}
 	}
 	
 	// Show validation error if any
@@ -536,26 +697,28 @@ func (m AddTaskModel) renderWizard() string {
 		b.WriteString(errorStyle.Render("❌ " + m.validationErr))
 	}
 	
+	// Show save errors until they time out
+	if m.toast.Visible() {
+		b.WriteString("\n\n" + m.toast.View(m.inputs[0].Width))
+	}
+	
 	b.WriteString("\n\n")
 	
 	// Help text
 	helpStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(ColorHelpText)).
 		Italic(true)
-	b.WriteString(helpStyle.Render("Enter: Next | Tab/↓: Next | Shift+Tab/↑: Back | Esc: Cancel"))
+	help := "Enter: Next | Tab/↓: Next | Shift+Tab/↑: Back | Esc: Cancel"
+	if m.currentStep == StepNotes {
+		help = "Enter: New line | Tab: Next | Shift+Tab: Back | Esc: Cancel"
+	} else if m.currentStep == StepSave && !m.isEditMode {
+		help = "Enter: Save | Space: Timer | Shift+Tab/↑: Back | Esc: Cancel"
+	}
+	b.WriteString(helpStyle.Render(help))
 	
 	return b.String()
 }
 
-// terminalSupportsColor checks if the terminal supports ANSI truecolor
-func (m AddTaskModel) terminalSupportsColor() bool {
-	// Only support terminals with truecolor capability
-	colorTerm := os.Getenv("COLORTERM")
-	
-	// Only enable colors for truecolor terminals
-	return colorTerm == "truecolor"
-}
-
 // stepHasValue checks if a step has been filled with a value (not skipped)
 func (m AddTaskModel) stepHasValue(step Step) bool {
 	switch step {
@@ -608,17 +771,8 @@ func (m AddTaskModel) hasChanges() bool {
 			return true
 		}
 		
-		// Compare tags (more complex comparison)
-		prefilledTags := strings.TrimSpace(m.prefilled["tags"])
-		currentTagsStr := ""
-		if len(m.tags) > 0 {
-			var tagNames []string
-			for _, tag := range m.tags {
-				tagNames = append(tagNames, "#"+tag)
-			}
-			currentTagsStr = strings.Join(tagNames, ", ")
-		}
-		if currentTagsStr != prefilledTags {
+		added, removed := m.tagChanges()
+		if len(added) > 0 || len(removed) > 0 {
 			return true
 		}
 		
@@ -689,13 +843,7 @@ func (m AddTaskModel) renderPreview() string {
 		Bold(true).
 		Align(lipgloss.Center)
 	
-	logo := `
-  ██╗    ██╗██████╗  ██████╗ ██╗  ██╗
-  ██║    ██║██╔══██╗██╔═══██╗██║ ██╔╝
-  ██║ █╗ ██║██████╔╝██║   ██║█████╔╝ 
-  ██║███╗██║██╔══██╗██║   ██║██╔═██╗ 
-  ╚███╔███╔╝██║  ██║╚██████╔╝██║  ██╗
-   ╚══╝╚══╝ ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝`
+	logo := "\n" + strings.Join(logoLines(), "\n")
 	
 	cardContent.WriteString(logoStyle.Render(logo))
 	cardContent.WriteString("\n")
@@ -727,8 +875,8 @@ func (m AddTaskModel) renderPreview() string {
 		Align(lipgloss.Center).
 		Width(cardWidth - 4) // Fit within card
 	
-	// Add emoji and shimmered title together (reset ANSI codes so border displays properly)
-	titleWithEmoji := fmt.Sprintf("🎯 %s\033[0m", shimmerTitle)
+	// Add emoji and shimmered title together
+	titleWithEmoji := "🎯 " + shimmerTitle
 	cardContent.WriteString(titleBoxStyle.Render(titleWithEmoji))
 	cardContent.WriteString("\n")
 	
@@ -771,26 +919,11 @@ func (m AddTaskModel) renderPreview() string {
 	
 	// Priority with visual indicators
 	if m.priority != "" {
-		normalizedPriority := parser.NormalizePriority(m.priority)
-		var priorityDisplay string
-		
-		switch normalizedPriority {
-		case "high":
-			priorityDisplay = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(ColorError)).
-				Bold(true).
-				Render("🔥 HIGH")
-		case "medium":
-			priorityDisplay = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(ColorWarning)).
-				Bold(true).
-				Render("⚡ MEDIUM")
-		case "low":
-			priorityDisplay = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(ColorSuccess)).
-				Bold(true).
-				Render("🟢 LOW")
-		}
+		value, _ := priority.Parse(m.priority)
+		priorityDisplay := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(priorityColor(value))).
+			Bold(true).
+			Render(priorityIcon(value) + " " + strings.ToUpper(priority.Name(value)))
 		metadata.WriteString(fmt.Sprintf("⚡ Priority: %s\n", priorityDisplay))
 	}
 	
@@ -839,7 +972,11 @@ func (m AddTaskModel) renderPreview() string {
 		noteStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color(ColorSecondaryText)).
 			Italic(true)
-		metadata.WriteString(fmt.Sprintf("📝 Notes: %s\n", noteStyle.Render(m.notes)))
+		if strings.Contains(m.notes, "\n") {
+			metadata.WriteString("📝 Notes:\n" + noteStyle.Render(m.notes) + "\n")
+		} else {
+			metadata.WriteString(fmt.Sprintf("📝 Notes: %s\n", noteStyle.Render(m.notes)))
+		}
 	}
 	
 	// Add metadata to card
@@ -885,7 +1022,7 @@ func (m AddTaskModel) renderSmallPreview() string {
 	}
 	
 	if m.priority != "" {
-		b.WriteString(fmt.Sprintf("⚡ %s\n", parser.NormalizePriority(m.priority)))
+		b.WriteString(fmt.Sprintf("⚡ %s\n", priority.Normalize(m.priority)))
 	}
 	
 	if m.jiraID != "" {
@@ -902,7 +1039,7 @@ func (m AddTaskModel) renderSmallPreview() string {
 	}
 	
 	if m.notes != "" {
-		b.WriteString(fmt.Sprintf("📝 %s\n", m.notes))
+		b.WriteString(fmt.Sprintf("📝 %s\n", strings.ReplaceAll(m.notes, "\n", "\n   ")))
 	}
 	
 	b.WriteString("═══════════════\n")
@@ -945,7 +1082,19 @@ func (m AddTaskModel) handleEnter() (AddTaskModel, tea.Cmd) {
 		return m.nextStep()
 		
 	case StepProject:
-		// Project is optional, just move on
+		// Project is optional; a highlighted suggestion wins over the typed text
+		if m.projectSuggest.selected >= 0 {
+			m.project, _ = m.projectSuggest.current()
+			m.inputs[1].SetValue(m.project)
+		}
+		m.project = strings.TrimSpace(m.project)
+		
+		// Warn once before creating a project that looks like a typo of an existing one
+		if _, ok := m.projectSuggest.similar(m.project); ok && m.projectWarned != m.project {
+			m.projectWarned = m.project
+			return m, nil
+		}
+		m.projectSuggest.filter("", nil)
 		return m.nextStep()
 		
 	case StepTags:
@@ -963,9 +1112,21 @@ func (m AddTaskModel) handleEnter() (AddTaskModel, tea.Cmd) {
 				return m.nextStep()
 			}
 		} else {
+			// A highlighted suggestion wins; otherwise reuse an existing tag that only
+			// differs by case or separators instead of creating a near-duplicate
+			currentTag = strings.TrimPrefix(currentTag, "#")
+			if m.tagSuggest.selected >= 0 {
+				currentTag, _ = m.tagSuggest.current()
+			} else if existing, ok := m.tagSuggest.nearDuplicate(currentTag); ok {
+				currentTag = existing
+			}
+			
 			// Add the tag and clear input for next tag
-			m.tags = append(m.tags, currentTag)
+			if currentTag != "" && !containsFold(m.tags, currentTag) {
+				m.tags = append(m.tags, currentTag)
+			}
 			m.inputs[2].SetValue("")
+			m.tagSuggest.filter("", m.tags)
 			m.inputs[2].Placeholder = fmt.Sprintf("Add another tag (%d added so far, Enter to finish, 'q' to stop)", len(m.tags))
 			return m, nil
 		}
@@ -979,10 +1140,8 @@ func (m AddTaskModel) handleEnter() (AddTaskModel, tea.Cmd) {
 			return m.nextStep()
 		} else {
 			// Validate the priority
-			normalizedInput := strings.ToLower(priorityInput)
-			if normalizedInput != "low" && normalizedInput != "medium" && normalizedInput != "med" && normalizedInput != "high" &&
-			   normalizedInput != "1" && normalizedInput != "2" && normalizedInput != "3" {
-				m.validationErr = "Invalid priority. Use: low, medium, high, 1, 2, or 3"
+			if !priority.Valid(priorityInput) {
+				m.validationErr = "Invalid priority. Use: " + priority.Hint()
 				return m, nil
 			}
 			m.priority = priorityInput
@@ -990,7 +1149,44 @@ func (m AddTaskModel) handleEnter() (AddTaskModel, tea.Cmd) {
 		}
 		
 	case StepJira:
-		// JIRA is optional, just move on
+		// A highlighted prefix completes the input rather than moving on
+		if m.jiraSuggest.selected >= 0 {
+			m.completeJiraPrefix()
+			return m, nil
+		}
+		
+		// JIRA is optional, validate if provided
+		jiraInput := strings.TrimSpace(m.inputs[4].Value())
+		if jiraInput == "" {
+			m.jiraID = ""
+			return m.nextStep()
+		}
+		if parser.IsValidJiraFormat(jiraInput) {
+			m.jiraID, _ = parser.NormalizeJiraID(jiraInput)
+			m.inputs[4].SetValue(m.jiraID)
+			return m.nextStep()
+		}
+		
+		// Correct near misses like "app123" in place; Enter again accepts the correction
+		if suggestion, ok := parser.SuggestJiraID(jiraInput); ok {
+			m.jiraCorrected = jiraInput
+			m.jiraID = suggestion
+			m.inputs[4].SetValue(suggestion)
+			m.inputs[4].CursorEnd()
+			return m, nil
+		}
+		
+		if m.jiraStrict {
+			m.validationErr = "Invalid JIRA ID. Use: XXX-111 (letters-numbers)"
+			return m, nil
+		}
+		
+		// Warn once before storing an ID that doesn't look like one
+		if m.jiraWarned != jiraInput {
+			m.jiraWarned = jiraInput
+			return m, nil
+		}
+		m.jiraID = jiraInput
 		return m.nextStep()
 		
 	case StepDueDate:
@@ -1026,12 +1222,9 @@ func (m AddTaskModel) handleEnter() (AddTaskModel, tea.Cmd) {
 // nextStep moves to the next step
 func (m AddTaskModel) nextStep() (AddTaskModel, tea.Cmd) {
 	if m.currentStep < StepSave {
-		m.inputs[m.currentStep].Blur()
+		m.blurStep(m.currentStep)
 		m.currentStep++
-		if m.currentStep < StepSave {
-			// Only focus input fields, not the Save step
-			m.inputs[m.currentStep].Focus()
-		}
+		m.focusStep(m.currentStep)
 		// Reset shimmer for new field
 		m.shimmer.Reset()
 	}
@@ -1041,19 +1234,35 @@ func (m AddTaskModel) nextStep() (AddTaskModel, tea.Cmd) {
 // prevStep moves to the previous step
 func (m AddTaskModel) prevStep() (AddTaskModel, tea.Cmd) {
 	if m.currentStep > StepTitle {
-		if m.currentStep <= StepNotes {
-			m.inputs[m.currentStep].Blur()
-		}
+		m.blurStep(m.currentStep)
 		m.currentStep--
-		if m.currentStep <= StepNotes {
-			m.inputs[m.currentStep].Focus()
-		}
+		m.focusStep(m.currentStep)
 		// Reset shimmer for new field
 		m.shimmer.Reset()
 	}
 	return m, textinput.Blink
 }
 
+// focusStep focuses the input of a step; the Save step has none
+func (m *AddTaskModel) focusStep(step Step) {
+	switch {
+	case step == StepNotes:
+		m.notesInput.Focus()
+	case step < StepNotes:
+		m.inputs[step].Focus()
+	}
+}
+
+// blurStep removes focus from the input of a step
+func (m *AddTaskModel) blurStep(step Step) {
+	switch {
+	case step == StepNotes:
+		m.notesInput.Blur()
+	case step < StepNotes:
+		m.inputs[step].Blur()
+	}
+}
+
 // updateCurrentField updates the model field based on current input
 func (m *AddTaskModel) updateCurrentField() {
 	switch m.currentStep {
@@ -1072,10 +1281,84 @@ func (m *AddTaskModel) updateCurrentField() {
 	case StepDueDate:
 		m.dueDate = m.inputs[5].Value()
 	case StepNotes:
-		m.notes = m.inputs[6].Value()
+		m.notes = m.notesInput.Value()
 	}
 }
 
+// completeJiraPrefix fills the JIRA input with the highlighted (or first) prefix suggestion
+func (m *AddTaskModel) completeJiraPrefix() {
+	prefix, ok := m.jiraSuggest.current()
+	if !ok {
+		return
+	}
+	m.inputs[4].SetValue(prefix)
+	m.inputs[4].CursorEnd()
+	m.jiraID = prefix
+	m.jiraSuggest.filter(prefix, nil)
+}
+
+// dueHint shows the day a due date stands for once it parses, warning when
+// it falls on a weekend or holiday
+func (m AddTaskModel) dueHint() string {
+	value := strings.TrimSpace(m.inputs[5].Value())
+	if value == "" {
+		return ""
+	}
+	due, err := parser.ParseDueDate(value)
+	if err != nil || due == nil {
+		return ""
+	}
+
+	if reason, ok := parser.NonWorkingDay(*due); ok {
+		warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorWarning))
+		return warnStyle.Render(fmt.Sprintf("⚠️  %s is %s, not a working day", due.Format("02/01/2006"), reason))
+	}
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText))
+	return hintStyle.Render("↳ " + due.Format("Monday 02/01/2006"))
+}
+
+// jiraHint returns the inline note shown under the JIRA input: the correction just
+// made, a warning about the format, or the recently used prefixes
+func (m AddTaskModel) jiraHint() string {
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorWarning))
+	value := strings.TrimSpace(m.inputs[4].Value())
+	
+	if value == "" {
+		if len(m.jiraPrefixes) == 0 {
+			return ""
+		}
+		recent := m.jiraPrefixes
+		if len(recent) > maxSuggestions {
+			recent = recent[:maxSuggestions]
+		}
+		return hintStyle.Render("Recent: " + strings.Join(recent, ", "))
+	}
+	
+	if suggestion, ok := parser.SuggestJiraID(m.jiraCorrected); ok && suggestion == value {
+		return hintStyle.Render(fmt.Sprintf("↳ corrected from '%s', Enter to accept", m.jiraCorrected))
+	}
+	if m.jiraWarned != "" && m.jiraWarned == value {
+		return warnStyle.Render(fmt.Sprintf("⚠️  '%s' is not a JIRA ID (XXX-123). Enter again to keep it", value))
+	}
+	if parser.IsValidJiraFormat(value) {
+		if normalized, _ := parser.NormalizeJiraID(value); normalized != value {
+			return hintStyle.Render("↳ will be saved as " + normalized)
+		}
+		return ""
+	}
+	if strings.Trim(strings.ToUpper(value), "ABCDEFGHIJKLMNOPQRSTUVWXYZ-") == "" {
+		return "" // still typing the prefix
+	}
+	if suggestion, ok := parser.SuggestJiraID(value); ok {
+		return hintStyle.Render(fmt.Sprintf("↳ did you mean %s? Enter to correct", suggestion))
+	}
+	if m.jiraStrict {
+		return warnStyle.Render("⚠️  Expected XXX-123; invalid IDs can't be saved")
+	}
+	return warnStyle.Render("⚠️  Expected XXX-123")
+}
+
 // createTask creates the task in the database
 func (m AddTaskModel) createTask() (AddTaskModel, tea.Cmd) {
 	// Parse due date if provided
@@ -1084,28 +1367,17 @@ func (m AddTaskModel) createTask() (AddTaskModel, tea.Cmd) {
 		parsedDate, err := parser.ParseDueDate(m.dueDate)
 		if err != nil {
 			m.err = fmt.Errorf("invalid due date: %w", err)
-			return m, nil
+			return m, m.toast.ShowError(m.err)
 		}
 		dueDate = parsedDate
 	}
 	
 	if m.isEditMode {
 		// Update existing task
-		updateReq := db.UpdateTaskRequest{
-			ID:       m.editTaskID,
-			Title:    m.title,
-			Project:  m.project,
-			Tags:     m.tags,
-			Priority: m.priority,
-			JiraID:   m.jiraID,
-			Note:     m.notes,
-			DueDate:  dueDate,
-		}
-		
-		task, err := db.UpdateTask(updateReq)
+		task, err := db.UpdateTask(m.updateRequest(dueDate))
 		if err != nil {
 			m.err = err
-			return m, nil
+			return m, m.toast.ShowError(fmt.Errorf("failed to update task: %w", err))
 		}
 		
 		m.completed = true
@@ -1119,14 +1391,16 @@ func (m AddTaskModel) createTask() (AddTaskModel, tea.Cmd) {
 			Tags:     m.tags,
 			Priority: m.priority,
 			JiraID:   m.jiraID,
+			URL:      m.url,
 			Note:     m.notes,
 			DueDate:  dueDate,
+			Estimate: m.estimate,
 		}
 		
 		task, err := db.CreateTask(createReq)
 		if err != nil {
 			m.err = err
-			return m, nil
+			return m, m.toast.ShowError(fmt.Errorf("failed to create task: %w", err))
 		}
 		
 		m.completed = true
@@ -1137,6 +1411,63 @@ func (m AddTaskModel) createTask() (AddTaskModel, tea.Cmd) {
 	return m, tea.Quit
 }
 
+// updateRequest returns the changes made in edit mode: only the fields that
+// differ from the task as it was opened, so saving doesn't undo edits made
+// elsewhere meanwhile. Tags are sent as the ones added and removed
+func (m AddTaskModel) updateRequest(dueDate *time.Time) db.UpdateTaskRequest {
+	req := db.UpdateTaskRequest{ID: m.editTaskID}
+	changed := func(value *string, key string) *string {
+		if strings.TrimSpace(*value) == strings.TrimSpace(m.prefilled[key]) {
+			return nil
+		}
+		return value
+	}
+
+	req.Title = changed(&m.title, "title")
+	req.Project = changed(&m.project, "project")
+	req.Priority = changed(&m.priority, "priority")
+	req.JiraID = changed(&m.jiraID, "jira")
+	req.URL = changed(&m.url, "url")
+	req.Note = changed(&m.notes, "notes")
+	if changed(&m.dueDate, "due_date") != nil {
+		req.DueDate = dueDate
+		req.ClearDue = dueDate == nil
+	}
+	if estimate, _ := parser.ParseEstimate(m.prefilled["estimate"]); m.estimate != estimate {
+		req.Estimate = &m.estimate
+	}
+	req.AddTags, req.RemoveTags = m.tagChanges()
+	return req
+}
+
+// tagChanges returns the tags added and removed since the task was opened
+func (m AddTaskModel) tagChanges() (added, removed []string) {
+	original := splitTagList(m.prefilled["tags"])
+	for _, tag := range m.tags {
+		if !containsFold(original, tag) {
+			added = append(added, tag)
+		}
+	}
+	for _, tag := range original {
+		if !containsFold(m.tags, tag) {
+			removed = append(removed, tag)
+		}
+	}
+	return added, removed
+}
+
+// splitTagList splits a prefilled tag list like "#api, #urgent" into tag names
+func splitTagList(list string) []string {
+	var tags []string
+	for _, tag := range strings.Split(list, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if tag != "" && !containsFold(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 // handleSaveChoice handles the save confirmation modal response
 func (m AddTaskModel) handleSaveChoice() (AddTaskModel, tea.Cmd) {
 	m.showSaveModal = false
@@ -0,0 +1,307 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/workflow"
+)
+
+// maxBoardDone is how many recently completed tasks the Done column shows
+const maxBoardDone = 20
+
+// boardColumn is one column of the board
+type boardColumn struct {
+	title  string
+	status string // the status of its tasks, and of cards moved into it
+	tasks  []models.Task
+}
+
+// BoardModel is the shell's Board screen: open tasks split into to do and in
+// progress (time tracked), next to the recently completed ones. With statuses
+// configured in [status], each of them is a column between To do and Done instead
+type BoardModel struct {
+	columns []boardColumn
+	tracked map[uint]time.Duration
+	active  uint // task with the running session, 0 if none
+
+	column int // selected column
+	row    int // selected task in the column
+
+	toast Toast
+	err   error
+}
+
+// loadBoard loads the tasks shown on the board
+func loadBoard(now time.Time) BoardModel {
+	m := BoardModel{}
+
+	tasks, err := db.GetTasksWithOptions(db.TaskQueryOptions{HideArchived: true, HideSnoozed: true, OrderBy: "id DESC"})
+	if err != nil {
+		m.err = err
+		return m
+	}
+
+	m.tracked, err = db.GetTrackedTime(tasks, now)
+	if err != nil {
+		m.err = err
+		return m
+	}
+	if active, err := db.GetActiveSession(); err == nil && active != nil {
+		m.active = active.TaskID
+	}
+
+	todo := boardColumn{title: "To do", status: workflow.Todo}
+	done := boardColumn{title: "Done", status: workflow.Done}
+	var middle []boardColumn
+	for _, state := range workflow.Extra() {
+		title := []rune(state.Name)
+		middle = append(middle, boardColumn{title: strings.ToUpper(string(title[:1])) + string(title[1:]), status: state.Name})
+	}
+	inferred := len(middle) == 0
+	if inferred {
+		middle = []boardColumn{{title: "In progress", status: workflow.Todo}}
+	}
+	for _, task := range tasks {
+		switch {
+		case task.Status == "done":
+			done.tasks = append(done.tasks, task)
+		case inferred && m.tracked[task.ID] > 0:
+			middle[0].tasks = append(middle[0].tasks, task)
+		case inferred:
+			todo.tasks = append(todo.tasks, task)
+		default:
+			// Statuses no longer configured stay in To do
+			column := slices.IndexFunc(middle, func(c boardColumn) bool { return c.status == task.Status })
+			if column < 0 {
+				todo.tasks = append(todo.tasks, task)
+			} else {
+				middle[column].tasks = append(middle[column].tasks, task)
+			}
+		}
+	}
+
+	db.SortByUrgency(todo.tasks, now)
+	for _, column := range middle {
+		db.SortByUrgency(column.tasks, now)
+	}
+	sort.SliceStable(done.tasks, func(i, j int) bool {
+		return doneTime(done.tasks[i]).After(doneTime(done.tasks[j]))
+	})
+	if len(done.tasks) > maxBoardDone {
+		done.tasks = done.tasks[:maxBoardDone]
+	}
+
+	m.columns = append(append([]boardColumn{todo}, middle...), done)
+	for c, column := range m.columns {
+		if len(column.tasks) > 0 {
+			m.column = c
+			break
+		}
+	}
+	return m
+}
+
+// doneTime returns when a task was completed, falling back to its last update
+func doneTime(task models.Task) time.Time {
+	if task.DoneAt != nil {
+		return *task.DoneAt
+	}
+	return task.UpdatedAt
+}
+
+// keepSelection moves the selection to the task with the given ID, or clamps it if the task is gone
+func (m BoardModel) keepSelection(taskID uint) BoardModel {
+	for c, column := range m.columns {
+		for r, task := range column.tasks {
+			if task.ID == taskID {
+				m.column, m.row = c, r
+				return m
+			}
+		}
+	}
+	if m.column < len(m.columns) {
+		m.row = min(m.row, max(len(m.columns[m.column].tasks)-1, 0))
+	}
+	return m
+}
+
+// currentTask returns the selected task
+func (m BoardModel) currentTask() (models.Task, bool) {
+	if m.column >= len(m.columns) || m.row >= len(m.columns[m.column].tasks) {
+		return models.Task{}, false
+	}
+	return m.columns[m.column].tasks[m.row], true
+}
+
+// Update handles keys on the board
+func (m BoardModel) Update(msg tea.KeyMsg) (BoardModel, tea.Cmd) {
+	if len(m.columns) == 0 {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "left", "h":
+		m.column = (m.column + len(m.columns) - 1) % len(m.columns)
+		m.row = min(m.row, max(len(m.columns[m.column].tasks)-1, 0))
+	case "right", "l":
+		m.column = (m.column + 1) % len(m.columns)
+		m.row = min(m.row, max(len(m.columns[m.column].tasks)-1, 0))
+	case "up", "k":
+		if m.row > 0 {
+			m.row--
+		}
+	case "down", "j":
+		if m.row < len(m.columns[m.column].tasks)-1 {
+			m.row++
+		}
+	case "d":
+		// Move the card to Done, or back out of it
+		task, ok := m.currentTask()
+		if !ok {
+			return m, nil
+		}
+		if cmd, refused := refuseInReadOnly(&m.toast); refused {
+			return m, cmd
+		}
+		var err error
+		if task.Status == "done" {
+			_, err = db.MarkTaskUndone(task.ID)
+		} else {
+			_, err = db.MarkTaskDone(task.ID)
+		}
+		if err != nil {
+			return m, m.toast.ShowError(err)
+		}
+		toast := m.toast
+		m = loadBoard(time.Now()).keepSelection(task.ID)
+		m.toast = toast
+	case "<":
+		return m.moveCard(-1)
+	case ">":
+		return m.moveCard(1)
+	}
+	return m, nil
+}
+
+// moveCard moves the selected card to the nearest column in the given
+// direction with another status, if the [status] transitions allow it
+func (m BoardModel) moveCard(direction int) (BoardModel, tea.Cmd) {
+	task, ok := m.currentTask()
+	if !ok {
+		return m, nil
+	}
+	if cmd, refused := refuseInReadOnly(&m.toast); refused {
+		return m, cmd
+	}
+	target := ""
+	for c := m.column + direction; c >= 0 && c < len(m.columns); c += direction {
+		if m.columns[c].status != task.Status {
+			target = m.columns[c].status
+			break
+		}
+	}
+	if target == "" {
+		return m, nil
+	}
+	if _, err := db.SetTaskStatus(task.ID, target); err != nil {
+		return m, m.toast.ShowError(err)
+	}
+	toast := m.toast
+	m = loadBoard(time.Now()).keepSelection(task.ID)
+	m.toast = toast
+	return m, nil
+}
+
+// View renders the board
+func (m BoardModel) View(width, height int) string {
+	if m.err != nil {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError)).Render("❌ " + m.err.Error())
+	}
+
+	gap := 1
+	columnWidth := (width - gap*(len(m.columns)-1)) / max(len(m.columns), 1)
+	// Column border (2) and title + spacer (2), plus the help line below
+	rows := max(height-5, 1)
+
+	var rendered []string
+	for c, column := range m.columns {
+		borderColor := ColorBorder
+		if c == m.column {
+			borderColor = ColorAccentMain
+		}
+		titleColor := ColorAccentBright
+		if color := workflow.Color(column.status); color != "" {
+			titleColor = color
+		}
+		titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(titleColor))
+		lines := []string{titleStyle.Render(fmt.Sprintf("%s (%d)", column.title, len(column.tasks))), ""}
+
+		// Scroll so the selected card stays visible
+		offset := 0
+		if c == m.column && m.row >= rows-2 {
+			offset = m.row - (rows - 3)
+		}
+		for r := offset; r < len(column.tasks) && len(lines) < rows; r++ {
+			lines = append(lines, m.renderCard(column.tasks[r], columnWidth-4, c == m.column && r == m.row))
+		}
+
+		rendered = append(rendered, lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color(borderColor)).
+			Padding(0, 1).
+			Width(columnWidth-2).
+			Height(rows).
+			Render(strings.Join(lines, "\n")))
+	}
+
+	board := lipgloss.JoinHorizontal(lipgloss.Top, interleave(rendered, strings.Repeat(" ", gap))...)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorHelpText)).
+		Italic(true).
+		Align(lipgloss.Center).
+		Width(width)
+	footer := helpLine(footerStyle, "←/→ column · ↑/↓ task · </> move card · d done/undone", "</>", "d")
+	if m.toast.Visible() {
+		footer = m.toast.View(width)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, board, footer)
+}
+
+// renderCard renders one task on the board
+func (m BoardModel) renderCard(task models.Task, width int, selected bool) string {
+	text := fmt.Sprintf("#%d %s", task.ID, task.Title)
+	if task.ID == m.active {
+		text = "⏱️ " + text
+	}
+	if tracked := m.tracked[task.ID]; tracked > 0 {
+		text += " · " + formatDurationShort(tracked)
+	}
+	text = truncateText(text, width-2) // room for the selection marker
+
+	if selected {
+		return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorAccentBright)).Render("▶ " + text)
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimaryText)).Render("  " + text)
+}
+
+// interleave puts sep between the given strings
+func interleave(items []string, sep string) []string {
+	var out []string
+	for i, item := range items {
+		if i > 0 {
+			out = append(out, sep)
+		}
+		out = append(out, item)
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/plain"
+)
+
+// BreakModel counts down the break max_focus insists on before a new session
+type BreakModel struct {
+	width  int
+	height int
+	streak db.FocusStreak
+	until  time.Time // when the break is long enough
+	left   time.Duration
+	label  string // the task waiting to be started, e.g. "#42 Fix login"
+
+	done      bool // the break is over; start the session
+	cancelled bool // gave up on starting it
+}
+
+// NewBreakModel creates the break screen for a streak that is over max_focus
+func NewBreakModel(streak db.FocusStreak, label string) BreakModel {
+	now := time.Now()
+	left := streak.BreakLeft(now)
+	return BreakModel{
+		streak: streak,
+		until:  now.Add(left),
+		left:   left,
+		label:  label,
+	}
+}
+
+// Init starts the countdown
+func (m BreakModel) Init() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return timerTickMsg{}
+	})
+}
+
+// Update counts down and quits once the break is over
+func (m BreakModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case timerTickMsg:
+		m.left = time.Until(m.until)
+		if m.left <= 0 {
+			m.done = true
+			return m, tea.Quit
+		}
+		return m, tea.Tick(time.Second, func(t time.Time) tea.Msg {
+			return timerTickMsg{}
+		})
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			m.cancelled = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// View renders the countdown
+func (m BreakModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return "Loading..."
+	}
+
+	header := "☕  TIME FOR A BREAK  ☕"
+	if plain.Enabled() {
+		header = "TIME FOR A BREAK"
+	}
+	headerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorSuccess)).
+		Bold(true)
+	textStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorSecondaryText)).
+		Italic(true)
+
+	reason := fmt.Sprintf("%s of focus without a break; max_focus is %s",
+		formatDuration(m.streak.Focused), formatDuration(m.streak.Limit))
+	next := m.label + " starts when the countdown ends"
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		headerStyle.Render(header),
+		"",
+		textStyle.Render(reason),
+		"",
+		renderBigDuration(m.left.Round(time.Second), ColorSuccess),
+		"",
+		lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimaryText)).Render(truncateText(next, m.width-4)),
+	)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorHelpText)).
+		Italic(true)
+	help := helpLine(helpStyle, "esc/q don't start · use --force to skip the break")
+
+	panel := lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-2).
+		Align(lipgloss.Center, lipgloss.Center).
+		Render(content)
+	return lipgloss.JoinVertical(lipgloss.Left, panel, lipgloss.PlaceHorizontal(m.width, lipgloss.Center, help))
+}
+
+// RunBreakTUI shows the break countdown and reports whether it ran out, so
+// the session may start, rather than being cancelled
+func RunBreakTUI(streak db.FocusStreak, label string) (bool, error) {
+	p := tea.NewProgram(NewBreakModel(streak, label), tea.WithAltScreen())
+	finalModel, err := p.Run()
+	if err != nil {
+		return false, err
+	}
+	return finalModel.(BreakModel).done, nil
+}
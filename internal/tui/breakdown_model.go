@@ -0,0 +1,172 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/balkashynov/wrok/internal/llm"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/plain"
+)
+
+// BreakdownModel lets the user pick which proposed steps of a task to create
+type BreakdownModel struct {
+	width    int
+	height   int
+	task     models.Task
+	subtasks []llm.Subtask
+	checked  []bool
+	cursor   int
+
+	confirmed bool
+}
+
+// NewBreakdownModel creates the confirmation screen with every step checked
+func NewBreakdownModel(task models.Task, subtasks []llm.Subtask) BreakdownModel {
+	checked := make([]bool, len(subtasks))
+	for i := range checked {
+		checked[i] = true
+	}
+	return BreakdownModel{task: task, subtasks: subtasks, checked: checked}
+}
+
+// Init does nothing; the screen only reacts to keys
+func (m BreakdownModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update moves the cursor and toggles steps
+func (m BreakdownModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.subtasks)-1 {
+				m.cursor++
+			}
+		case " ", "x":
+			m.checked[m.cursor] = !m.checked[m.cursor]
+		case "a":
+			// Check everything, or uncheck everything once all are checked
+			all := m.checkedCount() == len(m.checked)
+			for i := range m.checked {
+				m.checked[i] = !all
+			}
+		case "enter":
+			m.confirmed = true
+			return m, tea.Quit
+		case "ctrl+c", "esc", "q":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// checkedCount returns how many steps are checked
+func (m BreakdownModel) checkedCount() int {
+	count := 0
+	for _, checked := range m.checked {
+		if checked {
+			count++
+		}
+	}
+	return count
+}
+
+// View renders the task and its proposed steps
+func (m BreakdownModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return "Loading..."
+	}
+
+	headingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccentBright)).Bold(true)
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimaryText))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText))
+	cursorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccentMain)).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(headingStyle.Render(fmt.Sprintf("Break down #%d", m.task.ID)))
+	b.WriteString("\n")
+	b.WriteString(textStyle.Render(truncateText(m.task.Title, m.width-4)))
+	b.WriteString("\n\n")
+
+	var total time.Duration
+	for i, subtask := range m.subtasks {
+		box := "[ ]"
+		if m.checked[i] {
+			box = "[x]"
+			total += subtask.Estimate
+		}
+		estimate := "—"
+		if subtask.Estimate > 0 {
+			estimate = parser.FormatEstimate(subtask.Estimate)
+		}
+		line := fmt.Sprintf("%s %-7s %s", box, estimate, truncateText(subtask.Title, m.width-18))
+		if i == m.cursor {
+			b.WriteString(cursorStyle.Render("> " + line))
+		} else {
+			b.WriteString(textStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	summary := fmt.Sprintf("\n%d of %d steps", m.checkedCount(), len(m.subtasks))
+	if total > 0 {
+		summary += " · " + parser.FormatEstimate(total) + " estimated"
+	}
+	if m.task.EstimateSeconds > 0 {
+		summary += " (the task: " + parser.FormatEstimate(time.Duration(m.task.EstimateSeconds)*time.Second) + ")"
+	}
+	b.WriteString(mutedStyle.Render(summary))
+
+	if !plain.Enabled() {
+		b.WriteString("\n")
+		b.WriteString(mutedStyle.Italic(true).Render("Proposed by the model in [llm]; check them before creating"))
+	}
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorHelpText)).
+		Italic(true)
+	help := helpLine(helpStyle, "space toggle · a all/none · enter create checked · esc cancel", "enter")
+
+	panel := lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-2).
+		Padding(1, 2).
+		Render(b.String())
+	return lipgloss.JoinVertical(lipgloss.Left, panel, help)
+}
+
+// RunBreakdownTUI shows the proposed steps of a task and returns those the
+// user kept; nil when cancelled
+func RunBreakdownTUI(task models.Task, subtasks []llm.Subtask) ([]llm.Subtask, error) {
+	p := tea.NewProgram(NewBreakdownModel(task, subtasks), tea.WithAltScreen())
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+	m := finalModel.(BreakdownModel)
+	if !m.confirmed {
+		return nil, nil
+	}
+	var kept []llm.Subtask
+	for i, subtask := range m.subtasks {
+		if m.checked[i] {
+			kept = append(kept, subtask)
+		}
+	}
+	return kept, nil
+}
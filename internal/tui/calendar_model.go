@@ -0,0 +1,451 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// calendarFocus is the part of the calendar the keys go to
+type calendarFocus int
+
+const (
+	calendarFocusGrid calendarFocus = iota
+	calendarFocusDay
+	calendarFocusDetail
+)
+
+// calendarDayKey is how days are keyed in the calendar's task map
+const calendarDayKey = "2006-01-02"
+
+// CalendarModel is the 'wrok cal' month view: the number of open tasks due on
+// each day, the tasks of the selected day below, and a task picked up there
+// moved to another day to reschedule it
+type CalendarModel struct {
+	width  int
+	height int
+
+	opts     db.TaskQueryOptions
+	firstDay time.Weekday
+	cursor   time.Time                // selected day, at midnight
+	tasks    map[string][]models.Task // by due day, for the weeks on screen
+	focus    calendarFocus
+	row      int // selected task of the day
+
+	// The task being moved; it follows the cursor until dropped
+	grabbed     *models.Task
+	grabbedFrom time.Time
+
+	detail *DetailModel
+
+	toast Toast
+	err   error
+}
+
+// NewCalendarModel creates the calendar on the month of now, listing open
+// tasks matching opts
+func NewCalendarModel(opts db.TaskQueryOptions, firstDay time.Weekday, now time.Time) CalendarModel {
+	opts.Status = "open"
+	m := CalendarModel{
+		opts:     opts,
+		firstDay: firstDay,
+		cursor:   time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+	}
+	return m.reload()
+}
+
+// gridStart returns the first day drawn: the start of the week the month begins in
+func (m CalendarModel) gridStart() time.Time {
+	first := time.Date(m.cursor.Year(), m.cursor.Month(), 1, 0, 0, 0, 0, m.cursor.Location())
+	return parser.StartOfWeek(first, m.firstDay)
+}
+
+// gridWeeks returns how many weeks it takes to show the whole month
+func (m CalendarModel) gridWeeks() int {
+	last := time.Date(m.cursor.Year(), m.cursor.Month()+1, 0, 0, 0, 0, 0, m.cursor.Location())
+	days := int(last.Sub(m.gridStart()).Hours()+12)/24 + 1 // +12h rounds across DST changes
+	return (days + 6) / 7
+}
+
+// reload loads the tasks due in the weeks on screen
+func (m CalendarModel) reload() CalendarModel {
+	m.tasks = make(map[string][]models.Task)
+	tasks, err := db.GetTasksWithOptions(m.opts)
+	if err != nil {
+		m.err = err
+		return m
+	}
+	m.err = nil
+
+	from := m.gridStart()
+	to := from.AddDate(0, 0, 7*m.gridWeeks())
+	for _, task := range tasks {
+		if task.Due == nil || task.Due.Before(from) || !task.Due.Before(to) {
+			continue
+		}
+		key := task.Due.Format(calendarDayKey)
+		m.tasks[key] = append(m.tasks[key], task)
+	}
+	now := time.Now()
+	for key := range m.tasks {
+		db.SortByUrgency(m.tasks[key], now)
+	}
+	return m.clampRow()
+}
+
+// dayTasks returns the tasks due on the selected day
+func (m CalendarModel) dayTasks() []models.Task {
+	return m.tasks[m.cursor.Format(calendarDayKey)]
+}
+
+// clampRow keeps the selected task inside the selected day
+func (m CalendarModel) clampRow() CalendarModel {
+	m.row = min(m.row, max(len(m.dayTasks())-1, 0))
+	if len(m.dayTasks()) == 0 && m.focus == calendarFocusDay {
+		m.focus = calendarFocusGrid
+	}
+	return m
+}
+
+// selectTask moves the selection to a task of the selected day
+func (m CalendarModel) selectTask(taskID uint) CalendarModel {
+	for r, task := range m.dayTasks() {
+		if task.ID == taskID {
+			m.row = r
+			return m
+		}
+	}
+	return m.clampRow()
+}
+
+// moveCursor moves the selected day by days, loading another month when it leaves this one
+func (m CalendarModel) moveCursor(days int) CalendarModel {
+	month := m.cursor.Month()
+	m.cursor = m.cursor.AddDate(0, 0, days)
+	if m.cursor.Month() != month {
+		m = m.reload()
+	}
+	m.row = 0
+	return m.clampRow()
+}
+
+// moveMonth moves the selected day by months, to the same day where it exists
+func (m CalendarModel) moveMonth(months int) CalendarModel {
+	first := time.Date(m.cursor.Year(), m.cursor.Month()+time.Month(months), 1, 0, 0, 0, 0, m.cursor.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	m.cursor = first.AddDate(0, 0, min(m.cursor.Day(), last)-1)
+	m.row = 0
+	return m.reload()
+}
+
+// Init initializes the model
+func (m CalendarModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles input
+func (m CalendarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case toastExpiredMsg:
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch {
+		case m.grabbed != nil:
+			return m.handleMoveKeys(msg)
+		case m.focus == calendarFocusDetail:
+			return m.handleDetailKeys(msg)
+		case m.focus == calendarFocusDay:
+			return m.handleDayKeys(msg)
+		}
+		return m.handleGridKeys(msg)
+	}
+	return m, nil
+}
+
+// handleGridKeys moves around the month
+func (m CalendarModel) handleGridKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "left", "h":
+		return m.moveCursor(-1), nil
+	case "right", "l":
+		return m.moveCursor(1), nil
+	case "up", "k":
+		return m.moveCursor(-7), nil
+	case "down", "j":
+		return m.moveCursor(7), nil
+	case "[":
+		return m.moveMonth(-1), nil
+	case "]":
+		return m.moveMonth(1), nil
+	case "t":
+		now := time.Now()
+		m.cursor = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		return m.reload(), nil
+	case "enter", "tab":
+		if len(m.dayTasks()) > 0 {
+			m.focus = calendarFocusDay
+			m.row = 0
+		}
+	}
+	return m, nil
+}
+
+// handleDayKeys works on the tasks of the selected day
+func (m CalendarModel) handleDayKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tasks := m.dayTasks()
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc", "tab":
+		m.focus = calendarFocusGrid
+	case "up", "k":
+		if m.row > 0 {
+			m.row--
+		}
+	case "down", "j":
+		if m.row < len(tasks)-1 {
+			m.row++
+		}
+	case "enter":
+		if m.row >= len(tasks) {
+			return m, nil
+		}
+		detail, err := NewDetailModel(tasks[m.row].ID)
+		if err != nil {
+			return m, m.toast.ShowError(err)
+		}
+		m.detail = detail
+		m.focus = calendarFocusDetail
+	case " ", "m":
+		if m.row >= len(tasks) {
+			return m, nil
+		}
+		if cmd, refused := refuseInReadOnly(&m.toast); refused {
+			return m, cmd
+		}
+		task := tasks[m.row]
+		m.grabbed = &task
+		m.grabbedFrom = m.cursor
+	}
+	return m, nil
+}
+
+// handleMoveKeys moves the picked up task between days
+func (m CalendarModel) handleMoveKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "left", "h":
+		return m.moveCursor(-1), nil
+	case "right", "l":
+		return m.moveCursor(1), nil
+	case "up", "k":
+		return m.moveCursor(-7), nil
+	case "down", "j":
+		return m.moveCursor(7), nil
+	case "[":
+		return m.moveMonth(-1), nil
+	case "]":
+		return m.moveMonth(1), nil
+	case " ", "enter", "m":
+		task := *m.grabbed
+		m.grabbed = nil
+		return m.reschedule(task)
+	case "esc", "q":
+		taskID := m.grabbed.ID
+		m.grabbed = nil
+		m.cursor = m.grabbedFrom
+		return m.reload().selectTask(taskID), nil
+	}
+	return m, nil
+}
+
+// handleDetailKeys scrolls and closes the details of a task
+func (m CalendarModel) handleDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "enter":
+		m.detail = nil
+		m.focus = calendarFocusDay
+		return m.clampRow(), nil
+	case "up", "k":
+		m.detail.scrollBy(-1)
+	case "down", "j":
+		m.detail.scrollBy(1)
+	}
+	return m, nil
+}
+
+// reschedule makes a task due on the selected day, keeping its due time
+func (m CalendarModel) reschedule(task models.Task) (tea.Model, tea.Cmd) {
+	hour, minute, second := 23, 59, 59
+	if task.Due != nil {
+		hour, minute, second = task.Due.Clock()
+	}
+	due := time.Date(m.cursor.Year(), m.cursor.Month(), m.cursor.Day(), hour, minute, second, 0, m.cursor.Location())
+	if task.Due != nil && task.Due.Equal(due) {
+		return m.reload().selectTask(task.ID), nil
+	}
+
+	if _, err := db.RescheduleTask(task.ID, &due); err != nil {
+		return m, m.toast.ShowError(err)
+	}
+	m = m.reload().selectTask(task.ID)
+	m.focus = calendarFocusDay
+	return m, m.toast.Show(fmt.Sprintf("#%d due %s %s", task.ID, parser.DayLabel(due.Weekday()), due.Format("02/01")))
+}
+
+// View renders the calendar
+func (m CalendarModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return "Loading..."
+	}
+	if m.err != nil {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError)).Render("❌ " + m.err.Error())
+	}
+	if m.focus == calendarFocusDetail && m.detail != nil {
+		m.detail.width = m.width
+		m.detail.height = m.height
+		if m.toast.Visible() {
+			m.detail.height--
+			return lipgloss.JoinVertical(lipgloss.Left, m.detail.View(nil), m.toast.View(m.width))
+		}
+		return m.detail.View(nil)
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorAccentBright))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorSecondaryText))
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimaryText))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDisabledText))
+	todayStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorAccentBright))
+	overdueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError))
+	selectedStyle := lipgloss.NewStyle().Bold(true).
+		Foreground(lipgloss.Color(ColorPrimaryText)).
+		Background(lipgloss.Color(ColorAccentMain))
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("📅 " + m.cursor.Format("January 2006")))
+	b.WriteString("\n\n")
+
+	cellWidth := min(max((m.width-2)/7, 7), 14)
+	var header strings.Builder
+	for _, day := range parser.WeekDays(m.firstDay) {
+		header.WriteString(fmt.Sprintf("%-*s", cellWidth, parser.DayLabel(day)))
+	}
+	b.WriteString("  " + headerStyle.Render(header.String()))
+	b.WriteString("\n")
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	day := m.gridStart()
+	for week := 0; week < m.gridWeeks(); week++ {
+		b.WriteString("  ")
+		for i := 0; i < 7; i++ {
+			count := len(m.tasks[day.Format(calendarDayKey)])
+			if m.grabbed != nil {
+				// The moved task counts on the day it would be dropped on
+				if day.Equal(m.grabbedFrom) {
+					count--
+				}
+				if day.Equal(m.cursor) {
+					count++
+				}
+			}
+			cell := fmt.Sprintf("%2d", day.Day())
+			if count > 0 {
+				cell += fmt.Sprintf(" ●%d", count)
+			}
+			cell = fmt.Sprintf("%-*s", cellWidth-1, cell)
+
+			style := textStyle
+			_, dayOff := parser.NonWorkingDay(day)
+			switch {
+			case day.Equal(m.cursor):
+				style = selectedStyle
+			case day.Month() != m.cursor.Month():
+				style = mutedStyle
+			case count > 0 && day.Before(today):
+				style = overdueStyle
+			case day.Equal(today):
+				style = todayStyle
+			case dayOff:
+				style = mutedStyle
+			}
+			b.WriteString(style.Render(cell) + " ")
+			day = day.AddDate(0, 0, 1)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	// The selected day's tasks
+	b.WriteString(headerStyle.Render(fmt.Sprintf("  %s %s", parser.DayLabel(m.cursor.Weekday()), m.cursor.Format("02/01/2006"))))
+	b.WriteString("\n")
+	rows := max(m.height-m.gridWeeks()-9, 1)
+	tasks := m.dayTasks()
+	if m.grabbed != nil {
+		b.WriteString(selectedStyle.Render(truncateText(fmt.Sprintf("⇄ #%d %s", m.grabbed.ID, m.grabbed.Title), m.width-4)))
+		b.WriteString("\n")
+		rows--
+	}
+	if len(tasks) == 0 && m.grabbed == nil {
+		b.WriteString(mutedStyle.Render("  Nothing due"))
+		b.WriteString("\n")
+	}
+	offset := max(m.row-rows+1, 0)
+	for r := offset; r < len(tasks) && r-offset < rows; r++ {
+		task := tasks[r]
+		if m.grabbed != nil && task.ID == m.grabbed.ID {
+			continue
+		}
+		text := fmt.Sprintf("#%d %s", task.ID, task.Title)
+		if task.Project != "" {
+			text += " @" + task.Project
+		}
+		if m.focus == calendarFocusDay && m.grabbed == nil && r == m.row {
+			b.WriteString(todayStyle.Render(truncateText("▶ "+text, m.width-2)))
+		} else {
+			b.WriteString(textStyle.Render(truncateText("  "+text, m.width-2)))
+		}
+		b.WriteString("\n")
+	}
+
+	var help string
+	switch {
+	case m.grabbed != nil:
+		help = "←/→ day · ↑/↓ week · [/] month · space/enter drop · esc cancel"
+	case m.focus == calendarFocusDay:
+		help = "↑/↓ task · enter details · space move to another day · esc back · q quit"
+	default:
+		help = "←/→ day · ↑/↓ week · [/] month · t today · enter tasks · q quit"
+	}
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorHelpText)).Italic(true)
+	footer := helpLine(helpStyle, help, "space")
+	if m.toast.Visible() {
+		footer = m.toast.View(m.width)
+	}
+
+	content := lipgloss.NewStyle().Height(m.height - 1).Render(b.String())
+	return lipgloss.JoinVertical(lipgloss.Left, content, footer)
+}
+
+// RunCalendarTUI runs the month calendar
+func RunCalendarTUI(opts db.TaskQueryOptions, firstDay time.Weekday) error {
+	_, err := RunProgram(NewCalendarModel(opts, firstDay, time.Now()))
+	return err
+}
@@ -14,12 +14,13 @@ const (
 	ColorPlaceholder   = "#B1B8C7" // Same as secondary - that beautiful purple-grey with shine
 	ColorHelpText      = "240"      // Dark grey for help text
 
-	// Accent Colors (Purple theme)
-	ColorAccentMain   = "#7C3AED" // Logo, accent elements, active borders
-	ColorAccentBright = "#A78BFA" // Hover, highlights, current step
-
 	// State Colors
 	ColorError   = "#EF4444" // Validation errors
 	ColorSuccess = "#22C55E" // Success, confirmations
 	ColorWarning = "#F59E0B" // Warnings
-)
\ No newline at end of file
+)
+// Accent colors, set from the configured theme (see ApplyTheme)
+var (
+	ColorAccentMain   = "#7C3AED" // Logo, accent elements, active borders
+	ColorAccentBright = "#A78BFA" // Hover, highlights, current step
+)
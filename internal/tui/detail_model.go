@@ -0,0 +1,292 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/priority"
+)
+
+// DetailModel is the full-screen view of a single task and its session history
+type DetailModel struct {
+	width  int
+	height int
+
+	task     models.Task
+	files    []models.TaskFile // attached files, numbered from 1 in this order
+	activity []db.ActivityItem // creation, status changes and comments, oldest first
+	sessions []models.Session  // newest first
+	scroll   int               // first visible session row
+}
+
+// maxActivityRows is how many of the latest activity entries the detail view shows
+const maxActivityRows = 6
+
+// NewDetailModel loads a task and its sessions for the detail view
+func NewDetailModel(taskID uint) (*DetailModel, error) {
+	task, err := db.GetTaskByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := db.GetTaskFiles(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := db.GetSessionsForTask(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sessions have their own table below, so the feed keeps to the rest
+	activity, err := db.GetTaskActivity(taskID)
+	if err != nil {
+		return nil, err
+	}
+	var feed []db.ActivityItem
+	for _, item := range activity {
+		if item.Kind != db.ActivitySession {
+			feed = append(feed, item)
+		}
+	}
+
+	return &DetailModel{task: *task, files: files, activity: feed, sessions: sessions}, nil
+}
+
+// scrollBy moves the session history by delta rows, staying within bounds
+func (d *DetailModel) scrollBy(delta int) {
+	d.scroll += delta
+	if max := len(d.sessions) - 1; d.scroll > max {
+		d.scroll = max
+	}
+	if d.scroll < 0 {
+		d.scroll = 0
+	}
+}
+
+// View renders the detail view; activeSession marks a running timer
+func (d DetailModel) View(activeSession *models.Session) string {
+	task := d.task
+	contentWidth := d.width - 6
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorPrimaryText)).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorAccentMain)).
+		Padding(0, 1).
+		Width(contentWidth - 2)
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorSecondaryText)).
+		Width(12)
+	valueStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorPrimaryText))
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorDisabledText))
+	headingStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccentBright))
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorHelpText)).
+		Italic(true).
+		Align(lipgloss.Center).
+		Width(d.width)
+
+	var b strings.Builder
+
+	// Title
+	title := fmt.Sprintf("#%d  %s", task.ID, task.Title)
+	if task.Pinned {
+		title = pinMarker() + title
+	}
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	// Fields
+	field := func(label, value string) {
+		if value == "" {
+			b.WriteString(labelStyle.Render(label) + mutedStyle.Render("none") + "\n")
+			return
+		}
+		b.WriteString(labelStyle.Render(label) + valueStyle.Render(value) + "\n")
+	}
+	timestamp := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format("02/01/2006 15:04")
+	}
+
+	status := task.Status
+	if activeSession != nil && activeSession.TaskID == task.ID {
+		state := "running"
+		if activeSession.Paused() {
+			state = "paused"
+		}
+		status = fmt.Sprintf("%s · ⏱️ %s (%s)", status, state, formatDurationShort(activeSession.Elapsed(time.Now())))
+	}
+
+	var tagNames []string
+	for _, tag := range task.Tags {
+		tagNames = append(tagNames, "#"+tag.Name)
+	}
+
+	priorityName := priority.Name(task.Priority)
+
+	due := ""
+	if task.Due != nil {
+		due = parser.FormatDueDate(task.Due)
+	}
+
+	field("Status", status)
+	if task.ParentID != nil {
+		field("Step of", fmt.Sprintf("#%d", *task.ParentID))
+	}
+	field("Alias", task.Alias)
+	field("Project", task.Project)
+	field("Priority", priorityName)
+	field("Tags", strings.Join(tagNames, " "))
+	field("JIRA", task.JiraID)
+	field("Jira status", jiraBadge(task))
+	field("URL", task.URL)
+	field("Due", due)
+	if task.EstimateSeconds > 0 {
+		field("Estimate", parser.FormatEstimate(time.Duration(task.EstimateSeconds)*time.Second))
+	}
+	field("Created", timestamp(&task.CreatedAt))
+	field("Updated", timestamp(&task.UpdatedAt))
+	if task.DoneAt != nil {
+		field("Done", timestamp(task.DoneAt))
+	}
+	if task.ArchivedAt != nil {
+		field("Archived", timestamp(task.ArchivedAt))
+	}
+
+	// Notes
+	b.WriteString("\n")
+	b.WriteString(headingStyle.Render("Notes"))
+	b.WriteString("\n")
+	if task.Note == "" {
+		b.WriteString(mutedStyle.Render("No notes"))
+	} else {
+		b.WriteString(valueStyle.Width(contentWidth).Render(task.Note))
+	}
+	b.WriteString("\n\n")
+
+	// Attached files, numbered for 'wrok open <id> --file <n>'
+	if len(d.files) > 0 {
+		b.WriteString(headingStyle.Render(fmt.Sprintf("Files (%d)", len(d.files))))
+		b.WriteString("\n")
+		for i, file := range d.files {
+			line := fmt.Sprintf("%d. %s", i+1, file.Path)
+			if file.Copied {
+				line += " (copy)"
+			}
+			b.WriteString(valueStyle.Render(truncateText(line, contentWidth)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	// Activity: the latest entries, oldest of them first
+	b.WriteString(headingStyle.Render("Activity"))
+	b.WriteString("\n")
+	shown := d.activity
+	if len(shown) > maxActivityRows {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("… %d earlier (wrok comment %d)", len(shown)-maxActivityRows, task.ID)))
+		b.WriteString("\n")
+		shown = shown[len(shown)-maxActivityRows:]
+	}
+	for _, item := range shown {
+		line := fmt.Sprintf("%s  %s", item.At.Format("02/01 15:04"), item.Text)
+		if item.Kind == db.ActivityComment {
+			b.WriteString(valueStyle.Render(truncateText("💬 "+line, contentWidth)))
+		} else {
+			b.WriteString(mutedStyle.Render(truncateText("   "+line, contentWidth)))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	// Session history
+	var total time.Duration
+	for _, session := range d.sessions {
+		total += sessionElapsed(session)
+	}
+	b.WriteString(headingStyle.Render(fmt.Sprintf("Sessions (%d · %s total)", len(d.sessions), formatDurationShort(total))))
+	b.WriteString("\n")
+
+	header := b.String()
+	usedHeight := lipgloss.Height(header)
+
+	// Rows left for sessions: outer border/padding (4) + help bar (2) + table header (1)
+	rows := d.height - usedHeight - 7
+	if rows < 1 {
+		rows = 1
+	}
+
+	var sessionLines []string
+	if len(d.sessions) == 0 {
+		sessionLines = append(sessionLines, mutedStyle.Render("No time tracked yet"))
+	} else {
+		sessionLines = append(sessionLines, mutedStyle.Render(fmt.Sprintf("%-12s %-6s %-8s %-9s %s", "DATE", "START", "END", "DURATION", "NOTE")))
+		end := d.scroll + rows
+		if end > len(d.sessions) {
+			end = len(d.sessions)
+		}
+		for _, session := range d.sessions[d.scroll:end] {
+			finished := "running"
+			if session.FinishedAt != nil {
+				finished = session.FinishedAt.Format("15:04")
+			}
+			line := fmt.Sprintf("%-12s %-6s %-8s %-9s %s",
+				session.StartedAt.Format("Mon 02/01"),
+				session.StartedAt.Format("15:04"),
+				finished,
+				formatDurationShort(sessionElapsed(session)),
+				session.Note)
+			if session.FinishedAt == nil {
+				sessionLines = append(sessionLines, headingStyle.Render(line))
+			} else {
+				sessionLines = append(sessionLines, valueStyle.Render(line))
+			}
+		}
+		if end < len(d.sessions) {
+			sessionLines = append(sessionLines, mutedStyle.Render(fmt.Sprintf("… %d more (↓ to scroll)", len(d.sessions)-end)))
+		}
+	}
+
+	content := header + strings.Join(sessionLines, "\n")
+
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorBorder)).
+		Padding(1, 2).
+		Width(d.width - 2).
+		Height(d.height - 4).
+		Render(content)
+
+	help := helpLine(helpStyle, "e edit · E notes · s start/stop · d done/undone · a archive/unarchive · P pin · o open · c/C/Y copy · ↑/↓ scroll sessions · esc back",
+		"e", "E", "s", "d", "a", "P")
+
+	return lipgloss.JoinVertical(lipgloss.Left, panel, "", help)
+}
+
+// jiraBadge sums up the Jira status and assignee last fetched for a task,
+// e.g. "In Progress · Jane Doe (16/10 14:02)", or "" if never fetched
+func jiraBadge(task models.Task) string {
+	if task.JiraSyncedAt == nil {
+		return ""
+	}
+	assignee := task.JiraAssignee
+	if assignee == "" {
+		assignee = "unassigned"
+	}
+	return fmt.Sprintf("%s · %s (%s)", task.JiraStatus, assignee, task.JiraSyncedAt.Format("02/01 15:04"))
+}
@@ -0,0 +1,289 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// InitSettings holds the answers of the onboarding wizard
+type InitSettings struct {
+	Theme          string
+	WeekStart      string
+	JiraBaseURL    string
+	DefaultProject string
+	SeedExample    bool
+}
+
+// initStep is a page of the onboarding wizard
+type initStep int
+
+const (
+	initStepTheme initStep = iota
+	initStepWeekStart
+	initStepJira
+	initStepProject
+	initStepExample
+)
+
+// WeekStartOptions lists the supported first days of the week
+var WeekStartOptions = []string{"monday", "sunday", "saturday"}
+
+// InitModel is the onboarding wizard shown by `wrok init`
+type InitModel struct {
+	width  int
+	height int
+
+	step     initStep
+	settings InitSettings
+
+	themeIndex     int
+	weekStartIndex int
+	seedIndex      int // 0 = yes, 1 = no
+
+	jiraInput    textinput.Model
+	projectInput textinput.Model
+
+	completed bool
+	cancelled bool
+}
+
+// NewInitModel creates the wizard with the given settings preselected
+func NewInitModel(defaults InitSettings) InitModel {
+	jira := textinput.New()
+	jira.Placeholder = "https://company.atlassian.net (optional)"
+	jira.CharLimit = 200
+	jira.Width = 45
+	jira.SetValue(defaults.JiraBaseURL)
+
+	project := textinput.New()
+	project.Placeholder = "e.g. inbox (optional)"
+	project.CharLimit = 50
+	project.Width = 45
+	project.SetValue(defaults.DefaultProject)
+
+	m := InitModel{
+		settings:     defaults,
+		jiraInput:    jira,
+		projectInput: project,
+	}
+	m.themeIndex = indexOf(ThemeNames, defaults.Theme)
+	m.weekStartIndex = indexOf(WeekStartOptions, defaults.WeekStart)
+	if !defaults.SeedExample {
+		m.seedIndex = 1
+	}
+
+	return m
+}
+
+// indexOf returns the position of value in options, or 0 if missing
+func indexOf(options []string, value string) int {
+	for i, option := range options {
+		if option == value {
+			return i
+		}
+	}
+	return 0
+}
+
+// Init initializes the model
+func (m InitModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles wizard input
+func (m InitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.cancelled = true
+			return m, tea.Quit
+		case "enter":
+			return m.nextStep()
+		case "shift+tab":
+			if m.step > initStepTheme {
+				return m.setStep(m.step - 1)
+			}
+			return m, nil
+		}
+
+		switch m.step {
+		case initStepTheme:
+			m.themeIndex = moveChoice(m.themeIndex, len(ThemeNames), msg.String())
+			ApplyTheme(ThemeNames[m.themeIndex]) // live preview
+		case initStepWeekStart:
+			m.weekStartIndex = moveChoice(m.weekStartIndex, len(WeekStartOptions), msg.String())
+		case initStepExample:
+			m.seedIndex = moveChoice(m.seedIndex, 2, msg.String())
+		case initStepJira:
+			var cmd tea.Cmd
+			m.jiraInput, cmd = m.jiraInput.Update(msg)
+			return m, cmd
+		case initStepProject:
+			var cmd tea.Cmd
+			m.projectInput, cmd = m.projectInput.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+// moveChoice moves a selection index with the arrow keys, wrapping around
+func moveChoice(index, count int, key string) int {
+	switch key {
+	case "up", "left", "k", "h":
+		return (index - 1 + count) % count
+	case "down", "right", "j", "l", "tab":
+		return (index + 1) % count
+	}
+	return index
+}
+
+// nextStep advances the wizard, finishing after the last step
+func (m InitModel) nextStep() (tea.Model, tea.Cmd) {
+	if m.step == initStepExample {
+		m.settings = m.Settings()
+		m.completed = true
+		return m, tea.Quit
+	}
+	return m.setStep(m.step + 1)
+}
+
+// setStep switches to a step and focuses its text input, if any
+func (m InitModel) setStep(step initStep) (tea.Model, tea.Cmd) {
+	m.step = step
+	m.jiraInput.Blur()
+	m.projectInput.Blur()
+
+	switch step {
+	case initStepJira:
+		return m, m.jiraInput.Focus()
+	case initStepProject:
+		return m, m.projectInput.Focus()
+	}
+	return m, nil
+}
+
+// Settings returns the answers chosen so far
+func (m InitModel) Settings() InitSettings {
+	return InitSettings{
+		Theme:          ThemeNames[m.themeIndex],
+		WeekStart:      WeekStartOptions[m.weekStartIndex],
+		JiraBaseURL:    strings.TrimRight(strings.TrimSpace(m.jiraInput.Value()), "/"),
+		DefaultProject: strings.TrimSpace(m.projectInput.Value()),
+		SeedExample:    m.seedIndex == 0,
+	}
+}
+
+// View renders the wizard
+func (m InitModel) View() string {
+	if m.completed || m.cancelled {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccentBright)).
+		MarginBottom(1)
+	labelStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorPrimaryText))
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorSecondaryText))
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorHelpText))
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("👋 Welcome to wrok"))
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render(fmt.Sprintf("Step %d of 5", int(m.step)+1)))
+	b.WriteString("\n\n")
+
+	switch m.step {
+	case initStepTheme:
+		b.WriteString(labelStyle.Render("Color theme"))
+		b.WriteString("\n\n")
+		b.WriteString(renderChoices(ThemeNames, m.themeIndex))
+	case initStepWeekStart:
+		b.WriteString(labelStyle.Render("First day of the week"))
+		b.WriteString("\n")
+		b.WriteString(hintStyle.Render("Used by weekly reports and timesheets"))
+		b.WriteString("\n\n")
+		b.WriteString(renderChoices(WeekStartOptions, m.weekStartIndex))
+	case initStepJira:
+		b.WriteString(labelStyle.Render("Jira base URL"))
+		b.WriteString("\n")
+		b.WriteString(hintStyle.Render("Used to build links for tasks with a JIRA ID"))
+		b.WriteString("\n\n")
+		b.WriteString(m.jiraInput.View())
+	case initStepProject:
+		b.WriteString(labelStyle.Render("Default project"))
+		b.WriteString("\n")
+		b.WriteString(hintStyle.Render("New tasks without a project go here"))
+		b.WriteString("\n\n")
+		b.WriteString(m.projectInput.View())
+	case initStepExample:
+		b.WriteString(labelStyle.Render("Create an example task?"))
+		b.WriteString("\n\n")
+		b.WriteString(renderChoices([]string{"yes", "no"}, m.seedIndex))
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("enter next • shift+tab back • ↑/↓ choose • esc cancel"))
+
+	card := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorAccentMain)).
+		Padding(1, 3).
+		Width(60).
+		Render(b.String())
+
+	if m.width == 0 || m.height == 0 {
+		return card
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, card)
+}
+
+// renderChoices renders a vertical list of options with the selected one highlighted
+func renderChoices(options []string, selected int) string {
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccentBright))
+	optionStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorSecondaryText))
+
+	var lines []string
+	for i, option := range options {
+		if i == selected {
+			lines = append(lines, selectedStyle.Render("▶ "+option))
+		} else {
+			lines = append(lines, optionStyle.Render("  "+option))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RunInitTUI runs the onboarding wizard and returns the chosen settings, or nil if cancelled
+func RunInitTUI(defaults InitSettings) (*InitSettings, error) {
+	finalModel, err := RunProgram(NewInitModel(defaults))
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := finalModel.(InitModel)
+	if !ok || !m.completed {
+		return nil, nil
+	}
+	settings := m.settings
+	return &settings, nil
+}
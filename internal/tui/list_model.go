@@ -2,28 +2,65 @@ package tui
 
 import (
 	"fmt"
+	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/balkashynov/wrok/internal/browser"
+	"github.com/balkashynov/wrok/internal/clipboard"
+	"github.com/balkashynov/wrok/internal/config"
 	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/editor"
 	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/plain"
+	"github.com/balkashynov/wrok/internal/priority"
+	"github.com/balkashynov/wrok/internal/query"
+	"github.com/balkashynov/wrok/internal/workflow"
 )
 
 // sessionUpdateMsg is sent periodically to update active session data
 type sessionUpdateMsg struct{}
 
+// copyKeys maps the copy keys to what they put on the clipboard
+var copyKeys = map[string]string{"c": "title", "C": "markdown", "Y": "jira"}
+
+// notesEditedMsg is sent when the external editor opened on a task's notes exits
+type notesEditedMsg struct {
+	taskID uint
+	note   string // the notes before editing
+	path   string // temp file holding the edited notes
+	err    error
+}
+
+// pinMarker returns the prefix of pinned task titles, a word in plain mode
+// so the table is laid out with its real width
+func pinMarker() string {
+	if plain.Enabled() {
+		return "[pinned] "
+	}
+	return "📌 "
+}
+
 // ListModel represents the TUI model for listing tasks
 type ListModel struct {
 	width  int
 	height int
 	
-	// Task data
-	originalTasks []models.Task // All tasks before filtering
-	tasks         []models.Task // Currently displayed tasks (filtered or all)
-	selectedTask  int           // index in tasks slice
+	// Task data: how many tasks are listed, and the tasks of the page on
+	// screen, loaded from the database as pages are shown
+	taskTotal    int           // Listed tasks after filters and search, on all pages
+	listSearch   *query.Query  // searchQuery as run, nil when not searching
+	tasks        []models.Task // Tasks of the loaded page, from pageStart
+	pageStart    int           // index in the list of the first loaded task
+	pageEnd      int           // index in the list after the loaded window
+	pageLoaded   bool          // false when the page must be (re)loaded, see syncPage
+	selectedTask int           // index in the list
 	
 	// UI state
 	focus         Focus
@@ -45,15 +82,42 @@ type ListModel struct {
 	timerModalOpen bool
 	timerModel     *TimerModel // Embedded timer modal
 
+	// Detail view state
+	detailOpen  bool
+	detailModel *DetailModel // Full-screen view of one task
+
+	// Command palette state
+	paletteOpen   bool
+	palette       Palette
+	paletteTitles map[uint]string // titles of the listed tasks, for "goto"
+
 	// Active session tracking for live status updates
 	activeSession *models.Session // Current active session (if any)
 	
+	// Last edit or session per task, for the AGE column
+	lastActivity map[uint]time.Time
+	
 	// Shimmer effect for selected task title
 	shimmer *ShimmerState
 	
 	// Pagination
 	currentPage int
 	tasksPerPage int
+	restorePage  bool // open on currentPage once its size is known (see WithState)
+	
+	// Query used to (re)load tasks, so refreshes keep the command-line filters
+	queryOpts db.TaskQueryOptions
+	
+	// Set in the 'wrok tui' shell, whose number keys switch screens; priority
+	// filters are then left to the palette
+	screenKeys bool
+	
+	// Database change stamp at the last load; when another terminal changes
+	// the database, the list reloads (see reloadExternalChanges)
+	dbStamp string
+	
+	// Action results and errors, shown in place of the help bar
+	toast Toast
 }
 
 // Focus represents what UI element has focus
@@ -65,36 +129,103 @@ const (
 	FocusModal
 	FocusEdit
 	FocusTimer
+	FocusDetail
+	FocusPalette
 )
 
-// NewListModel creates a new list TUI model
-func NewListModel(tasks []models.Task) ListModel {
+// sortOptions are the choices offered by the sort modal, default first
+var sortOptions = []struct {
+	field     string
+	direction string
+	label     string
+}{
+	{"urgency", "desc", "Urgency (most urgent first)"},
+	{"id", "desc", "ID ↓ (newest first)"},
+	{"id", "asc", "ID ↑ (oldest first)"},
+	{"title", "asc", "Title A-Z"},
+	{"title", "desc", "Title Z-A"},
+	{"priority", "desc", "Priority ↓ (high to low)"},
+	{"priority", "asc", "Priority ↑ (low to high)"},
+	{"due", "asc", "Due (soonest first)"},
+	{"status", "asc", "Status (workflow order)"},
+	{"created_at", "desc", "Created ↓ (newest first)"},
+	{"created_at", "asc", "Created ↑ (oldest first)"},
+}
+
+// sortColumns are the SQL orderings of the sort fields, ascending; the sort
+// direction applies to each column. Urgency is scored by the database (see
+// db.OrderUrgency) and status follows the configured workflow (see statusOrderColumn)
+var sortColumns = map[string][]string{
+	"id":         {"id"},
+	"title":      {"LOWER(title)"},
+	"priority":   {"priority"},
+	"created_at": {"created_at"},
+	"due":        {"due IS NULL", "due"}, // Tasks without a due date go last
+}
+
+// defaultTasksPerPage is the page loaded before the window size is known
+const defaultTasksPerPage = 20
+
+// statusFilters returns the views the S key rotates through, in order: every
+// status of the workflow, then all
+func statusFilters() []string {
+	return append(workflow.Names(), "all")
+}
+
+// statusOrderColumn orders tasks by their status's place in the workflow;
+// statuses no longer configured go last
+func statusOrderColumn() string {
+	var b strings.Builder
+	b.WriteString("CASE status")
+	for i, name := range workflow.Names() {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", name, i)
+	}
+	b.WriteString(" ELSE 999 END")
+	return b.String()
+}
+
+// NewListModel creates a new list TUI model listing every task
+func NewListModel() ListModel {
+	return NewListModelWithOptions(db.TaskQueryOptions{})
+}
+
+// NewListModelWithOptions creates a list TUI model of the tasks matching opts.
+// Only their number is fetched up front; the tasks are loaded a page at a time
+func NewListModelWithOptions(opts db.TaskQueryOptions) ListModel {
 	// Initialize shimmer effect
 	shimmerConfig := DefaultShimmerConfig()
 	shimmer := NewShimmerState(shimmerConfig)
 
 	model := ListModel{
-		originalTasks: tasks,
-		tasks:         tasks,
 		selectedTask:  0,
 		focus:         FocusTable,
 		shimmer:       shimmer,
 		currentPage:   0,
-		// Default sorting: ID descending (newest first)
-		sortField:     "id",
+		// Default sorting: most urgent first
+		sortField:     "urgency",
 		sortDirection: "desc",
 		sortSelection: 0,
+		queryOpts:     opts,
 	}
 
 	// Load active session for live status updates
+	model.dbStamp, _ = db.ChangeStamp()
 	model = model.updateActiveSession()
+	model, _ = model.loadTaskCount()
 	
-	// Pre-select first task if available
-	if len(tasks) > 0 {
-		model.selectedTask = 0
+	return model.syncPage()
+}
+
+// WithSort returns the model sorted by the given field and direction ("asc" or "desc")
+func (m ListModel) WithSort(field, direction string) ListModel {
+	m.sortField = field
+	m.sortDirection = direction
+	for i, option := range sortOptions {
+		if option.field == field && option.direction == direction {
+			m.sortSelection = i
+		}
 	}
-	
-	return model
+	return m.applySorting().syncPage()
 }
 
 // Init initializes the model
@@ -116,8 +247,17 @@ func (m ListModel) Init() tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
-// Update handles messages
+// Update handles messages, then loads the page they moved to
 func (m ListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.update(msg)
+	if list, ok := updated.(ListModel); ok {
+		return list.syncPage(), cmd
+	}
+	return updated, cmd
+}
+
+// update handles messages
+func (m ListModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case timerTickMsg, animationTickMsg:
 		// Always pass timer messages to timer modal if open
@@ -127,7 +267,8 @@ func (m ListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case sessionUpdateMsg:
-		// Update active session data for live status display
+		// Pick up changes from other terminals, and update active session data for live status display
+		m = m.reloadExternalChanges()
 		m = m.updateActiveSession()
 		// Continue the session update timer
 		sessionCmd := tea.Tick(time.Second, func(time.Time) tea.Msg {
@@ -152,6 +293,9 @@ func (m ListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 		
+	case notesEditedMsg:
+		return m.saveEditedNotes(msg)
+		
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -164,6 +308,17 @@ func (m ListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.tasksPerPage = availableHeight
 		
+		if m.restorePage {
+			// Open on the saved page, or the last one if the list got shorter
+			m.restorePage = false
+			lastPage := max(m.taskCount()-1, 0) / m.tasksPerPage
+			m.currentPage = min(m.currentPage, lastPage)
+			m.selectedTask = min(m.currentPage*m.tasksPerPage, max(m.taskCount()-1, 0))
+		} else {
+			// Keep the selected task on screen
+			m.currentPage = m.selectedTask / m.tasksPerPage
+		}
+		
 		return m, nil
 		
 	case tea.KeyMsg:
@@ -183,6 +338,14 @@ func (m ListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleTimerModal(msg)
 		}
 		
+		if m.focus == FocusDetail && m.detailOpen {
+			return m.handleDetailKeys(msg)
+		}
+		
+		if m.focus == FocusPalette && m.paletteOpen {
+			return m.handlePaletteKeys(msg)
+		}
+		
 		switch msg.String() {
 		case "ctrl+c", "q", "esc":
 			// Handle escape key - exit search mode first if active, otherwise quit
@@ -191,9 +354,7 @@ func (m ListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.searchActive = false
 				m.searchPersisted = false
 				m.searchQuery = ""
-				m.tasks = m.originalTasks // Restore full task list
-				m.selectedTask = 0 // Reset selection to first task
-				m.currentPage = 0 // Reset to first page
+				m = m.applyLiveSearch() // Restore full task list
 				m.shimmer.SetActive(true) // Resume shimmer
 				return m, nil
 			}
@@ -211,6 +372,13 @@ func (m ListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "right", "l":
 			return m.nextPage(), nil
 			
+		case "enter":
+			// Open full-screen details of selected task
+			if _, ok := m.currentTask(); ok {
+				return m.openDetailView()
+			}
+			return m, nil
+			
 		case "/":
 			// Enter search mode
 			m.focus = FocusSearch
@@ -220,18 +388,39 @@ func (m ListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			
 		case "a":
 			// Archive/unarchive selected task
-			if len(m.tasks) > 0 && m.selectedTask < len(m.tasks) {
+			if _, ok := m.currentTask(); ok {
 				return m.archiveTask()
 			}
 			return m, nil
 			
 		case "d":
 			// Toggle done status of selected task
-			if len(m.tasks) > 0 && m.selectedTask < len(m.tasks) {
+			if _, ok := m.currentTask(); ok {
 				return m.toggleDoneTask()
 			}
 			return m, nil
 			
+		case "P":
+			// Pin/unpin selected task
+			if _, ok := m.currentTask(); ok {
+				return m.togglePinTask()
+			}
+			return m, nil
+			
+		case "o":
+			// Open the selected task's URL in the browser
+			if _, ok := m.currentTask(); ok {
+				return m.openTaskURL()
+			}
+			return m, nil
+			
+		case "c", "C", "Y":
+			// Copy the title, a Markdown line or the Jira URL of the selected task
+			if _, ok := m.currentTask(); ok {
+				return m.copyTaskText(copyKeys[msg.String()])
+			}
+			return m, nil
+			
 		case "F", "f":
 			// Open sort modal
 			m.focus = FocusModal
@@ -240,19 +429,59 @@ func (m ListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.shimmer.SetActive(false) // Stop shimmer when modal is open
 			return m, nil
 			
-		case "e", "E":
+		case "e":
 			// Edit selected task
-			if len(m.tasks) > 0 && m.selectedTask < len(m.tasks) {
+			if _, ok := m.currentTask(); ok {
 				return m.openEditModal()
 			}
 			return m, nil
+			
+		case "E":
+			// Edit the selected task's notes in $EDITOR
+			if _, ok := m.currentTask(); ok {
+				return m.editNotesExternally()
+			}
+			return m, nil
 
-		case "s", "S":
+		case "s":
 			// Start/stop timer for selected task
-			if len(m.tasks) > 0 && m.selectedTask < len(m.tasks) {
+			if _, ok := m.currentTask(); ok {
 				return m.toggleTimer()
 			}
 			return m, nil
+			
+		case "S":
+			// Rotate the status filter
+			return m.cycleStatusFilter()
+			
+		case "z":
+			// Show/hide archived tasks
+			return m.toggleArchived()
+			
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			// Show/hide tasks of a priority level
+			level, _ := strconv.Atoi(msg.String())
+			if m.screenKeys || level > priority.Max() {
+				return m, nil
+			}
+			return m.togglePriorityFilter(level)
+			
+		case "O":
+			// Show only overdue tasks, or all again
+			m.queryOpts.Overdue = !m.queryOpts.Overdue
+			return m.setStatusFilter(m.queryOpts.Status, m.queryOpts.HideArchived)
+			
+		case "g":
+			// Pick tags to filter by
+			return m.openTagPicker()
+			
+		case "ctrl+o":
+			// Switch to another project
+			return m.openProjectSwitcher()
+			
+		case ":", "ctrl+p":
+			// Command palette: actions, filters and tasks
+			return m.openPalette(), nil
 		}
 	}
 	
@@ -268,9 +497,7 @@ func (m ListModel) handleSearchKeys(msg tea.KeyMsg) (ListModel, tea.Cmd) {
 		m.searchActive = false
 		m.searchPersisted = false
 		m.searchQuery = ""
-		m.tasks = m.originalTasks // Restore full task list
-		m.selectedTask = 0 // Reset selection to first task
-		m.currentPage = 0 // Reset to first page
+		m = m.applyLiveSearch() // Restore full task list
 		m.shimmer.SetActive(true)
 		return m, nil
 		
@@ -324,22 +551,6 @@ func (m ListModel) handleSearchKeys(msg tea.KeyMsg) (ListModel, tea.Cmd) {
 
 // handleSortModalKeys handles key input when the sort modal is open
 func (m ListModel) handleSortModalKeys(msg tea.KeyMsg) (ListModel, tea.Cmd) {
-	sortOptions := []struct {
-		field     string
-		direction string
-		label     string
-	}{
-		{"id", "desc", "ID ↓ (newest first)"},
-		{"id", "asc", "ID ↑ (oldest first)"},
-		{"title", "asc", "Title A-Z"},
-		{"title", "desc", "Title Z-A"},
-		{"priority", "desc", "Priority ↓ (high to low)"},
-		{"priority", "asc", "Priority ↑ (low to high)"},
-		{"status", "asc", "Status (todo → done → archived)"},
-		{"created_at", "desc", "Created ↓ (newest first)"},
-		{"created_at", "asc", "Created ↑ (oldest first)"},
-	}
-	
 	switch msg.String() {
 	case "esc", "q":
 		// Close modal without applying changes
@@ -387,72 +598,18 @@ func (m ListModel) handleSortModalKeys(msg tea.KeyMsg) (ListModel, tea.Cmd) {
 
 // applyLiveSearch applies real-time search filtering
 func (m ListModel) applyLiveSearch() ListModel {
-	if m.searchQuery == "" {
-		// Empty search - show all tasks
-		m.tasks = m.originalTasks
-	} else {
-		// Create a temporary model with original tasks for search
-		// We'll manually apply the search algorithm here instead of using db.SearchTasks
-		// since that function queries the database, but we want to search our in-memory tasks
-		m.tasks = m.searchInMemoryTasks(m.searchQuery, m.originalTasks)
-	}
+	m, _ = m.loadTaskCount()
 	
 	// Reset selection and pagination when search results change
 	m.selectedTask = 0
 	m.currentPage = 0
 	
-	// Ensure selected task index is valid
-	if len(m.tasks) > 0 && m.selectedTask >= len(m.tasks) {
-		m.selectedTask = len(m.tasks) - 1
-	}
-	
 	return m
 }
 
 // applySorting applies the current sort settings to the task list
 func (m ListModel) applySorting() ListModel {
-	if len(m.tasks) == 0 {
-		return m
-	}
-	
-	// Sort tasks based on current sort field and direction
-	sort.Slice(m.tasks, func(i, j int) bool {
-		task1, task2 := m.tasks[i], m.tasks[j]
-		
-		var result bool
-		switch m.sortField {
-		case "id":
-			result = task1.ID < task2.ID
-		case "title":
-			result = strings.ToLower(task1.Title) < strings.ToLower(task2.Title)
-		case "priority":
-			result = task1.Priority < task2.Priority
-		case "status":
-			// Custom status ordering: todo < done < archived
-			statusOrder := map[string]int{"todo": 0, "done": 1, "archived": 2}
-			order1, exists1 := statusOrder[task1.Status]
-			if !exists1 {
-				order1 = 999
-			}
-			order2, exists2 := statusOrder[task2.Status]
-			if !exists2 {
-				order2 = 999
-			}
-			result = order1 < order2
-		case "created_at":
-			result = task1.CreatedAt.Before(task2.CreatedAt)
-		default:
-			// Default to ID
-			result = task1.ID < task2.ID
-		}
-		
-		// Reverse if descending
-		if m.sortDirection == "desc" {
-			result = !result
-		}
-		
-		return result
-	})
+	m, _ = m.loadTaskCount()
 	
 	// Reset selection to first task after sorting
 	m.selectedTask = 0
@@ -461,103 +618,122 @@ func (m ListModel) applySorting() ListModel {
 	return m
 }
 
-// searchInMemoryTasks applies search logic to in-memory tasks
-// Replicates the logic from db.SearchTasks but works on a task slice
-func (m ListModel) searchInMemoryTasks(query string, tasks []models.Task) []models.Task {
-	query = strings.ToLower(strings.TrimSpace(query))
-	if query == "" {
-		return tasks
+// sortOrder returns the TaskQueryOptions.OrderBy of the current sort field
+// and direction, with the ID breaking ties
+func (m ListModel) sortOrder() string {
+	if m.sortField == "urgency" {
+		return db.OrderUrgency
+	}
+	columns, ok := sortColumns[m.sortField]
+	if m.sortField == "status" {
+		columns, ok = []string{statusOrderColumn()}, true
+	}
+	if !ok {
+		columns = sortColumns["id"]
+	}
+	direction := " ASC"
+	if m.sortDirection == "desc" {
+		direction = " DESC"
 	}
 	
-	var exactMatches []models.Task
-	var prefixMatches []models.Task
-	var suffixMatches []models.Task
-	var fuzzyMatches []models.Task
+	order := make([]string, 0, len(columns)+1)
+	for _, column := range columns {
+		order = append(order, column+direction)
+	}
+	if m.sortField != "id" {
+		order = append(order, "id DESC")
+	}
+	return strings.Join(order, ", ")
+}
+
+// loadTaskCount counts the tasks listed: those matching the filters and
+// search. The page is loaded afresh by the next syncPage
+func (m ListModel) loadTaskCount() (ListModel, error) {
+	var count int
+	var err error
+	m.listSearch = nil
+	if strings.TrimSpace(m.searchQuery) != "" {
+		// Half-typed field terms ("due:") search as plain text until complete
+		q, parseErr := query.Parse(m.searchQuery)
+		if parseErr != nil {
+			q = query.Query{Text: m.searchQuery}
+		}
+		count, err = db.CountSearchTasks(q, m.queryOpts)
+		if err != nil && parseErr == nil {
+			q = query.Query{Text: m.searchQuery}
+			count, err = db.CountSearchTasks(q, m.queryOpts)
+		}
+		m.listSearch = &q
+	} else {
+		count, err = db.CountTasks(m.queryOpts)
+	}
+	if err != nil {
+		return m, err
+	}
 	
-	for _, task := range tasks {
-		// Build searchable text from all fields
-		searchableFields := []string{
-			fmt.Sprintf("%d", task.ID), // Include task ID
-			task.Title,
-			task.Project,
-			task.JiraID,
-			task.Note,
-		}
-		
-		// Add tag names
-		for _, tag := range task.Tags {
-			searchableFields = append(searchableFields, tag.Name)
-		}
-		
-		// Add priority as text
-		switch task.Priority {
-		case 1:
-			searchableFields = append(searchableFields, "low", "1")
-		case 2:
-			searchableFields = append(searchableFields, "medium", "med", "2")
-		case 3:
-			searchableFields = append(searchableFields, "high", "3")
-		}
-		
-		// Add status
-		searchableFields = append(searchableFields, task.Status)
-		
-		// Check each field for matches
-		found := false
-		matchType := ""
-		
-		for _, field := range searchableFields {
-			if field == "" {
-				continue
-			}
-			
-			fieldLower := strings.ToLower(field)
-			
-			// 1. Exact match (highest priority)
-			if fieldLower == query {
-				exactMatches = append(exactMatches, task)
-				found = true
-				matchType = "exact"
-				break
-			}
-			
-			// 2. Prefix match
-			if matchType == "" && strings.HasPrefix(fieldLower, query) {
-				matchType = "prefix"
-			}
-			
-			// 3. Suffix match
-			if matchType == "" && strings.HasSuffix(fieldLower, query) {
-				matchType = "suffix"
-			}
-			
-			// 4. Fuzzy match (contains)
-			if matchType == "" && strings.Contains(fieldLower, query) {
-				matchType = "fuzzy"
-			}
-		}
-		
-		// Add to appropriate list if found and not exact
-		if !found && matchType != "" {
-			switch matchType {
-			case "prefix":
-				prefixMatches = append(prefixMatches, task)
-			case "suffix":
-				suffixMatches = append(suffixMatches, task)
-			case "fuzzy":
-				fuzzyMatches = append(fuzzyMatches, task)
-			}
+	m.taskTotal = count
+	m.pageLoaded = false
+	return m, nil
+}
+
+// listedIDs returns the IDs of the listed tasks in order, sorted by the
+// current sort, from offset and at most limit of them (0 = all)
+func (m ListModel) listedIDs(offset, limit int) ([]uint, error) {
+	opts := m.queryOpts
+	opts.OrderBy = m.sortOrder()
+	// --limit caps the whole list, not a page
+	if opts.Limit > 0 {
+		if offset >= opts.Limit {
+			return nil, nil
+		}
+		if limit == 0 || offset+limit > opts.Limit {
+			limit = opts.Limit - offset
 		}
 	}
+	opts.Offset, opts.Limit = offset, limit
 	
-	// Combine results in priority order
-	var results []models.Task
-	results = append(results, exactMatches...)
-	results = append(results, prefixMatches...)
-	results = append(results, suffixMatches...)
-	results = append(results, fuzzyMatches...)
+	if m.listSearch != nil {
+		return db.SearchTaskIDs(*m.listSearch, opts)
+	}
+	return db.TaskIDs(opts)
+}
+
+// taskCount returns how many tasks are listed, on all pages
+func (m ListModel) taskCount() int {
+	return m.taskTotal
+}
+
+// syncPage loads the tasks of the current page unless they are loaded already.
+// Tasks deleted since the list was counted are left out until it is reloaded
+func (m ListModel) syncPage() ListModel {
+	perPage := m.tasksPerPage
+	if perPage <= 0 {
+		perPage = defaultTasksPerPage
+	}
+	start := min(m.currentPage*perPage, m.taskCount())
+	end := min(start+perPage, m.taskCount())
+	if m.pageLoaded && m.pageStart == start && m.pageEnd == end {
+		return m
+	}
+	
+	var tasks []models.Task
+	var err error
+	if end > start {
+		var ids []uint
+		ids, err = m.listedIDs(start, end-start)
+		if err == nil {
+			tasks, err = db.GetTasksByIDs(ids)
+		}
+	}
+	if err != nil {
+		// Shown until the list is reloaded; the toast expires on the next tick
+		m.toast.ShowError(fmt.Errorf("failed to load tasks: %w", err))
+	}
 	
-	return results
+	m.tasks = tasks
+	m.pageStart, m.pageEnd = start, end
+	m.pageLoaded = true
+	return m.updateLastActivity()
 }
 
 // moveSelectionUp moves the selection up
@@ -577,13 +753,13 @@ func (m ListModel) moveSelectionUp() ListModel {
 
 // moveSelectionDown moves the selection down
 func (m ListModel) moveSelectionDown() ListModel {
-	if m.selectedTask < len(m.tasks)-1 {
+	if m.selectedTask < m.taskCount()-1 {
 		m.selectedTask++
 		m.shimmer.Reset() // Reset shimmer for new selection
 		
 		// Auto-pagination: if we scrolled below current page, go to next page
-		currentPageEnd := min((m.currentPage+1)*m.tasksPerPage-1, len(m.tasks)-1)
-		maxPages := (len(m.tasks) + m.tasksPerPage - 1) / m.tasksPerPage
+		currentPageEnd := min((m.currentPage+1)*m.tasksPerPage-1, m.taskCount()-1)
+		maxPages := (m.taskCount() + m.tasksPerPage - 1) / m.tasksPerPage
 		if m.selectedTask > currentPageEnd && m.currentPage < maxPages-1 {
 			m.currentPage++
 		}
@@ -596,7 +772,7 @@ func (m ListModel) prevPage() ListModel {
 	if m.currentPage > 0 {
 		m.currentPage--
 		// Adjust selection to be within the new page
-		maxIndex := min((m.currentPage+1)*m.tasksPerPage-1, len(m.tasks)-1)
+		maxIndex := min((m.currentPage+1)*m.tasksPerPage-1, m.taskCount()-1)
 		if m.selectedTask > maxIndex {
 			m.selectedTask = maxIndex
 		}
@@ -611,7 +787,7 @@ func (m ListModel) prevPage() ListModel {
 
 // nextPage goes to next page
 func (m ListModel) nextPage() ListModel {
-	maxPages := (len(m.tasks) + m.tasksPerPage - 1) / m.tasksPerPage
+	maxPages := (m.taskCount() + m.tasksPerPage - 1) / m.tasksPerPage
 	if m.currentPage < maxPages-1 {
 		m.currentPage++
 		// Adjust selection to be within the new page
@@ -619,7 +795,7 @@ func (m ListModel) nextPage() ListModel {
 		if m.selectedTask < minIndex {
 			m.selectedTask = minIndex
 		}
-		maxIndex := min((m.currentPage+1)*m.tasksPerPage-1, len(m.tasks)-1)
+		maxIndex := min((m.currentPage+1)*m.tasksPerPage-1, m.taskCount()-1)
 		if m.selectedTask > maxIndex {
 			m.selectedTask = maxIndex
 		}
@@ -628,28 +804,181 @@ func (m ListModel) nextPage() ListModel {
 	return m
 }
 
+// statusFilterLabel names the tasks currently loaded: a status, "active" (everything
+// but archived) or "all"
+func (m ListModel) statusFilterLabel() string {
+	if m.queryOpts.Status != "" {
+		return m.queryOpts.Status
+	}
+	if m.queryOpts.HideArchived {
+		return "active"
+	}
+	return "all"
+}
+
+// cycleStatusFilter moves to the next status filter (todo → … → done → archived → all)
+func (m ListModel) cycleStatusFilter() (ListModel, tea.Cmd) {
+	current := m.statusFilterLabel()
+	filters := statusFilters()
+	next := filters[0]
+	for i, status := range filters {
+		if status == current {
+			next = filters[(i+1)%len(filters)]
+			break
+		}
+	}
+	
+	if next == "all" {
+		return m.setStatusFilter("", false)
+	}
+	return m.setStatusFilter(next, false)
+}
+
+// toggleArchived switches between hiding archived tasks and showing everything
+func (m ListModel) toggleArchived() (ListModel, tea.Cmd) {
+	if m.statusFilterLabel() == "active" {
+		return m.setStatusFilter("", false)
+	}
+	return m.setStatusFilter("", true)
+}
+
+// setStatusFilter reloads the list with the given status filter, keeping any search
+func (m ListModel) setStatusFilter(status string, hideArchived bool) (ListModel, tea.Cmd) {
+	m.queryOpts.Status = status
+	m.queryOpts.HideArchived = hideArchived
+	
+	m, cmd := m.refreshTasks()
+	m.selectedTask = 0
+	m.currentPage = 0
+	
+	return m, cmd
+}
+
+// togglePriorityFilter adds a priority level to the levels shown, or removes
+// it; with no levels picked every priority is shown
+func (m ListModel) togglePriorityFilter(level int) (ListModel, tea.Cmd) {
+	var levels []int
+	found := false
+	for _, picked := range m.queryOpts.Priorities {
+		if picked == level {
+			found = true
+		} else {
+			levels = append(levels, picked)
+		}
+	}
+	if !found {
+		levels = append(levels, level)
+		sort.Sort(sort.Reverse(sort.IntSlice(levels)))
+	}
+	m.queryOpts.Priorities = levels
+	return m.setStatusFilter(m.queryOpts.Status, m.queryOpts.HideArchived)
+}
+
+// openTagPicker opens a palette of the tags in use; picking one adds it to the
+// tag filter, or removes it if already there
+func (m ListModel) openTagPicker() (ListModel, tea.Cmd) {
+	tags, err := db.GetTagNames()
+	if err != nil {
+		return m, m.toast.ShowError(err)
+	}
+	if len(tags) == 0 {
+		return m, m.toast.Show("No tags yet")
+	}
+	
+	filtered := make(map[string]bool)
+	for _, tag := range m.queryOpts.Tags {
+		filtered[tag] = true
+	}
+	var items []paletteItem
+	for _, tag := range tags {
+		hint := ""
+		if filtered[tag] {
+			hint = "✓ filtering, pick to remove"
+		}
+		items = append(items, paletteItem{label: "#" + tag, hint: hint, action: "tag", arg: tag})
+	}
+	
+	m.palette = newPalette("filter by tag…", items, nil)
+	m.paletteOpen = true
+	m.focus = FocusPalette
+	m.shimmer.SetActive(false)
+	return m, nil
+}
+
+// openProjectSwitcher opens a palette of the projects with open tasks, their
+// task counts and tracked time; picking one filters the list to it
+func (m ListModel) openProjectSwitcher() (ListModel, tea.Cmd) {
+	projects, err := db.GetProjects(false)
+	if err != nil {
+		return m, m.toast.ShowError(err)
+	}
+	if len(projects) == 0 {
+		return m, m.toast.Show("No projects yet")
+	}
+	
+	var items []paletteItem
+	if m.queryOpts.Project != "" {
+		items = append(items, paletteItem{label: "All projects", hint: "clear the project filter", action: "project"})
+	}
+	now := time.Now()
+	for _, project := range projects {
+		hint := fmt.Sprintf("%d todo · %d done", project.Todo, project.Done)
+		if tracked, err := db.SumTrackedTime(db.TrackedTimeQuery{Project: project.Name}, now); err == nil && tracked.Total > 0 {
+			hint += " · " + formatDurationShort(tracked.Total)
+		}
+		if project.Name == m.queryOpts.Project {
+			hint = "✓ " + hint
+		}
+		items = append(items, paletteItem{label: "@" + project.Name, hint: hint, action: "project", arg: project.Name})
+	}
+	
+	m.palette = newPalette("switch project…", items, nil)
+	m.paletteOpen = true
+	m.focus = FocusPalette
+	m.shimmer.SetActive(false)
+	return m, nil
+}
+
+// toggleTagFilter adds a tag to the tag filter (tasks must have all of them), or removes it
+func (m ListModel) toggleTagFilter(tag string) (ListModel, tea.Cmd) {
+	var tags []string
+	found := false
+	for _, picked := range m.queryOpts.Tags {
+		if picked == tag {
+			found = true
+		} else {
+			tags = append(tags, picked)
+		}
+	}
+	if !found {
+		tags = append(tags, tag)
+	}
+	m.queryOpts.Tags = tags
+	return m.setStatusFilter(m.queryOpts.Status, m.queryOpts.HideArchived)
+}
+
 // archiveTask toggles archive status of the currently selected task and refreshes the list
 func (m ListModel) archiveTask() (ListModel, tea.Cmd) {
-	if len(m.tasks) == 0 || m.selectedTask >= len(m.tasks) {
+	if cmd, refused := refuseInReadOnly(&m.toast); refused {
+		return m, cmd
+	}
+	task, ok := m.currentTask()
+	if !ok {
 		return m, nil
 	}
 	
-	task := m.tasks[m.selectedTask]
-	
 	// Toggle archive status
 	if task.Status == "archived" {
 		// Unarchive - move back to todo
 		_, err := db.UnarchiveTask(task.ID)
 		if err != nil {
-			// TODO: Show error message to user
-			return m, nil
+			return m, m.toast.ShowError(err)
 		}
 	} else {
 		// Archive the task
 		_, err := db.ArchiveTask(task.ID)
 		if err != nil {
-			// TODO: Show error message to user
-			return m, nil
+			return m, m.toast.ShowError(err)
 		}
 	}
 	
@@ -659,26 +988,26 @@ func (m ListModel) archiveTask() (ListModel, tea.Cmd) {
 
 // toggleDoneTask toggles done status of the currently selected task and refreshes the list
 func (m ListModel) toggleDoneTask() (ListModel, tea.Cmd) {
-	if len(m.tasks) == 0 || m.selectedTask >= len(m.tasks) {
+	if cmd, refused := refuseInReadOnly(&m.toast); refused {
+		return m, cmd
+	}
+	task, ok := m.currentTask()
+	if !ok {
 		return m, nil
 	}
 	
-	task := m.tasks[m.selectedTask]
-	
 	// Toggle done status
 	if task.Status == "done" {
 		// Mark as undone - move back to todo
 		_, err := db.MarkTaskUndone(task.ID)
 		if err != nil {
-			// TODO: Show error message to user
-			return m, nil
+			return m, m.toast.ShowError(err)
 		}
 	} else {
 		// Mark as done
 		_, err := db.MarkTaskDone(task.ID)
 		if err != nil {
-			// TODO: Show error message to user
-			return m, nil
+			return m, m.toast.ShowError(err)
 		}
 	}
 	
@@ -686,24 +1015,563 @@ func (m ListModel) toggleDoneTask() (ListModel, tea.Cmd) {
 	return m.refreshTasks()
 }
 
-// refreshTasks fetches fresh data from the database
-func (m ListModel) refreshTasks() (ListModel, tea.Cmd) {
-	// Re-fetch tasks from database
-	tasks, err := db.GetTasks()
+// setTaskStatus moves the currently selected task to a configured status
+func (m ListModel) setTaskStatus(status string) (ListModel, tea.Cmd) {
+	if cmd, refused := refuseInReadOnly(&m.toast); refused {
+		return m, cmd
+	}
+	task, ok := m.currentTask()
+	if !ok {
+		return m, nil
+	}
+	if _, err := db.SetTaskStatus(task.ID, status); err != nil {
+		return m, m.toast.ShowError(err)
+	}
+	m, cmd := m.refreshTasks()
+	return m, tea.Batch(cmd, m.toast.Show(fmt.Sprintf("#%d is now %s", task.ID, status)))
+}
+
+// togglePinTask toggles pinned state of the currently selected task and refreshes the list
+func (m ListModel) togglePinTask() (ListModel, tea.Cmd) {
+	if cmd, refused := refuseInReadOnly(&m.toast); refused {
+		return m, cmd
+	}
+	task, ok := m.currentTask()
+	if !ok {
+		return m, nil
+	}
+	
+	// Toggle pinned state
+	if task.Pinned {
+		_, err := db.UnpinTask(task.ID)
+		if err != nil {
+			return m, m.toast.ShowError(err)
+		}
+	} else {
+		_, err := db.PinTask(task.ID)
+		if err != nil {
+			return m, m.toast.ShowError(err)
+		}
+	}
+	
+	// Refresh the task list
+	return m.refreshTasks()
+}
+
+// openTaskURL opens the current task's URL (or its Jira issue) in the browser
+func (m ListModel) openTaskURL() (ListModel, tea.Cmd) {
+	task, ok := m.currentTask()
+	if !ok {
+		return m, nil
+	}
+	
+	cfg, err := config.Load()
 	if err != nil {
-		// TODO: Handle error
+		return m, m.toast.ShowError(err)
+	}
+	
+	url, err := browser.TaskURL(task, cfg.Jira.BaseURL)
+	if err != nil {
+		return m, m.toast.ShowError(err)
+	}
+	
+	if err := browser.Open(url); err != nil {
+		return m, m.toast.ShowError(err)
+	}
+	return m, m.toast.Show("Opened " + url)
+}
+
+// copyTaskText copies the current task's title ("title"), Markdown line
+// ("markdown") or Jira URL ("jira") to the clipboard
+func (m ListModel) copyTaskText(what string) (ListModel, tea.Cmd) {
+	task, ok := m.currentTask()
+	if !ok {
 		return m, nil
 	}
 	
-	// Update model with fresh data
-	m.tasks = tasks
+	var text, label string
+	switch what {
+	case "title":
+		text, label = task.Title, "title"
+	case "markdown":
+		text, label = taskMarkdown(task), "Markdown line"
+	case "jira":
+		if task.JiraID == "" {
+			return m, m.toast.ShowError(fmt.Errorf("task #%d has no JIRA ID", task.ID))
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return m, m.toast.ShowError(err)
+		}
+		if cfg.Jira.BaseURL == "" {
+			return m, m.toast.ShowError(fmt.Errorf("set [jira] base_url in the config to copy the link to %s", task.JiraID))
+		}
+		text, label = browser.JiraURL(task.JiraID, cfg.Jira.BaseURL), "Jira URL"
+	default:
+		return m, nil
+	}
 	
-	// Adjust selection if it's now out of bounds
-	if m.selectedTask >= len(m.tasks) {
-		if len(m.tasks) > 0 {
-			m.selectedTask = len(m.tasks) - 1
-		} else {
-			m.selectedTask = 0
+	if err := clipboard.Copy(text); err != nil {
+		return m, m.toast.ShowError(err)
+	}
+	return m, m.toast.Show(fmt.Sprintf("Copied %s of #%d", label, task.ID))
+}
+
+// taskMarkdown renders a task as a Markdown checklist line, e.g.
+// "- [ ] Fix login (APP-42, due 15/06)"
+func taskMarkdown(task models.Task) string {
+	box := "[ ]"
+	if task.Status == "done" {
+		box = "[x]"
+	}
+	var details []string
+	if task.JiraID != "" {
+		details = append(details, task.JiraID)
+	}
+	if task.Due != nil {
+		details = append(details, "due "+task.Due.Format("02/01"))
+	}
+	line := fmt.Sprintf("- %s %s", box, task.Title)
+	if len(details) > 0 {
+		line += " (" + strings.Join(details, ", ") + ")"
+	}
+	return line
+}
+
+// editNotesExternally suspends the TUI and opens the selected task's notes in
+// the user's editor; saveEditedNotes stores them once it exits
+func (m ListModel) editNotesExternally() (ListModel, tea.Cmd) {
+	if cmd, refused := refuseInReadOnly(&m.toast); refused {
+		return m, cmd
+	}
+	task, ok := m.currentTask()
+	if !ok {
+		return m, nil
+	}
+	
+	path, err := editor.TempFile(task.Note)
+	if err != nil {
+		return m, m.toast.ShowError(err)
+	}
+	return m, tea.ExecProcess(editor.Command(path), func(err error) tea.Msg {
+		return notesEditedMsg{taskID: task.ID, note: task.Note, path: path, err: err}
+	})
+}
+
+// saveEditedNotes stores the notes written in the external editor
+func (m ListModel) saveEditedNotes(msg notesEditedMsg) (ListModel, tea.Cmd) {
+	if msg.err != nil {
+		os.Remove(msg.path)
+		return m, m.toast.ShowError(fmt.Errorf("editor failed: %w", msg.err))
+	}
+	
+	note, err := editor.ReadFile(msg.path)
+	if err != nil {
+		return m, m.toast.ShowError(err)
+	}
+	if note == msg.note {
+		return m, m.toast.Show("Notes unchanged")
+	}
+	
+	if _, err := db.SetTaskNote(msg.taskID, note); err != nil {
+		return m, m.toast.ShowError(err)
+	}
+	m, _ = m.refreshTasks()
+	return m, m.toast.Show(fmt.Sprintf("Updated notes for #%d", msg.taskID))
+}
+
+// openPalette opens the command palette with actions for the selected task,
+// filters and every task in the list
+func (m ListModel) openPalette() ListModel {
+	var items []paletteItem
+	if task, ok := m.currentTask(); ok {
+		hint := fmt.Sprintf("#%d %s", task.ID, task.Title)
+		timerLabel := "start timer"
+		if m.activeSession != nil && m.activeSession.TaskID == task.ID {
+			timerLabel = "stop timer"
+		}
+		doneLabel := "mark done"
+		if task.Status == "done" {
+			doneLabel = "mark undone"
+		}
+		archiveLabel := "archive"
+		if task.Status == "archived" {
+			archiveLabel = "unarchive"
+		}
+		pinLabel := "pin"
+		if task.Pinned {
+			pinLabel = "unpin"
+		}
+		items = append(items,
+			paletteItem{label: timerLabel, hint: hint, action: "timer"},
+			paletteItem{label: doneLabel, hint: hint, action: "done"},
+			paletteItem{label: "edit", hint: hint, action: "edit"},
+			paletteItem{label: "edit notes in $EDITOR", hint: hint, action: "notes"},
+			paletteItem{label: archiveLabel, hint: hint, action: "archive"},
+		)
+		// Configured statuses: moves to done and archived are the items above
+		if len(workflow.Extra()) > 0 {
+			for _, status := range workflow.Next(task.Status) {
+				if workflow.IsOpen(status) {
+					items = append(items, paletteItem{label: "move to " + status, hint: hint, action: "status", arg: status})
+				}
+			}
+		}
+		items = append(items,
+			paletteItem{label: pinLabel, hint: hint, action: "pin"},
+			paletteItem{label: "open url", hint: hint, action: "open"},
+			paletteItem{label: "copy title", hint: hint, action: "copy", arg: "title"},
+			paletteItem{label: "copy as markdown", hint: hint, action: "copy", arg: "markdown"},
+			paletteItem{label: "copy jira url", hint: hint, action: "copy", arg: "jira"},
+			paletteItem{label: "details", hint: hint, action: "details"},
+		)
+	}
+
+	for _, status := range append(workflow.Names(), "active", "all") {
+		items = append(items, paletteItem{label: "filter status:" + status, action: "filter", arg: "status:" + status})
+	}
+	if projects, err := db.GetProjectNames(); err == nil {
+		for _, project := range projects {
+			items = append(items, paletteItem{label: "filter project:" + project, action: "filter", arg: "project:" + project})
+		}
+	}
+	if tags, err := db.GetTagNames(); err == nil {
+		for _, tag := range tags {
+			items = append(items, paletteItem{label: "filter tag:" + tag, action: "filter", arg: "tag:" + tag})
+		}
+	}
+	for level := priority.Max(); level >= 1; level-- {
+		items = append(items, paletteItem{label: "filter priority:" + priority.Name(level), hint: "toggle", action: "filter", arg: "priority:" + priority.Name(level)})
+	}
+	items = append(items, paletteItem{label: "filter overdue", hint: "toggle", action: "filter", arg: "overdue"})
+	items = append(items, paletteItem{label: "filter clear", hint: "drop project, tag, priority, overdue and status filters", action: "filter", arg: "clear"})
+
+	// Only the titles are needed, not whole tasks
+	ids, _ := m.listedIDs(0, 0)
+	m.paletteTitles, _ = db.GetTaskTitles(ids)
+	for _, id := range ids {
+		if title, ok := m.paletteTitles[id]; ok {
+			items = append(items, paletteItem{label: fmt.Sprintf("goto #%d %s", id, title), action: "goto", arg: fmt.Sprint(id)})
+		}
+	}
+
+	m.palette = newPalette("start 42, goto #87, filter project:web…", items, m.parsePaletteQuery)
+	m.paletteOpen = true
+	m.focus = FocusPalette
+	m.shimmer.SetActive(false)
+	return m
+}
+
+// parsePaletteQuery turns a typed command ("start 42", "goto #87", "#87",
+// "filter project:web") into a palette item
+func (m ListModel) parsePaletteQuery(query string) []paletteItem {
+	fields := strings.Fields(query)
+	verb, rest := "goto", ""
+	switch {
+	case len(fields) == 1:
+		rest = fields[0]
+	case len(fields) >= 2:
+		verb, rest = strings.ToLower(fields[0]), strings.Join(fields[1:], " ")
+	default:
+		return nil
+	}
+
+	if verb == "filter" || strings.Contains(rest, ":") && len(fields) == 1 {
+		key, value, found := strings.Cut(rest, ":")
+		if !found || value == "" || (key != "project" && key != "tag" && key != "status" && key != "priority") {
+			return nil
+		}
+		return []paletteItem{{label: "filter " + rest, action: "filter", arg: key + ":" + value}}
+	}
+
+	if verb != "goto" && verb != "start" {
+		return nil
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(rest, "#"), 10, 64)
+	if err != nil {
+		return nil
+	}
+	hint := "not in this list"
+	if title, ok := m.paletteTitles[uint(id)]; ok {
+		hint = title
+	}
+	return []paletteItem{{label: fmt.Sprintf("%s #%d", verb, id), hint: hint, action: verb, arg: fmt.Sprint(id)}}
+}
+
+// handlePaletteKeys handles keys while the command palette is open
+func (m ListModel) handlePaletteKeys(msg tea.KeyMsg) (ListModel, tea.Cmd) {
+	palette, event := m.palette.Update(msg)
+	m.palette = palette
+
+	switch event {
+	case paletteClosed:
+		m.paletteOpen = false
+		return m.restoreFocus(), nil
+	case palettePicked:
+		item, _ := m.palette.picked()
+		m.paletteOpen = false
+		m = m.restoreFocus()
+		return m.runPaletteItem(item)
+	}
+	return m, nil
+}
+
+// runPaletteItem performs the action of a palette item
+func (m ListModel) runPaletteItem(item paletteItem) (ListModel, tea.Cmd) {
+	switch item.action {
+	case "timer":
+		return m.toggleTimer()
+	case "done":
+		return m.toggleDoneTask()
+	case "edit":
+		return m.openEditModal()
+	case "notes":
+		return m.editNotesExternally()
+	case "archive":
+		return m.archiveTask()
+	case "status":
+		return m.setTaskStatus(item.arg)
+	case "pin":
+		return m.togglePinTask()
+	case "open":
+		return m.openTaskURL()
+	case "copy":
+		return m.copyTaskText(item.arg)
+	case "details":
+		return m.openDetailView()
+	case "filter":
+		return m.applyPaletteFilter(item.arg)
+	case "tag":
+		return m.toggleTagFilter(item.arg)
+	case "project":
+		m.queryOpts.Project = item.arg
+		return m.setStatusFilter(m.queryOpts.Status, m.queryOpts.HideArchived)
+	case "goto", "start":
+		id, _ := strconv.ParseUint(item.arg, 10, 64)
+		var ok bool
+		if m, ok = m.selectTaskByID(uint(id)); !ok {
+			return m, m.toast.ShowError(fmt.Errorf("task #%d is not in this list", id))
+		}
+		if item.action == "start" {
+			return m.toggleTimer()
+		}
+	}
+	return m, nil
+}
+
+// selectTaskByID moves the selection to the task with the given ID, if it is
+// listed. The current page is looked at first; only a task elsewhere in the
+// list takes fetching every listed ID
+func (m ListModel) selectTaskByID(id uint) (ListModel, bool) {
+	if id == 0 {
+		return m, false
+	}
+	m = m.syncPage()
+	index := -1
+	for i, task := range m.tasks {
+		if task.ID == id {
+			index = m.pageStart + i
+		}
+	}
+	if index < 0 {
+		ids, err := m.listedIDs(0, 0)
+		if err != nil {
+			return m, false
+		}
+		if index = slices.Index(ids, id); index < 0 {
+			return m, false
+		}
+	}
+	
+	m.selectedTask = index
+	if m.tasksPerPage > 0 {
+		m.currentPage = index / m.tasksPerPage
+	}
+	m.shimmer.Reset()
+	return m, true
+}
+
+// applyPaletteFilter reloads the list with a filter from the palette:
+// "project:web", "tag:bug", "status:done", "clear", or the toggles
+// "priority:high" and "overdue"
+func (m ListModel) applyPaletteFilter(filter string) (ListModel, tea.Cmd) {
+	key, value, _ := strings.Cut(filter, ":")
+	switch key {
+	case "project":
+		m.queryOpts.Project = value
+	case "tag":
+		m.queryOpts.Tags = []string{value}
+	case "priority":
+		level, err := priority.Parse(value)
+		if err != nil {
+			return m, m.toast.ShowError(err)
+		}
+		return m.togglePriorityFilter(level)
+	case "overdue":
+		m.queryOpts.Overdue = !m.queryOpts.Overdue
+	case "status":
+		switch value {
+		case "all":
+			return m.setStatusFilter("", false)
+		case "active":
+			return m.setStatusFilter("", true)
+		}
+		return m.setStatusFilter(value, false)
+	case "clear":
+		m.queryOpts.Project = ""
+		m.queryOpts.Tags = nil
+		m.queryOpts.Priorities = nil
+		m.queryOpts.Overdue = false
+		return m.setStatusFilter("", true)
+	}
+	return m.setStatusFilter(m.queryOpts.Status, m.queryOpts.HideArchived)
+}
+
+// currentTask returns the task that actions apply to: the one shown in the
+// detail view if open, otherwise the selected row
+func (m ListModel) currentTask() (models.Task, bool) {
+	if m.detailOpen && m.detailModel != nil {
+		return m.detailModel.task, true
+	}
+	return m.selectedRow()
+}
+
+// selectedRow returns the task of the selected row, when its page is loaded
+func (m ListModel) selectedRow() (models.Task, bool) {
+	i := m.selectedTask - m.pageStart
+	if !m.pageLoaded || i < 0 || i >= len(m.tasks) {
+		return models.Task{}, false
+	}
+	return m.tasks[i], true
+}
+
+// restoreFocus returns focus to the detail view if open, otherwise to the table
+func (m ListModel) restoreFocus() ListModel {
+	if m.detailOpen {
+		m.focus = FocusDetail
+		return m
+	}
+	m.focus = FocusTable
+	m.shimmer.SetActive(true)
+	return m
+}
+
+// openDetailView opens the full-screen details of the selected task
+func (m ListModel) openDetailView() (ListModel, tea.Cmd) {
+	task, ok := m.currentTask()
+	if !ok {
+		return m, nil
+	}
+	
+	detail, err := NewDetailModel(task.ID)
+	if err != nil {
+		return m, m.toast.ShowError(err)
+	}
+	
+	m.detailOpen = true
+	m.detailModel = detail
+	m.focus = FocusDetail
+	m.shimmer.SetActive(false)
+	
+	return m, nil
+}
+
+// handleDetailKeys handles keys in the detail view
+func (m ListModel) handleDetailKeys(msg tea.KeyMsg) (ListModel, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+		
+	case "esc", "q", "enter":
+		// Back to the list
+		m.detailOpen = false
+		m.detailModel = nil
+		return m.restoreFocus(), nil
+		
+	case "up", "k":
+		m.detailModel.scrollBy(-1)
+		
+	case "down", "j":
+		m.detailModel.scrollBy(1)
+		
+	case "e":
+		return m.openEditModal()
+		
+	case "E":
+		return m.editNotesExternally()
+		
+	case "s", "S":
+		return m.toggleTimer()
+		
+	case "d":
+		return m.toggleDoneTask()
+		
+	case "a":
+		return m.archiveTask()
+		
+	case "P":
+		return m.togglePinTask()
+		
+	case "o":
+		return m.openTaskURL()
+		
+	case "c", "C", "Y":
+		return m.copyTaskText(copyKeys[msg.String()])
+	}
+	
+	return m, nil
+}
+
+// reloadExternalChanges reloads the tasks when the database changed since the
+// last load, e.g. from another terminal, keeping the selection, search and page
+func (m ListModel) reloadExternalChanges() ListModel {
+	stamp, err := db.ChangeStamp()
+	if err != nil || stamp == m.dbStamp {
+		return m
+	}
+	m.dbStamp = stamp
+	
+	selectedID := uint(0)
+	if task, ok := m.selectedRow(); ok {
+		selectedID = task.ID
+	}
+	
+	if m, err = m.loadTaskCount(); err != nil {
+		return m
+	}
+	
+	// Stay on the selected task; if it's gone, on the same row
+	m = m.keepSelection(selectedID)
+	
+	if m.detailOpen && m.detailModel != nil {
+		if detail, err := NewDetailModel(m.detailModel.task.ID); err == nil {
+			detail.scroll = m.detailModel.scroll
+			m.detailModel = detail
+		}
+	}
+	return m
+}
+
+// refreshTasks fetches fresh data from the database
+func (m ListModel) refreshTasks() (ListModel, tea.Cmd) {
+	// Keep the sort order, search and the selected task
+	selectedID := uint(0)
+	if task, ok := m.selectedRow(); ok {
+		selectedID = task.ID
+	}
+	
+	m, err := m.loadTaskCount()
+	if err != nil {
+		return m, m.toast.ShowError(fmt.Errorf("failed to load tasks: %w", err))
+	}
+	m = m.keepSelection(selectedID)
+	m = m.updateActiveSession()
+	
+	// Reload the detail view so it reflects the change
+	if m.detailOpen && m.detailModel != nil {
+		if detail, err := NewDetailModel(m.detailModel.task.ID); err == nil {
+			detail.scroll = m.detailModel.scroll
+			m.detailModel = detail
 		}
 	}
 	
@@ -713,28 +1581,43 @@ func (m ListModel) refreshTasks() (ListModel, tea.Cmd) {
 	return m, nil
 }
 
+// keepSelection selects the task with the given ID after the list was
+// reloaded; when it is no longer listed, the selection stays on the same row
+func (m ListModel) keepSelection(id uint) ListModel {
+	if selected, ok := m.selectTaskByID(id); ok {
+		return selected
+	}
+	m.selectedTask = min(m.selectedTask, max(m.taskCount()-1, 0))
+	if m.tasksPerPage > 0 {
+		m.currentPage = m.selectedTask / m.tasksPerPage
+	}
+	return m
+}
+
 // openEditModal opens the edit modal for the selected task
 func (m ListModel) openEditModal() (ListModel, tea.Cmd) {
-	if len(m.tasks) == 0 || m.selectedTask >= len(m.tasks) {
+	if cmd, refused := refuseInReadOnly(&m.toast); refused {
+		return m, cmd
+	}
+	task, ok := m.currentTask()
+	if !ok {
 		return m, nil
 	}
 	
-	// Get the selected task
-	task := m.tasks[m.selectedTask]
-	
 	// Create prefilled data from the task
 	prefilled := make(map[string]string)
 	prefilled["title"] = task.Title
 	prefilled["project"] = task.Project
 	prefilled["jira"] = task.JiraID
 	prefilled["notes"] = task.Note
+	prefilled["url"] = task.URL
+	if task.EstimateSeconds > 0 {
+		prefilled["estimate"] = parser.FormatEstimate(time.Duration(task.EstimateSeconds) * time.Second)
+	}
 	
 	// Convert priority to string
 	if task.Priority > 0 {
-		priorities := []string{"", "low", "medium", "high"}
-		if int(task.Priority) < len(priorities) {
-			prefilled["priority"] = priorities[task.Priority]
-		}
+		prefilled["priority"] = priority.Name(task.Priority)
 	}
 	
 	// Convert tags to comma-separated string with # prefix for each tag
@@ -776,11 +1659,10 @@ func (m ListModel) handleEditModalKeys(msg tea.KeyMsg) (ListModel, tea.Cmd) {
 	
 	// Check if edit is complete or cancelled
 	if editModel.completed || editModel.cancelled {
-		// Close edit modal and return to list
+		// Close edit modal and return to list (or detail view)
 		m.editModalOpen = false
 		m.editModel = nil
-		m.focus = FocusTable
-		m.shimmer.SetActive(true)
+		m = m.restoreFocus()
 		
 		// If task was edited successfully, refresh the task list
 		if editModel.completed {
@@ -834,6 +1716,8 @@ func (m ListModel) View() string {
 	var searchBar string
 	if m.searchActive || m.searchPersisted {
 		searchBar = m.renderSearchBar()
+	} else if m.toast.Visible() {
+		searchBar = m.toast.View(m.width)
 	} else {
 		searchBar = m.renderHelpBar()
 	}
@@ -863,6 +1747,15 @@ func (m ListModel) View() string {
 		return m.renderSortModal(mainView)
 	}
 	
+	// Overlay the command palette if open
+	if m.paletteOpen {
+		return lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render(m.palette.View(min(m.width-4, 72)))
+	}
+	
 	// Overlay edit modal if open
 	if m.editModalOpen && m.editModel != nil {
 		// Set the edit model dimensions to match our window
@@ -879,6 +1772,18 @@ func (m ListModel) View() string {
 		return m.timerModel.View()
 	}
 
+	// Full-screen task details
+	if m.detailOpen && m.detailModel != nil {
+		m.detailModel.width = m.width
+		m.detailModel.height = m.height
+		if m.toast.Visible() {
+			// Give up the last line to the toast
+			m.detailModel.height--
+			return lipgloss.JoinVertical(lipgloss.Left, m.detailModel.View(m.activeSession), m.toast.View(m.width))
+		}
+		return m.detailModel.View(m.activeSession)
+	}
+
 	return mainView
 }
 
@@ -899,7 +1804,26 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 		Bold(true).
 		Foreground(lipgloss.Color(ColorAccentBright))
 	
+	filterStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorSecondaryText))
+	
 	b.WriteString(headerStyle.Render("  📋 Tasks"))
+	b.WriteString(filterStyle.Render(" · " + m.statusFilterLabel()))
+	if m.queryOpts.Project != "" {
+		b.WriteString(filterStyle.Render(" @" + m.queryOpts.Project))
+	}
+	for _, tag := range m.queryOpts.Tags {
+		b.WriteString(filterStyle.Render(" #" + tag))
+	}
+	for _, level := range m.queryOpts.Priorities {
+		b.WriteString(filterStyle.Render(" +" + priority.Name(level)))
+	}
+	if m.queryOpts.Overdue {
+		b.WriteString(filterStyle.Render(" overdue"))
+	}
+	if m.searchPersisted && m.searchQuery != "" {
+		b.WriteString(filterStyle.Render(fmt.Sprintf(" %q", m.searchQuery)))
+	}
 	b.WriteString("\n\n")
 	
 	// Always show column headers, even when no tasks
@@ -919,6 +1843,7 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 	priorityWidth := 8   // For "high" / "med" / "low" / "-"
 	jiraWidth := 9       // For "ABC-123" / "-" - reduced to 9 chars max
 	dueWidth := 9        // For "TOMORROW" / "OVERDUE"
+	ageWidth := 5        // For "<1d" / "5mo" / "11mo!"
 	
 	// Responsive layout: hide priority, jira, due when terminal < 105 chars
 	// The 'width' here is the left panel width (60% of terminal)
@@ -931,7 +1856,7 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 	
 	if showExtraColumns {
 		// Full layout with all columns
-		rightSideWidth = statusWidth + priorityWidth + jiraWidth + dueWidth + 3 // +3 for single spaces between 4 columns
+		rightSideWidth = statusWidth + priorityWidth + jiraWidth + dueWidth + ageWidth + 4 // +4 for single spaces between 5 columns
 		titleWidth = availableWidth - idWidth - rightSideWidth - 2 // -2 for spacing around title
 		
 		// Ensure minimum widths
@@ -940,11 +1865,12 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 		}
 		
 		headerLeft = fmt.Sprintf("%-*s %-*s", idWidth, "ID", titleWidth, "TITLE")
-		headerRight = fmt.Sprintf("%-*s %-*s %-*s %-*s", 
+		headerRight = fmt.Sprintf("%-*s %-*s %-*s %-*s %-*s", 
 			statusWidth, "STATUS",
 			priorityWidth, "PRIORITY",
 			jiraWidth, "JIRA",
-			dueWidth, "DUE")
+			dueWidth, "DUE",
+			ageWidth, "AGE")
 	} else {
 		// Compact layout: only ID, TITLE, STATUS
 		rightSideWidth = statusWidth
@@ -969,12 +1895,8 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 	b.WriteString(columnHeaderStyle.Render(headers))
 	b.WriteString("\n\n")
 	
-	// Calculate visible tasks for current page  
-	startIndex := m.currentPage * m.tasksPerPage
-	endIndex := min(startIndex+m.tasksPerPage, len(m.tasks))
-	
 	// Handle empty task list
-	if len(m.tasks) == 0 {
+	if m.taskCount() == 0 {
 		emptyStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color(ColorSecondaryText)).
 			Italic(true).
@@ -986,10 +1908,9 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 		b.WriteString("\n")
 	} else {
 	
-	// Render task rows
-	for i := startIndex; i < endIndex; i++ {
-		task := m.tasks[i]
-		isSelected := i == m.selectedTask
+	// Render the rows of the loaded page
+	for offset, task := range m.tasks {
+		isSelected := m.pageStart+offset == m.selectedTask
 		
 		// Format columns
 		id := fmt.Sprintf("#%d", task.ID)
@@ -1016,18 +1937,28 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 			// Check if this task has an active session
 			if m.activeSession != nil && m.activeSession.TaskID == task.ID {
 				// Show elapsed time for running task
-				elapsed := time.Since(m.activeSession.StartedAt)
+				elapsed := m.activeSession.Elapsed(time.Now())
 				statusText = fmt.Sprintf("⏱ %s", formatDurationShort(elapsed))
+				if m.activeSession.Paused() {
+					statusText = fmt.Sprintf("⏸ %s", formatDurationShort(elapsed))
+				}
 			} else {
-				statusText = "○ todo"
+				statusText = "○ " + task.Status
+			}
+		}
+		if plain.Enabled() {
+			// Words that fit the column instead of symbols
+			if strings.HasPrefix(statusText, "⏸") {
+				statusText = "paused"
+			} else if _, word, ok := strings.Cut(statusText, " "); ok {
+				statusText = word
 			}
 		}
 		
 		// Format due date text (always plain text for consistent column alignment)
 		var dueText string
 		if task.Due != nil {
-			now := time.Now()
-			days := int(task.Due.Sub(now).Hours() / 24)
+			days := parser.DaysUntil(*task.Due, time.Now())
 			if days < 0 {
 				dueText = "OVERDUE"
 			} else if days == 0 {
@@ -1044,24 +1975,7 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 		}
 		
 		// Format and color priority
-		var priorityText, coloredPriorityText string
-		if task.Priority > 0 && task.Priority <= 3 {
-			priorities := []string{"", "low", "med", "high"}
-			priorityText = priorities[task.Priority]
-			
-			// Color coding: high=red, med=yellow, low=dim
-			switch task.Priority {
-			case 3: // high
-				coloredPriorityText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError)).Render(priorityText)
-			case 2: // medium
-				coloredPriorityText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorWarning)).Render(priorityText)
-			case 1: // low
-				coloredPriorityText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText)).Render(priorityText)
-			}
-		} else {
-			priorityText = "-"
-			coloredPriorityText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDisabledText)).Render(priorityText)
-		}
+		priorityText, coloredPriorityText := priorityLabel(task.Priority)
 		
 		// Format and color JIRA - ensure consistent styling
 		var jiraText, coloredJiraText string
@@ -1084,37 +1998,35 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 			maxTitleLen = 10 // Minimum reasonable title length
 		}
 		
-		if !isSelected && len(title) > maxTitleLen {
-			title = title[:maxTitleLen-3] + "..."
-		} else if isSelected {
+		if task.Pinned {
+			// Make room for the pin marker
+			maxTitleLen -= lipgloss.Width(pinMarker())
+		}
+		
+		if !isSelected {
+			title = truncateText(title, maxTitleLen)
+		} else {
 			// For selected items, truncate the original title first, then apply shimmer
-			originalTitle := task.Title
-			if len(originalTitle) > maxTitleLen {
-				originalTitle = originalTitle[:maxTitleLen-3] + "..."
-			}
-			title = m.shimmer.RenderShimmerText(originalTitle, titleWidth)
+			title = m.shimmer.RenderShimmerText(truncateText(task.Title, maxTitleLen), titleWidth)
 		}
 		
 		// Apply colors to status and due date
 		var coloredStatusText string
-		if task.Status == "done" {
-			coloredStatusText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSuccess)).Render(statusText)
-		} else if task.Status == "archived" {
-			coloredStatusText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDisabledText)).Render(statusText)
+		if task.Status == "done" || task.Status == "archived" {
+			coloredStatusText = lipgloss.NewStyle().Foreground(lipgloss.Color(taskStatusColor(task.Status))).Render(statusText)
 		} else {
 			// Check if this task is running (has active session)
 			if m.activeSession != nil && m.activeSession.TaskID == task.ID {
 				// Use bright accent color for running tasks
 				coloredStatusText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccentBright)).Render(statusText)
 			} else {
-				coloredStatusText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText)).Render(statusText)
+				coloredStatusText = lipgloss.NewStyle().Foreground(lipgloss.Color(taskStatusColor(task.Status))).Render(statusText)
 			}
 		}
 		
 		var coloredDueText string
 		if task.Due != nil {
-			now := time.Now()
-			days := int(task.Due.Sub(now).Hours() / 24)
+			days := parser.DaysUntil(*task.Due, time.Now())
 			if days < 0 {
 				coloredDueText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError)).Render(dueText)
 			} else if days == 0 {
@@ -1138,12 +2050,11 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 		}
 		
 		// DUE: 9 chars max
-		if len(dueText) > 9 {
-			dueText = dueText[:6] + "..."
+		if textWidth(dueText) > 9 {
+			dueText = truncateText(dueText, 9)
 			// Re-apply color after truncation
 			if task.Due != nil {
-				now := time.Now()
-				days := int(task.Due.Sub(now).Hours() / 24)
+				days := parser.DaysUntil(*task.Due, time.Now())
 				if days < 0 {
 					coloredDueText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError)).Render(dueText)
 				} else if days == 0 {
@@ -1161,8 +2072,8 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 		}
 		
 		// JIRA: 9 chars max (as requested)
-		if len(jiraText) > 9 {
-			jiraText = jiraText[:6] + "..."
+		if textWidth(jiraText) > 9 {
+			jiraText = truncateText(jiraText, 9)
 			// Re-apply color after truncation
 			if task.JiraID != "" {
 				coloredJiraText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccentMain)).Bold(true).Render(jiraText)
@@ -1172,55 +2083,46 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 		}
 		
 		// PRIORITY: 8 chars max
-		if len(priorityText) > 8 {
-			priorityText = priorityText[:5] + "..."
+		if textWidth(priorityText) > 8 {
+			priorityText = truncateText(priorityText, 8)
 			// Re-apply color after truncation
-			if task.Priority > 0 && task.Priority <= 3 {
-				switch task.Priority {
-				case 3: // high
-					coloredPriorityText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError)).Render(priorityText)
-				case 2: // medium
-					coloredPriorityText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorWarning)).Render(priorityText)
-				case 1: // low
-					coloredPriorityText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText)).Render(priorityText)
-				}
-			} else {
-				coloredPriorityText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDisabledText)).Render(priorityText)
-			}
+			coloredPriorityText = lipgloss.NewStyle().Foreground(lipgloss.Color(priorityColor(task.Priority))).Render(priorityText)
 		}
 		
 		// STATUS: 8 chars max
-		if len(statusText) > 8 {
-			statusText = statusText[:5] + "..."
+		if textWidth(statusText) > 8 {
+			statusText = truncateText(statusText, 8)
 			// Re-apply color after truncation
-			if task.Status == "done" {
-				coloredStatusText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSuccess)).Render(statusText)
-			} else if task.Status == "archived" {
-				coloredStatusText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDisabledText)).Render(statusText)
-			} else {
-				coloredStatusText = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText)).Render(statusText)
-			}
+			coloredStatusText = lipgloss.NewStyle().Foreground(lipgloss.Color(taskStatusColor(task.Status))).Render(statusText)
+		}
+		
+		// Prefix pinned tasks with the marker
+		if task.Pinned && !isSelected {
+			title = pinMarker() + title
 		}
 		
 		// Create row content with exact column alignment (responsive)
-		// Add extra spaces to align values with headers
-		rowLeft := fmt.Sprintf(" %-*s %-*s", idWidth, id, titleWidth, title)  // Added leading space
+		// Pad by display width so wide characters keep the columns aligned
+		rowLeft := " " + padText(id, idWidth) + " " + padText(title, titleWidth) // Added leading space
 		
 		var rowRight string
 		if showExtraColumns {
 			// Full layout
-			rowRight = fmt.Sprintf("%-*s %-*s %-*s %-*s", 
-				statusWidth, statusText,
-				priorityWidth, priorityText,
-				jiraWidth, jiraText,
-				dueWidth, dueText)
+			rowRight = padText(statusText, statusWidth) + " " +
+				padText(priorityText, priorityWidth) + " " +
+				padText(jiraText, jiraWidth) + " " +
+				padText(dueText, dueWidth)
 		} else {
 			// Compact layout: only status
-			rowRight = fmt.Sprintf("%-*s", statusWidth, statusText)
+			rowRight = padText(statusText, statusWidth)
 		}
 		
 		// Calculate spacing to align right side (account for the extra space we added)
-		spacingNeeded := availableWidth - len(rowLeft) - len(rowRight)
+		spacingNeeded := availableWidth - textWidth(rowLeft) - textWidth(rowRight)
+		if showExtraColumns {
+			// The age column is appended after coloring so its text can't collide with the replacements below
+			spacingNeeded -= ageWidth + 1
+		}
 		if spacingNeeded < 1 {
 			spacingNeeded = 1
 		}
@@ -1241,7 +2143,7 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 		}
 		
 		// Apply priority coloring
-		if task.Priority > 0 && task.Priority <= 3 {
+		if task.Priority > 0 {
 			rowContent = strings.Replace(rowContent, priorityText, coloredPriorityText, 1)
 		}
 		
@@ -1266,6 +2168,18 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 			}
 		}
 		
+		// Age: dimmed normally, flagged once an open task goes stale
+		if showExtraColumns {
+			age, stale := m.taskAge(task)
+			ageText := parser.FormatAge(age)
+			ageStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDisabledText))
+			if stale {
+				ageText += "!"
+				ageStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorWarning))
+			}
+			rowContent += " " + ageStyle.Render(padText(ageText, ageWidth))
+		}
+		
 		if isSelected {
 			// Selected row: custom text with ID, title, and non-null fields
 			var customParts []string
@@ -1280,27 +2194,18 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 				// todo - keep default color
 				coloredID = id
 			}
+			if task.Pinned {
+				coloredID += " " + strings.TrimSpace(pinMarker())
+			}
 			customParts = append(customParts, coloredID)
 			
 			// Add title with shimmer effect (give it plenty of width)
-			shimmeredTitle := m.shimmer.RenderShimmerText(task.Title, len(task.Title)+20) // Extra width for shimmer effect
+			shimmeredTitle := m.shimmer.RenderShimmerText(task.Title, textWidth(task.Title)+20) // Extra width for shimmer effect
 			customParts = append(customParts, shimmeredTitle)
 			
 			// Add priority with same colors as table
-			if task.Priority > 0 && task.Priority <= 3 {
-				priorities := []string{"", "low", "med", "high"}
-				priorityText := priorities[task.Priority]
-				
-				// Apply same color coding as table
-				var coloredPriority string
-				switch task.Priority {
-				case 3: // high
-					coloredPriority = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError)).Render(priorityText)
-				case 2: // medium
-					coloredPriority = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorWarning)).Render(priorityText)
-				case 1: // low
-					coloredPriority = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText)).Render(priorityText)
-				}
+			if task.Priority > 0 {
+				_, coloredPriority := priorityLabel(task.Priority)
 				customParts = append(customParts, coloredPriority)
 			}
 			
@@ -1312,8 +2217,7 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 			
 			// Add due date with same colors as table
 			if task.Due != nil {
-				now := time.Now()
-				days := int(task.Due.Sub(now).Hours() / 24)
+				days := parser.DaysUntil(*task.Due, time.Now())
 				var dueDisplay string
 				var coloredDue string
 				
@@ -1336,36 +2240,25 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 				customParts = append(customParts, coloredDue)
 			}
 			
+			// Flag stale tasks; the tighter layouts below drop this first
+			if age, stale := m.taskAge(task); stale {
+				staleHint := "untouched " + parser.FormatAge(age)
+				customParts = append(customParts, lipgloss.NewStyle().Foreground(lipgloss.Color(ColorWarning)).Render(staleHint))
+			}
+			
 			// Smart truncation: JIRA first, then title
 			maxWidth := availableWidth - 4 // Account for border + padding
 			
-			// Helper function to calculate visual length (without ANSI codes)
-			visualLength := func(text string) int {
-				// Count visible characters by removing ANSI escape sequences
-				visibleLen := 0
-				inEscape := false
-				for _, r := range text {
-					if r == '\033' { // ESC character
-						inEscape = true
-					} else if inEscape && r == 'm' {
-						inEscape = false
-					} else if !inEscape {
-						visibleLen++
-					}
-				}
-				return visibleLen
-			}
-			
 			// Try different truncation strategies
 			customText := ""
 			
 			// Strategy 1: No truncation
 			customText = strings.Join(customParts, "   ")
-			if visualLength(customText) <= maxWidth {
+			if textWidth(customText) <= maxWidth {
 				// Perfect fit, done
-			} else if task.JiraID != "" && len(task.JiraID) > 9 {
+			} else if task.JiraID != "" && textWidth(task.JiraID) > 9 {
 				// Strategy 2: Truncate JIRA first
-				truncatedJira := task.JiraID[:6] + "..."
+				truncatedJira := truncateText(task.JiraID, 9)
 				truncatedParts := make([]string, 0)
 				
 				// Rebuild parts with truncated JIRA (with styling)
@@ -1378,26 +2271,17 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 				} else {
 					coloredID = id
 				}
+				if task.Pinned {
+					coloredID += " " + strings.TrimSpace(pinMarker())
+				}
 				truncatedParts = append(truncatedParts, coloredID)
 				
 				// Add shimmered title
-				shimmeredTitle := m.shimmer.RenderShimmerText(task.Title, len(task.Title)+20)
+				shimmeredTitle := m.shimmer.RenderShimmerText(task.Title, textWidth(task.Title)+20)
 				truncatedParts = append(truncatedParts, shimmeredTitle)
 				
-				if task.Priority > 0 && task.Priority <= 3 {
-					priorities := []string{"", "low", "med", "high"}
-					priorityText := priorities[task.Priority]
-					
-					// Apply same color coding
-					var coloredPriority string
-					switch task.Priority {
-					case 3: // high
-						coloredPriority = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError)).Render(priorityText)
-					case 2: // medium
-						coloredPriority = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorWarning)).Render(priorityText)
-					case 1: // low
-						coloredPriority = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText)).Render(priorityText)
-					}
+				if task.Priority > 0 {
+					_, coloredPriority := priorityLabel(task.Priority)
 					truncatedParts = append(truncatedParts, coloredPriority)
 				}
 				
@@ -1406,8 +2290,7 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 				truncatedParts = append(truncatedParts, coloredTruncatedJira)
 				
 				if task.Due != nil {
-					now := time.Now()
-					days := int(task.Due.Sub(now).Hours() / 24)
+					days := parser.DaysUntil(*task.Due, time.Now())
 					var dueDisplay string
 					var coloredDue string
 					
@@ -1431,25 +2314,25 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 				}
 				
 				customText = strings.Join(truncatedParts, "   ")
-				if visualLength(customText) > maxWidth {
+				if textWidth(customText) > maxWidth {
 					// Strategy 3: Truncate title too
-					overflow := visualLength(customText) - maxWidth + 3 // +3 for "..."
-					if len(task.Title) > overflow + 10 { // Keep at least 10 chars
-						truncatedTitle := task.Title[:len(task.Title)-overflow] + "..."
+					overflow := textWidth(customText) - maxWidth + 3 // +3 for "..."
+					if textWidth(task.Title) > overflow + 10 { // Keep at least 10 chars
+						truncatedTitle := truncateText(task.Title, textWidth(task.Title)-overflow+len(ellipsis))
 						// Apply shimmer to truncated title with proper width
-					shimmeredTruncatedTitle := m.shimmer.RenderShimmerText(truncatedTitle, len(truncatedTitle))
+					shimmeredTruncatedTitle := m.shimmer.RenderShimmerText(truncatedTitle, textWidth(truncatedTitle))
 					truncatedParts[1] = shimmeredTruncatedTitle // Title is at index 1 with shimmer
 						customText = strings.Join(truncatedParts, "   ")
 					} else {
 						// Fallback: truncate entire string
-						customText = customText[:maxWidth-3] + "..."
+						customText = truncateStyled(customText, maxWidth)
 					}
 				}
 			} else {
 				// Strategy 3: No JIRA to truncate, truncate title directly
-				overflow := visualLength(customText) - maxWidth + 3 // +3 for "..."
-				if len(task.Title) > overflow + 10 { // Keep at least 10 chars
-					truncatedTitle := task.Title[:len(task.Title)-overflow] + "..."
+				overflow := textWidth(customText) - maxWidth + 3 // +3 for "..."
+				if textWidth(task.Title) > overflow + 10 { // Keep at least 10 chars
+					truncatedTitle := truncateText(task.Title, textWidth(task.Title)-overflow+len(ellipsis))
 					truncatedParts := make([]string, 0)
 					
 					// Rebuild with truncated title
@@ -1462,14 +2345,19 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 					} else {
 						coloredID = id
 					}
+					if task.Pinned {
+						coloredID += " " + strings.TrimSpace(pinMarker())
+					}
 					truncatedParts = append(truncatedParts, coloredID)
+				if task.Pinned {
+					truncatedParts = append(truncatedParts, strings.TrimSpace(pinMarker()))
+				}
 					// Apply shimmer to truncated title with proper width
-				shimmeredTruncatedTitle := m.shimmer.RenderShimmerText(truncatedTitle, len(truncatedTitle))
+				shimmeredTruncatedTitle := m.shimmer.RenderShimmerText(truncatedTitle, textWidth(truncatedTitle))
 				truncatedParts = append(truncatedParts, shimmeredTruncatedTitle)
 					
-					if task.Priority > 0 && task.Priority <= 3 {
-						priorities := []string{"", "low", "med", "high"}
-						truncatedParts = append(truncatedParts, priorities[task.Priority])
+					if task.Priority > 0 {
+						truncatedParts = append(truncatedParts, priority.Short(task.Priority))
 					}
 					
 					if task.JiraID != "" {
@@ -1477,8 +2365,7 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 					}
 					
 					if task.Due != nil {
-						now := time.Now()
-						days := int(task.Due.Sub(now).Hours() / 24)
+						days := parser.DaysUntil(*task.Due, time.Now())
 						var dueDisplay string
 						if days < 0 {
 							dueDisplay = "OVERDUE"
@@ -1497,7 +2384,7 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 					customText = strings.Join(truncatedParts, "   ")
 				} else {
 					// Fallback: truncate entire string
-					customText = customText[:maxWidth-3] + "..."
+					customText = truncateStyled(customText, maxWidth)
 				}
 			}
 			
@@ -1517,9 +2404,9 @@ func (m ListModel) renderTaskTable(width int, height int) string {
 	} // Close the else block for when there are tasks
 	
 	// Pagination info
-	if m.tasksPerPage < len(m.tasks) {
-		totalPages := (len(m.tasks) + m.tasksPerPage - 1) / m.tasksPerPage
-		pageInfo := fmt.Sprintf("Page %d/%d (%d tasks)", m.currentPage+1, totalPages, len(m.tasks))
+	if m.tasksPerPage < m.taskCount() {
+		totalPages := (m.taskCount() + m.tasksPerPage - 1) / m.tasksPerPage
+		pageInfo := fmt.Sprintf("Page %d/%d (%d tasks)", m.currentPage+1, totalPages, m.taskCount())
 		pageStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color(ColorHelpText)).
 			Align(lipgloss.Center).
@@ -1547,7 +2434,8 @@ func (m ListModel) renderTaskDetails(width, height int) string {
 		return m.renderNarrowTaskDetails(width, height)
 	}
 	
-	if len(m.tasks) == 0 || m.selectedTask >= len(m.tasks) {
+	task, selected := m.selectedRow()
+	if !selected {
 		// Empty state - center vertically with logo
 		availableHeight := height - 4
 		verticalPadding := availableHeight / 3
@@ -1561,22 +2449,13 @@ func (m ListModel) renderTaskDetails(width, height int) string {
 		}
 		
 		// ASCII logo
-		logoLines := []string{
-			"██╗    ██╗██████╗  ██████╗ ██╗  ██╗",
-			"██║    ██║██╔══██╗██╔═══██╗██║ ██╔╝", 
-			"██║ █╗ ██║██████╔╝██║   ██║█████╔╝ ",
-			"██║███╗██║██╔══██╗██║   ██║██╔═██╗ ",
-			"╚███╔███╔╝██║  ██║╚██████╔╝██║  ██╗",
-			" ╚══╝╚══╝ ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝",
-		}
-		
 		logoStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color(ColorAccentMain)).
 			Bold(true).
 			Align(lipgloss.Center).
 			Width(width-8)
 		
-		b.WriteString(logoStyle.Render(strings.Join(logoLines, "\n")))
+		b.WriteString(logoStyle.Render(strings.Join(logoLines(), "\n")))
 		b.WriteString("\n\n")
 		
 		emptyStyle := lipgloss.NewStyle().
@@ -1588,27 +2467,17 @@ func (m ListModel) renderTaskDetails(width, height int) string {
 		
 	} else {
 		// Selected task - show details with some top padding
-		task := m.tasks[m.selectedTask]
 		
 		b.WriteString("\n")
 		
 		// ASCII logo at top
-		logoLines := []string{
-			"██╗    ██╗██████╗  ██████╗ ██╗  ██╗",
-			"██║    ██║██╔══██╗██╔═══██╗██║ ██╔╝", 
-			"██║ █╗ ██║██████╔╝██║   ██║█████╔╝ ",
-			"██║███╗██║██╔══██╗██║   ██║██╔═██╗ ",
-			"╚███╔███╔╝██║  ██║╚██████╔╝██║  ██╗",
-			" ╚══╝╚══╝ ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝",
-		}
-		
 		logoStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color(ColorAccentMain)).
 			Bold(true).
 			Align(lipgloss.Center).
 			Width(width-8)
 		
-		b.WriteString(logoStyle.Render(strings.Join(logoLines, "\n")))
+		b.WriteString(logoStyle.Render(strings.Join(logoLines(), "\n")))
 		b.WriteString("\n\n")
 		
 		// Separator line
@@ -1629,30 +2498,34 @@ func (m ListModel) renderTaskDetails(width, height int) string {
 			BorderForeground(lipgloss.Color(ColorAccentMain)).
 			Width(width-12).
 			Padding(0, 1)
-		b.WriteString(titleStyle.Render(task.Title))
+		detailTitle := task.Title
+		if task.Pinned {
+			detailTitle = pinMarker() + detailTitle
+		}
+		b.WriteString(titleStyle.Render(detailTitle))
 		b.WriteString("\n\n")
 		
 		// Task details in structured format
 		// Status with emoji
 		statusIcon := "○"
-		statusColor := ColorSecondaryText
-		statusText := "todo"
+		statusColor := taskStatusColor(task.Status)
+		statusText := task.Status
 		if task.Status == "done" {
 			statusIcon = "✅"
-			statusColor = ColorSuccess
-			statusText = "done"
 		} else if task.Status == "archived" {
 			statusIcon = "▪"
-			statusColor = ColorDisabledText
-			statusText = "archived"
 		} else {
 			// Check if this task has an active session (same logic as table)
 			if m.activeSession != nil && m.activeSession.TaskID == task.ID {
 				// Show elapsed time for running task
-				elapsed := time.Since(m.activeSession.StartedAt)
+				elapsed := m.activeSession.Elapsed(time.Now())
 				statusIcon = "⏱️"
 				statusColor = ColorAccentBright
 				statusText = fmt.Sprintf("running (%s)", formatDurationShort(elapsed))
+				if m.activeSession.Paused() {
+					statusIcon = "⏸️"
+					statusText = fmt.Sprintf("paused (%s)", formatDurationShort(elapsed))
+				}
 			}
 		}
 		
@@ -1679,30 +2552,24 @@ func (m ListModel) renderTaskDetails(width, height int) string {
 		b.WriteString(projectStyle.Render(projectLine))
 		b.WriteString("\n")
 		
+		// Alias, only when the task has one
+		if task.Alias != "" {
+			aliasLine := fmt.Sprintf("🏷️  Alias: %s",
+				lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccentBright)).Render(task.Alias))
+			b.WriteString(projectStyle.Render(aliasLine))
+			b.WriteString("\n")
+		}
+		
 		// Priority with emoji
 		priorityStyle := lipgloss.NewStyle().
 			Align(lipgloss.Center).
 			Width(width-8)
-		priorityIcon := "⚪"
 		priorityValue := "none"
-		priorityColor := ColorDisabledText
-		if task.Priority > 0 && task.Priority <= 3 {
-			priorities := []string{"", "low", "medium", "high"}
-			priorityValue = priorities[task.Priority]
-			switch task.Priority {
-			case 3:
-				priorityIcon = "🔴"
-				priorityColor = ColorError
-			case 2:
-				priorityIcon = "🟡"
-				priorityColor = ColorWarning
-			case 1:
-				priorityIcon = "🟢"
-				priorityColor = ColorSecondaryText
-			}
+		if task.Priority > 0 {
+			priorityValue = priority.Name(task.Priority)
 		}
-		priorityLine := fmt.Sprintf("%s Priority: %s", priorityIcon, 
-			lipgloss.NewStyle().Foreground(lipgloss.Color(priorityColor)).Bold(true).Render(priorityValue))
+		priorityLine := fmt.Sprintf("%s Priority: %s", priorityIcon(task.Priority), 
+			lipgloss.NewStyle().Foreground(lipgloss.Color(priorityColor(task.Priority))).Bold(true).Render(priorityValue))
 		b.WriteString(priorityStyle.Render(priorityLine))
 		b.WriteString("\n")
 		
@@ -1737,6 +2604,9 @@ func (m ListModel) renderTaskDetails(width, height int) string {
 		}
 		jiraLine := fmt.Sprintf("🎯 JIRA: %s", 
 			lipgloss.NewStyle().Foreground(lipgloss.Color(jiraColor)).Bold(true).Render(jiraValue))
+		if badge := jiraBadge(task); badge != "" {
+			jiraLine += " " + lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText)).Render("· "+badge)
+		}
 		b.WriteString(jiraStyle.Render(jiraLine))
 		b.WriteString("\n")
 		
@@ -1748,8 +2618,7 @@ func (m ListModel) renderTaskDetails(width, height int) string {
 		dueValue := "none"
 		dueColor := ColorDisabledText
 		if task.Due != nil {
-			now := time.Now()
-			days := int(task.Due.Sub(now).Hours() / 24)
+			days := parser.DaysUntil(*task.Due, time.Now())
 			
 			if days < 0 {
 				dueIcon = "⚠️"
@@ -1832,7 +2701,8 @@ func (m ListModel) renderTaskDetails(width, height int) string {
 func (m ListModel) renderNarrowTaskDetails(width, height int) string {
 	var b strings.Builder
 	
-	if len(m.tasks) == 0 || m.selectedTask >= len(m.tasks) {
+	task, selected := m.selectedRow()
+	if !selected {
 		// Empty state for narrow terminals
 		availableHeight := height - 4
 		verticalPadding := availableHeight / 3
@@ -1867,7 +2737,6 @@ func (m ListModel) renderNarrowTaskDetails(width, height int) string {
 		
 	} else {
 		// Selected task - compact view
-		task := m.tasks[m.selectedTask]
 		
 		b.WriteString("\n")
 		
@@ -1890,8 +2759,9 @@ func (m ListModel) renderNarrowTaskDetails(width, height int) string {
 		
 		// Truncate title if too long for narrow view
 		title := task.Title
-		if len(title) > width-12 {
-			title = title[:width-15] + "..."
+		title = truncateText(title, width-12)
+		if task.Pinned {
+			title = pinMarker() + title
 		}
 		b.WriteString(titleStyle.Render(title))
 		b.WriteString("\n\n")
@@ -1903,22 +2773,24 @@ func (m ListModel) renderNarrowTaskDetails(width, height int) string {
 		
 		// Status
 		statusIcon := "○"
-		statusColor := ColorSecondaryText
+		statusColor := taskStatusColor(task.Status)
 		statusText := task.Status
 		if task.Status == "done" {
 			statusIcon = "✓"
-			statusColor = ColorSuccess
 		} else if task.Status == "archived" {
 			statusIcon = "▪"
-			statusColor = ColorDisabledText
 		} else {
 			// Check if this task has an active session (same logic as table)
 			if m.activeSession != nil && m.activeSession.TaskID == task.ID {
 				// Show elapsed time for running task
-				elapsed := time.Since(m.activeSession.StartedAt)
+				elapsed := m.activeSession.Elapsed(time.Now())
 				statusIcon = "⏱️"
 				statusColor = ColorAccentBright
 				statusText = fmt.Sprintf("running (%s)", formatDurationShort(elapsed))
+				if m.activeSession.Paused() {
+					statusIcon = "⏸️"
+					statusText = fmt.Sprintf("paused (%s)", formatDurationShort(elapsed))
+				}
 			}
 		}
 		statusLine := fmt.Sprintf("%s Status: %s", statusIcon,
@@ -1929,32 +2801,15 @@ func (m ListModel) renderNarrowTaskDetails(width, height int) string {
 		// Project (if exists)
 		if task.Project != "" {
 			projectLine := fmt.Sprintf("📁 %s", task.Project)
-			if len(projectLine) > width-10 {
-				projectLine = projectLine[:width-13] + "..."
-			}
+			projectLine = truncateText(projectLine, width-10)
 			b.WriteString(fieldStyle.Render(projectLine))
 			b.WriteString("\n")
 		}
 		
 		// Priority (if exists)
-		if task.Priority > 0 && task.Priority <= 3 {
-			priorities := []string{"", "low", "med", "high"}
-			priorityText := priorities[task.Priority]
-			var priorityColor string
-			var priorityIcon string
-			switch task.Priority {
-			case 3:
-				priorityIcon = "🔴"
-				priorityColor = ColorError
-			case 2:
-				priorityIcon = "🟡"
-				priorityColor = ColorWarning
-			case 1:
-				priorityIcon = "🟢"
-				priorityColor = ColorSecondaryText
-			}
-			priorityLine := fmt.Sprintf("%s %s", priorityIcon, 
-				lipgloss.NewStyle().Foreground(lipgloss.Color(priorityColor)).Bold(true).Render(priorityText))
+		if task.Priority > 0 {
+			priorityLine := fmt.Sprintf("%s %s", priorityIcon(task.Priority), 
+				lipgloss.NewStyle().Foreground(lipgloss.Color(priorityColor(task.Priority))).Bold(true).Render(priority.Short(task.Priority)))
 			b.WriteString(fieldStyle.Render(priorityLine))
 			b.WriteString("\n")
 		}
@@ -1966,25 +2821,31 @@ func (m ListModel) renderNarrowTaskDetails(width, height int) string {
 				tagNames = append(tagNames, "#"+tag.Name)
 			}
 			tagsText := strings.Join(tagNames, " ")
-			if len(tagsText) > width-12 {
-				tagsText = tagsText[:width-15] + "..."
-			}
+			tagsText = truncateText(tagsText, width-12)
 			tagsLine := fmt.Sprintf("🔖 %s", tagsText)
 			b.WriteString(fieldStyle.Render(tagsLine))
 			b.WriteString("\n")
 		}
 		
+		// Alias (if exists)
+		if task.Alias != "" {
+			b.WriteString(fieldStyle.Render("🏷️  " + task.Alias))
+			b.WriteString("\n")
+		}
+		
 		// JIRA (if exists)
 		if task.JiraID != "" {
 			jiraLine := fmt.Sprintf("🎯 %s", task.JiraID)
+			if task.JiraStatus != "" {
+				jiraLine += " · " + task.JiraStatus
+			}
 			b.WriteString(fieldStyle.Render(jiraLine))
 			b.WriteString("\n")
 		}
 		
 		// Due date (if exists)
 		if task.Due != nil {
-			now := time.Now()
-			days := int(task.Due.Sub(now).Hours() / 24)
+			days := parser.DaysUntil(*task.Due, time.Now())
 			var dueText, dueColor string
 			
 			if days < 0 {
@@ -2020,12 +2881,8 @@ func (m ListModel) renderNarrowTaskDetails(width, height int) string {
 			b.WriteString(noteHeaderStyle.Render("📝 Notes"))
 			b.WriteString("\n")
 			
-			// Truncate notes for narrow view
-			notes := task.Note
-			maxNoteLen := (width - 10) * 3 // Allow up to 3 lines
-			if len(notes) > maxNoteLen {
-				notes = notes[:maxNoteLen-3] + "..."
-			}
+			// Clip notes to 3 lines for narrow view, keeping their line breaks
+			notes := clipLines(task.Note, 3, width-14)
 			
 			compactNoteStyle := lipgloss.NewStyle().
 				Foreground(lipgloss.Color(ColorSecondaryText)).
@@ -2068,7 +2925,7 @@ func (m ListModel) renderSearchBar() string {
 	var prompt string
 	if m.searchActive {
 		// Live search mode with cursor
-		resultCount := len(m.tasks)
+		resultCount := m.taskCount()
 		if m.searchQuery == "" {
 			prompt = "Search: █ (start typing for live search)"
 		} else {
@@ -2076,7 +2933,7 @@ func (m ListModel) renderSearchBar() string {
 		}
 	} else if m.searchPersisted {
 		// Search is persisted (applied)
-		resultCount := len(m.tasks)
+		resultCount := m.taskCount()
 		prompt = fmt.Sprintf("🔍 Filtered: \"%s\" (%d results) - ESC to clear", m.searchQuery, resultCount)
 		// Different style for persisted search
 		searchStyle = searchStyle.
@@ -2104,30 +2961,18 @@ func (m ListModel) renderHelpBar() string {
 		helpText = "💡 Stretch terminal for full experience · q/esc quit"
 	} else {
 		// Full help text for wider screens
-		helpText = "↑/↓ nav · ←/→ page · / search · f sort · e edit · d done/undone · a archive/unarchive · s start/stop · q/esc quit"
+		priorityKeys := fmt.Sprintf("1-%d priority · ", priority.Max())
+		if m.screenKeys {
+			priorityKeys = ""
+		}
+		helpText = fmt.Sprintf("↑/↓ nav · ←/→ page · enter open · / search · : palette · f sort · e edit · E notes · d done · a archive · P pin · o open · c/C/Y copy · s start/stop · S status · z archived · %sO overdue · g tags · ctrl+o project · q/esc quit", priorityKeys)
 	}
 	
-	return helpStyle.Render(helpText)
+	return helpLine(helpStyle, helpText, "e", "E", "d", "a", "P", "s")
 }
 
 // renderSortModal renders the sorting modal overlayed on the main view
 func (m ListModel) renderSortModal(backgroundView string) string {
-	sortOptions := []struct {
-		field     string
-		direction string
-		label     string
-	}{
-		{"id", "desc", "ID ↓ (newest first)"},
-		{"id", "asc", "ID ↑ (oldest first)"},
-		{"title", "asc", "Title A-Z"},
-		{"title", "desc", "Title Z-A"},
-		{"priority", "desc", "Priority ↓ (high to low)"},
-		{"priority", "asc", "Priority ↑ (low to high)"},
-		{"status", "asc", "Status (todo → done → archived)"},
-		{"created_at", "desc", "Created ↓ (newest first)"},
-		{"created_at", "asc", "Created ↑ (oldest first)"},
-	}
-	
 	// Modal content
 	var modalContent strings.Builder
 	
@@ -2216,22 +3061,13 @@ func (m ListModel) renderMinimalView() string {
 	}
 	
 	// Simple ASCII logo (smaller version)
-	logoLines := []string{
-		"██╗    ██╗██████╗  ██████╗ ██╗  ██╗",
-		"██║    ██║██╔══██╗██╔═══██╗██║ ██╔╝",
-		"██║ █╗ ██║██████╔╝██║   ██║█████╔╝ ",
-		"██║███╗██║██╔══██╗██║   ██║██╔═██╗ ",
-		"╚███╔███╔╝██║  ██║╚██████╔╝██║  ██╗",
-		" ╚══╝╚══╝ ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝",
-	}
-	
 	logoStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(ColorAccentMain)).
 		Bold(true).
 		Align(lipgloss.Center).
 		Width(m.width)
 	
-	for _, line := range logoLines {
+	for _, line := range logoLines() {
 		b.WriteString(logoStyle.Render(line))
 		b.WriteString("\n")
 	}
@@ -2256,41 +3092,43 @@ func (m ListModel) renderMinimalView() string {
 
 // toggleTimer handles start/stop timer functionality
 func (m ListModel) toggleTimer() (ListModel, tea.Cmd) {
-	task := m.tasks[m.selectedTask]
+	if cmd, refused := refuseInReadOnly(&m.toast); refused {
+		return m, cmd
+	}
+	task, ok := m.currentTask()
+	if !ok {
+		return m, nil
+	}
 
 	// Check if there's already an active session
 	activeSession, err := db.GetActiveSession()
 	if err != nil {
-		// Handle error - for now just ignore
-		return m, nil
+		return m, m.toast.ShowError(err)
 	}
 
 	// If there's an active session for this task, stop it
 	if activeSession != nil && activeSession.TaskID == task.ID {
 		stoppedSession, err := db.StopActiveSession()
 		if err != nil {
-			return m, nil
+			return m, m.toast.ShowError(fmt.Errorf("failed to stop timer: %w", err))
 		}
 
-		// Show success message briefly (could add a status message system later)
 		duration := time.Duration(stoppedSession.DurationSeconds) * time.Second
-		// For now, just return - could add status notification later
-		_ = duration
-		return m, nil
+		return m, m.toast.Show(fmt.Sprintf("Stopped #%d after %s", task.ID, formatDurationShort(duration)))
 	}
 
-	// If there's an active session for a different task, stop it first
-	if activeSession != nil {
-		_, err := db.StopActiveSession()
-		if err != nil {
-			return m, nil
-		}
+	// Past max_focus, a new session waits for a break ('wrok start --force' skips it)
+	now := time.Now()
+	if streak, err := db.GetFocusStreak(now); err != nil {
+		return m, m.toast.ShowError(err)
+	} else if streak != nil && streak.BreakLeft(now) > 0 {
+		return m, m.toast.Show(streak.Warning(now))
 	}
 
-	// Start new session for the selected task
-	session, err := db.StartSession(task.ID)
+	// Switch from a session on a different task in one step, or start afresh
+	_, session, err := db.SwitchSession(task.ID)
 	if err != nil {
-		return m, nil
+		return m, m.toast.ShowError(fmt.Errorf("failed to start timer: %w", err))
 	}
 
 	// Open timer modal
@@ -2321,24 +3159,23 @@ func (m ListModel) handleTimerModal(msg tea.Msg) (ListModel, tea.Cmd) {
 
 	// Check if timer should close (stopping, exiting, or quit message)
 	if timerModel.stopping || timerModel.exiting {
-		// Close timer modal and return to list
+		// Close timer modal and return to list (or detail view)
 		m.timerModalOpen = false
 		m.timerModel = nil
-		m.focus = FocusTable
-		m.shimmer.SetActive(true)
+		m = m.restoreFocus()
 
 		// Handle the timer completion (same logic as in RunTimerTUI)
+		var toastCmd tea.Cmd
 		if timerModel.stopping {
 			// Stop the active session
-			stoppedSession, err := db.StopActiveSession()
-			if err == nil {
-				// Could show a brief success message
-				_ = stoppedSession
+			if _, err := db.StopActiveSession(); err != nil {
+				toastCmd = m.toast.ShowError(fmt.Errorf("failed to stop timer: %w", err))
 			}
 		}
 
 		// Refresh tasks to show any status changes
-		return m.refreshTasks()
+		m, refreshCmd := m.refreshTasks()
+		return m, tea.Batch(toastCmd, refreshCmd)
 	}
 
 	return m, cmd
@@ -2356,6 +3193,27 @@ func (m ListModel) updateActiveSession() ListModel {
 	return m
 }
 
+// updateLastActivity reloads when each task was last touched
+func (m ListModel) updateLastActivity() ListModel {
+	lastActivity, err := db.GetLastActivity(m.tasks, time.Now())
+	if err != nil {
+		// Fall back to UpdatedAt for every task
+		lastActivity = map[uint]time.Time{}
+	}
+	m.lastActivity = lastActivity
+	return m
+}
+
+// taskAge returns how long ago the task was last touched and whether it counts as stale
+func (m ListModel) taskAge(task models.Task) (time.Duration, bool) {
+	now := time.Now()
+	last, ok := m.lastActivity[task.ID]
+	if !ok {
+		last = task.UpdatedAt
+	}
+	return now.Sub(last), db.IsStale(task, last, now)
+}
+
 // formatDurationShort formats duration for status column (compact format)
 func formatDurationShort(d time.Duration) string {
 	if d.Hours() >= 1 {
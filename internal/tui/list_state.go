@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+// listStateFile holds where 'wrok ls' was left, in the wrok data directory
+const listStateFile = "tui-state.json"
+
+// ListState is where the task list was left: sort order, filters, search,
+// page and selected task. 'wrok ls' saves it on exit and restores it next time
+type ListState struct {
+	SortField     string   `json:"sort_field"`
+	SortDirection string   `json:"sort_direction"`
+	Status        string   `json:"status,omitempty"`
+	HideArchived  bool     `json:"hide_archived"`
+	Project       string   `json:"project,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	Priorities    []int    `json:"priorities,omitempty"`
+	Overdue       bool     `json:"overdue,omitempty"`
+	Search        string   `json:"search,omitempty"`
+	Page          int      `json:"page"`
+	SelectedID    uint     `json:"selected_id,omitempty"`
+
+	// The focus context in effect when saved (e.g. "@web"); the filters
+	// include it, so they only apply while the focus is the same
+	Focus string `json:"focus,omitempty"`
+}
+
+// ApplyFilters puts the saved filters into opts
+func (s ListState) ApplyFilters(opts *db.TaskQueryOptions) {
+	opts.Status = s.Status
+	opts.HideArchived = s.HideArchived
+	opts.Project = s.Project
+	opts.Tags = s.Tags
+	opts.Priorities = s.Priorities
+	opts.Overdue = s.Overdue
+}
+
+// listStatePath returns the path of the state file
+func listStatePath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, listStateFile), nil
+}
+
+// LoadListState reads the saved list state; ok is false when there is none
+// or it can't be read, in which case the list starts fresh
+func LoadListState() (state ListState, ok bool) {
+	path, err := listStatePath()
+	if err != nil {
+		return ListState{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ListState{}, false
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return ListState{}, false
+	}
+	return state, true
+}
+
+// SaveListState writes the list state for the next 'wrok ls'
+func SaveListState(state ListState) error {
+	path, err := listStatePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// State returns where the list is: its sort, filters, search, page and selection
+func (m ListModel) State() ListState {
+	state := ListState{
+		SortField:     m.sortField,
+		SortDirection: m.sortDirection,
+		Status:        m.queryOpts.Status,
+		HideArchived:  m.queryOpts.HideArchived,
+		Project:       m.queryOpts.Project,
+		Tags:          m.queryOpts.Tags,
+		Priorities:    m.queryOpts.Priorities,
+		Overdue:       m.queryOpts.Overdue,
+		Page:          m.currentPage,
+	}
+	if m.searchPersisted {
+		state.Search = m.searchQuery
+	}
+	if task, ok := m.selectedRow(); ok {
+		state.SelectedID = task.ID
+	}
+	return state
+}
+
+// WithState returns the model with a saved search, page and selection applied.
+// The filters and sort are applied by the caller, through the query and WithSort.
+// When the selected task is gone, the list opens on the saved page instead
+func (m ListModel) WithState(state ListState) ListModel {
+	if state.Search != "" {
+		m.searchQuery = state.Search
+		m.searchPersisted = true
+		m = m.applyLiveSearch()
+	}
+
+	if selected, ok := m.selectTaskByID(state.SelectedID); ok {
+		return selected.syncPage()
+	}
+	m.currentPage = state.Page
+	m.restorePage = true
+	return m.syncPage()
+}
@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxPaletteMatches is how many palette items are listed at once
+const maxPaletteMatches = 10
+
+// paletteItem is one choice in a command palette
+type paletteItem struct {
+	label  string // shown and matched against the query
+	hint   string // dimmed detail shown after the label
+	action string // what the host does with the item, e.g. "goto"
+	arg    string // argument for the action, e.g. a task ID
+}
+
+// paletteEvent tells the host what a key did to the palette
+type paletteEvent int
+
+const (
+	paletteNone   paletteEvent = iota
+	palettePicked              // Enter on an item; see picked()
+	paletteClosed              // Esc, nothing picked
+)
+
+// Palette is a fuzzy-filtered command palette: a query input over a list of items.
+// The host supplies the items and acts on the one picked
+type Palette struct {
+	input    textinput.Model
+	items    []paletteItem
+	parse    func(query string) []paletteItem // items built from the typed query, listed first
+	matches  []paletteItem
+	selected int
+}
+
+// newPalette creates a palette over items. parse may be nil
+func newPalette(placeholder string, items []paletteItem, parse func(query string) []paletteItem) Palette {
+	input := textinput.New()
+	input.Placeholder = placeholder
+	input.Prompt = "› "
+	input.Width = 50
+	input.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimaryText))
+	input.PlaceholderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPlaceholder))
+	input.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccentBright))
+	input.Focus()
+
+	p := Palette{input: input, items: items, parse: parse}
+	p.filter()
+	return p
+}
+
+// filter updates the matches for the current query: parsed items first, then
+// prefix, substring and fuzzy matches of the labels
+func (p *Palette) filter() {
+	raw := strings.TrimSpace(p.input.Value())
+	query := strings.ToLower(raw)
+	p.selected = 0
+
+	var parsed []paletteItem
+	if p.parse != nil && raw != "" {
+		parsed = p.parse(raw)
+	}
+
+	type scored struct {
+		item  paletteItem
+		score int
+	}
+	var ranked []scored
+	for _, item := range p.items {
+		if score, ok := paletteScore(query, strings.ToLower(item.label)); ok {
+			ranked = append(ranked, scored{item, score})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score < ranked[j].score
+	})
+
+	p.matches = parsed
+	seen := make(map[string]bool)
+	for _, item := range parsed {
+		seen[item.action+" "+item.arg] = true
+	}
+	for _, r := range ranked {
+		if len(p.matches) >= maxPaletteMatches {
+			break
+		}
+		if seen[r.item.action+" "+r.item.arg] {
+			continue
+		}
+		p.matches = append(p.matches, r.item)
+	}
+}
+
+// paletteScore ranks how well label matches query, lower is better
+func paletteScore(query, label string) (int, bool) {
+	switch {
+	case query == "":
+		return 0, true
+	case strings.HasPrefix(label, query):
+		return 0, true
+	case strings.Contains(label, " "+query):
+		return 1, true
+	case strings.Contains(label, query):
+		return 2, true
+	case isSubsequence(strings.ReplaceAll(query, " ", ""), label):
+		return 3, true
+	}
+	return 0, false
+}
+
+// picked returns the highlighted item
+func (p Palette) picked() (paletteItem, bool) {
+	if p.selected >= len(p.matches) {
+		return paletteItem{}, false
+	}
+	return p.matches[p.selected], true
+}
+
+// Update handles a key while the palette is open
+func (p Palette) Update(msg tea.KeyMsg) (Palette, paletteEvent) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		return p, paletteClosed
+	case "enter":
+		if _, ok := p.picked(); ok {
+			return p, palettePicked
+		}
+		return p, paletteNone
+	case "up", "ctrl+k":
+		if len(p.matches) > 0 {
+			p.selected = (p.selected + len(p.matches) - 1) % len(p.matches)
+		}
+		return p, paletteNone
+	case "down", "ctrl+j", "tab":
+		if len(p.matches) > 0 {
+			p.selected = (p.selected + 1) % len(p.matches)
+		}
+		return p, paletteNone
+	}
+
+	before := p.input.Value()
+	p.input, _ = p.input.Update(msg)
+	if p.input.Value() != before {
+		p.filter()
+	}
+	return p, paletteNone
+}
+
+// View renders the palette box, width cells wide
+func (p Palette) View(width int) string {
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorPrimaryText)).
+		Background(lipgloss.Color(ColorAccentMain)).
+		Bold(true)
+	optionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimaryText))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText))
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorHelpText)).
+		Italic(true)
+
+	inner := width - 4
+	var b strings.Builder
+	b.WriteString(p.input.View() + "\n\n")
+	if len(p.matches) == 0 {
+		b.WriteString(hintStyle.Render("  No matches") + "\n")
+	}
+	for i, item := range p.matches {
+		label := truncateText(item.label, inner-2)
+		hint := ""
+		if room := inner - 2 - textWidth(label) - 2; item.hint != "" && room > 3 {
+			hint = "  " + truncateText(item.hint, room)
+		}
+		if i == p.selected {
+			b.WriteString(selectedStyle.Render(padText("▶ "+label+hint, inner)) + "\n")
+		} else {
+			b.WriteString(optionStyle.Render("  "+label) + hintStyle.Render(hint) + "\n")
+		}
+	}
+	b.WriteString("\n" + helpStyle.Render("↑/↓ choose · enter run · esc close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorAccentMain)).
+		Background(lipgloss.Color(ColorCardBackground)).
+		Width(width - 2).
+		Padding(0, 1).
+		Render(b.String())
+}
@@ -0,0 +1,401 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// planColumns is the unscheduled column (no due date, or overdue) followed by the seven days of the week
+const planColumns = 8
+
+// PlanModel is the 'wrok plan' week planner: open tasks without a due date (or
+// overdue) next to the days of the week, moved between columns to set their due date
+type PlanModel struct {
+	width  int
+	height int
+
+	opts      db.TaskQueryOptions
+	firstDay  time.Weekday
+	weekStart time.Time
+	columns   [planColumns][]models.Task
+
+	column int // selected column, 0 is unscheduled
+	row    int // selected task in the column
+
+	// The task being dragged; it is drawn in the selected column until dropped
+	grabbed     *models.Task
+	grabbedFrom int
+
+	toast Toast
+	err   error
+}
+
+// NewPlanModel creates the planner for the week containing now, listing open
+// tasks matching opts
+func NewPlanModel(opts db.TaskQueryOptions, firstDay time.Weekday, now time.Time) PlanModel {
+	opts.Status = "open"
+	m := PlanModel{
+		opts:      opts,
+		firstDay:  firstDay,
+		weekStart: parser.StartOfWeek(now, firstDay),
+	}
+	m = m.reload()
+	m.column = 0
+	if len(m.columns[0]) == 0 {
+		// Nothing to plan: start on today
+		m.column = m.dayColumn(now)
+	}
+	return m
+}
+
+// reload loads the tasks for the current week
+func (m PlanModel) reload() PlanModel {
+	m.columns = [planColumns][]models.Task{}
+	tasks, err := db.GetTasksWithOptions(m.opts)
+	if err != nil {
+		m.err = err
+		return m
+	}
+	m.err = nil
+
+	now := time.Now()
+	weekEnd := m.weekStart.AddDate(0, 0, 7)
+	for _, task := range tasks {
+		switch {
+		case task.Due == nil:
+			m.columns[0] = append(m.columns[0], task)
+		case task.Due.Before(m.weekStart):
+			// Overdue tasks need planning again; ones due in an earlier week that
+			// hasn't passed yet are already planned
+			if task.Due.Before(now) {
+				m.columns[0] = append(m.columns[0], task)
+			}
+		case task.Due.Before(weekEnd):
+			column := m.dayColumn(*task.Due)
+			m.columns[column] = append(m.columns[column], task)
+		}
+	}
+	for c := range m.columns {
+		db.SortByUrgency(m.columns[c], now)
+	}
+	return m.clampRow()
+}
+
+// dayColumn returns the column of the day t falls on, or 0 outside the week
+func (m PlanModel) dayColumn(t time.Time) int {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, m.weekStart.Location())
+	offset := int(day.Sub(m.weekStart).Hours()+12) / 24 // +12h rounds across DST changes
+	if offset < 0 || offset > 6 {
+		return 0
+	}
+	return offset + 1
+}
+
+// columnDay returns midnight on the day shown in a day column
+func (m PlanModel) columnDay(column int) time.Time {
+	return m.weekStart.AddDate(0, 0, column-1)
+}
+
+// clampRow keeps the selected row inside the selected column
+func (m PlanModel) clampRow() PlanModel {
+	m.row = min(m.row, max(len(m.columns[m.column])-1, 0))
+	return m
+}
+
+// selectTask moves the selection to the task with the given ID, if it is shown
+func (m PlanModel) selectTask(taskID uint) PlanModel {
+	for c, column := range m.columns {
+		for r, task := range column {
+			if task.ID == taskID {
+				m.column, m.row = c, r
+				return m
+			}
+		}
+	}
+	return m.clampRow()
+}
+
+// currentTask returns the selected task
+func (m PlanModel) currentTask() (models.Task, bool) {
+	if m.row >= len(m.columns[m.column]) {
+		return models.Task{}, false
+	}
+	return m.columns[m.column][m.row], true
+}
+
+// Init initializes the model
+func (m PlanModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles input
+func (m PlanModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case toastExpiredMsg:
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.grabbed != nil {
+			return m.handleDragKeys(msg)
+		}
+		return m.handleKeys(msg)
+	}
+	return m, nil
+}
+
+// handleKeys handles keys while browsing the week
+func (m PlanModel) handleKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "left", "h":
+		m.column = (m.column + planColumns - 1) % planColumns
+		return m.clampRow(), nil
+	case "right", "l":
+		m.column = (m.column + 1) % planColumns
+		return m.clampRow(), nil
+	case "up", "k":
+		if m.row > 0 {
+			m.row--
+		}
+	case "down", "j":
+		if m.row < len(m.columns[m.column])-1 {
+			m.row++
+		}
+	case "[":
+		m.weekStart = m.weekStart.AddDate(0, 0, -7)
+		return m.reload(), nil
+	case "]":
+		m.weekStart = m.weekStart.AddDate(0, 0, 7)
+		return m.reload(), nil
+	case "t":
+		m.weekStart = parser.StartOfWeek(time.Now(), m.firstDay)
+		m = m.reload()
+		m.column = m.dayColumn(time.Now())
+		return m.clampRow(), nil
+	case " ", "enter":
+		task, ok := m.currentTask()
+		if !ok {
+			return m, nil
+		}
+		if cmd, refused := refuseInReadOnly(&m.toast); refused {
+			return m, cmd
+		}
+		m.grabbed = &task
+		m.grabbedFrom = m.column
+	case "u":
+		// Back to unscheduled
+		task, ok := m.currentTask()
+		if !ok || task.Due == nil {
+			return m, nil
+		}
+		if cmd, refused := refuseInReadOnly(&m.toast); refused {
+			return m, cmd
+		}
+		return m.schedule(task, 0)
+	}
+	return m, nil
+}
+
+// handleDragKeys handles keys while a task is being dragged
+func (m PlanModel) handleDragKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "left", "h":
+		m.column = (m.column + planColumns - 1) % planColumns
+	case "right", "l":
+		m.column = (m.column + 1) % planColumns
+	case "[":
+		m.weekStart = m.weekStart.AddDate(0, 0, -7)
+		return m.reload(), nil
+	case "]":
+		m.weekStart = m.weekStart.AddDate(0, 0, 7)
+		return m.reload(), nil
+	case " ", "enter":
+		task := *m.grabbed
+		m.grabbed = nil
+		return m.schedule(task, m.column)
+	case "esc", "q":
+		taskID := m.grabbed.ID
+		m.grabbed = nil
+		m.column = m.grabbedFrom
+		return m.reload().selectTask(taskID), nil
+	}
+	return m, nil
+}
+
+// schedule moves a task to a column: a day sets its due date on that day
+// (keeping the time of an existing due date), column 0 clears it
+func (m PlanModel) schedule(task models.Task, column int) (tea.Model, tea.Cmd) {
+	var due *time.Time
+	if column > 0 {
+		day := m.columnDay(column)
+		hour, minute, second := 23, 59, 59
+		if task.Due != nil {
+			hour, minute, second = task.Due.Clock()
+		}
+		at := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, day.Location())
+		due = &at
+	}
+	if (task.Due == nil && due == nil) || (task.Due != nil && due != nil && task.Due.Equal(*due)) {
+		return m.reload().selectTask(task.ID), nil
+	}
+
+	if _, err := db.RescheduleTask(task.ID, due); err != nil {
+		return m, m.toast.ShowError(err)
+	}
+	m = m.reload().selectTask(task.ID)
+	if due == nil {
+		return m, m.toast.Show(fmt.Sprintf("#%d unscheduled", task.ID))
+	}
+	return m, m.toast.Show(fmt.Sprintf("#%d due %s %s", task.ID, parser.DayLabel(due.Weekday()), due.Format("02/01")))
+}
+
+// View renders the planner
+func (m PlanModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return "Loading..."
+	}
+	if m.err != nil {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError)).Render("❌ " + m.err.Error())
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorAccentBright))
+	weekEnd := m.weekStart.AddDate(0, 0, 6)
+	header := titleStyle.Render(fmt.Sprintf("📅 Week of %s – %s", m.weekStart.Format("02 Jan"), weekEnd.Format("02 Jan 2006")))
+
+	columnWidth := max(m.width/planColumns, 10)
+	// Header and blank line above, column border (2) and title + spacer (2), help line below
+	rows := max(m.height-7, 1)
+	today := time.Now()
+
+	var rendered []string
+	for c := range m.columns {
+		borderColor := ColorBorder
+		if c == m.column {
+			borderColor = ColorAccentMain
+		}
+
+		title := "Unscheduled"
+		if c > 0 {
+			day := m.columnDay(c)
+			title = fmt.Sprintf("%s %d", parser.DayLabel(day.Weekday()), day.Day())
+			if m.dayColumn(today) == c {
+				title = "▸ " + title
+			}
+		}
+		lines := []string{titleStyle.Render(truncateText(title, columnWidth-4)), ""}
+
+		cards := m.columnCards(c)
+		offset := 0
+		if c == m.column && m.row >= rows-2 {
+			offset = m.row - (rows - 3)
+		}
+		for r := offset; r < len(cards) && len(lines) < rows; r++ {
+			lines = append(lines, cards[r].render(columnWidth-4))
+		}
+
+		rendered = append(rendered, lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color(borderColor)).
+			Padding(0, 1).
+			Width(columnWidth-2).
+			Height(rows).
+			Render(strings.Join(lines, "\n")))
+	}
+
+	help := "←/→ day · ↑/↓ task · space pick up · u unschedule · [/] week · t this week · q quit"
+	if m.grabbed != nil {
+		help = "←/→ move · space/enter drop · [/] week · esc cancel"
+	}
+	footerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorHelpText)).
+		Italic(true).
+		Align(lipgloss.Center).
+		Width(m.width)
+	footer := helpLine(footerStyle, help, "space", "u")
+	if m.toast.Visible() {
+		footer = m.toast.View(m.width)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, rendered...),
+		footer)
+}
+
+// planCard is one task as drawn in a column
+type planCard struct {
+	task     models.Task
+	selected bool
+	dragging bool
+	overdue  bool
+}
+
+// columnCards returns the cards of a column, with the dragged task moved to the
+// top of the selected column
+func (m PlanModel) columnCards(column int) []planCard {
+	now := time.Now()
+	var cards []planCard
+	if m.grabbed != nil && column == m.column {
+		cards = append(cards, planCard{task: *m.grabbed, selected: true, dragging: true})
+	}
+	for r, task := range m.columns[column] {
+		if m.grabbed != nil && task.ID == m.grabbed.ID {
+			continue
+		}
+		cards = append(cards, planCard{
+			task:     task,
+			selected: m.grabbed == nil && column == m.column && r == m.row,
+			overdue:  task.Due != nil && task.Due.Before(now),
+		})
+	}
+	return cards
+}
+
+// render draws the card width cells wide
+func (c planCard) render(width int) string {
+	marker := "  "
+	switch {
+	case c.dragging:
+		marker = "⇄ "
+	case c.selected:
+		marker = "▶ "
+	}
+	text := marker + truncateText(fmt.Sprintf("#%d %s", c.task.ID, c.task.Title), width-2)
+
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimaryText))
+	switch {
+	case c.dragging:
+		style = lipgloss.NewStyle().Bold(true).
+			Foreground(lipgloss.Color(ColorPrimaryText)).
+			Background(lipgloss.Color(ColorAccentMain))
+	case c.selected:
+		style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorAccentBright))
+	case c.overdue:
+		style = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError))
+	}
+	return style.Render(text)
+}
+
+// RunPlanTUI runs the week planner
+func RunPlanTUI(opts db.TaskQueryOptions, firstDay time.Weekday) error {
+	_, err := RunProgram(NewPlanModel(opts, firstDay, time.Now()))
+	return err
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/balkashynov/wrok/internal/priority"
+)
+
+// priorityColor returns the display color of a stored priority: the one set in
+// the config, otherwise red for the highest level, amber for the next and grey below
+func priorityColor(value int) string {
+	if value <= 0 {
+		return ColorDisabledText
+	}
+	if color := priority.Color(value); color != "" {
+		return color
+	}
+	switch priority.Rank(value) {
+	case 0:
+		return ColorError
+	case 1:
+		return ColorWarning
+	default:
+		return ColorSecondaryText
+	}
+}
+
+// priorityIcon returns the marker shown next to a stored priority
+func priorityIcon(value int) string {
+	if value <= 0 {
+		return "⚪"
+	}
+	switch priority.Rank(value) {
+	case 0:
+		return "🔴"
+	case 1:
+		return "🟡"
+	default:
+		return "🟢"
+	}
+}
+
+// priorityLabel returns the column name of a stored priority in its color, "-" if none
+func priorityLabel(value int) (plain, colored string) {
+	plain = priority.Short(value)
+	if plain == "" {
+		plain = "-"
+	}
+	return plain, lipgloss.NewStyle().Foreground(lipgloss.Color(priorityColor(value))).Render(plain)
+}
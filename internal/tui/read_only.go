@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+// helpLine renders a help line of " · "-separated hotkey hints in style. In
+// read-only mode it starts with a READ-ONLY badge and the hints whose key is
+// in changing are struck through and faint
+func helpLine(style lipgloss.Style, help string, changing ...string) string {
+	if !db.ReadOnly() {
+		return style.Render(help)
+	}
+
+	inline := style.UnsetWidth().UnsetAlign()
+	disabled := inline.Strikethrough(true).Faint(true)
+	badge := inline.Italic(false).Bold(true).Foreground(lipgloss.Color(ColorWarning))
+
+	hints := strings.Split(help, " · ")
+	for i, hint := range hints {
+		key, _, _ := strings.Cut(hint, " ")
+		if slices.Contains(changing, key) {
+			hints[i] = disabled.Render(hint)
+		} else {
+			hints[i] = inline.Render(hint)
+		}
+	}
+	line := badge.Render("READ-ONLY") + inline.Render(" · ") + strings.Join(hints, inline.Render(" · "))
+
+	if width := style.GetWidth(); width > 0 {
+		return lipgloss.PlaceHorizontal(width, style.GetAlignHorizontal(), line)
+	}
+	return line
+}
+
+// refuseInReadOnly shows why a change can't be made when the database is
+// read-only and reports whether it did
+func refuseInReadOnly(toast *Toast) (tea.Cmd, bool) {
+	if !db.ReadOnly() {
+		return nil, false
+	}
+	cmd := toast.Show(db.ErrReadOnly.Error())
+	toast.isError = true
+	return cmd, true
+}
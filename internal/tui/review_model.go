@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// ReviewModel is the shell's Review screen: what happened today and what is still due,
+// the same ground 'wrok wrapup' covers but without changing anything
+type ReviewModel struct {
+	tracked   []taskTime    // time per task today, most first
+	total     time.Duration // all time tracked today
+	completed []models.Task // tasks completed today
+	due       []models.Task // open tasks due today or overdue
+	dayStart  time.Time
+	err       error
+}
+
+// taskTime is the time tracked on one task
+type taskTime struct {
+	task     models.Task
+	duration time.Duration
+}
+
+// loadReview gathers today's sessions, completed tasks and due tasks
+func loadReview(now time.Time) ReviewModel {
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1).Add(-time.Second)
+	m := ReviewModel{dayStart: dayStart}
+
+	sessions, err := db.GetSessionsInRange(dayStart, dayEnd)
+	if err != nil {
+		m.err = err
+		return m
+	}
+	// The running session isn't finished yet, so count it up to now
+	if active, err := db.GetActiveSession(); err == nil && active != nil && !active.StartedAt.Before(dayStart) {
+		sessions = append(sessions, *active)
+	}
+
+	byTask := make(map[uint]*taskTime)
+	for _, session := range sessions {
+		duration := sessionDuration(session, now)
+		m.total += duration
+		if entry, ok := byTask[session.TaskID]; ok {
+			entry.duration += duration
+		} else {
+			byTask[session.TaskID] = &taskTime{task: session.Task, duration: duration}
+		}
+	}
+	for _, entry := range byTask {
+		m.tracked = append(m.tracked, *entry)
+	}
+	sort.Slice(m.tracked, func(i, j int) bool {
+		if m.tracked[i].duration != m.tracked[j].duration {
+			return m.tracked[i].duration > m.tracked[j].duration
+		}
+		return m.tracked[i].task.ID < m.tracked[j].task.ID
+	})
+
+	if m.completed, err = db.GetTasksCompletedInRange(dayStart, dayEnd); err != nil {
+		m.err = err
+		return m
+	}
+	if m.due, err = db.GetOpenTasksDueBy(dayEnd); err != nil {
+		m.err = err
+		return m
+	}
+
+	return m
+}
+
+// View renders the Review screen
+func (m ReviewModel) View(width, height int) string {
+	if m.err != nil {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError)).Render("❌ " + m.err.Error())
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorAccentBright))
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimaryText))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText))
+	lineWidth := max(width-12, 20)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("⏱️  Tracked today · %s", formatDurationShort(m.total))) + "\n")
+	if len(m.tracked) == 0 {
+		b.WriteString(mutedStyle.Render("  Nothing tracked yet") + "\n")
+	}
+	for _, entry := range m.tracked {
+		b.WriteString(fmt.Sprintf("  %s %s\n",
+			mutedStyle.Render(padText(formatDurationShort(entry.duration), 6)),
+			textStyle.Render(truncateText(fmt.Sprintf("#%d %s", entry.task.ID, entry.task.Title), lineWidth))))
+	}
+
+	b.WriteString("\n" + titleStyle.Render(fmt.Sprintf("✅ Completed today · %d", len(m.completed))) + "\n")
+	if len(m.completed) == 0 {
+		b.WriteString(mutedStyle.Render("  Nothing completed yet") + "\n")
+	}
+	for _, task := range m.completed {
+		b.WriteString("  " + textStyle.Render(truncateText(fmt.Sprintf("#%d %s", task.ID, task.Title), lineWidth)) + "\n")
+	}
+
+	b.WriteString("\n" + titleStyle.Render(fmt.Sprintf("📅 Due today or overdue · %d", len(m.due))) + "\n")
+	if len(m.due) == 0 {
+		b.WriteString(mutedStyle.Render("  All clear") + "\n")
+	}
+	overdueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError))
+	for _, task := range m.due {
+		line := textStyle.Render(truncateText(fmt.Sprintf("#%d %s", task.ID, task.Title), lineWidth))
+		if task.Due.Before(m.dayStart) {
+			line += " " + overdueStyle.Render("overdue")
+		}
+		b.WriteString("  " + line + "\n")
+	}
+
+	b.WriteString("\n" + mutedStyle.Italic(true).Render("Run 'wrok wrapup' to close the day and roll unfinished tasks over"))
+
+	return lipgloss.NewStyle().Padding(1, 2).Width(width).MaxHeight(height).Render(b.String())
+}
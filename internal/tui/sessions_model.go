@@ -0,0 +1,523 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// sessionsMode is what the sessions browser is currently doing
+type sessionsMode int
+
+const (
+	sessionsModeList sessionsMode = iota
+	sessionsModeEdit
+	sessionsModeSplit
+	sessionsModeConfirmDelete
+)
+
+// sessionTimeLayout is the format used for editing session times
+const sessionTimeLayout = "02/01/2006 15:04"
+
+// SessionsModel is the interactive session history browser
+type SessionsModel struct {
+	width  int
+	height int
+
+	opts     db.SessionQueryOptions
+	force    bool // locked sessions may be changed
+	sessions []models.Session // newest first
+	selected int
+	offset   int // first visible row
+
+	mode       sessionsMode
+	inputs     []textinput.Model // edit: start, end, note · split: time
+	inputFocus int
+
+	message      string
+	messageError bool
+}
+
+// NewSessionsModel creates the browser for sessions matching opts. Locked
+// sessions can only be changed when force is set
+func NewSessionsModel(sessions []models.Session, opts db.SessionQueryOptions, force bool) SessionsModel {
+	return SessionsModel{
+		opts:     opts,
+		force:    force,
+		sessions: sessions,
+	}
+}
+
+// Init initializes the model
+func (m SessionsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles input
+func (m SessionsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+
+		switch m.mode {
+		case sessionsModeEdit, sessionsModeSplit:
+			return m.handleFormKeys(msg)
+		case sessionsModeConfirmDelete:
+			return m.handleConfirmDeleteKeys(msg)
+		}
+		return m.handleListKeys(msg)
+	}
+
+	return m, nil
+}
+
+// handleListKeys handles keys while browsing
+func (m SessionsModel) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.message = ""
+
+	// Every change is refused up front in read-only mode
+	if db.ReadOnly() {
+		switch msg.String() {
+		case "e", "E", "s", "S", "m", "M", "x", "delete":
+			return m.setError(db.ErrReadOnly.Error()), nil
+		}
+	}
+
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.selected > 0 {
+			m.selected--
+		}
+
+	case "down", "j":
+		if m.selected < len(m.sessions)-1 {
+			m.selected++
+		}
+
+	case "e", "E":
+		if session, ok := m.current(); ok {
+			if m.refuseLocked(session) {
+				return m.setError(lockedMessage(session)), nil
+			}
+			end := ""
+			if session.FinishedAt != nil {
+				end = session.FinishedAt.Format(sessionTimeLayout)
+			}
+			m.inputs = []textinput.Model{
+				newSessionInput("Start", session.StartedAt.Format(sessionTimeLayout)),
+				newSessionInput("End", end),
+				newSessionInput("Note", session.Note),
+			}
+			m.inputs[1].Placeholder = "empty while running"
+			m.mode = sessionsModeEdit
+			return m.focusInput(0)
+		}
+
+	case "s", "S":
+		if session, ok := m.current(); ok {
+			if m.refuseLocked(session) {
+				return m.setError(lockedMessage(session)), nil
+			}
+			if session.FinishedAt == nil {
+				return m.setError("Stop the timer before splitting this session"), nil
+			}
+			// Suggest the middle of the session
+			middle := session.StartedAt.Add(session.FinishedAt.Sub(session.StartedAt) / 2)
+			m.inputs = []textinput.Model{newSessionInput("Split at", middle.Format("15:04"))}
+			m.mode = sessionsModeSplit
+			return m.focusInput(0)
+		}
+
+	case "m", "M":
+		// Merge with the session below (the previous one in time)
+		if m.selected+1 >= len(m.sessions) {
+			return m.setError("No session below to merge with"), nil
+		}
+		session := m.sessions[m.selected]
+		other := m.sessions[m.selected+1]
+		for _, part := range []models.Session{session, other} {
+			if m.refuseLocked(part) {
+				return m.setError(lockedMessage(part)), nil
+			}
+		}
+		merged, err := db.MergeSessions(session.ID, other.ID, m.force)
+		if err != nil {
+			return m.setError(err.Error()), nil
+		}
+		m = m.reload()
+		return m.setInfo(fmt.Sprintf("Merged into session #%d (%s)", merged.ID, formatDurationShort(time.Duration(merged.DurationSeconds)*time.Second))), nil
+
+	case "x", "delete":
+		if session, ok := m.current(); ok {
+			if m.refuseLocked(session) {
+				return m.setError(lockedMessage(session)), nil
+			}
+			m.mode = sessionsModeConfirmDelete
+		}
+	}
+
+	return m.ensureVisible(), nil
+}
+
+// handleFormKeys handles keys in the edit and split forms
+func (m SessionsModel) handleFormKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = sessionsModeList
+		m.inputs = nil
+		return m, nil
+
+	case "tab", "down":
+		return m.focusInput((m.inputFocus + 1) % len(m.inputs))
+
+	case "shift+tab", "up":
+		return m.focusInput((m.inputFocus - 1 + len(m.inputs)) % len(m.inputs))
+
+	case "enter":
+		if m.mode == sessionsModeEdit {
+			return m.saveEdit()
+		}
+		return m.saveSplit()
+	}
+
+	var cmd tea.Cmd
+	m.inputs[m.inputFocus], cmd = m.inputs[m.inputFocus].Update(msg)
+	return m, cmd
+}
+
+// handleConfirmDeleteKeys asks for confirmation before deleting
+func (m SessionsModel) handleConfirmDeleteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.mode = sessionsModeList
+
+	if msg.String() != "y" && msg.String() != "Y" {
+		return m, nil
+	}
+
+	session, ok := m.current()
+	if !ok {
+		return m, nil
+	}
+	if err := db.DeleteSession(session.ID, m.force); err != nil {
+		return m.setError(err.Error()), nil
+	}
+	m = m.reload()
+	return m.setInfo(fmt.Sprintf("Deleted session #%d", session.ID)), nil
+}
+
+// saveEdit validates and stores the edit form
+func (m SessionsModel) saveEdit() (tea.Model, tea.Cmd) {
+	session, ok := m.current()
+	if !ok {
+		return m, nil
+	}
+
+	// Unchanged fields keep their exact (sub-minute) times
+	start := session.StartedAt
+	if value := strings.TrimSpace(m.inputs[0].Value()); value != session.StartedAt.Format(sessionTimeLayout) {
+		parsed, err := parser.ParseClockTime(value, session.StartedAt)
+		if err != nil {
+			return m.setError(err.Error()), nil
+		}
+		start = parsed
+	}
+
+	var end *time.Time
+	if value := strings.TrimSpace(m.inputs[1].Value()); value != "" {
+		if session.FinishedAt != nil && value == session.FinishedAt.Format(sessionTimeLayout) {
+			end = session.FinishedAt
+		} else {
+			parsed, err := parser.ParseClockTime(value, start)
+			if err != nil {
+				return m.setError(err.Error()), nil
+			}
+			end = &parsed
+		}
+	}
+
+	updated, err := db.UpdateSession(session.ID, start, end, strings.TrimSpace(m.inputs[2].Value()), m.force)
+	if err != nil {
+		return m.setError(err.Error()), nil
+	}
+
+	m.mode = sessionsModeList
+	m.inputs = nil
+	m = m.reload()
+	return m.setInfo(fmt.Sprintf("Updated session #%d", updated.ID)), nil
+}
+
+// saveSplit splits the selected session at the entered time
+func (m SessionsModel) saveSplit() (tea.Model, tea.Cmd) {
+	session, ok := m.current()
+	if !ok {
+		return m, nil
+	}
+
+	at, err := parser.ParseClockTime(m.inputs[0].Value(), session.StartedAt)
+	if err != nil {
+		return m.setError(err.Error()), nil
+	}
+
+	_, second, err := db.SplitSession(session.ID, at, 0, m.force)
+	if err != nil {
+		return m.setError(err.Error()), nil
+	}
+
+	m.mode = sessionsModeList
+	m.inputs = nil
+	m = m.reload()
+	return m.setInfo(fmt.Sprintf("Split session #%d at %s into #%d", session.ID, at.Format("15:04"), second.ID)), nil
+}
+
+// newSessionInput creates a text input for the session forms
+func newSessionInput(prompt, value string) textinput.Model {
+	input := textinput.New()
+	input.Prompt = fmt.Sprintf("%-9s ", prompt)
+	input.CharLimit = 200
+	input.Width = 40
+	input.SetValue(value)
+	return input
+}
+
+// focusInput focuses the form input at index
+func (m SessionsModel) focusInput(index int) (tea.Model, tea.Cmd) {
+	for i := range m.inputs {
+		m.inputs[i].Blur()
+	}
+	m.inputFocus = index
+	return m, m.inputs[index].Focus()
+}
+
+// current returns the selected session
+func (m SessionsModel) current() (models.Session, bool) {
+	if m.selected < 0 || m.selected >= len(m.sessions) {
+		return models.Session{}, false
+	}
+	return m.sessions[m.selected], true
+}
+
+// reload fetches the sessions again, keeping the selection in range
+func (m SessionsModel) reload() SessionsModel {
+	sessions, err := db.GetSessions(m.opts)
+	if err != nil {
+		return m.setError(err.Error())
+	}
+	m.sessions = sessions
+	if m.selected >= len(m.sessions) {
+		m.selected = len(m.sessions) - 1
+	}
+	if m.selected < 0 {
+		m.selected = 0
+	}
+	return m.ensureVisible()
+}
+
+// setError shows an error in the status line
+func (m SessionsModel) setError(text string) SessionsModel {
+	m.message = text
+	m.messageError = true
+	return m
+}
+
+// setInfo shows a confirmation in the status line
+func (m SessionsModel) setInfo(text string) SessionsModel {
+	m.message = text
+	m.messageError = false
+	return m
+}
+
+// visibleRows returns how many session rows fit on screen
+func (m SessionsModel) visibleRows() int {
+	// title (2) + table header (1) + total (2) + form (4) + status (2) + help (1)
+	rows := m.height - 12
+	if rows < 3 {
+		rows = 3
+	}
+	return rows
+}
+
+// ensureVisible scrolls so the selected row is on screen
+func (m SessionsModel) ensureVisible() SessionsModel {
+	rows := m.visibleRows()
+	if m.selected < m.offset {
+		m.offset = m.selected
+	}
+	if m.selected >= m.offset+rows {
+		m.offset = m.selected - rows + 1
+	}
+	return m
+}
+
+// View renders the browser
+func (m SessionsModel) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccentBright))
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorSecondaryText))
+	rowStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorPrimaryText))
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccentBright))
+	mutedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorDisabledText))
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorHelpText)).
+		Italic(true)
+
+	width := m.width
+	if width == 0 {
+		width = 100
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("⏱️  Sessions"))
+	b.WriteString("\n\n")
+
+	// Columns: ID, date, start, end, duration, then task and note share the rest
+	fixed := 2 + 6 + 11 + 6 + 8 + 7
+	flexible := width - fixed - 2
+	if flexible < 20 {
+		flexible = 20
+	}
+	taskWidth := flexible * 60 / 100
+	noteWidth := flexible - taskWidth - 1
+
+	row := func(id, date, start, end, duration, task, note string) string {
+		return fmt.Sprintf("%-6s%-11s%-6s%-8s%-7s%s %s",
+			id, date, start, end, duration,
+			runewidth.FillRight(runewidth.Truncate(task, taskWidth, "…"), taskWidth),
+			runewidth.Truncate(note, noteWidth, "…"))
+	}
+
+	b.WriteString("  " + headerStyle.Render(row("ID", "DATE", "START", "END", "DUR", "TASK", "NOTE")))
+	b.WriteString("\n")
+
+	var total time.Duration
+	for _, session := range m.sessions {
+		total += sessionElapsed(session)
+	}
+
+	if len(m.sessions) == 0 {
+		b.WriteString(mutedStyle.Render("  No sessions found"))
+		b.WriteString("\n")
+	}
+
+	end := m.offset + m.visibleRows()
+	if end > len(m.sessions) {
+		end = len(m.sessions)
+	}
+	for i := m.offset; i < end; i++ {
+		session := m.sessions[i]
+		finished := "running"
+		if session.FinishedAt != nil {
+			finished = session.FinishedAt.Format("15:04")
+		}
+		task := fmt.Sprintf("#%d %s", session.TaskID, session.Task.Title)
+		if session.Locked() {
+			task = "🔒 " + task
+		}
+		line := row(
+			fmt.Sprintf("%d", session.ID),
+			session.StartedAt.Format("Mon 02/01"),
+			session.StartedAt.Format("15:04"),
+			finished,
+			formatDurationShort(sessionElapsed(session)),
+			task,
+			session.Note,
+		)
+
+		if i == m.selected {
+			b.WriteString(selectedStyle.Render("▶ " + line))
+		} else {
+			b.WriteString(rowStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(fmt.Sprintf("  %d session(s) · %s total", len(m.sessions), formatDurationShort(total))))
+	b.WriteString("\n\n")
+
+	// Forms and confirmations
+	switch m.mode {
+	case sessionsModeEdit:
+		b.WriteString(titleStyle.Render("Edit session"))
+		b.WriteString("\n")
+		for _, input := range m.inputs {
+			b.WriteString(input.View())
+			b.WriteString("\n")
+		}
+	case sessionsModeSplit:
+		b.WriteString(titleStyle.Render("Split session (HH:MM)"))
+		b.WriteString("\n")
+		b.WriteString(m.inputs[0].View())
+		b.WriteString("\n")
+	case sessionsModeConfirmDelete:
+		if session, ok := m.current(); ok {
+			b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(ColorWarning)).Bold(true).
+				Render(fmt.Sprintf("Delete session #%d (%s)? [y/N]", session.ID, formatDurationShort(sessionElapsed(session)))))
+			b.WriteString("\n")
+		}
+	}
+
+	// Status line
+	if m.message != "" {
+		color := ColorSuccess
+		if m.messageError {
+			color = ColorError
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(m.message))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	switch m.mode {
+	case sessionsModeEdit, sessionsModeSplit:
+		b.WriteString(helpStyle.Render("enter save · tab next field · esc cancel"))
+	default:
+		b.WriteString(helpLine(helpStyle, "↑/↓ nav · e edit · s split · m merge with below · x delete · q/esc quit", "e", "s", "m", "x"))
+	}
+
+	return b.String()
+}
+
+// refuseLocked reports whether session is locked and the browser wasn't opened with --force
+func (m SessionsModel) refuseLocked(session models.Session) bool {
+	return session.Locked() && !m.force
+}
+
+// lockedMessage explains why a locked session can't be changed
+func lockedMessage(session models.Session) string {
+	return fmt.Sprintf("Session #%d is locked; open 'wrok sessions --force' to change it", session.ID)
+}
+
+// sessionElapsed returns the tracked time of a session, counting running sessions up to now
+func sessionElapsed(session models.Session) time.Duration {
+	return session.Elapsed(time.Now())
+}
+
+// RunSessionsTUI starts the interactive session browser
+func RunSessionsTUI(sessions []models.Session, opts db.SessionQueryOptions, force bool) error {
+	_, err := RunProgram(NewSessionsModel(sessions, opts, force))
+	return err
+}
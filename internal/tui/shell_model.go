@@ -0,0 +1,290 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+// shellScreen is one of the screens of the application shell
+type shellScreen int
+
+const (
+	screenTasks shellScreen = iota
+	screenTimer
+	screenStats
+	screenBoard
+	screenReview
+)
+
+// shellTabs are the screen names shown in the tab bar, in screen order
+var shellTabs = []string{"Tasks", "Timer", "Stats", "Board", "Review"}
+
+// shellTabHeight is the number of lines the tab bar takes above each screen
+const shellTabHeight = 2
+
+// shellTickMsg drives the Timer screen; its own ticks are left to the task list's timer modal
+type shellTickMsg struct{}
+
+// ShellModel is the 'wrok tui' application: one program hosting the task list,
+// timer, stats, board and review screens behind a tab bar
+type ShellModel struct {
+	width  int
+	height int
+	screen shellScreen
+
+	tasks  ListModel
+	timer  *TimerModel // running session, nil when no timer is running
+	stats  StatsModel
+	board  BoardModel
+	review ReviewModel
+
+	// Timer screen results and errors
+	toast Toast
+}
+
+// NewShellModel creates the shell with the task list showing the tasks matching opts
+func NewShellModel(opts db.TaskQueryOptions) ShellModel {
+	list := NewListModelWithOptions(opts)
+	list.screenKeys = true
+	return ShellModel{
+		screen: screenTasks,
+		tasks:  list,
+	}
+}
+
+// Init starts the task list's ticks and the shell's own
+func (m ShellModel) Init() tea.Cmd {
+	return tea.Batch(m.tasks.Init(), shellTick())
+}
+
+// shellTick schedules the next Timer screen update
+func shellTick() tea.Cmd {
+	return tea.Tick(250*time.Millisecond, func(time.Time) tea.Msg {
+		return shellTickMsg{}
+	})
+}
+
+// Update handles global keys and routes everything else to the screens
+func (m ShellModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m.updateTasks(tea.WindowSizeMsg{Width: msg.Width, Height: msg.Height - shellTabHeight})
+
+	case shellTickMsg:
+		if m.screen == screenTimer && m.timer != nil {
+			// The timer reschedules its own ticks; the shell's tick replaces them
+			updated, _ := m.timer.Update(timerTickMsg{})
+			updated, _ = updated.(TimerModel).Update(animationTickMsg{})
+			timer := updated.(TimerModel)
+			m.timer = &timer
+		}
+		return m, shellTick()
+
+	case toastExpiredMsg:
+		// Every screen with a toast redraws without it
+		return m.updateTasks(msg)
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+
+		if !m.capturingInput() {
+			switch msg.String() {
+			case "1", "2", "3", "4", "5":
+				return m.switchTo(shellScreen(msg.String()[0] - '1'))
+			case "tab":
+				return m.switchTo((m.screen + 1) % shellScreen(len(shellTabs)))
+			case "shift+tab":
+				return m.switchTo((m.screen + shellScreen(len(shellTabs)) - 1) % shellScreen(len(shellTabs)))
+			}
+		}
+
+		switch m.screen {
+		case screenTasks:
+			return m.updateTasks(msg)
+		case screenTimer:
+			return m.handleTimerKeys(msg)
+		case screenBoard:
+			if msg.String() != "q" && msg.String() != "esc" {
+				var cmd tea.Cmd
+				m.board, cmd = m.board.Update(msg)
+				return m, cmd
+			}
+		}
+
+		if msg.String() == "q" || msg.String() == "esc" {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	// Ticks and other messages belong to the task list
+	return m.updateTasks(msg)
+}
+
+// updateTasks passes msg to the task list
+func (m ShellModel) updateTasks(msg tea.Msg) (ShellModel, tea.Cmd) {
+	updated, cmd := m.tasks.Update(msg)
+	m.tasks = updated.(ListModel)
+	return m, cmd
+}
+
+// capturingInput reports whether the current screen is using the keyboard for
+// something other than navigation (search, a modal, the detail view), in which
+// case the shell leaves its keys alone
+func (m ShellModel) capturingInput() bool {
+	return m.screen == screenTasks && m.tasks.focus != FocusTable
+}
+
+// switchTo shows a screen, reloading its data so changes made elsewhere show up
+func (m ShellModel) switchTo(screen shellScreen) (ShellModel, tea.Cmd) {
+	m.screen = screen
+	now := time.Now()
+
+	switch screen {
+	case screenTasks:
+		var cmd tea.Cmd
+		m.tasks, cmd = m.tasks.refreshTasks()
+		return m, cmd
+	case screenTimer:
+		return m.loadTimer()
+	case screenStats:
+		m.stats = loadStats(now)
+	case screenBoard:
+		m.board = loadBoard(now)
+	case screenReview:
+		m.review = loadReview(now)
+	}
+	return m, nil
+}
+
+// loadTimer points the Timer screen at the running session, if any
+func (m ShellModel) loadTimer() (ShellModel, tea.Cmd) {
+	session, err := db.GetActiveSession()
+	if err != nil {
+		m.timer = nil
+		return m, m.toast.ShowError(err)
+	}
+	if session == nil {
+		m.timer = nil
+		return m, nil
+	}
+	if m.timer == nil || m.timer.session.ID != session.ID {
+		timer := NewTimerModel(session)
+		m.timer = &timer
+	}
+	m.timer.width = m.width
+	m.timer.height = m.height - shellTabHeight
+	return m, nil
+}
+
+// handleTimerKeys handles keys on the Timer screen. Leaving the shell keeps the timer running
+func (m ShellModel) handleTimerKeys(msg tea.KeyMsg) (ShellModel, tea.Cmd) {
+	switch msg.String() {
+	case "s", "S":
+		if m.timer == nil {
+			return m, nil
+		}
+		session, err := db.StopActiveSession()
+		m.timer = nil
+		if err != nil {
+			return m, m.toast.ShowError(fmt.Errorf("failed to stop timer: %w", err))
+		}
+		duration := time.Duration(session.DurationSeconds) * time.Second
+		return m, m.toast.Show(fmt.Sprintf("Stopped #%d after %s", session.TaskID, formatDurationShort(duration)))
+	case "q", "esc":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// View renders the tab bar and the current screen
+func (m ShellModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return "Loading..."
+	}
+
+	height := m.height - shellTabHeight
+	var screen string
+	switch m.screen {
+	case screenTasks:
+		screen = m.tasks.View()
+	case screenTimer:
+		screen = m.renderTimerScreen(height)
+	case screenStats:
+		screen = m.stats.View(m.width, height)
+	case screenBoard:
+		screen = m.board.View(m.width, height)
+	case screenReview:
+		screen = m.review.View(m.width, height)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, m.renderTabBar(), "", screen)
+}
+
+// renderTimerScreen renders the running timer, or a hint when nothing is running
+func (m ShellModel) renderTimerScreen(height int) string {
+	if m.timer != nil {
+		m.timer.width = m.width
+		m.timer.height = height
+		return m.timer.View()
+	}
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorSecondaryText)).
+		Align(lipgloss.Center, lipgloss.Center).
+		Width(m.width).
+		Height(height - 1)
+	hint := hintStyle.Render("⏱️  No timer running\n\nPick a task on the Tasks screen (1) and press s to start one")
+	if m.toast.Visible() {
+		return lipgloss.JoinVertical(lipgloss.Left, hint, m.toast.View(m.width))
+	}
+	return hint
+}
+
+// renderTabBar renders the screen tabs, the current one highlighted
+func (m ShellModel) renderTabBar() string {
+	activeStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorPrimaryText)).
+		Background(lipgloss.Color(ColorAccentMain)).
+		Padding(0, 1)
+	inactiveStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorSecondaryText)).
+		Padding(0, 1)
+
+	var tabs []string
+	for i, name := range shellTabs {
+		label := fmt.Sprintf("%d %s", i+1, name)
+		if shellScreen(i) == m.screen {
+			tabs = append(tabs, activeStyle.Render(label))
+		} else {
+			tabs = append(tabs, inactiveStyle.Render(label))
+		}
+	}
+	bar := strings.Join(tabs, " ")
+
+	hint := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorHelpText)).
+		Italic(true).
+		Render("tab/1-5 switch · ctrl+c quit")
+	if gap := m.width - textWidth(bar) - textWidth(hint); gap > 0 {
+		bar += strings.Repeat(" ", gap) + hint
+	}
+	return bar
+}
+
+// RunShell runs the 'wrok tui' application
+func RunShell(opts db.TaskQueryOptions) error {
+	_, err := RunProgram(NewShellModel(opts))
+	return err
+}
@@ -3,9 +3,10 @@ package tui
 import (
 	"fmt"
 	"math"
-	"os"
 	"strings"
 	"time"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 // ShimmerConfig holds configuration for shimmer effects
@@ -30,11 +31,12 @@ type ShimmerState struct {
 	PauseStartTime time.Time // when the current pause started
 }
 
-// DefaultShimmerConfig returns default shimmer configuration
+// DefaultShimmerConfig returns default shimmer configuration: off without
+// colors, a static highlight when motion is reduced (see reduceMotion)
 func DefaultShimmerConfig() ShimmerConfig {
 	return ShimmerConfig{
-		Enabled:        true,
-		ReduceMotion:   false,
+		Enabled:        hasColor(),
+		ReduceMotion:   reduceMotion(),
 		SpeedMs:        100,
 		WidthRatio:     0.25,
 		CycleMs:        1800,
@@ -49,16 +51,10 @@ func NewShimmerState(config ShimmerConfig) *ShimmerState {
 		LastUpdate:   time.Now(),
 		Active:       config.Enabled && !config.ReduceMotion,
 		Config:       config,
-		SupportsTrueColor: supportsShimmerTrueColor(),
+		SupportsTrueColor: hasTrueColor(),
 	}
 }
 
-// supportsShimmerTrueColor detects if terminal supports truecolor for shimmer
-func supportsShimmerTrueColor() bool {
-	colorTerm := os.Getenv("COLORTERM")
-	return colorTerm == "truecolor"
-}
-
 // Update advances the shimmer animation
 func (s *ShimmerState) Update(visibleLen int) {
 	if !s.Active || s.Config.ReduceMotion {
@@ -127,12 +123,9 @@ func (s *ShimmerState) SetActive(active bool) {
 // RenderShimmerText renders text with shimmer effect
 func (s *ShimmerState) RenderShimmerText(text string, maxWidth int) string {
 	// Truncate text to fit within maxWidth
-	visibleText := text
-	if len(text) > maxWidth {
-		visibleText = text[:maxWidth-3] + "..."
-	}
+	visibleText := truncateText(text, maxWidth)
 	
-	textLen := len(visibleText)
+	textLen := len(textCells(visibleText))
 	if textLen == 0 {
 		return ""
 	}
@@ -157,7 +150,8 @@ func (s *ShimmerState) RenderShimmerText(text string, maxWidth int) string {
 // renderTrueColorShimmer renders the full truecolor shimmer effect
 func (s *ShimmerState) renderTrueColorShimmer(text string) string {
 	var b strings.Builder
-	textLen := len(text)
+	cells := textCells(text)
+	textLen := len(cells)
 	
 	// Base color: #B1B8C7 (rgb 177,184,199)
 	baseR, baseG, baseB := 177, 184, 199
@@ -171,7 +165,7 @@ func (s *ShimmerState) renderTrueColorShimmer(text string) string {
 		sigma = 1.0
 	}
 	
-	for i, char := range text {
+	for i, char := range cells {
 		// Calculate weight using Gaussian bell curve
 		dx := float64(i) - s.Center
 		weight := math.Exp(-(dx * dx) / (2 * sigma * sigma))
@@ -190,24 +184,22 @@ func (s *ShimmerState) renderTrueColorShimmer(text string) string {
 		finalB := int(float64(baseB)*(1-weight) + float64(highlightB)*weight)
 		
 		// Write the character with its color
-		b.WriteString(fmt.Sprintf("\033[38;2;%d;%d;%dm%c", finalR, finalG, finalB, char))
+		b.WriteString(foreground(lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", finalR, finalG, finalB)), char))
 	}
 	
-	// Reset color
-	b.WriteString("\033[0m")
-	
 	return b.String()
 }
 
 // renderStaticShimmerText renders static highlighted text (no animation)
 func renderStaticShimmerText(text string) string {
-	// Use a static accent color for reduced motion
-	return fmt.Sprintf("\033[38;2;167;139;250m%s\033[0m", text) // ColorAccentBright
+	// Use a static accent color for reduced motion, darker on light backgrounds
+	return foreground(lipgloss.AdaptiveColor{Light: ColorAccentMain, Dark: ColorAccentBright}, text)
 }
 
 // renderFallbackShimmerText renders a simple fallback shimmer for non-truecolor terminals
 func renderFallbackShimmerText(text string, center float64, widthRatio float64) string {
-	textLen := len(text)
+	cells := textCells(text)
+	textLen := len(cells)
 	if textLen == 0 {
 		return text
 	}
@@ -221,20 +213,19 @@ func renderFallbackShimmerText(text string, center float64, widthRatio float64)
 	startHighlight := int(center) - highlightWidth/2
 	endHighlight := startHighlight + highlightWidth
 	
+	// 256-color approximations of our purple and grey, darker on light backgrounds
+	highlight := lipgloss.AdaptiveColor{Light: "91", Dark: "147"}
+	base := lipgloss.AdaptiveColor{Light: "240", Dark: "250"}
+
 	var b strings.Builder
-	for i, char := range text {
+	for i, char := range cells {
 		if i >= startHighlight && i < endHighlight {
-			// Highlight color (256-color approximation of our purple)
-			b.WriteString(fmt.Sprintf("\033[38;5;147m%c", char)) // Light purple
+			b.WriteString(foreground(highlight, char))
 		} else {
-			// Base color (256-color approximation)
-			b.WriteString(fmt.Sprintf("\033[38;5;250m%c", char)) // Light grey
+			b.WriteString(foreground(base, char))
 		}
 	}
 	
-	// Reset color
-	b.WriteString("\033[0m")
-	
 	return b.String()
 }
 
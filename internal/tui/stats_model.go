@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/balkashynov/wrok/internal/config"
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+	"github.com/balkashynov/wrok/internal/workflow"
+)
+
+// maxStatsProjects is how many projects the Stats screen breaks the week down into
+const maxStatsProjects = 8
+
+// StatsModel is the shell's Stats screen: task counts and tracked time at a glance
+type StatsModel struct {
+	counts   map[string]int // tasks per status
+	overdue  int
+	today    time.Duration
+	week     time.Duration
+	projects []projectTime // this week, most time first
+	goals    []db.GoalStatus
+	err      error
+}
+
+// projectTime is the time tracked on one project
+type projectTime struct {
+	name     string
+	duration time.Duration
+}
+
+// loadStats gathers the numbers shown on the Stats screen
+func loadStats(now time.Time) StatsModel {
+	m := StatsModel{counts: make(map[string]int)}
+
+	tasks, err := db.GetTasksWithOptions(db.TaskQueryOptions{})
+	if err != nil {
+		m.err = err
+		return m
+	}
+	for _, task := range tasks {
+		m.counts[task.Status]++
+		if workflow.IsOpen(task.Status) && task.Due != nil && task.Due.Before(now) {
+			m.overdue++
+		}
+	}
+
+	firstDay := time.Monday
+	if cfg, err := config.Load(); err == nil {
+		firstDay = cfg.FirstWeekday()
+	}
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekStart := parser.StartOfWeek(now, firstDay)
+
+	// Whole day and week ranges, so the sums are cached between refreshes;
+	// a running session counts up to now
+	week, err := db.SumTrackedTime(db.TrackedTimeQuery{From: weekStart, To: weekStart.AddDate(0, 0, 7)}, now)
+	if err != nil {
+		m.err = err
+		return m
+	}
+	today, err := db.SumTrackedTime(db.TrackedTimeQuery{From: dayStart, To: dayStart.AddDate(0, 0, 1)}, now)
+	if err != nil {
+		m.err = err
+		return m
+	}
+	m.week, m.today = week.Total, today.Total
+
+	byProject := make(map[string]time.Duration)
+	for _, taskTime := range week.Tasks {
+		project := taskTime.Task.Project
+		if project == "" {
+			project = "(no project)"
+		}
+		byProject[project] += taskTime.Duration
+	}
+
+	for name, duration := range byProject {
+		m.projects = append(m.projects, projectTime{name: name, duration: duration})
+	}
+	sort.Slice(m.projects, func(i, j int) bool {
+		if m.projects[i].duration != m.projects[j].duration {
+			return m.projects[i].duration > m.projects[j].duration
+		}
+		return m.projects[i].name < m.projects[j].name
+	})
+	if len(m.projects) > maxStatsProjects {
+		m.projects = m.projects[:maxStatsProjects]
+	}
+
+	m.goals, m.err = db.GetGoalStatuses(now)
+	return m
+}
+
+// sessionDuration returns how long a session ran, counting a running one up to now
+func sessionDuration(session models.Session, now time.Time) time.Duration {
+	return session.Elapsed(now)
+}
+
+// View renders the Stats screen
+func (m StatsModel) View(width, height int) string {
+	if m.err != nil {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(ColorError)).Render("❌ " + m.err.Error())
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorAccentBright))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText))
+	valueStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorPrimaryText))
+	barStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccentMain))
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("📋 Tasks") + "\n")
+	for _, status := range workflow.Names() {
+		b.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render(padText(status, 10)), valueStyle.Render(fmt.Sprint(m.counts[status]))))
+	}
+	overdue := valueStyle.Render(fmt.Sprint(m.overdue))
+	if m.overdue > 0 {
+		overdue = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorError)).Render(fmt.Sprint(m.overdue))
+	}
+	b.WriteString(fmt.Sprintf("  %s %s\n\n", labelStyle.Render(padText("overdue", 10)), overdue))
+
+	b.WriteString(titleStyle.Render("⏱️  Tracked") + "\n")
+	b.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render(padText("today", 10)), valueStyle.Render(formatDurationShort(m.today))))
+	b.WriteString(fmt.Sprintf("  %s %s\n\n", labelStyle.Render(padText("this week", 10)), valueStyle.Render(formatDurationShort(m.week))))
+
+	if len(m.projects) > 0 {
+		b.WriteString(titleStyle.Render("📁 This week by project") + "\n")
+		nameWidth := 0
+		for _, project := range m.projects {
+			nameWidth = max(nameWidth, textWidth(project.name))
+		}
+		nameWidth = min(nameWidth, 24)
+		barWidth := max(width-nameWidth-16, 10)
+		longest := m.projects[0].duration
+		for _, project := range m.projects {
+			bar := 1
+			if longest > 0 {
+				bar = int(float64(barWidth) * project.duration.Seconds() / longest.Seconds())
+			}
+			b.WriteString(fmt.Sprintf("  %s %s %s\n",
+				labelStyle.Render(padText(truncateText(project.name, nameWidth), nameWidth)),
+				barStyle.Render(strings.Repeat("█", max(bar, 1))),
+				valueStyle.Render(formatDurationShort(project.duration))))
+		}
+	}
+
+	if len(m.goals) > 0 {
+		b.WriteString("\n" + titleStyle.Render("🎯 Goals") + "\n")
+		metStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSuccess))
+		emptyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorBorder))
+		barWidth := max(min(width-50, 30), 10)
+		for _, goal := range m.goals {
+			filled := min(int(goal.Ratio()*float64(barWidth)), barWidth)
+			style := barStyle
+			if goal.Ratio() >= 1 {
+				style = metStyle
+			}
+			b.WriteString(fmt.Sprintf("  %s %s%s %s\n",
+				labelStyle.Render(padText(truncateText(db.GoalLabel(goal.Goal), 16), 16)),
+				style.Render(strings.Repeat("█", filled)),
+				emptyStyle.Render(strings.Repeat("░", barWidth-filled)),
+				valueStyle.Render(fmt.Sprintf("%s / %s this %s", formatDurationShort(goal.Tracked), formatDurationShort(goal.Target), goal.Goal.Period))))
+		}
+	}
+
+	return lipgloss.NewStyle().Padding(1, 2).Width(width).MaxHeight(height).Render(b.String())
+}
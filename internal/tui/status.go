@@ -0,0 +1,24 @@
+package tui
+
+import (
+	"github.com/balkashynov/wrok/internal/workflow"
+)
+
+// taskStatusColor returns the display color of a status: the one set in the
+// config, otherwise green for done, grey for archived and todo, and the accent
+// for the configured statuses in between
+func taskStatusColor(status string) string {
+	if color := workflow.Color(status); color != "" {
+		return color
+	}
+	switch status {
+	case workflow.Done:
+		return ColorSuccess
+	case workflow.Archived:
+		return ColorDisabledText
+	case workflow.Todo:
+		return ColorSecondaryText
+	default:
+		return ColorAccentBright
+	}
+}
@@ -0,0 +1,202 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxSuggestions is how many autocomplete suggestions are shown at once
+const maxSuggestions = 5
+
+// suggester offers fuzzy-filtered completions for a text input from a list of known values
+type suggester struct {
+	items    []string // known values, in preference order
+	matches  []string // items matching the current query
+	selected int      // highlighted match, -1 when none
+}
+
+// newSuggester creates a suggester over the given known values
+func newSuggester(items []string) suggester {
+	return suggester{items: items, selected: -1}
+}
+
+// filter updates the matches for query, leaving out exact matches and excluded values
+func (s *suggester) filter(query string, exclude []string) {
+	s.matches = nil
+	s.selected = -1
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return
+	}
+
+	var prefix, contains, fuzzy []string
+	for _, item := range s.items {
+		lower := strings.ToLower(item)
+		if lower == query || containsFold(exclude, item) {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(lower, query):
+			prefix = append(prefix, item)
+		case strings.Contains(lower, query):
+			contains = append(contains, item)
+		case isSubsequence(normalizeName(query), normalizeName(item)):
+			fuzzy = append(fuzzy, item)
+		}
+	}
+
+	s.matches = append(append(prefix, contains...), fuzzy...)
+	if len(s.matches) > maxSuggestions {
+		s.matches = s.matches[:maxSuggestions]
+	}
+}
+
+// move shifts the highlight by delta, wrapping around the matches
+func (s *suggester) move(delta int) {
+	if len(s.matches) == 0 {
+		return
+	}
+	if s.selected < 0 && delta < 0 {
+		s.selected = len(s.matches) - 1
+		return
+	}
+	s.selected = (s.selected + delta + len(s.matches)) % len(s.matches)
+}
+
+// current returns the highlighted match, or the first one if none is highlighted
+func (s suggester) current() (string, bool) {
+	if len(s.matches) == 0 {
+		return "", false
+	}
+	if s.selected < 0 {
+		return s.matches[0], true
+	}
+	return s.matches[s.selected], true
+}
+
+// nearDuplicate returns the known value that name only differs from by case or separators
+func (s suggester) nearDuplicate(name string) (string, bool) {
+	key := normalizeName(name)
+	if key == "" {
+		return "", false
+	}
+	for _, item := range s.items {
+		if item != name && normalizeName(item) == key {
+			return item, true
+		}
+	}
+	return "", false
+}
+
+// similar returns the known value that name looks like a typo of, if name is not known itself
+func (s suggester) similar(name string) (string, bool) {
+	if name == "" || containsFold(s.items, name) {
+		return "", false
+	}
+	if existing, ok := s.nearDuplicate(name); ok {
+		return existing, true
+	}
+
+	// Allow one edit for short names, two for longer ones
+	key := normalizeName(name)
+	maxDistance := 1
+	if len([]rune(key)) >= 6 {
+		maxDistance = 2
+	}
+	for _, item := range s.items {
+		if editDistance(key, normalizeName(item)) <= maxDistance {
+			return item, true
+		}
+	}
+	return "", false
+}
+
+// view renders the matches below an input, prefixing each with marker
+func (s suggester) view(marker string) string {
+	if len(s.matches) == 0 {
+		return ""
+	}
+
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccentBright))
+	optionStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorSecondaryText))
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorHelpText)).
+		Italic(true)
+
+	var lines []string
+	for i, match := range s.matches {
+		if i == s.selected {
+			lines = append(lines, selectedStyle.Render("▶ "+marker+match))
+		} else {
+			lines = append(lines, optionStyle.Render("  "+marker+match))
+		}
+	}
+	lines = append(lines, hintStyle.Render("tab complete · ↑/↓ choose"))
+	return strings.Join(lines, "\n")
+}
+
+// normalizeName lowercases a name and drops separators, so "Back-End" and "backend" compare equal
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '.', ' ', '/':
+			return -1
+		}
+		return r
+	}, name)
+}
+
+// isSubsequence reports whether all runes of query appear in target in order
+func isSubsequence(query, target string) bool {
+	if query == "" {
+		return false
+	}
+	remaining := []rune(query)
+	for _, r := range target {
+		if r == remaining[0] {
+			remaining = remaining[1:]
+			if len(remaining) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr := make([]int, len(rb)+1)
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(min(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(rb)]
+}
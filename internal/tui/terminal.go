@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/balkashynov/wrok/internal/plain"
+)
+
+// Terminal capabilities the screens adapt to. Colors go through lipgloss,
+// which detects what the terminal supports (truecolor, 256 or 16 colors) and
+// honors NO_COLOR; never write raw color escape codes, they would bypass it
+
+// reduceMotion reports whether animations are off: WROK_REDUCE_MOTION is set
+// to anything but "0" or "false", or the output is plain. Shimmer is then a
+// static highlight and the timer's icons stand still
+func reduceMotion() bool {
+	if plain.Enabled() {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("WROK_REDUCE_MOTION"))) {
+	case "", "0", "false", "no", "off":
+		return false
+	}
+	return true
+}
+
+// hasColor reports whether the terminal shows colors at all; not with
+// NO_COLOR set or when output isn't a terminal
+func hasColor() bool {
+	return lipgloss.ColorProfile() != termenv.Ascii
+}
+
+// hasTrueColor reports whether the terminal shows 24-bit colors, which the
+// shimmer's gradient needs
+func hasTrueColor() bool {
+	return lipgloss.ColorProfile() == termenv.TrueColor
+}
+
+// foreground renders text in color, degraded to what the terminal supports
+func foreground(color lipgloss.TerminalColor, text string) string {
+	return lipgloss.NewStyle().Foreground(color).Render(text)
+}
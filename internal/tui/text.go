@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// Width-aware text helpers. Titles, notes and tags may contain CJK, emoji or
+// combining characters, so byte length says nothing about how many terminal
+// cells a string takes; measure and cut with these instead of len and slicing.
+
+// ellipsis marks text that was cut to fit
+const ellipsis = "..."
+
+// textWidth returns the number of terminal cells s occupies, ignoring ANSI escape codes
+func textWidth(s string) int {
+	return ansi.StringWidth(s)
+}
+
+// truncateText shortens plain text to at most width cells, ending in an ellipsis when cut
+func truncateText(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if runewidth.StringWidth(s) <= width {
+		return s
+	}
+	if width <= len(ellipsis) {
+		return runewidth.Truncate(s, width, "")
+	}
+	return runewidth.Truncate(s, width, ellipsis)
+}
+
+// truncateStyled shortens text that may contain ANSI escape codes to at most width cells
+func truncateStyled(s string, width int) string {
+	if textWidth(s) <= width {
+		return s
+	}
+	return ansi.Truncate(s, width, ellipsis)
+}
+
+// padText pads s with spaces on the right until it occupies width cells
+func padText(s string, width int) string {
+	if gap := width - textWidth(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
+
+// textCells splits s into user-perceived characters, keeping combining marks
+// and emoji sequences together so they can be styled one at a time
+func textCells(s string) []string {
+	var cells []string
+	graphemes := uniseg.NewGraphemes(s)
+	for graphemes.Next() {
+		cells = append(cells, graphemes.Str())
+	}
+	return cells
+}
+
+// clipLines wraps plain text to width cells and keeps at most n lines, ending
+// the last one in an ellipsis when lines were dropped. Newlines in s are kept
+func clipLines(s string, n, width int) string {
+	if n <= 0 || width <= 0 {
+		return ""
+	}
+	lines := strings.Split(ansi.Wrap(strings.TrimRight(s, "\n"), width, ""), "\n")
+	if len(lines) <= n {
+		return strings.Join(lines, "\n")
+	}
+	lines = lines[:n]
+	last := strings.TrimRight(lines[n-1], " ")
+	if runewidth.StringWidth(last)+len(ellipsis) > width {
+		last = runewidth.Truncate(last, width-len(ellipsis), "")
+	}
+	lines[n-1] = last + ellipsis
+	return strings.Join(lines, "\n")
+}
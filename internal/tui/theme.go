@@ -0,0 +1,28 @@
+package tui
+
+// Theme holds the accent colors of a color theme
+type Theme struct {
+	AccentMain   string
+	AccentBright string
+}
+
+// Themes available in the config file, keyed by name
+var Themes = map[string]Theme{
+	"purple": {AccentMain: "#7C3AED", AccentBright: "#A78BFA"},
+	"blue":   {AccentMain: "#2563EB", AccentBright: "#60A5FA"},
+	"green":  {AccentMain: "#059669", AccentBright: "#34D399"},
+	"orange": {AccentMain: "#EA580C", AccentBright: "#FB923C"},
+}
+
+// ThemeNames lists the themes in display order, the default first
+var ThemeNames = []string{"purple", "blue", "green", "orange"}
+
+// ApplyTheme switches the accent colors to the named theme; unknown names keep the default
+func ApplyTheme(name string) {
+	theme, ok := Themes[name]
+	if !ok {
+		return
+	}
+	ColorAccentMain = theme.AccentMain
+	ColorAccentBright = theme.AccentBright
+}
@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/balkashynov/wrok/internal/models"
+)
+
+// timelineColors tell projects apart on the timeline, assigned in order of the
+// project's first session of the day
+var timelineColors = []string{"#7C3AED", "#22C55E", "#F59E0B", "#3B82F6", "#EC4899", "#14B8A6", "#EF4444", "#A3E635"}
+
+// timelineLabelWidth is the width of the task column left of the bars
+const timelineLabelWidth = 24
+
+// timelineRow is one task on the timeline: where its sessions fall and how long it was worked on
+type timelineRow struct {
+	task    models.Task
+	spans   [][2]time.Time
+	elapsed time.Duration
+}
+
+// RenderTimeline renders the sessions of the day starting at day as one row of
+// bars per task along an hour axis, colored by project, with the time per
+// project below. Sessions are cut at the day's edges; running ones end at now
+func RenderTimeline(day time.Time, sessions []models.Session, now time.Time) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorAccentBright))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText))
+	axisStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDisabledText))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimaryText))
+
+	dayEnd := day.AddDate(0, 0, 1)
+	title := titleStyle.Render("🕒 Timeline · "+day.Format("Mon 02/01/2006")) + "\n\n"
+
+	// Group the sessions by task in the order they were first worked on
+	var rows []*timelineRow
+	byTask := make(map[uint]*timelineRow)
+	first, last := dayEnd, day
+	for _, session := range sessions {
+		start, end := session.StartedAt, now
+		if session.FinishedAt != nil {
+			end = *session.FinishedAt
+		}
+		// Breaks are left out, except of sessions running past midnight
+		worked := session.Elapsed(now)
+		if start.Before(day) || end.After(dayEnd) {
+			start, end = maxTime(start, day), minTime(end, dayEnd)
+			worked = end.Sub(start)
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		row, ok := byTask[session.TaskID]
+		if !ok {
+			row = &timelineRow{task: session.Task}
+			byTask[session.TaskID] = row
+			rows = append(rows, row)
+		}
+		row.spans = append(row.spans, [2]time.Time{start, end})
+		row.elapsed += worked
+		if start.Before(first) {
+			first = start
+		}
+		if end.After(last) {
+			last = end
+		}
+	}
+	if len(rows) == 0 {
+		return title + labelStyle.Render("No time tracked on this day.")
+	}
+
+	// The axis runs over whole hours from the first session to the last, in
+	// quarter hours, or half hours when that gets too wide
+	from := startOfHour(first)
+	to := startOfHour(last)
+	if to.Before(last) {
+		to = to.Add(time.Hour)
+	}
+	hours := int(to.Sub(from).Hours())
+	cellsPerHour, labelEvery := 4, 1
+	if hours > 14 {
+		cellsPerHour, labelEvery = 2, 2
+	}
+	cell := time.Hour / time.Duration(cellsPerHour)
+	cells := hours * cellsPerHour
+
+	var b strings.Builder
+	b.WriteString(title)
+
+	axis := make([]rune, cells)
+	for i := range axis {
+		axis[i] = ' '
+	}
+	for h := 0; h < hours; h += labelEvery {
+		copy(axis[h*cellsPerHour:], []rune(from.Add(time.Duration(h)*time.Hour).Format("15")))
+	}
+	b.WriteString(strings.Repeat(" ", timelineLabelWidth+1) + axisStyle.Render(string(axis)) + "\n")
+
+	colors := make(map[string]string)
+	projectTime := make(map[string]time.Duration)
+	var projects []string
+	for _, row := range rows {
+		project := row.task.Project
+		if _, ok := colors[project]; !ok {
+			colors[project] = timelineColors[len(projects)%len(timelineColors)]
+			projects = append(projects, project)
+		}
+		projectTime[project] += row.elapsed
+
+		filled := make([]bool, cells)
+		for _, span := range row.spans {
+			// A session shorter than a cell still gets one
+			startCell := int(span[0].Sub(from) / cell)
+			endCell := max(int((span[1].Sub(from)+cell-1)/cell), startCell+1)
+			for i := startCell; i < min(endCell, cells); i++ {
+				filled[i] = true
+			}
+		}
+
+		barStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors[project]))
+		var bar strings.Builder
+		for i, on := range filled {
+			switch {
+			case on:
+				bar.WriteString(barStyle.Render("█"))
+			case i%cellsPerHour == 0:
+				bar.WriteString(axisStyle.Render("·"))
+			default:
+				bar.WriteString(" ")
+			}
+		}
+
+		label := truncateText(fmt.Sprintf("#%d %s", row.task.ID, row.task.Title), timelineLabelWidth)
+		b.WriteString(labelStyle.Render(padText(label, timelineLabelWidth)) + " " + bar.String() + " " +
+			valueStyle.Render(formatDurationShort(row.elapsed)) + "\n")
+	}
+
+	// Time per project, most first
+	sort.SliceStable(projects, func(i, j int) bool {
+		return projectTime[projects[i]] > projectTime[projects[j]]
+	})
+	var total time.Duration
+	b.WriteString("\n")
+	for _, project := range projects {
+		name := project
+		if name == "" {
+			name = "no project"
+		} else {
+			name = "@" + name
+		}
+		total += projectTime[project]
+		swatch := lipgloss.NewStyle().Foreground(lipgloss.Color(colors[project])).Render("██")
+		b.WriteString(swatch + " " + labelStyle.Render(padText(truncateText(name, timelineLabelWidth-3), timelineLabelWidth-3)) + " " +
+			valueStyle.Render(formatDurationShort(projectTime[project])) + "\n")
+	}
+	b.WriteString(strings.Repeat(" ", 3) + titleStyle.Render(padText("Total", timelineLabelWidth-3)) + " " + valueStyle.Render(formatDurationShort(total)))
+
+	return b.String()
+}
+
+// startOfHour returns t with the minutes and seconds cut off, in t's timezone
+func startOfHour(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+}
+
+// maxTime returns the later of a and b
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// minTime returns the earlier of a and b
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
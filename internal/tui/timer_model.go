@@ -10,6 +10,8 @@ import (
 
 	"github.com/balkashynov/wrok/internal/db"
 	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/plain"
+	"github.com/balkashynov/wrok/internal/priority"
 )
 
 // TimerModel represents the TUI model for time tracking
@@ -26,6 +28,14 @@ type TimerModel struct {
 	// Animation state
 	timerAnimation int // For animated timer display
 
+	// Project budget and daily limit, checked once; time tracked since then is added live
+	budget        *db.BudgetStatus
+	dailyLimit    *db.DailyLimitStatus
+	budgetElapsed time.Duration // the session's elapsed time when they were checked
+
+	// Errors shown in place of the help bar until they time out
+	toast Toast
+
 	// UI state
 	stopping bool // True when user pressed S and we're stopping
 	exiting  bool // True when user pressed ESC/Q and we're exiting without stopping
@@ -39,28 +49,46 @@ type animationTickMsg struct{}
 
 // NewTimerModel creates a new timer TUI model
 func NewTimerModel(session *models.Session) TimerModel {
-	return TimerModel{
-		session:        session,
-		task:           &session.Task,
-		elapsedTime:    time.Since(session.StartedAt),
-		lastUpdate:     time.Now(),
+	now := time.Now()
+	budget, err := db.GetBudgetStatus(session.Task.Project, now)
+
+	model := TimerModel{
+		session:         session,
+		task:            &session.Task,
+		elapsedTime:    session.Elapsed(now),
+		lastUpdate:     now,
+		budget:         budget,
+		budgetElapsed:  session.Elapsed(now),
 		timerAnimation: 0,
 		stopping:       false,
 		exiting:        false,
 	}
+
+	// The timer redraws every second, so the toast clears without its own tick
+	if err != nil {
+		model.toast.ShowError(fmt.Errorf("failed to load budget: %w", err))
+	}
+	if model.dailyLimit, err = db.GetDailyLimitStatus(now); err != nil {
+		model.toast.ShowError(err)
+	}
+
+	return model
 }
 
 // Init initializes the timer model
 func (m TimerModel) Init() tea.Cmd {
-	// Start both timer and animation tickers
-	return tea.Batch(
+	// Start both timer and animation tickers; the icons stand still when motion is reduced
+	cmds := []tea.Cmd{
 		tea.Tick(time.Second, func(t time.Time) tea.Msg {
 			return timerTickMsg{}
 		}),
-		tea.Tick(250*time.Millisecond, func(t time.Time) tea.Msg {
+	}
+	if !reduceMotion() {
+		cmds = append(cmds, tea.Tick(250*time.Millisecond, func(t time.Time) tea.Msg {
 			return animationTickMsg{}
-		}),
-	)
+		}))
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update handles messages
@@ -69,7 +97,7 @@ func (m TimerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case timerTickMsg:
 		// Update elapsed time
 		now := time.Now()
-		m.elapsedTime = now.Sub(m.session.StartedAt)
+		m.elapsedTime = m.session.Elapsed(now)
 		m.lastUpdate = now
 
 		// Continue ticking if not stopping or exiting
@@ -81,6 +109,9 @@ func (m TimerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case animationTickMsg:
+		if reduceMotion() {
+			return m, nil
+		}
 		// Update animation states
 		m.timerAnimation = (m.timerAnimation + 1) % 4
 
@@ -101,8 +132,31 @@ func (m TimerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "s", "S":
 			// Stop the timer and save
+			if _, refused := refuseInReadOnly(&m.toast); refused {
+				return m, nil
+			}
 			m.stopping = true
 			return m, tea.Quit
+		case "p", "P":
+			// Pause for a break, or resume after one
+			if _, refused := refuseInReadOnly(&m.toast); refused {
+				return m, nil
+			}
+			var session *models.Session
+			var err error
+			if m.session.Paused() {
+				session, err = db.ResumeActiveSession()
+			} else {
+				session, err = db.PauseActiveSession()
+			}
+			if err != nil {
+				m.toast.ShowError(err)
+				return m, nil
+			}
+			m.session = session
+			m.task = &session.Task
+			m.elapsedTime = session.Elapsed(time.Now())
+			return m, nil
 		case "ctrl+c", "esc", "q":
 			// Exit without stopping
 			m.exiting = true
@@ -173,13 +227,38 @@ func (m TimerModel) renderTimerPanel(width, height int) string {
 	animChars := []string{"⏱", "⏲", "⏱", "⏲"}
 	animChar := animChars[m.timerAnimation]
 	headerText := fmt.Sprintf("%s  TRACKING TIME  %s", animChar, animChar)
+	headerColor := ColorAccentBright
+	if m.session.Paused() {
+		headerText = "⏸  PAUSED  ⏸"
+		if plain.Enabled() {
+			headerText = "PAUSED"
+		}
+		headerColor = ColorWarning
+	}
 
 	headerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(ColorAccentBright)).
+		Foreground(lipgloss.Color(headerColor)).
 		Bold(true).
 		Align(lipgloss.Center).
 		Width(width)
 
+	// Banner once today's tracked time passes the daily limit
+	if m.dailyLimit != nil {
+		limit := *m.dailyLimit
+		limit.Tracked += m.elapsedTime - m.budgetElapsed
+		if warning := limit.Warning(); warning != "" {
+			bannerStyle := lipgloss.NewStyle().
+				Foreground(lipgloss.Color(ColorWarning)).
+				Bold(true).
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color(ColorWarning)).
+				Padding(0, 2).
+				Width(width - 4).
+				Align(lipgloss.Center)
+			components = append(components, bannerStyle.Render(warning))
+		}
+	}
+
 	components = append(components, headerStyle.Render(headerText))
 
 	// Task ID and title
@@ -199,10 +278,7 @@ func (m TimerModel) renderTimerPanel(width, height int) string {
 		Align(lipgloss.Center).
 		Width(width)
 
-	titleText := m.task.Title
-	if len(titleText) > width-4 {
-		titleText = titleText[:width-7] + "..."
-	}
+	titleText := truncateText(m.task.Title, width-4)
 	components = append(components, titleStyle.Render(titleText))
 
 	// Big clock display
@@ -220,6 +296,11 @@ func (m TimerModel) renderTimerPanel(width, height int) string {
 
 	// Session start time
 	sessionInfo := fmt.Sprintf("Started at %s", m.session.StartedAt.Format("15:04:05"))
+	if m.session.Paused() {
+		sessionInfo = fmt.Sprintf("On a break since %s · p to resume", m.session.PausedAt.Format("15:04:05"))
+	} else if m.session.PausedSeconds > 0 {
+		sessionInfo += fmt.Sprintf(" · %s of breaks", formatDuration(time.Duration(m.session.PausedSeconds)*time.Second))
+	}
 	sessionStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(ColorSecondaryText)).
 		Italic(true).
@@ -227,6 +308,24 @@ func (m TimerModel) renderTimerPanel(width, height int) string {
 		Width(width)
 	components = append(components, sessionStyle.Render(sessionInfo))
 
+	// Budget warning once the project nears or exceeds its budget
+	if m.budget != nil {
+		budget := *m.budget
+		budget.Used += m.elapsedTime - m.budgetElapsed
+		if warning := budget.Warning(); warning != "" {
+			color := ColorWarning
+			if budget.Ratio() >= db.BudgetOverRatio {
+				color = ColorError
+			}
+			warningStyle := lipgloss.NewStyle().
+				Foreground(lipgloss.Color(color)).
+				Bold(true).
+				Align(lipgloss.Center).
+				Width(width)
+			components = append(components, warningStyle.Render(warning))
+		}
+	}
+
 	// Join all components with spacing and center vertically
 	content := strings.Join(components, "\n\n")
 
@@ -241,7 +340,11 @@ func (m TimerModel) renderTimerPanel(width, height int) string {
 
 // renderBigClock renders ASCII art clock
 func (m TimerModel) renderBigClock() string {
-	duration := m.elapsedTime
+	return renderBigDuration(m.elapsedTime, ColorAccentBright)
+}
+
+// renderBigDuration renders a duration as a big ASCII art clock in color
+func renderBigDuration(duration time.Duration, color string) string {
 	hours := int(duration.Hours())
 	minutes := int(duration.Minutes()) % 60
 	seconds := int(duration.Seconds()) % 60
@@ -335,6 +438,11 @@ func (m TimerModel) renderBigClock() string {
 		timeStr = fmt.Sprintf("%02d:%02d", minutes, seconds)
 	}
 
+	// Plain mode has the time as text, not art
+	if plain.Enabled() {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(true).Render(timeStr)
+	}
+
 	// Build the big clock display
 	var lines [5]strings.Builder
 
@@ -349,7 +457,7 @@ func (m TimerModel) renderBigClock() string {
 
 	// Apply consistent color (no blinking)
 	clockStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(ColorAccentBright)).
+		Foreground(lipgloss.Color(color)).
 		Bold(true)
 
 	var result strings.Builder
@@ -371,22 +479,13 @@ func (m TimerModel) renderTaskDetailsPanel(width, height int) string {
 	b.WriteString("\n")
 
 	// ASCII logo at top (from ls)
-	logoLines := []string{
-		"██╗    ██╗██████╗  ██████╗ ██╗  ██╗",
-		"██║    ██║██╔══██╗██╔═══██╗██║ ██╔╝",
-		"██║ █╗ ██║██████╔╝██║   ██║█████╔╝ ",
-		"██║███╗██║██╔══██╗██║   ██║██╔═██╗ ",
-		"╚███╔███╔╝██║  ██║╚██████╔╝██║  ██╗",
-		" ╚══╝╚══╝ ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝",
-	}
-
 	logoStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(ColorAccentMain)).
 		Bold(true).
 		Align(lipgloss.Center).
 		Width(width-8)
 
-	b.WriteString(logoStyle.Render(strings.Join(logoLines, "\n")))
+	b.WriteString(logoStyle.Render(strings.Join(logoLines(), "\n")))
 	b.WriteString("\n\n")
 
 	// Separator line (from ls)
@@ -413,16 +512,12 @@ func (m TimerModel) renderTaskDetailsPanel(width, height int) string {
 	// Task details in structured format (from ls)
 	// Status with emoji
 	statusIcon := "○"
-	statusColor := ColorSecondaryText
-	statusText := "todo"
+	statusColor := taskStatusColor(task.Status)
+	statusText := task.Status
 	if task.Status == "done" {
 		statusIcon = "✅"
-		statusColor = ColorSuccess
-		statusText = "done"
 	} else if task.Status == "archived" {
 		statusIcon = "▪"
-		statusColor = ColorDisabledText
-		statusText = "archived"
 	}
 
 	statusStyle := lipgloss.NewStyle().
@@ -452,26 +547,12 @@ func (m TimerModel) renderTaskDetailsPanel(width, height int) string {
 	priorityStyle := lipgloss.NewStyle().
 		Align(lipgloss.Center).
 		Width(width-8)
-	priorityIcon := "⚪"
 	priorityValue := "none"
-	priorityColor := ColorDisabledText
-	if task.Priority > 0 && task.Priority <= 3 {
-		priorities := []string{"", "low", "medium", "high"}
-		priorityValue = priorities[task.Priority]
-		switch task.Priority {
-		case 3:
-			priorityIcon = "🔴"
-			priorityColor = ColorError
-		case 2:
-			priorityIcon = "🟡"
-			priorityColor = ColorWarning
-		case 1:
-			priorityIcon = "🟢"
-			priorityColor = ColorSecondaryText
-		}
+	if task.Priority > 0 {
+		priorityValue = priority.Name(task.Priority)
 	}
-	priorityLine := fmt.Sprintf("%s Priority: %s", priorityIcon,
-		lipgloss.NewStyle().Foreground(lipgloss.Color(priorityColor)).Render(priorityValue))
+	priorityLine := fmt.Sprintf("%s Priority: %s", priorityIcon(task.Priority),
+		lipgloss.NewStyle().Foreground(lipgloss.Color(priorityColor(task.Priority))).Render(priorityValue))
 	b.WriteString(priorityStyle.Render(priorityLine))
 	b.WriteString("\n")
 
@@ -544,9 +625,16 @@ func (m TimerModel) renderHelpBar() string {
 		Align(lipgloss.Center).
 		Width(m.width)
 
-	helpText := "s stop & save · esc/q exit (keep running) · ctrl+c force quit"
+	if m.toast.Visible() {
+		return m.toast.View(m.width)
+	}
+
+	helpText := "s stop & save · p pause · esc/q exit (keep running) · ctrl+c force quit"
+	if m.session.Paused() {
+		helpText = "p resume · s stop & save · esc/q exit (stay paused) · ctrl+c force quit"
+	}
 
-	return helpStyle.Render(helpText)
+	return helpLine(helpStyle, helpText, "s", "p")
 }
 
 // RunTimerTUI runs the timer TUI
@@ -569,14 +657,26 @@ func RunTimerTUI(session *models.Session) error {
 			return fmt.Errorf("failed to stop session: %w", err)
 		}
 
-		// Show completion message
-		duration := time.Duration(stoppedSession.DurationSeconds) * time.Second
-		fmt.Printf("⏹️  Stopped tracking time for task #%d: %s\n", stoppedSession.TaskID, stoppedSession.Task.Title)
-		fmt.Printf("📊 Session duration: %s\n", formatDuration(duration))
+		// Show completion message, with the totals around the session when they load
+		if summary, err := db.GetStopSummary(*stoppedSession, time.Now()); err == nil {
+			fmt.Println(summary.String())
+		} else {
+			duration := time.Duration(stoppedSession.DurationSeconds) * time.Second
+			fmt.Printf("⏹️  Stopped tracking time for task #%d: %s\n", stoppedSession.TaskID, stoppedSession.Task.Title)
+			fmt.Printf("📊 Session duration: %s\n", formatDuration(duration))
+		}
+		if limit, err := db.GetDailyLimitStatus(time.Now()); err == nil && limit != nil && limit.Over() {
+			fmt.Println(limit.Warning())
+		}
 	} else if timerModel.exiting {
 		// Just exiting without stopping
+		if timerModel.session.Paused() {
+			fmt.Printf("\n⏸️  Timer is paused for task #%d: %s\n", session.TaskID, session.Task.Title)
+			fmt.Printf("   Use 'wrok resume' to carry on, 'wrok attach' to reopen the timer or 'wrok stop' to stop it.\n")
+			return nil
+		}
 		fmt.Printf("\n💡 Timer is still running in the background for task #%d: %s\n", session.TaskID, session.Task.Title)
-		fmt.Printf("   Use 'wrok status' to check current timer or 'wrok stop' to stop it.\n")
+		fmt.Printf("   Use 'wrok attach' to reopen the timer, 'wrok status' to check it or 'wrok stop' to stop it.\n")
 	}
 
 	return nil
@@ -0,0 +1,578 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/balkashynov/wrok/internal/db"
+	"github.com/balkashynov/wrok/internal/models"
+	"github.com/balkashynov/wrok/internal/parser"
+)
+
+// timesheetColumn is an editable column of the timesheet
+type timesheetColumn int
+
+const (
+	timesheetTask timesheetColumn = iota
+	timesheetStart
+	timesheetEnd
+	timesheetNote
+	timesheetColumns
+)
+
+// timesheetDefaultLength is how long a row added to the timesheet lasts at first
+const timesheetDefaultLength = 30 * time.Minute
+
+// timesheetRow is a session on the timesheet, as edited so far
+type timesheetRow struct {
+	db.TimesheetRow
+	title   string
+	running bool // the session is running, so it may stay without an end
+	locked  bool
+	changed bool
+}
+
+// TimesheetModel edits the sessions of one day as a grid. Nothing is stored
+// until the sheet is saved, which writes every change in one transaction
+type TimesheetModel struct {
+	width  int
+	height int
+
+	day   time.Time
+	force bool           // locked sessions may be changed
+	rows  []timesheetRow // by start time
+
+	selected int
+	column   timesheetColumn
+	offset   int
+
+	editing     bool
+	input       textinput.Model
+	dirty       bool
+	confirmQuit bool
+
+	message      string
+	messageError bool
+}
+
+// NewTimesheetModel creates the timesheet of day from its sessions. Locked
+// sessions can only be changed when force is set
+func NewTimesheetModel(day time.Time, sessions []models.Session, force bool) TimesheetModel {
+	m := TimesheetModel{day: day, force: force}
+	m.rows = timesheetRows(sessions)
+	return m
+}
+
+// timesheetRows turns sessions into unchanged timesheet rows
+func timesheetRows(sessions []models.Session) []timesheetRow {
+	rows := make([]timesheetRow, len(sessions))
+	for i, session := range sessions {
+		rows[i] = timesheetRow{
+			TimesheetRow: db.TimesheetRow{
+				ID:     session.ID,
+				TaskID: session.TaskID,
+				Start:  session.StartedAt,
+				End:    session.FinishedAt,
+				Note:   session.Note,
+			},
+			title:   session.Task.Title,
+			running: session.FinishedAt == nil,
+			locked:  session.Locked(),
+		}
+	}
+	return rows
+}
+
+// Init initializes the model
+func (m TimesheetModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles input
+func (m TimesheetModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch {
+		case m.editing:
+			return m.handleEditKeys(msg)
+		case m.confirmQuit:
+			m.confirmQuit = false
+			if msg.String() == "y" || msg.String() == "Y" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		return m.handleGridKeys(msg)
+	}
+
+	return m, nil
+}
+
+// handleGridKeys moves around the grid and changes rows
+func (m TimesheetModel) handleGridKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.message = ""
+
+	// Every change is refused up front in read-only mode
+	if db.ReadOnly() {
+		switch msg.String() {
+		case "enter", "e", "a", "x", "delete", "ctrl+s", "w":
+			return m.setError(db.ErrReadOnly.Error()), nil
+		}
+	}
+
+	switch msg.String() {
+	case "q", "esc":
+		if m.dirty {
+			m.confirmQuit = true
+			return m, nil
+		}
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.selected > 0 {
+			m.selected--
+		}
+	case "down", "j":
+		if m.selected < len(m.rows)-1 {
+			m.selected++
+		}
+	case "left", "h", "shift+tab":
+		m.column = (m.column - 1 + timesheetColumns) % timesheetColumns
+	case "right", "l", "tab":
+		m.column = (m.column + 1) % timesheetColumns
+
+	case "enter", "e":
+		if len(m.rows) == 0 {
+			return m, nil
+		}
+		if m.refuseLocked(m.rows[m.selected]) {
+			return m.setError(m.lockedMessage(m.rows[m.selected])), nil
+		}
+		return m.startEdit()
+
+	case "a":
+		return m.addRow()
+
+	case "x", "delete":
+		if len(m.rows) == 0 {
+			return m, nil
+		}
+		row := m.rows[m.selected]
+		if m.refuseLocked(row) {
+			return m.setError(m.lockedMessage(row)), nil
+		}
+		m.rows = append(m.rows[:m.selected], m.rows[m.selected+1:]...)
+		if m.selected >= len(m.rows) && m.selected > 0 {
+			m.selected--
+		}
+		m.dirty = true
+		if row.ID != 0 {
+			return m.setInfo(fmt.Sprintf("Session #%d goes when you save", row.ID)), nil
+		}
+
+	case "ctrl+s", "w":
+		return m.save()
+	}
+
+	return m.ensureVisible(), nil
+}
+
+// handleEditKeys edits the selected cell
+func (m TimesheetModel) handleEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.editing = false
+		return m, nil
+	case "enter", "tab":
+		next := msg.String() == "tab"
+		var err error
+		if m, err = m.applyEdit(); err != nil {
+			return m.setError(err.Error()), nil
+		}
+		m.editing = false
+		if next && m.column < timesheetNote {
+			m.column++
+			return m.startEdit()
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// startEdit opens the input on the selected cell, filled with its value
+func (m TimesheetModel) startEdit() (tea.Model, tea.Cmd) {
+	row := m.rows[m.selected]
+	m.input = textinput.New()
+	m.input.CharLimit = 200
+	m.input.Width = 40
+	switch m.column {
+	case timesheetTask:
+		m.input.Prompt = "Task #  "
+		if row.TaskID != 0 {
+			m.input.SetValue(strconv.FormatUint(uint64(row.TaskID), 10))
+		}
+	case timesheetStart:
+		m.input.Prompt = "Start   "
+		m.input.SetValue(row.Start.Format("15:04"))
+	case timesheetEnd:
+		m.input.Prompt = "End     "
+		if row.End != nil {
+			m.input.SetValue(row.End.Format("15:04"))
+		}
+		if row.running {
+			m.input.Placeholder = "empty while running"
+		}
+	case timesheetNote:
+		m.input.Prompt = "Note    "
+		m.input.SetValue(row.Note)
+	}
+	m.input.CursorEnd()
+	m.editing = true
+	return m, m.input.Focus()
+}
+
+// applyEdit stores the input in the selected cell, checking it first
+func (m TimesheetModel) applyEdit() (TimesheetModel, error) {
+	row := m.rows[m.selected]
+	value := strings.TrimSpace(m.input.Value())
+
+	switch m.column {
+	case timesheetTask:
+		id, err := strconv.ParseUint(strings.TrimPrefix(value, "#"), 10, 32)
+		if err != nil {
+			return m, fmt.Errorf("give the task as its ID, e.g. 42")
+		}
+		task, err := db.GetTaskByID(uint(id))
+		if err != nil {
+			return m, err
+		}
+		row.TaskID = task.ID
+		row.title = task.Title
+
+	case timesheetStart:
+		// Unchanged times keep their seconds
+		if value == row.Start.Format("15:04") {
+			return m, nil
+		}
+		start, err := parser.ParseClockTime(value, m.day)
+		if err != nil {
+			return m, err
+		}
+		row.Start = start
+
+	case timesheetEnd:
+		if row.End != nil && value == row.End.Format("15:04") {
+			return m, nil
+		}
+		if value == "" {
+			if !row.running {
+				return m, fmt.Errorf("the session needs an end time")
+			}
+			row.End = nil
+			break
+		}
+		end, err := parser.ParseClockTime(value, row.Start)
+		if err != nil {
+			return m, err
+		}
+		// An end before the start is past midnight
+		if !end.After(row.Start) && len(value) <= len("15:04") {
+			end = end.AddDate(0, 0, 1)
+		}
+		row.End = &end
+
+	case timesheetNote:
+		row.Note = value
+	}
+
+	row.changed = true
+	m.rows[m.selected] = row
+	m.dirty = true
+	return m.sortRows(), nil
+}
+
+// addRow adds a session after the selected one, on the same task, starting
+// where that one ended
+func (m TimesheetModel) addRow() (tea.Model, tea.Cmd) {
+	now := time.Now()
+	row := timesheetRow{changed: true}
+	row.Start = time.Date(m.day.Year(), m.day.Month(), m.day.Day(), 9, 0, 0, 0, m.day.Location())
+	if len(m.rows) > 0 {
+		previous := m.rows[m.selected]
+		row.TaskID, row.title = previous.TaskID, previous.title
+		if previous.End != nil {
+			row.Start = *previous.End
+		} else {
+			row.Start = previous.Start
+		}
+	}
+	if row.Start.After(now) {
+		row.Start = now.Add(-timesheetDefaultLength).Truncate(time.Minute)
+	}
+	end := row.Start.Add(timesheetDefaultLength)
+	if end.After(now) {
+		end = now.Truncate(time.Minute)
+	}
+	row.End = &end
+
+	m.rows = append(m.rows, row)
+	m.selected = len(m.rows) - 1
+	m.dirty = true
+	m = m.sortRows()
+	m.column = timesheetTask
+	return m.startEdit()
+}
+
+// sortRows keeps the rows in start order, and the selection on the same row
+func (m TimesheetModel) sortRows() TimesheetModel {
+	if len(m.rows) == 0 {
+		return m
+	}
+	selected := m.rows[m.selected]
+	sort.SliceStable(m.rows, func(i, j int) bool { return m.rows[i].Start.Before(m.rows[j].Start) })
+	for i := range m.rows {
+		if m.rows[i].ID == selected.ID && m.rows[i].Start.Equal(selected.Start) && m.rows[i].TaskID == selected.TaskID {
+			m.selected = i
+			break
+		}
+	}
+	return m.ensureVisible()
+}
+
+// save writes the timesheet; the database checks it as a whole
+func (m TimesheetModel) save() (tea.Model, tea.Cmd) {
+	if !m.dirty {
+		return m.setInfo("Nothing to save"), nil
+	}
+	rows := make([]db.TimesheetRow, len(m.rows))
+	for i, row := range m.rows {
+		if row.TaskID == 0 {
+			m.selected = i
+			return m.setError("Pick a task for the new session"), nil
+		}
+		rows[i] = row.TimesheetRow
+	}
+
+	changes, err := db.SaveTimesheet(m.day, rows, m.force)
+	if err != nil {
+		return m.setError(err.Error()), nil
+	}
+
+	sessions, err := db.GetTimesheet(m.day)
+	if err != nil {
+		return m.setError(err.Error()), nil
+	}
+	m.rows = timesheetRows(sessions)
+	m.dirty = false
+	if m.selected >= len(m.rows) {
+		m.selected = max(len(m.rows)-1, 0)
+	}
+	return m.setInfo(fmt.Sprintf("Saved: %d added, %d changed, %d deleted", changes.Added, changes.Updated, changes.Deleted)), nil
+}
+
+// overlapsPrevious reports whether row i starts before the one above it ends
+func (m TimesheetModel) overlapsPrevious(i int) bool {
+	if i == 0 {
+		return false
+	}
+	previous := m.rows[i-1]
+	return previous.End == nil || previous.End.After(m.rows[i].Start)
+}
+
+// refuseLocked reports whether row is a locked session and the sheet wasn't opened with --force
+func (m TimesheetModel) refuseLocked(row timesheetRow) bool {
+	return row.locked && !m.force
+}
+
+// lockedMessage explains why a locked row can't be changed
+func (m TimesheetModel) lockedMessage(row timesheetRow) string {
+	return fmt.Sprintf("Session #%d is locked; open 'wrok timesheet --force' to change it", row.ID)
+}
+
+// setError shows an error in the status line
+func (m TimesheetModel) setError(text string) TimesheetModel {
+	m.message = text
+	m.messageError = true
+	return m
+}
+
+// setInfo shows a confirmation in the status line
+func (m TimesheetModel) setInfo(text string) TimesheetModel {
+	m.message = text
+	m.messageError = false
+	return m
+}
+
+// visibleRows returns how many rows fit on screen
+func (m TimesheetModel) visibleRows() int {
+	// title (2) + header (1) + total (2) + input (2) + status (2) + help (1)
+	return max(m.height-10, 3)
+}
+
+// ensureVisible scrolls so the selected row is on screen
+func (m TimesheetModel) ensureVisible() TimesheetModel {
+	rows := m.visibleRows()
+	if m.selected < m.offset {
+		m.offset = m.selected
+	}
+	if m.selected >= m.offset+rows {
+		m.offset = m.selected - rows + 1
+	}
+	return m
+}
+
+// View renders the timesheet
+func (m TimesheetModel) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorAccentBright))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorSecondaryText))
+	rowStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimaryText))
+	cellStyle := lipgloss.NewStyle().Bold(true).Reverse(true)
+	overlapStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorWarning))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDisabledText))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorHelpText)).Italic(true)
+
+	width := m.width
+	if width == 0 {
+		width = 100
+	}
+
+	var b strings.Builder
+	title := "🗓️  Timesheet · " + m.day.Format("Monday 02/01/2006")
+	if m.dirty {
+		title += " · unsaved"
+	}
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	// Columns: marker, ID, task, start, end, duration, then the note takes the rest
+	taskWidth := max((width-40)*55/100, 16)
+	noteWidth := max(width-40-taskWidth, 10)
+	widths := [timesheetColumns]int{taskWidth, 6, 8, noteWidth}
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("  %-6s%-*s %-6s %-8s%-7s%s", "ID", taskWidth, "TASK", "START", "END", "DUR", "NOTE")))
+	b.WriteString("\n")
+
+	if len(m.rows) == 0 {
+		b.WriteString(mutedStyle.Render("  No sessions this day · a adds one"))
+		b.WriteString("\n")
+	}
+
+	var total time.Duration
+	now := time.Now()
+	for _, row := range m.rows {
+		end := now
+		if row.End != nil {
+			end = *row.End
+		}
+		total += end.Sub(row.Start)
+	}
+
+	last := min(m.offset+m.visibleRows(), len(m.rows))
+	for i := m.offset; i < last; i++ {
+		row := m.rows[i]
+		id := "new"
+		if row.ID != 0 {
+			id = strconv.FormatUint(uint64(row.ID), 10)
+		}
+		if row.changed {
+			id += "*"
+		}
+		task := "—"
+		if row.TaskID != 0 {
+			task = fmt.Sprintf("#%d %s", row.TaskID, row.title)
+		}
+		if row.locked {
+			task = "🔒 " + task
+		}
+		finished, end := "running", now
+		if row.End != nil {
+			finished, end = row.End.Format("15:04"), *row.End
+			if row.End.YearDay() != row.Start.YearDay() {
+				finished += "+1"
+			}
+		}
+
+		cells := [timesheetColumns]string{task, row.Start.Format("15:04"), finished, row.Note}
+		var line strings.Builder
+		line.WriteString(fmt.Sprintf("%-6s", id))
+		for column, cell := range cells {
+			text := runewidth.FillRight(runewidth.Truncate(cell, widths[column], "…"), widths[column])
+			if i == m.selected && timesheetColumn(column) == m.column {
+				text = cellStyle.Render(text)
+			}
+			line.WriteString(text)
+			if timesheetColumn(column) == timesheetEnd {
+				line.WriteString(fmt.Sprintf("%-7s", formatDurationShort(end.Sub(row.Start))))
+			} else if timesheetColumn(column) != timesheetNote {
+				line.WriteString(" ")
+			}
+		}
+
+		marker := "  "
+		if i == m.selected {
+			marker = "▶ "
+		}
+		style := rowStyle
+		if m.overlapsPrevious(i) {
+			style = overlapStyle
+			marker = "! "
+		}
+		b.WriteString(style.Render(marker) + style.Render(line.String()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(fmt.Sprintf("  %d session(s) · %s total", len(m.rows), formatDurationShort(total))))
+	b.WriteString("\n\n")
+
+	if m.editing {
+		b.WriteString(m.input.View())
+		b.WriteString("\n")
+	}
+	if m.confirmQuit {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(ColorWarning)).Bold(true).
+			Render("Discard the unsaved changes? [y/N]"))
+		b.WriteString("\n")
+	}
+	if m.message != "" {
+		color := ColorSuccess
+		if m.messageError {
+			color = ColorError
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(m.message))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if m.editing {
+		b.WriteString(helpStyle.Render("enter set · tab set and next cell · esc cancel"))
+	} else {
+		b.WriteString(helpLine(helpStyle, "↑/↓/←/→ move · enter edit · a add · x delete · w save · q/esc quit", "enter", "a", "x", "w"))
+	}
+	return b.String()
+}
+
+// RunTimesheetTUI opens the timesheet of day on its sessions
+func RunTimesheetTUI(day time.Time, sessions []models.Session, force bool) error {
+	_, err := RunProgram(NewTimesheetModel(day, sessions, force))
+	return err
+}
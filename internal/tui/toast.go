@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/balkashynov/wrok/internal/config"
+)
+
+// toastDuration is how long a notification stays on screen
+const toastDuration = 4 * time.Second
+
+// toastExpiredMsg is sent when a toast's time is up so the screen redraws without it
+type toastExpiredMsg struct{}
+
+// Toast is a short-lived notification line shared by the list, add/edit and timer screens
+type Toast struct {
+	message   string
+	isError   bool
+	expiresAt time.Time
+}
+
+// Show displays an informational message
+func (t *Toast) Show(message string) tea.Cmd {
+	t.message = message
+	t.isError = false
+	t.expiresAt = time.Now().Add(toastDuration)
+	return toastExpireCmd()
+}
+
+// ShowError displays err and appends it to the error log
+func (t *Toast) ShowError(err error) tea.Cmd {
+	logError(err)
+	cmd := t.Show(err.Error())
+	t.isError = true
+	return cmd
+}
+
+// Visible reports whether the toast should currently be drawn
+func (t Toast) Visible() bool {
+	return t.message != "" && time.Now().Before(t.expiresAt)
+}
+
+// View renders the toast as a single centered line of the given width
+func (t Toast) View(width int) string {
+	icon, color := "✓", ColorSuccess
+	if t.isError {
+		icon, color = "❌", ColorError
+	}
+
+	style := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(color)).
+		Bold(true).
+		Align(lipgloss.Center).
+		Width(width)
+
+	return style.Render(truncateText(icon+" "+t.message, width-2))
+}
+
+// toastExpireCmd fires a redraw once a toast has timed out
+func toastExpireCmd() tea.Cmd {
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{}
+	})
+}
+
+// logError appends err to ~/.wrok/logs/errors.log; logging problems are ignored
+// since the error is already on screen
+func logError(err error) {
+	dir, dirErr := config.Dir()
+	if dirErr != nil {
+		return
+	}
+	logDir := filepath.Join(dir, "logs")
+	if os.MkdirAll(logDir, 0755) != nil {
+		return
+	}
+
+	file, openErr := os.OpenFile(filepath.Join(logDir, "errors.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if openErr != nil {
+		return
+	}
+	defer file.Close()
+
+	fmt.Fprintf(file, "%s %v\n", time.Now().Format(time.RFC3339), err)
+}
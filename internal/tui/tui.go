@@ -3,39 +3,77 @@ package tui
 import (
 	"fmt"
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/balkashynov/wrok/internal/plain"
 )
 
+// RunProgram runs a TUI on the alternate screen and returns its final model.
+// In plain mode its views go through plain.Text and straight to the terminal,
+// since stdout is then a filter
+func RunProgram(model tea.Model) (tea.Model, error) {
+	if !plain.Enabled() {
+		return tea.NewProgram(model, tea.WithAltScreen()).Run()
+	}
+
+	p := tea.NewProgram(plainModel{model}, tea.WithAltScreen(), tea.WithOutput(plain.Stdout()))
+	final, err := p.Run()
+	if wrapped, ok := final.(plainModel); ok {
+		final = wrapped.Model
+	}
+	return final, err
+}
+
+// plainModel shows a model's view without emoji or box-drawing characters
+type plainModel struct {
+	tea.Model
+}
+
+func (m plainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.Model.Update(msg)
+	return plainModel{model}, cmd
+}
+
+func (m plainModel) View() string {
+	return plain.Text(m.Model.View())
+}
+
+// AddTaskResult is what the add wizard saved
+type AddTaskResult struct {
+	TaskID    uint // 0 if nothing was saved
+	AfterSave AfterSave
+}
+
 // RunAddTaskTUI starts the interactive add task TUI
-func RunAddTaskTUI(prefilled map[string]string) error {
+func RunAddTaskTUI(prefilled map[string]string) (AddTaskResult, error) {
 	model := NewAddTaskModel(prefilled)
 	
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	finalModel, err := p.Run()
+	finalModel, err := RunProgram(model)
 	
 	// Handle exit messages after TUI closes
 	if err != nil {
-		return err
+		return AddTaskResult{}, err
 	}
 	
+	var result AddTaskResult
 	if m, ok := finalModel.(AddTaskModel); ok {
 		if m.cancelled {
 			fmt.Println("❌ Task creation cancelled.")
 		} else if m.completed && m.createdTaskID > 0 {
 			fmt.Printf("✅ New task \"%s\" added - ID: %d\n", m.createdTaskTitle, m.createdTaskID)
+			result = AddTaskResult{TaskID: m.createdTaskID, AfterSave: m.afterSave}
 		} else if m.err != nil {
 			fmt.Printf("❌ Error: %v\n", m.err)
 		}
 	}
 	
-	return nil
+	return result, nil
 }
 
 // RunEditTaskTUI starts the interactive edit task TUI
 func RunEditTaskTUI(taskID uint, prefilled map[string]string) error {
 	model := NewEditTaskModel(taskID, prefilled)
 	
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	finalModel, err := p.Run()
+	finalModel, err := RunProgram(model)
 	
 	// Handle exit messages after TUI closes
 	if err != nil {
@@ -53,4 +91,21 @@ func RunEditTaskTUI(taskID uint, prefilled map[string]string) error {
 	}
 	
 	return nil
-}
\ No newline at end of file
+}
+// wrokLogo is the ASCII art logo at the top of the views
+var wrokLogo = []string{
+	"██╗    ██╗██████╗  ██████╗ ██╗  ██╗",
+	"██║    ██║██╔══██╗██╔═══██╗██║ ██╔╝",
+	"██║ █╗ ██║██████╔╝██║   ██║█████╔╝ ",
+	"██║███╗██║██╔══██╗██║   ██║██╔═██╗ ",
+	"╚███╔███╔╝██║  ██║╚██████╔╝██║  ██╗",
+	" ╚══╝╚══╝ ╚═╝  ╚═╝ ╚═════╝ ╚═╝  ╚═╝",
+}
+
+// logoLines returns the logo to show: the ASCII art, or just the name in plain mode
+func logoLines() []string {
+	if plain.Enabled() {
+		return []string{"WROK"}
+	}
+	return wrokLogo
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/balkashynov/wrok/internal/db"
+)
+
+// wrappedIcons head the lines of the wrapped card
+var wrappedIcons = map[string]string{
+	"Top project": "🏆",
+	"Longest":     "🧘",
+	"Busiest day": "📅",
+	"Completed":   "✅",
+	"Streak":      "🔥",
+}
+
+// wrappedCardWidth is the width of the card's content
+const wrappedCardWidth = 56
+
+// RenderWrappedCard renders a 'wrok wrapped' summary as a bordered card in the
+// theme's colors, for screenshots
+func RenderWrappedCard(w db.Wrapped) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorAccentBright))
+	totalStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorPrimaryText))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSecondaryText))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPrimaryText))
+	footerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDisabledText)).Italic(true)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("✨ wrok wrapped · "+w.Title()) + "\n\n")
+	for _, line := range w.Lines() {
+		label, value := line[0], line[1]
+		if label == "Tracked" {
+			// The headline: "32.5h in 41 sessions"
+			b.WriteString(totalStyle.Render("⏱️  "+value) + "\n\n")
+			continue
+		}
+		// Long task titles are cut to keep the card's frame intact
+		value = truncateText(value, wrappedCardWidth-20)
+		b.WriteString(padText(wrappedIcons[label], 3) + labelStyle.Render(padText(label, 13)) + valueStyle.Render(value) + "\n")
+	}
+	last := w.End.AddDate(0, 0, -1)
+	b.WriteString("\n" + footerStyle.Render(w.Start.Format("2 Jan")+" – "+last.Format("2 Jan 2006")))
+
+	card := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorAccentMain)).
+		Padding(1, 2).
+		Width(wrappedCardWidth)
+	return card.Render(b.String())
+}
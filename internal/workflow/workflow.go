@@ -0,0 +1,181 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/balkashynov/wrok/internal/config"
+)
+
+// The built-in statuses. Tasks start as todo; done and archived close them.
+// Statuses added in the [status] config section sit between todo and done and
+// count as open, like todo
+const (
+	Todo     = "todo"
+	Done     = "done"
+	Archived = "archived"
+)
+
+// reserved are filter words that can't be status names
+var reserved = []string{"all", "active", "open", "none"}
+
+// State is one status of the workflow
+type State struct {
+	Name  string // e.g. "doing"
+	Color string // display color (#RRGGBB or an ANSI 256 code); empty for the default
+}
+
+// scheme is the workflow read from the config
+type scheme struct {
+	states      []State             // todo, the extra statuses, done, archived
+	transitions map[string][]string // allowed moves by status; a status missing here may move to any
+	problems    []string            // what was ignored, and why
+}
+
+// workflow caches the scheme for the lifetime of the process
+var workflow *scheme
+
+// load reads the [status] config section once
+func load() {
+	if workflow != nil {
+		return
+	}
+	workflow = &scheme{transitions: make(map[string][]string)}
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+
+	colors := make(map[string]string)
+	var extra []State
+	for _, state := range cfg.Status.States {
+		name := strings.ToLower(strings.TrimSpace(state.Name))
+		switch {
+		case name == "":
+		case name == Todo || name == Done || name == Archived:
+			colors[name] = state.Color
+		case slices.Contains(reserved, name) || strings.ContainsAny(name, " :"):
+			workflow.problems = append(workflow.problems, fmt.Sprintf("'%s' can't be a status name", state.Name))
+		case slices.ContainsFunc(extra, func(s State) bool { return s.Name == name }):
+			workflow.problems = append(workflow.problems, fmt.Sprintf("status '%s' is defined twice", name))
+		default:
+			extra = append(extra, State{Name: name, Color: state.Color})
+		}
+	}
+	workflow.states = append([]State{{Name: Todo, Color: colors[Todo]}}, extra...)
+	workflow.states = append(workflow.states, State{Name: Done, Color: colors[Done]}, State{Name: Archived, Color: colors[Archived]})
+
+	for from, targets := range cfg.Status.Transitions {
+		from = strings.ToLower(strings.TrimSpace(from))
+		if !Known(from) {
+			workflow.problems = append(workflow.problems, fmt.Sprintf("transitions from unknown status '%s'", from))
+			continue
+		}
+		allowed := []string{}
+		for _, to := range targets {
+			to = strings.ToLower(strings.TrimSpace(to))
+			if !Known(to) {
+				workflow.problems = append(workflow.problems, fmt.Sprintf("transition from %s to unknown status '%s'", from, to))
+				continue
+			}
+			allowed = append(allowed, to)
+		}
+		workflow.transitions[from] = allowed
+	}
+	sort.Strings(workflow.problems) // transitions come from a map
+}
+
+// States returns every status in workflow order: todo, the configured ones, done, archived
+func States() []State {
+	load()
+	return workflow.states
+}
+
+// Names returns the names of every status in workflow order
+func Names() []string {
+	var names []string
+	for _, state := range States() {
+		names = append(names, state.Name)
+	}
+	return names
+}
+
+// Extra returns the configured statuses beyond todo, done and archived
+func Extra() []State {
+	states := States()
+	return states[1 : len(states)-2]
+}
+
+// Open returns the statuses of tasks still to be worked on: todo and the configured ones
+func Open() []string {
+	return Names()[:len(States())-2]
+}
+
+// IsOpen reports whether a task with the status is still to be worked on.
+// Statuses no longer in the config count as open
+func IsOpen(status string) bool {
+	return status != Done && status != Archived
+}
+
+// Known reports whether status is a status of the workflow
+func Known(status string) bool {
+	return slices.Contains(Names(), status)
+}
+
+// Parse returns the status named by input, ignoring case
+func Parse(input string) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(input))
+	if !Known(name) {
+		return "", fmt.Errorf("invalid status '%s'. Use: %s", input, Hint())
+	}
+	return name, nil
+}
+
+// Hint lists the statuses, e.g. "todo, doing, done or archived"
+func Hint() string {
+	names := Names()
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+// Next returns the statuses a task may move to from status, in workflow order
+func Next(status string) []string {
+	load()
+	allowed, restricted := workflow.transitions[status]
+	var next []string
+	for _, name := range Names() {
+		if name != status && (!restricted || slices.Contains(allowed, name)) {
+			next = append(next, name)
+		}
+	}
+	return next
+}
+
+// CheckMove returns an error unless a task may move from one status to another
+func CheckMove(from, to string) error {
+	next := Next(from)
+	if slices.Contains(next, to) {
+		return nil
+	}
+	if len(next) == 0 {
+		return fmt.Errorf("a task can't leave %s (see [status] transitions in the config)", from)
+	}
+	return fmt.Errorf("a task can't move from %s to %s, only to %s", from, to, strings.Join(next, ", "))
+}
+
+// Color returns the configured display color of a status, "" if none is set
+func Color(status string) string {
+	for _, state := range States() {
+		if state.Name == status {
+			return state.Color
+		}
+	}
+	return ""
+}
+
+// Problems describes the parts of the [status] config section that were ignored
+func Problems() []string {
+	load()
+	return workflow.problems
+}